@@ -0,0 +1,12 @@
+// tenantscope 检查跨租户数据访问注解，用法：go run ./cmd/tenantscope ./...
+package main
+
+import (
+	"admin/pkg/analysis/tenantscope"
+
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(tenantscope.Analyzer)
+}
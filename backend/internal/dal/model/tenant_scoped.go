@@ -0,0 +1,25 @@
+package model
+
+// 租户数据模型实现 database.TenantScoped，由租户自动过滤插件追加租户条件
+// tenants 和 tenant_purges 为平台级数据，不在此列
+
+func (u *User) SetTenantID(tenantID string)                     { u.TenantID = tenantID }
+func (r *Role) SetTenantID(tenantID string)                     { r.TenantID = tenantID }
+func (u *UserRole) SetTenantID(tenantID string)                 { u.TenantID = tenantID }
+func (r *RolePermission) SetTenantID(tenantID string)           { r.TenantID = tenantID }
+func (d *Department) SetTenantID(tenantID string)               { d.TenantID = tenantID }
+func (p *Position) SetTenantID(tenantID string)                 { p.TenantID = tenantID }
+func (d *DictType) SetTenantID(tenantID string)                 { d.TenantID = tenantID }
+func (d *DictItem) SetTenantID(tenantID string)                 { d.TenantID = tenantID }
+func (l *LoginLog) SetTenantID(tenantID string)                 { l.TenantID = tenantID }
+func (o *OperationLog) SetTenantID(tenantID string)             { o.TenantID = tenantID }
+func (a *AccessToken) SetTenantID(tenantID string)              { a.TenantID = tenantID }
+func (s *ServiceAccountCredential) SetTenantID(tenantID string) { s.TenantID = tenantID }
+func (t *TenantQuota) SetTenantID(tenantID string)              { t.TenantID = tenantID }
+func (t *TenantDomain) SetTenantID(tenantID string)             { t.TenantID = tenantID }
+func (t *TenantSetting) SetTenantID(tenantID string)            { t.TenantID = tenantID }
+func (i *Invitation) SetTenantID(tenantID string)               { i.TenantID = tenantID }
+func (t *TenantMember) SetTenantID(tenantID string)             { t.TenantID = tenantID }
+func (e *ExportJob) SetTenantID(tenantID string)                { e.TenantID = tenantID }
+func (t *Task) SetTenantID(tenantID string)                     { t.TenantID = tenantID }
+func (d *DepartmentLeader) SetTenantID(tenantID string)         { d.TenantID = tenantID }
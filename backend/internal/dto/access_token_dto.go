@@ -0,0 +1,54 @@
+package dto
+
+import "admin/pkg/utils/pagination"
+
+// CreateAccessTokenRequest 创建访问令牌请求（PAT / API Key 通用）
+type CreateAccessTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100" example:"CI 部署脚本"`                                        // 令牌名称
+	PermissionIDs []string `json:"permission_ids" binding:"required,min=1,dive,required" example:"[\"123456789012345678\"]"` // 授权的 API 权限ID列表（须为所有者权限的子集）
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=3650" example:"90"`                          // 有效天数（不传表示永不过期）
+}
+
+// AccessTokenScope 访问令牌授权范围
+type AccessTokenScope struct {
+	PermissionID string `json:"permission_id" example:"123456789012345678"` // 权限ID
+	Path         string `json:"path" example:"/api/v1/users"`               // API 路径
+	Method       string `json:"method" example:"GET"`                       // 请求方法
+}
+
+// AccessTokenInfo 访问令牌信息（不含密钥）
+type AccessTokenInfo struct {
+	TokenID    string              `json:"token_id" example:"123456789012345678"`       // 令牌ID
+	TokenType  string              `json:"token_type" example:"PAT" enum:"PAT,API_KEY"` // 令牌类型 PAT:个人访问令牌 API_KEY:租户 API Key
+	Name       string              `json:"name" example:"CI 部署脚本"`                      // 令牌名称
+	UserID     string              `json:"user_id" example:"123456789012345678"`        // 所有者ID
+	Scopes     []*AccessTokenScope `json:"scopes"`                                      // 授权范围
+	ExpiresAt  int64               `json:"expires_at" example:"1735200000000"`          // 过期时间（0 表示永不过期）
+	LastUsedAt int64               `json:"last_used_at" example:"1735200000000"`        // 最后使用时间
+	LastUsedIP string              `json:"last_used_ip" example:"192.168.1.100"`        // 最后使用IP
+	CreatedAt  int64               `json:"created_at" example:"1735200000000"`          // 创建时间
+}
+
+// CreateAccessTokenResponse 创建访问令牌响应
+type CreateAccessTokenResponse struct {
+	AccessTokenInfo
+	Token   string `json:"token" example:"pat_123456789012345678_9f86d081884c7d65"` // 令牌明文（仅返回一次）
+	Message string `json:"message" example:"请妥善保存令牌，关闭后将无法再次查看"`                    // 提示信息
+}
+
+// ListAccessTokensRequest 访问令牌列表请求
+type ListAccessTokensRequest struct {
+	pagination.Request `json:",inline"`
+	Name               string `form:"name" binding:"omitempty,max=100"` // 令牌名称（可选，模糊匹配）
+}
+
+// ListAccessTokensResponse 访问令牌列表响应
+type ListAccessTokensResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*AccessTokenInfo `json:"list"` // 列表数据
+}
+
+// AccessTokenDeleteRequest 吊销访问令牌请求
+type AccessTokenDeleteRequest struct {
+	TokenID string `json:"token_id" form:"token_id" binding:"required" example:"123456789012345678"` // 令牌ID
+}
@@ -19,6 +19,54 @@ type LoginResponse struct {
 	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`  // 访问令牌
 	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 刷新令牌
 	ExpiresIn    int64  `json:"expires_in" example:"3600"`                                       // 过期时间（秒）
+
+	// 登录存在风险且租户策略要求二次验证时返回，此时不签发令牌
+	MFARequired bool   `json:"mfa_required,omitempty" example:"false"` // 是否需要二次验证
+	ChallengeID string `json:"challenge_id,omitempty"`                 // 二次验证挑战ID
+	Message     string `json:"message,omitempty"`                      // 提示信息
+}
+
+// LoginMFARequest 登录二次验证请求
+type LoginMFARequest struct {
+	ChallengeID string `json:"challenge_id" binding:"required"`                        // 二次验证挑战ID
+	Code        string `json:"code" binding:"required,len=6,numeric" example:"123456"` // 邮箱验证码
+}
+
+// ClientCredentialsRequest OAuth2 client_credentials 授权请求（RFC 6749 4.4）
+// 客户端凭证可放在表单字段中，也可通过 HTTP Basic 认证头传递
+type ClientCredentialsRequest struct {
+	GrantType    string `form:"grant_type" json:"grant_type" binding:"required" example:"client_credentials"` // 授权类型，固定为 client_credentials
+	ClientID     string `form:"client_id" json:"client_id" example:"sa_123456789012345678"`                   // 客户端ID
+	ClientSecret string `form:"client_secret" json:"client_secret" example:"9f86d081884c7d65"`                // 客户端密钥
+}
+
+// ClientCredentialsResponse OAuth2 令牌响应（RFC 6749 5.1）
+type ClientCredentialsResponse struct {
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 访问令牌
+	TokenType   string `json:"token_type" example:"Bearer"`                                    // 令牌类型
+	ExpiresIn   int64  `json:"expires_in" example:"3600"`                                      // 过期时间（秒）
+}
+
+// OAuthErrorResponse OAuth2 错误响应（RFC 6749 5.2）
+type OAuthErrorResponse struct {
+	Error            string `json:"error" example:"invalid_client"`         // 错误码
+	ErrorDescription string `json:"error_description" example:"客户端ID或密钥错误"` // 错误描述
+}
+
+// ImpersonateRequest 模拟登录请求
+type ImpersonateRequest struct {
+	UserID          string `json:"user_id" binding:"required" example:"123456789012345678"`         // 目标用户ID
+	Reason          string `json:"reason" binding:"required,max=255" example:"复现工单 #1024 菜单显示问题"`   // 模拟原因（写入审计日志）
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1,max=120" example:"30"` // 有效时长（分钟，默认30）
+}
+
+// ImpersonateResponse 模拟登录响应
+type ImpersonateResponse struct {
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 目标用户身份的访问令牌（不可刷新）
+	ExpiresIn   int64  `json:"expires_in" example:"1800"`                                      // 过期时间（秒）
+	UserID      string `json:"user_id" example:"123456789012345678"`                           // 目标用户ID
+	UserName    string `json:"username" example:"alice"`                                       // 目标用户名
+	TenantID    string `json:"tenant_id" example:"123456789012345678"`                         // 目标用户租户ID
 }
 
 // SwitchTenantRequest 切换租户请求
@@ -78,3 +78,48 @@ type DepartmentStatusRequest struct {
 type DepartmentBatchDeleteRequest struct {
 	DepartmentIDs []string `json:"department_ids" binding:"required,min=1,dive,required" example:"[\"123456789012345678\", \"987654321098765432\"]"` // 部门ID列表
 }
+
+// DepartmentLeaderInfo 部门负责人信息
+type DepartmentLeaderInfo struct {
+	UserID       string `json:"user_id" example:"123456789012345678"`       // 用户ID
+	UserName     string `json:"username" example:"zhangsan"`                // 用户名
+	Nickname     string `json:"nickname" example:"张三"`                      // 昵称
+	Avatar       string `json:"avatar" example:"/uploads/avatars/a.png"`    // 头像URL
+	DepartmentID string `json:"department_id" example:"123456789012345678"` // 本人所在部门ID
+	PositionID   string `json:"position_id" example:"123456789012345678"`   // 主岗位ID
+	PositionName string `json:"position_name" example:"技术总监"`               // 主岗位名称
+	Level        int    `json:"level" example:"9"`                          // 主岗位职级
+}
+
+// SetDepartmentLeadersRequest 设置部门负责人请求（覆盖式）
+type SetDepartmentLeadersRequest struct {
+	DepartmentID string   `json:"department_id" binding:"required" example:"123456789012345678"` // 部门ID
+	UserIDs      []string `json:"user_ids" binding:"omitempty,max=20,dive,required"`             // 负责人用户ID列表，顺序即排序，第一位为主要负责人；为空表示清空
+}
+
+// DepartmentLeadersResponse 部门负责人响应
+type DepartmentLeadersResponse struct {
+	DepartmentID string                  `json:"department_id" example:"123456789012345678"` // 部门ID
+	Leaders      []*DepartmentLeaderInfo `json:"leaders"`                                    // 负责人列表（按排序）
+}
+
+// OrgChartNode 组织架构图节点
+type OrgChartNode struct {
+	*DepartmentInfo
+	HeadCount      int64                   `json:"head_count" example:"12"`       // 本部门人数（不含子部门）
+	TotalHeadCount int64                   `json:"total_head_count" example:"58"` // 本部门及所有子部门人数
+	Leaders        []*DepartmentLeaderInfo `json:"leaders"`                       // 负责人列表（按排序）
+	Children       []*OrgChartNode         `json:"children"`                      // 子部门
+}
+
+// OrgChartResponse 组织架构图响应
+type OrgChartResponse struct {
+	Tree []*OrgChartNode `json:"tree"` // 部门树
+}
+
+// UserManagerResponse 用户直属上级响应
+type UserManagerResponse struct {
+	UserID       string                `json:"user_id" example:"123456789012345678"`       // 用户ID
+	DepartmentID string                `json:"department_id" example:"123456789012345678"` // 上级所负责的部门ID（无上级时为空）
+	Manager      *DepartmentLeaderInfo `json:"manager"`                                    // 直属上级（无上级时为空）
+}
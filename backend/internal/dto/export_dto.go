@@ -0,0 +1,41 @@
+package dto
+
+import "admin/pkg/utils/pagination"
+
+// ExportRequest 列表导出请求（与对应列表接口的筛选条件一起使用）
+type ExportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx" example:"xlsx"`   // 文件格式，默认 xlsx
+	Lang   string `form:"lang" binding:"omitempty,oneof=zh-CN en-US" example:"zh-CN"` // 表头语言，默认按 Accept-Language 或租户默认语言
+}
+
+// ExportJobInfo 导出任务信息
+type ExportJobInfo struct {
+	ExportID     string `json:"export_id" example:"123456789012345678"`                                       // 导出任务ID
+	TaskID       string `json:"task_id,omitempty" example:"123456789012345679"`                               // 执行导出的后台任务ID，可通过 /api/v1/tasks/detail 查询进度
+	Resource     string `json:"resource" example:"user"`                                                      // 导出资源
+	Format       string `json:"format" example:"xlsx"`                                                        // 文件格式
+	Status       string `json:"status" example:"PENDING" enum:"PENDING,RUNNING,SUCCEEDED,FAILED"`             // 状态
+	RowCount     int64  `json:"row_count" example:"12000"`                                                    // 导出行数
+	FileSize     int64  `json:"file_size" example:"1048576"`                                                  // 文件大小(字节)
+	ErrorMessage string `json:"error_message,omitempty"`                                                      // 失败原因
+	DownloadURL  string `json:"download_url" example:"/api/v1/exports/download?export_id=123456789012345678"` // 下载地址
+	ExpiresAt    int64  `json:"expires_at" example:"1735286400000"`                                           // 文件过期时间(毫秒)
+	FinishedAt   int64  `json:"finished_at" example:"1735200060000"`                                          // 完成时间(毫秒)
+	CreatedAt    int64  `json:"created_at" example:"1735200000000"`                                           // 创建时间(毫秒)
+}
+
+// ListExportJobsRequest 导出任务列表请求
+type ListExportJobsRequest struct {
+	pagination.Request `json:",inline"`
+}
+
+// ListExportJobsResponse 导出任务列表响应
+type ListExportJobsResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*ExportJobInfo `json:"list"` // 列表数据
+}
+
+// ExportDownloadRequest 下载导出文件请求
+type ExportDownloadRequest struct {
+	ExportID string `form:"export_id" binding:"required" example:"123456789012345678"` // 导出任务ID
+}
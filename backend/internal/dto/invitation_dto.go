@@ -0,0 +1,78 @@
+package dto
+
+import "admin/pkg/utils/pagination"
+
+// CreateInvitationRequest 邀请成员请求
+type CreateInvitationRequest struct {
+	Email        string   `json:"email" binding:"required,email,max=100" example:"user@example.com"`      // 被邀请邮箱
+	RoleCodes    []string `json:"role_codes" binding:"required,min=1,dive,required" example:"[\"user\"]"` // 预分配角色编码列表
+	DepartmentID string   `json:"department_id" binding:"omitempty,max=20" example:"123456789012345678"`  // 预分配部门ID
+	PositionID   string   `json:"position_id" binding:"omitempty,max=20" example:"123456789012345678"`    // 预分配岗位ID
+}
+
+// InvitationInfo 邀请信息
+type InvitationInfo struct {
+	InvitationID   string   `json:"invitation_id" example:"123456789012345678"`                       // 邀请ID
+	Email          string   `json:"email" example:"user@example.com"`                                 // 被邀请邮箱
+	RoleIDs        []string `json:"role_ids"`                                                         // 预分配角色ID列表
+	DepartmentID   string   `json:"department_id,omitempty" example:"123456789012345678"`             // 预分配部门ID
+	PositionID     string   `json:"position_id,omitempty" example:"123456789012345678"`               // 预分配岗位ID
+	Status         string   `json:"status" example:"PENDING" enum:"PENDING,ACCEPTED,REVOKED,EXPIRED"` // 状态 PENDING:待接受 ACCEPTED:已接受 REVOKED:已撤销 EXPIRED:已过期
+	InvitedBy      string   `json:"invited_by" example:"123456789012345678"`                          // 邀请人ID
+	AcceptedUserID string   `json:"accepted_user_id,omitempty" example:"123456789012345678"`          // 接受邀请的用户ID
+	ExpiresAt      int64    `json:"expires_at" example:"1735200000000"`                               // 过期时间
+	AcceptedAt     int64    `json:"accepted_at,omitempty" example:"1735200000000"`                    // 接受时间
+	CreatedAt      int64    `json:"created_at" example:"1735200000000"`                               // 创建时间
+}
+
+// CreateInvitationResponse 邀请成员响应
+type CreateInvitationResponse struct {
+	Invitation *InvitationInfo `json:"invitation"`                                                    // 邀请信息
+	Link       string          `json:"link" example:"https://admin.example.com/invitation?token=xxx"` // 邀请链接（邮件无法送达时可手动转交）
+}
+
+// ListInvitationsRequest 邀请列表请求
+type ListInvitationsRequest struct {
+	pagination.Request `json:",inline"`
+	Email              string `form:"email" binding:"omitempty,max=100"`                                 // 邮箱（可选，模糊匹配）
+	Status             string `form:"status" binding:"omitempty,oneof=PENDING ACCEPTED REVOKED EXPIRED"` // 状态（可选）
+}
+
+// ListInvitationsResponse 邀请列表响应
+type ListInvitationsResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*InvitationInfo `json:"list"` // 列表数据
+}
+
+// InvitationIDRequest 邀请ID请求（用于重新发送、撤销）
+type InvitationIDRequest struct {
+	InvitationID string `json:"invitation_id" binding:"required" example:"123456789012345678"` // 邀请ID
+}
+
+// InvitationTokenRequest 邀请链接令牌请求
+type InvitationTokenRequest struct {
+	Token string `form:"token" json:"token" binding:"required,max=200"` // 邀请链接中的令牌
+}
+
+// InvitationPreviewResponse 邀请预览响应（接受邀请页面展示）
+type InvitationPreviewResponse struct {
+	TenantCode      string `json:"tenant_code" example:"acme"`         // 邀请租户编码
+	TenantName      string `json:"tenant_name" example:"Acme"`         // 邀请租户名称
+	Email           string `json:"email" example:"user@example.com"`   // 被邀请邮箱
+	ExistingAccount bool   `json:"existing_account" example:"false"`   // 是否已有账号（已有账号无需设置密码，接受后通过切换租户访问）
+	ExpiresAt       int64  `json:"expires_at" example:"1735200000000"` // 过期时间
+}
+
+// AcceptInvitationRequest 接受邀请请求
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required,max=200"`                 // 邀请链接中的令牌
+	Password string `json:"password" binding:"omitempty"`                     // 登录密码（RSA 加密，新用户必填）
+	Nickname string `json:"nickname" binding:"omitempty,max=50" example:"张三"` // 昵称（可选，新用户默认使用邮箱）
+}
+
+// AcceptInvitationResponse 接受邀请响应
+type AcceptInvitationResponse struct {
+	TenantCode      string `json:"tenant_code" example:"acme"`           // 加入的租户编码，新用户使用该租户登录
+	UserID          string `json:"user_id" example:"123456789012345678"` // 用户ID
+	ExistingAccount bool   `json:"existing_account" example:"false"`     // 是否为已有账号（已有账号登录原租户后切换到该租户）
+}
@@ -23,18 +23,23 @@ type ListLoginLogsResponse struct {
 
 // LoginLogInfo 登录日志信息（可复用）
 type LoginLogInfo struct {
-	LogID         string `json:"log_id" example:"123456789012345678"`
-	TenantID      string `json:"tenant_id" example:"123456789012345678"`
-	UserID        string `json:"user_id" example:"123456789012345678"`
-	UserName      string `json:"user_name" example:"admin"`
-	OperationType string `json:"operation_type" example:"LOGIN"` // LOGIN:登录, LOGOUT:登出
-	LoginType     string `json:"login_type" example:"PASSWORD"`  // PASSWORD:密码, SSO:单点登录, OAUTH:第三方登录
-	LoginIP       string `json:"login_ip" example:"192.168.1.100"`
-	LoginLocation string `json:"login_location" example:"北京市朝阳区"` // IP解析的地理位置
-	UserAgent     string `json:"user_agent" example:"Mozilla/5.0"`
-	Status        int16  `json:"status" example:"1"`     // 1:成功 0:失败
-	FailReason    string `json:"fail_reason" example:""` // 失败原因
-	CreatedAt     int64  `json:"created_at" example:"1735206400"`
+	LogID             string `json:"log_id" example:"123456789012345678"`
+	TenantID          string `json:"tenant_id" example:"123456789012345678"`
+	UserID            string `json:"user_id" example:"123456789012345678"`
+	UserName          string `json:"user_name" example:"admin"`
+	OperationType     string `json:"operation_type" example:"LOGIN"` // LOGIN:登录, LOGOUT:登出
+	LoginType         string `json:"login_type" example:"PASSWORD"`  // PASSWORD:密码, SSO:单点登录, OAUTH:第三方登录
+	LoginIP           string `json:"login_ip" example:"192.168.1.100"`
+	LoginLocation     string `json:"login_location" example:"北京市朝阳区"` // IP解析的地理位置
+	UserAgent         string `json:"user_agent" example:"Mozilla/5.0"`
+	Status            int16  `json:"status" example:"1"`                            // 1:成功 0:失败
+	FailReason        string `json:"fail_reason" example:""`                        // 失败原因
+	DeviceFingerprint string `json:"device_fingerprint" example:"3f2a9c1e7b4d5a60"` // 设备指纹
+	CountryCode       string `json:"country_code" example:"CN"`                     // 国家代码
+	RiskScore         int16  `json:"risk_score" example:"30"`                       // 风险评分(0-100)
+	RiskFlags         string `json:"risk_flags" example:"NEW_DEVICE"`               // 风险标记，逗号分隔(NEW_DEVICE,NEW_COUNTRY,IMPOSSIBLE_TRAVEL)
+	RiskAction        string `json:"risk_action" example:"ALLOW"`                   // 处置动作(ALLOW/MFA/BLOCK)
+	CreatedAt         int64  `json:"created_at" example:"1735206400"`
 }
 
 // LoginLogDetailRequest 获取登录日志详情请求
@@ -0,0 +1,50 @@
+package dto
+
+import "admin/pkg/utils/pagination"
+
+// MemberInfo 租户成员信息（从其他租户加入的用户）
+type MemberInfo struct {
+	UserID         string `json:"user_id" example:"123456789012345678"`       // 用户ID
+	UserName       string `json:"user_name" example:"user@example.com"`       // 用户名
+	Nickname       string `json:"nickname" example:"张三"`                      // 昵称
+	Email          string `json:"email" example:"user@example.com"`           // 邮箱
+	Phone          string `json:"phone" example:"13800138000"`                // 手机号
+	HomeTenantCode string `json:"home_tenant_code" example:"acme"`            // 主租户编码（账号所在租户）
+	HomeTenantName string `json:"home_tenant_name" example:"Acme"`            // 主租户名称
+	Status         int16  `json:"status" example:"1" enum:"1,2"`              // 在本租户的状态 1:正常 2:禁用
+	DepartmentID   string `json:"department_id" example:"123456789012345678"` // 在本租户的部门ID
+	PositionID     string `json:"position_id" example:"123456789012345678"`   // 在本租户的岗位ID
+	InvitedBy      string `json:"invited_by" example:"123456789012345678"`    // 邀请人ID
+	JoinedAt       int64  `json:"joined_at" example:"1735200000000"`          // 加入时间
+}
+
+// ListMembersRequest 租户成员列表请求
+type ListMembersRequest struct {
+	pagination.Request `json:",inline"`
+	DepartmentID       string `form:"department_id" binding:"omitempty,max=20"` // 部门ID（可选）
+	Status             int    `form:"status" binding:"omitempty,oneof=1 2"`     // 状态（可选）
+}
+
+// ListMembersResponse 租户成员列表响应
+type ListMembersResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*MemberInfo `json:"list"` // 列表数据
+}
+
+// UpdateMemberRequest 更新租户成员请求
+type UpdateMemberRequest struct {
+	UserID       string `json:"user_id" binding:"required" example:"123456789012345678"`               // 用户ID
+	DepartmentID string `json:"department_id" binding:"omitempty,max=20" example:"123456789012345678"` // 部门ID（为空表示不属于任何部门）
+	PositionID   string `json:"position_id" binding:"omitempty,max=20" example:"123456789012345678"`   // 岗位ID（为空表示无岗位）
+}
+
+// MemberStatusRequest 更新租户成员状态请求
+type MemberStatusRequest struct {
+	UserID string `json:"user_id" binding:"required" example:"123456789012345678"` // 用户ID
+	Status int    `json:"status" binding:"required,oneof=1 2" example:"2"`         // 状态 1:正常 2:禁用
+}
+
+// MemberDeleteRequest 移除租户成员请求
+type MemberDeleteRequest struct {
+	UserID string `json:"user_id" binding:"required" example:"123456789012345678"` // 用户ID
+}
@@ -22,25 +22,29 @@ type ListOperationLogsResponse struct {
 
 // OperationLogInfo 操作日志信息（可复用）
 type OperationLogInfo struct {
-	LogID         string `json:"log_id" example:"123456789012345678"`      // 日志ID
-	TenantID      string `json:"tenant_id" example:"123456789012345678"`   // 租户ID
-	UserID        string `json:"user_id" example:"123456789012345678"`     // 用户ID
-	UserName      string `json:"user_name" example:"admin"`                // 用户名
-	Module        string `json:"module" example:"用户管理"`                    // 模块名
-	OperationType string `json:"operation_type" example:"CREATE"`          // 操作类型
-	ResourceType  string `json:"resource_type" example:"用户"`               // 资源类型
-	ResourceID    string `json:"resource_id" example:"123456789012345678"` // 资源ID
-	ResourceName  string `json:"resource_name" example:"admin"`            // 资源名称
-	RequestMethod string `json:"request_method" example:"POST"`            // 请求方法
-	RequestPath   string `json:"request_path" example:"/api/v1/users"`     // 请求路径
-	RequestParams string `json:"request_params" example:"{}"`              // 请求参数
-	OldValue      string `json:"old_value" example:""`                     // 操作前数据
-	NewValue      string `json:"new_value" example:"{}"`                   // 操作后数据
-	Status        int    `json:"status" example:"1"`                       // 操作状态
-	ErrorMessage  string `json:"error_message" example:""`                 // 错误信息
-	IPAddress     string `json:"ip_address" example:"192.168.1.100"`       // IP地址
-	UserAgent     string `json:"user_agent" example:"Mozilla/5.0"`         // 用户代理
-	CreatedAt     int64  `json:"created_at" example:"1735206400"`          // 创建时间
+	LogID            string `json:"log_id" example:"123456789012345678"`      // 日志ID
+	TenantID         string `json:"tenant_id" example:"123456789012345678"`   // 租户ID
+	UserID           string `json:"user_id" example:"123456789012345678"`     // 用户ID
+	UserName         string `json:"user_name" example:"admin"`                // 用户名
+	AccessKeyID      string `json:"access_key_id" example:""`                 // 访问令牌ID（PAT / API Key 调用时有值）
+	ImpersonatorID   string `json:"impersonator_id" example:""`               // 模拟登录时的实际操作者ID
+	ImpersonatorName string `json:"impersonator_name" example:""`             // 模拟登录时的实际操作者用户名
+	Module           string `json:"module" example:"用户管理"`                    // 模块名
+	OperationType    string `json:"operation_type" example:"CREATE"`          // 操作类型
+	ResourceType     string `json:"resource_type" example:"用户"`               // 资源类型
+	ResourceID       string `json:"resource_id" example:"123456789012345678"` // 资源ID
+	ResourceName     string `json:"resource_name" example:"admin"`            // 资源名称
+	RequestMethod    string `json:"request_method" example:"POST"`            // 请求方法
+	RequestPath      string `json:"request_path" example:"/api/v1/users"`     // 请求路径
+	RequestParams    string `json:"request_params" example:"{}"`              // 请求参数
+	OldValue         string `json:"old_value" example:""`                     // 操作前数据
+	NewValue         string `json:"new_value" example:"{}"`                   // 操作后数据
+	Status           int    `json:"status" example:"1"`                       // 操作状态
+	ErrorMessage     string `json:"error_message" example:""`                 // 错误信息
+	IPAddress        string `json:"ip_address" example:"192.168.1.100"`       // IP地址
+	Location         string `json:"location" example:"中国 广东省 深圳市"`            // IP解析的地理位置
+	UserAgent        string `json:"user_agent" example:"Mozilla/5.0"`         // 用户代理
+	CreatedAt        int64  `json:"created_at" example:"1735206400"`          // 创建时间
 }
 
 // OperationLogDetailRequest 获取操作日志详情请求
@@ -0,0 +1,94 @@
+package dto
+
+import "admin/pkg/utils/pagination"
+
+// CreatePlanRequest 创建套餐请求
+type CreatePlanRequest struct {
+	PlanCode    string `json:"plan_code" binding:"required,min=2,max=50" example:"standard"` // 套餐编码（全局唯一）
+	Name        string `json:"name" binding:"required,max=100" example:"标准版"`                // 套餐名称
+	Description string `json:"description" binding:"omitempty" example:"包含组织架构与日志审计"`        // 套餐描述
+	Status      int    `json:"status" binding:"omitempty,oneof=1 2" example:"1"`             // 状态 1:启用 2:禁用
+	Sort        int    `json:"sort" binding:"omitempty,min=0" example:"1"`                   // 排序
+	PlanQuota   `json:",inline"`
+}
+
+// UpdatePlanRequest 更新套餐请求
+type UpdatePlanRequest struct {
+	PlanID      string `json:"plan_id" binding:"required" example:"123456789012345678"` // 套餐ID
+	Name        string `json:"name" binding:"omitempty,max=100" example:"标准版"`          // 套餐名称
+	Description string `json:"description" binding:"omitempty" example:"包含组织架构与日志审计"`   // 套餐描述
+	Status      int    `json:"status" binding:"omitempty,oneof=1 2" example:"1"`        // 状态 1:启用 2:禁用
+	Sort        *int   `json:"sort" binding:"omitempty,min=0" example:"1"`              // 排序
+	// 资源配额，不传表示不修改，0 表示不限制
+	MaxUsers       *int   `json:"max_users" binding:"omitempty,min=0" example:"100"`        // 最大用户数
+	MaxRoles       *int   `json:"max_roles" binding:"omitempty,min=0" example:"20"`         // 最大角色数
+	MaxDepartments *int   `json:"max_departments" binding:"omitempty,min=0" example:"50"`   // 最大部门数
+	MaxStorageMB   *int64 `json:"max_storage_mb" binding:"omitempty,min=0" example:"10240"` // 最大存储空间（MB）
+}
+
+// PlanQuota 套餐默认资源配额，0 表示不限制
+type PlanQuota struct {
+	MaxUsers       int   `json:"max_users" binding:"omitempty,min=0" example:"100"`        // 最大用户数
+	MaxRoles       int   `json:"max_roles" binding:"omitempty,min=0" example:"20"`         // 最大角色数
+	MaxDepartments int   `json:"max_departments" binding:"omitempty,min=0" example:"50"`   // 最大部门数
+	MaxStorageMB   int64 `json:"max_storage_mb" binding:"omitempty,min=0" example:"10240"` // 最大存储空间（MB）
+}
+
+// PlanDetailRequest 套餐详情请求
+type PlanDetailRequest struct {
+	PlanID string `json:"plan_id" form:"plan_id" binding:"required" example:"123456789012345678"` // 套餐ID
+}
+
+// PlanDeleteRequest 删除套餐请求
+type PlanDeleteRequest struct {
+	PlanID string `json:"plan_id" form:"plan_id" binding:"required" example:"123456789012345678"` // 套餐ID
+}
+
+// ListPlansRequest 套餐列表请求
+type ListPlansRequest struct {
+	pagination.Request `json:",inline"`
+	Name               string `form:"name" binding:"omitempty,max=100"`     // 套餐名称（模糊匹配）
+	PlanCode           string `form:"plan_code" binding:"omitempty,max=50"` // 套餐编码（模糊匹配）
+	Status             int    `form:"status" binding:"omitempty,oneof=1 2"` // 状态筛选
+}
+
+// ListPlansResponse 套餐列表响应
+type ListPlansResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*PlanInfo `json:"list"` // 列表数据
+}
+
+// PlanInfo 套餐信息
+type PlanInfo struct {
+	PlanID      string `json:"plan_id" example:"123456789012345678"` // 套餐ID
+	PlanCode    string `json:"plan_code" example:"standard"`         // 套餐编码
+	Name        string `json:"name" example:"标准版"`                   // 套餐名称
+	Description string `json:"description" example:"包含组织架构与日志审计"`    // 套餐描述
+	Status      int    `json:"status" example:"1"`                   // 状态 1:启用 2:禁用
+	Sort        int    `json:"sort" example:"1"`                     // 排序
+	PlanQuota   `json:",inline"`
+	TenantCount int64 `json:"tenant_count" example:"8"`           // 使用该套餐的租户数（仅详情返回）
+	CreatedAt   int64 `json:"created_at" example:"1735200000000"` // 创建时间
+	UpdatedAt   int64 `json:"updated_at" example:"1735206400000"` // 更新时间
+}
+
+// AssignPlanPermissionsRequest 设置套餐权限请求（菜单+按钮+接口）
+type AssignPlanPermissionsRequest struct {
+	PlanID        string   `json:"plan_id" binding:"required" example:"123456789012345678"` // 套餐ID
+	MenuPermIDs   []string `json:"menu_perm_ids" binding:"omitempty"`                       // 菜单权限ID列表
+	ButtonPermIDs []string `json:"button_perm_ids" binding:"omitempty"`                     // 按钮权限ID列表
+	APIPermIDs    []string `json:"api_perm_ids" binding:"omitempty"`                        // 接口权限ID列表
+}
+
+// AssignPlanPermissionsResponse 设置套餐权限响应
+type AssignPlanPermissionsResponse struct {
+	PlanID         string `json:"plan_id" example:"123456789012345678"` // 套餐ID
+	StrippedGrants int64  `json:"stripped_grants" example:"12"`         // 回收的超出套餐范围的角色授权数
+}
+
+// PlanPermissionsResponse 套餐权限响应
+type PlanPermissionsResponse struct {
+	MenuPermIDs   []string `json:"menu_perm_ids"`   // 菜单权限ID列表
+	ButtonPermIDs []string `json:"button_perm_ids"` // 按钮权限ID列表
+	APIPermIDs    []string `json:"api_perm_ids"`    // 接口权限ID列表
+}
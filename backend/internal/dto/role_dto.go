@@ -43,6 +43,7 @@ type RoleInfo struct {
 	Description    string  `json:"description" example:"系统管理员，拥有所有权限"`     // 角色描述
 	Status         int     `json:"status" example:"1" enum:"1,2"`          // 状态 1:启用 2:禁用
 	ParentRoleCode *string `json:"parent_role_code"`                       // 父角色编码
+	TemplateID     string  `json:"template_id,omitempty"`                  // 来源角色模板ID
 	CreatedAt      int64   `json:"created_at" example:"1735200000"`        // 创建时间
 	UpdatedAt      int64   `json:"updated_at" example:"1735206400"`        // 更新时间
 }
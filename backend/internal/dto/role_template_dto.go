@@ -0,0 +1,119 @@
+package dto
+
+import "admin/pkg/utils/pagination"
+
+// CreateRoleTemplateRequest 创建角色模板请求
+type CreateRoleTemplateRequest struct {
+	TemplateCode string `json:"template_code" binding:"required,min=2,max=50" example:"auditor"` // 模板编码（全局唯一，实例化时默认作为角色编码）
+	Name         string `json:"name" binding:"required,max=100" example:"监管员"`                   // 模板名称
+	Description  string `json:"description" binding:"omitempty" example:"只读查看日志与用户"`             // 模板描述
+	Status       int    `json:"status" binding:"omitempty,oneof=1 2" example:"1"`                // 状态 1:启用 2:禁用
+}
+
+// UpdateRoleTemplateRequest 更新角色模板请求
+type UpdateRoleTemplateRequest struct {
+	TemplateID  string `json:"template_id" binding:"required" example:"123456789012345678"` // 模板ID
+	Name        string `json:"name" binding:"omitempty,max=100" example:"监管员"`              // 模板名称
+	Description string `json:"description" binding:"omitempty" example:"只读查看日志与用户"`         // 模板描述
+	Status      int    `json:"status" binding:"omitempty,oneof=1 2" example:"1"`            // 状态 1:启用 2:禁用
+}
+
+// RoleTemplateDetailRequest 角色模板详情请求
+type RoleTemplateDetailRequest struct {
+	TemplateID string `json:"template_id" form:"template_id" binding:"required" example:"123456789012345678"` // 模板ID
+}
+
+// RoleTemplateDeleteRequest 删除角色模板请求
+type RoleTemplateDeleteRequest struct {
+	TemplateID string `json:"template_id" form:"template_id" binding:"required" example:"123456789012345678"` // 模板ID
+}
+
+// ListRoleTemplatesRequest 角色模板列表请求
+type ListRoleTemplatesRequest struct {
+	pagination.Request `json:",inline"`
+	Name               string `form:"name" binding:"omitempty,max=100"`         // 模板名称（模糊匹配）
+	TemplateCode       string `form:"template_code" binding:"omitempty,max=50"` // 模板编码（模糊匹配）
+	Status             int    `form:"status" binding:"omitempty,oneof=1 2"`     // 状态筛选
+}
+
+// ListRoleTemplatesResponse 角色模板列表响应
+type ListRoleTemplatesResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*RoleTemplateInfo `json:"list"` // 列表数据
+}
+
+// RoleTemplateInfo 角色模板信息
+type RoleTemplateInfo struct {
+	TemplateID   string `json:"template_id" example:"123456789012345678"` // 模板ID
+	TemplateCode string `json:"template_code" example:"auditor"`          // 模板编码
+	Name         string `json:"name" example:"监管员"`                       // 模板名称
+	Description  string `json:"description" example:"只读查看日志与用户"`          // 模板描述
+	Status       int    `json:"status" example:"1"`                       // 状态 1:启用 2:禁用
+	RoleCount    int64  `json:"role_count" example:"12"`                  // 派生角色数量（仅详情返回）
+	CreatedAt    int64  `json:"created_at" example:"1735200000000"`       // 创建时间
+	UpdatedAt    int64  `json:"updated_at" example:"1735206400000"`       // 更新时间
+}
+
+// AssignRoleTemplatePermissionsRequest 设置角色模板权限请求（菜单+按钮+接口）
+type AssignRoleTemplatePermissionsRequest struct {
+	TemplateID    string   `json:"template_id" binding:"required" example:"123456789012345678"` // 模板ID
+	MenuPermIDs   []string `json:"menu_perm_ids" binding:"omitempty"`                           // 菜单权限ID列表
+	ButtonPermIDs []string `json:"button_perm_ids" binding:"omitempty"`                         // 按钮权限ID列表
+	APIPermIDs    []string `json:"api_perm_ids" binding:"omitempty"`                            // 接口权限ID列表
+}
+
+// RoleTemplatePermissionsResponse 角色模板权限响应
+type RoleTemplatePermissionsResponse struct {
+	MenuPermIDs   []string `json:"menu_perm_ids"`   // 菜单权限ID列表
+	ButtonPermIDs []string `json:"button_perm_ids"` // 按钮权限ID列表
+	APIPermIDs    []string `json:"api_perm_ids"`    // 接口权限ID列表
+}
+
+// InstantiateRoleTemplateRequest 由模板创建角色请求
+type InstantiateRoleTemplateRequest struct {
+	TemplateID  string `json:"template_id" binding:"required" example:"123456789012345678"` // 模板ID
+	TenantID    string `json:"tenant_id" binding:"omitempty" example:"123456789012345678"`  // 目标租户ID（仅超级管理员可指定，默认当前租户）
+	RoleCode    string `json:"role_code" binding:"omitempty,max=50" example:"auditor"`      // 角色编码（默认使用模板编码）
+	Name        string `json:"name" binding:"omitempty,max=100" example:"监管员"`              // 角色名称（默认使用模板名称）
+	Description string `json:"description" binding:"omitempty"`                             // 角色描述（默认使用模板描述）
+}
+
+// RoleTemplateSyncRequest 模板同步请求
+type RoleTemplateSyncRequest struct {
+	TemplateID string `json:"template_id" form:"template_id" binding:"required" example:"123456789012345678"` // 模板ID
+}
+
+// PermissionBrief 权限摘要
+type PermissionBrief struct {
+	PermissionID string `json:"permission_id" example:"123456789012345678"` // 权限ID
+	Name         string `json:"name" example:"用户管理"`                        // 权限名称
+	Type         string `json:"type" example:"MENU"`                        // 类型：MENU/BUTTON/API
+	Resource     string `json:"resource" example:"menu:user"`               // 资源标识
+	Action       string `json:"action,omitempty" example:"GET"`             // 请求方法（仅API类型）
+}
+
+// RoleTemplateRoleDiff 派生角色与模板之间的权限差异
+type RoleTemplateRoleDiff struct {
+	RoleID     string             `json:"role_id" example:"123456789012345678"`   // 角色ID
+	RoleCode   string             `json:"role_code" example:"auditor"`            // 角色编码
+	RoleName   string             `json:"role_name" example:"监管员"`                // 角色名称
+	TenantID   string             `json:"tenant_id" example:"123456789012345678"` // 租户ID
+	TenantCode string             `json:"tenant_code" example:"tenant_shanghai"`  // 租户编码
+	Added      []*PermissionBrief `json:"added"`                                  // 同步后新增的权限
+	Removed    []*PermissionBrief `json:"removed"`                                // 同步后移除的权限
+}
+
+// RoleTemplateSyncPreviewResponse 模板同步预览响应
+type RoleTemplateSyncPreviewResponse struct {
+	TemplateID   string                  `json:"template_id" example:"123456789012345678"` // 模板ID
+	TotalRoles   int                     `json:"total_roles" example:"12"`                 // 派生角色总数
+	ChangedRoles int                     `json:"changed_roles" example:"3"`                // 需要变更的角色数
+	Roles        []*RoleTemplateRoleDiff `json:"roles"`                                    // 需要变更的角色及差异
+}
+
+// RoleTemplateSyncResponse 模板同步结果
+type RoleTemplateSyncResponse struct {
+	TemplateID  string `json:"template_id" example:"123456789012345678"` // 模板ID
+	TotalRoles  int    `json:"total_roles" example:"12"`                 // 派生角色总数
+	SyncedRoles int    `json:"synced_roles" example:"3"`                 // 实际变更的角色数
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "admin/pkg/utils/pagination"
+
+// CreateServiceAccountRequest 创建服务账号请求
+type CreateServiceAccountRequest struct {
+	UserName    string   `json:"username" binding:"required,max=100" example:"ci-bot"`      // 账号名称（租户内唯一）
+	Nickname    string   `json:"nickname" binding:"omitempty,max=100" example:"CI 机器人"`     // 显示名称
+	Description string   `json:"description" binding:"omitempty,max=500" example:"部署流水线使用"` // 描述
+	RoleCodes   []string `json:"role_codes" example:"[\"auditor\"]"`                        // 初始角色编码列表（可选，后续通过用户角色接口调整）
+}
+
+// ServiceAccountInfo 服务账号信息（不含密钥）
+type ServiceAccountInfo struct {
+	UserID          string `json:"user_id" example:"123456789012345678"`      // 服务账号用户ID
+	UserName        string `json:"username" example:"ci-bot"`                 // 账号名称
+	Nickname        string `json:"nickname" example:"CI 机器人"`                 // 显示名称
+	Description     string `json:"description" example:"部署流水线使用"`             // 描述
+	Status          int    `json:"status" example:"1" enum:"1,2"`             // 状态 1:正常 2:禁用
+	ClientID        string `json:"client_id" example:"sa_123456789012345678"` // 客户端ID
+	SecretRotatedAt int64  `json:"secret_rotated_at" example:"1735200000000"` // 最近一次密钥轮换时间
+	LastUsedAt      int64  `json:"last_used_at" example:"1735200000000"`      // 最后换取令牌时间
+	CreatedAt       int64  `json:"created_at" example:"1735200000000"`        // 创建时间
+}
+
+// CreateServiceAccountResponse 创建服务账号响应
+type CreateServiceAccountResponse struct {
+	ServiceAccountInfo
+	ClientSecret string `json:"client_secret" example:"9f86d081884c7d65"` // 客户端密钥明文（仅返回一次）
+	Message      string `json:"message" example:"请妥善保存客户端密钥，关闭后将无法再次查看"`  // 提示信息
+}
+
+// ListServiceAccountsRequest 服务账号列表请求
+type ListServiceAccountsRequest struct {
+	pagination.Request `json:",inline"`
+	Keyword            string `form:"keyword" binding:"omitempty,max=100"` // 账号名称/显示名称（模糊匹配）
+}
+
+// ListServiceAccountsResponse 服务账号列表响应
+type ListServiceAccountsResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*ServiceAccountInfo `json:"list"` // 列表数据
+}
+
+// RotateServiceAccountSecretRequest 轮换服务账号密钥请求
+type RotateServiceAccountSecretRequest struct {
+	UserID string `json:"user_id" binding:"required" example:"123456789012345678"` // 服务账号用户ID
+}
+
+// RotateServiceAccountSecretResponse 轮换服务账号密钥响应
+type RotateServiceAccountSecretResponse struct {
+	ClientID     string `json:"client_id" example:"sa_123456789012345678"` // 客户端ID
+	ClientSecret string `json:"client_secret" example:"9f86d081884c7d65"`  // 新的客户端密钥明文（仅返回一次）
+	Message      string `json:"message" example:"密钥已轮换，旧密钥及已签发的令牌立即失效"`    // 提示信息
+}
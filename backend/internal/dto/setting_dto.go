@@ -0,0 +1,50 @@
+package dto
+
+import "encoding/json"
+
+// SettingInfo 设置项信息（已合并内置默认值、平台默认值和租户覆盖值）
+type SettingInfo struct {
+	Key          string   `json:"key" example:"branding.primary_color"`      // 设置项键
+	Type         string   `json:"type" example:"color"`                      // 值类型（string/int/bool/color/url/enum/string_list）
+	Label        string   `json:"label" example:"主题色"`                       // 显示名称
+	Description  string   `json:"description,omitempty"`                     // 说明
+	Value        any      `json:"value"`                                     // 生效值
+	DefaultValue any      `json:"default_value"`                             // 恢复默认后的值（平台默认值或内置默认值）
+	Source       string   `json:"source" example:"platform"`                 // 生效值来源（builtin:内置默认, platform:平台默认, custom:租户覆盖）
+	Version      int32    `json:"version" example:"1"`                       // 当前租户记录的版本号（0:未设置），修改时原样提交
+	Options      []string `json:"options,omitempty"`                         // 可选值（enum/string_list）
+	Min          int      `json:"min,omitempty"`                             // 最小值（int）或最少项数（string_list）
+	Max          int      `json:"max,omitempty"`                             // 最大值（int）或最大长度（string/url）
+	Public       bool     `json:"public" example:"true"`                     // 是否在登录页公开
+	UpdatedBy    string   `json:"updated_by,omitempty"`                      // 最后修改人
+	UpdatedAt    int64    `json:"updated_at,omitempty" example:"1735200000"` // 最后修改时间
+}
+
+// ListSettingsResponse 设置列表响应
+type ListSettingsResponse struct {
+	List []*SettingInfo `json:"list"` // 设置项列表
+}
+
+// UpdateSettingItem 单个设置项修改
+type UpdateSettingItem struct {
+	Key     string          `json:"key" binding:"required,max=100" example:"branding.primary_color"`     // 设置项键
+	Value   json.RawMessage `json:"value" binding:"required" swaggertype:"string" example:"\"#1677ff\""` // 设置值（JSON，类型需与设置项一致）
+	Version int32           `json:"version" binding:"min=0" example:"1"`                                 // 读取时的版本号，未设置过为0
+}
+
+// UpdateSettingsRequest 批量修改设置请求
+type UpdateSettingsRequest struct {
+	Items []UpdateSettingItem `json:"items" binding:"required,min=1,max=50,dive"` // 设置项列表
+}
+
+// SettingKeyRequest 设置项键请求（用于恢复默认值）
+type SettingKeyRequest struct {
+	Key string `json:"key" binding:"required,max=100" example:"branding.logo_url"` // 设置项键
+}
+
+// BrandingResponse 登录页品牌信息响应
+type BrandingResponse struct {
+	TenantCode string         `json:"tenant_code,omitempty" example:"acme"` // 识别出的租户编码，未识别时为空
+	TenantName string         `json:"tenant_name,omitempty" example:"Acme"` // 租户名称
+	Settings   map[string]any `json:"settings"`                             // 公开设置项（键 -> 生效值）
+}
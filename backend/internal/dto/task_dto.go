@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"admin/pkg/utils/pagination"
+	"encoding/json"
+)
+
+// TaskInfo 后台任务信息
+type TaskInfo struct {
+	TaskID          string          `json:"task_id" example:"123456789012345678"`                                                // 任务ID
+	Type            string          `json:"type" example:"export"`                                                               // 任务类型
+	Status          string          `json:"status" example:"RUNNING" enum:"PENDING,RUNNING,CANCELING,SUCCEEDED,FAILED,CANCELED"` // 状态
+	Progress        int16           `json:"progress" example:"40"`                                                               // 进度(0-100)
+	ProgressMessage string          `json:"progress_message,omitempty" example:"已导出 4000/10000 行"`                               // 进度说明
+	Result          json.RawMessage `json:"result,omitempty" swaggertype:"object"`                                               // 执行结果，结构由任务类型决定
+	ErrorMessage    string          `json:"error_message,omitempty"`                                                             // 最近一次失败原因
+	Attempts        int32           `json:"attempts" example:"1"`                                                                // 已执行次数
+	MaxAttempts     int32           `json:"max_attempts" example:"3"`                                                            // 最大执行次数
+	RunAt           int64           `json:"run_at" example:"1735200000000"`                                                      // 最早执行时间(毫秒)，重试时为下次执行时间
+	StartedAt       int64           `json:"started_at" example:"1735200001000"`                                                  // 最近一次开始执行时间(毫秒)
+	FinishedAt      int64           `json:"finished_at" example:"1735200060000"`                                                 // 结束时间(毫秒)
+	CreatedAt       int64           `json:"created_at" example:"1735200000000"`                                                  // 创建时间(毫秒)
+}
+
+// ListTasksRequest 任务列表请求
+type ListTasksRequest struct {
+	pagination.Request `json:",inline"`
+	Type               string `form:"type" example:"export"`                                                                                  // 任务类型筛选
+	Status             string `form:"status" binding:"omitempty,oneof=PENDING RUNNING CANCELING SUCCEEDED FAILED CANCELED" example:"RUNNING"` // 状态筛选
+}
+
+// ListTasksResponse 任务列表响应
+type ListTasksResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*TaskInfo `json:"list"` // 列表数据
+}
+
+// TaskDetailRequest 查询任务请求
+type TaskDetailRequest struct {
+	TaskID string `form:"task_id" binding:"required" example:"123456789012345678"` // 任务ID
+}
+
+// CancelTaskRequest 取消任务请求
+type CancelTaskRequest struct {
+	TaskID string `json:"task_id" binding:"required" example:"123456789012345678"` // 任务ID
+}
@@ -0,0 +1,30 @@
+package dto
+
+// TenantExportRequest 导出租户数据请求
+type TenantExportRequest struct {
+	TenantID           string `form:"tenant_id" binding:"omitempty" example:"123456789012345678"` // 租户ID（仅超级管理员可指定，默认当前租户）
+	IncludeLogs        bool   `form:"include_logs" example:"false"`                               // 是否包含登录日志和操作日志
+	IncludeCredentials bool   `form:"include_credentials" example:"false"`                        // 是否包含用户密码哈希（仅超级管理员，用于环境迁移）
+}
+
+// TenantImportRequest 导入租户数据请求（multipart/form-data，数据包字段名为 file）
+type TenantImportRequest struct {
+	TenantCode string `form:"tenant_code" binding:"omitempty,min=2,max=50" example:"tenant_shanghai"` // 新租户编码，为空时使用数据包中的编码
+	Name       string `form:"name" binding:"omitempty,min=2,max=200" example:"上海分公司"`                 // 新租户名称，为空时使用数据包中的名称
+	DryRun     bool   `form:"dry_run" example:"true"`                                                 // 预检：完整执行导入后回滚，只返回报告
+}
+
+// TenantImportReport 租户数据导入报告
+type TenantImportReport struct {
+	DryRun           bool             `json:"dry_run" example:"true"`                 // 是否为预检
+	Applied          bool             `json:"applied" example:"false"`                // 是否已实际写入
+	TenantID         string           `json:"tenant_id" example:"123456789012345678"` // 新租户ID（预检时为回滚前分配的ID）
+	TenantCode       string           `json:"tenant_code" example:"tenant_shanghai"`  // 新租户编码
+	Name             string           `json:"name" example:"上海分公司"`                   // 新租户名称
+	SourceTenantCode string           `json:"source_tenant_code" example:"tenant_sh"` // 数据包来源租户编码
+	ArchiveVersion   int              `json:"archive_version" example:"1"`            // 数据包格式版本
+	Counts           map[string]int64 `json:"counts"`                                 // 各表导入行数
+	Conflicts        []string         `json:"conflicts"`                              // 冲突（存在冲突时不允许导入）
+	Warnings         []string         `json:"warnings"`                               // 警告（如目标环境缺少的权限、套餐，对应数据被跳过）
+	StrippedGrants   int64            `json:"stripped_grants" example:"0"`            // 超出套餐范围被回收的角色授权数
+}
@@ -4,21 +4,116 @@ import "admin/pkg/utils/pagination"
 
 // TenantCreateRequest 创建租户请求
 type TenantCreateRequest struct {
-	TenantCode   string `json:"tenant_code" binding:"required,min=2,max=50" example:"tenant_shanghai"` // 租户编码（全局唯一）
-	Name         string `json:"name" binding:"required,min=2,max=200" example:"上海分公司"`                 // 租户名称
-	Description  string `json:"description" example:"上海地区业务运营"`                                        // 租户描述
-	ContactName  string `json:"contact_name" binding:"required,max=100" example:"张三"`                  // 联系人姓名
-	ContactPhone string `json:"contact_phone" binding:"required,max=20" example:"13800138000"`         // 联系人手机号
+	TenantCode   string `json:"tenant_code" binding:"required,min=2,max=50" example:"tenant_shanghai"`  // 租户编码（全局唯一）
+	Name         string `json:"name" binding:"required,min=2,max=200" example:"上海分公司"`                  // 租户名称
+	Description  string `json:"description" example:"上海地区业务运营"`                                         // 租户描述
+	ContactName  string `json:"contact_name" binding:"required,max=100" example:"张三"`                   // 联系人姓名
+	ContactPhone string `json:"contact_phone" binding:"required,max=20" example:"13800138000"`          // 联系人手机号
+	ContactEmail string `json:"contact_email" binding:"omitempty,email" example:"contact@shanghai.com"` // 联系人邮箱（接收到期提醒）
+	AdminEmail   string `json:"admin_email" binding:"required,email" example:"admin@shanghai.com"`      // 初始管理员邮箱（登录账号，全局唯一）
+	AdminName    string `json:"admin_name" binding:"omitempty,max=100" example:"shanghai_admin"`        // 初始管理员用户名，为空时使用邮箱
+	AdminPhone   string `json:"admin_phone" binding:"omitempty,max=20" example:"13900139000"`           // 初始管理员手机号
+	PlanID       string `json:"plan_id" binding:"omitempty" example:"123456789012345678"`               // 套餐ID，为空时不限制权限范围
+	Trial        bool   `json:"trial" example:"false"`                                                  // 是否为试用租户
+	ExpiresAt    int64  `json:"expires_at" binding:"omitempty,min=0" example:"1735689600000"`           // 到期时间（毫秒时间戳），0 表示永不过期
+}
+
+// TenantCreateResponse 创建租户响应
+// 初始管理员密码只在初始化完成时返回一次
+type TenantCreateResponse struct {
+	Tenant        *TenantInfo `json:"tenant"`                                      // 租户信息
+	AdminUserID   string      `json:"admin_user_id" example:"123456789012345678"`  // 初始管理员用户ID
+	AdminEmail    string      `json:"admin_email" example:"admin@shanghai.com"`    // 初始管理员邮箱
+	AdminPassword string      `json:"admin_password,omitempty" example:"aB3dE5fG"` // 初始管理员一次性密码
+	Message       string      `json:"message" example:"租户创建成功，请妥善保存管理员初始密码"`       // 提示信息
+}
+
+// TenantProvisionRequest 重试租户初始化请求
+// 初始化中途失败的租户可重新执行，已完成的步骤会被跳过
+type TenantProvisionRequest struct {
+	TenantID   string `json:"tenant_id" binding:"required" example:"123456789012345678"`         // 租户ID
+	AdminEmail string `json:"admin_email" binding:"required,email" example:"admin@shanghai.com"` // 初始管理员邮箱
+	AdminName  string `json:"admin_name" binding:"omitempty,max=100" example:"shanghai_admin"`   // 初始管理员用户名
+	AdminPhone string `json:"admin_phone" binding:"omitempty,max=20" example:"13900139000"`      // 初始管理员手机号
+}
+
+// TenantCloneRequest 克隆租户请求
+// 新租户按创建租户流程初始化，并复制源租户的角色及授权、部门和岗位、字典，可选复制脱敏后的用户
+type TenantCloneRequest struct {
+	SourceTenantID      string `json:"source_tenant_id" binding:"required" example:"123456789012345678"` // 源租户ID
+	IncludeUsers        bool   `json:"include_users" example:"false"`                                    // 是否复制用户（姓名、邮箱、手机号等个人信息脱敏，不复制密码）
+	TenantCreateRequest `json:",inline"`
+}
+
+// TenantCloneResponse 克隆租户响应
+type TenantCloneResponse struct {
+	TenantCreateResponse `json:",inline"`
+	SourceTenantID       string           `json:"source_tenant_id" example:"123456789012345678"` // 源租户ID
+	Counts               map[string]int64 `json:"counts"`                                        // 各类数据复制数量
 }
 
 // TenantUpdateRequest 更新租户请求
 type TenantUpdateRequest struct {
-	TenantID     string `json:"tenant_id" form:"tenant_id" binding:"required" example:"123456789012345678"` // 租户ID
-	Name         string `json:"name" binding:"omitempty,min=2,max=200" example:"上海分公司"`                     // 租户名称
-	Description  string `json:"description" example:"上海地区业务运营"`                                             // 租户描述
-	ContactName  string `json:"contact_name" binding:"omitempty,max=100" example:"张三"`                      // 联系人姓名
-	ContactPhone string `json:"contact_phone" binding:"omitempty,max=20" example:"13800138000"`             // 联系人手机号
-	Status       int    `json:"status" binding:"omitempty,oneof=1 2" example:"1"`                           // 状态：1-正常，2-禁用
+	TenantID         string `json:"tenant_id" form:"tenant_id" binding:"required" example:"123456789012345678"` // 租户ID
+	Name             string `json:"name" binding:"omitempty,min=2,max=200" example:"上海分公司"`                     // 租户名称
+	Description      string `json:"description" example:"上海地区业务运营"`                                             // 租户描述
+	ContactName      string `json:"contact_name" binding:"omitempty,max=100" example:"张三"`                      // 联系人姓名
+	ContactPhone     string `json:"contact_phone" binding:"omitempty,max=20" example:"13800138000"`             // 联系人手机号
+	ContactEmail     string `json:"contact_email" binding:"omitempty,email" example:"contact@shanghai.com"`     // 联系人邮箱
+	Status           int    `json:"status" binding:"omitempty,oneof=1 2" example:"1"`                           // 状态：1-正常，2-禁用
+	LoginRiskPolicy  string `json:"login_risk_policy" binding:"omitempty,oneof=ALLOW MFA BLOCK" example:"MFA"`  // 登录风险策略：ALLOW-放行并通知，MFA-二次验证，BLOCK-拒绝
+	AllowSharedEmail int    `json:"allow_shared_email" binding:"omitempty,oneof=1 2" example:"2"`               // 是否允许与其他租户共享邮箱：1-是，2-否
+}
+
+// TenantSubscriptionRequest 变更租户订阅请求（续期、转正、归档）
+type TenantSubscriptionRequest struct {
+	TenantID        string `json:"tenant_id" binding:"required" example:"123456789012345678"`                         // 租户ID
+	LifecycleStatus string `json:"lifecycle_status" binding:"omitempty,oneof=TRIAL ACTIVE ARCHIVED" example:"ACTIVE"` // 生命周期状态，为空时按到期时间自动判断
+	ExpiresAt       *int64 `json:"expires_at" binding:"omitempty,min=0" example:"1767225600000"`                      // 到期时间（毫秒时间戳），0 表示永不过期，不传表示不变
+}
+
+// TenantPlanRequest 变更租户套餐请求
+type TenantPlanRequest struct {
+	TenantID string `json:"tenant_id" binding:"required" example:"123456789012345678"` // 租户ID
+	PlanID   string `json:"plan_id" binding:"omitempty" example:"123456789012345678"`  // 套餐ID，为空表示取消套餐限制
+}
+
+// TenantPlanResponse 变更租户套餐响应
+type TenantPlanResponse struct {
+	Tenant         *TenantInfo `json:"tenant"`                       // 租户信息
+	StrippedGrants int64       `json:"stripped_grants" example:"12"` // 回收的超出套餐范围的角色授权数
+}
+
+// TenantUsageRequest 租户资源用量请求
+type TenantUsageRequest struct {
+	TenantID string `form:"tenant_id" binding:"omitempty" example:"123456789012345678"` // 租户ID（仅超级管理员可指定，默认当前租户）
+}
+
+// QuotaUsage 单项资源用量
+type QuotaUsage struct {
+	Resource string `json:"resource" example:"users"` // 资源类型：users/roles/departments/storage
+	Used     int64  `json:"used" example:"42"`        // 当前用量（storage 单位为字节）
+	Limit    int64  `json:"limit" example:"100"`      // 配额上限，0 表示不限制（storage 单位为字节）
+	Source   string `json:"source" example:"PLAN"`    // 配额来源：TENANT-租户单独设置，PLAN-套餐默认，NONE-不限制
+}
+
+// TenantUsageResponse 租户资源用量响应
+type TenantUsageResponse struct {
+	TenantID string        `json:"tenant_id" example:"123456789012345678"` // 租户ID
+	PlanID   string        `json:"plan_id" example:"123456789012345678"`   // 套餐ID
+	Items    []*QuotaUsage `json:"items"`                                  // 各项资源用量
+}
+
+// TenantQuotaItem 租户配额设置项
+type TenantQuotaItem struct {
+	Resource string `json:"resource" binding:"required,oneof=users roles departments storage" example:"users"` // 资源类型
+	Limit    *int64 `json:"limit" binding:"omitempty,min=0" example:"200"`                                     // 配额上限（storage 单位为MB），0 表示不限制，不传表示恢复套餐默认
+}
+
+// TenantQuotaRequest 设置租户配额请求
+type TenantQuotaRequest struct {
+	TenantID string             `json:"tenant_id" binding:"required" example:"123456789012345678"` // 租户ID
+	Quotas   []*TenantQuotaItem `json:"quotas" binding:"required,min=1,dive"`                      // 配额设置
 }
 
 // TenantDetailRequest 获取租户详情请求
@@ -58,13 +153,84 @@ type TenantListResponse struct {
 
 // TenantInfo 租户信息（可复用）
 type TenantInfo struct {
-	TenantID     string `json:"tenant_id" example:"123456789012345678"` // 租户ID
-	TenantCode   string `json:"tenant_code" example:"tenant_shanghai"`  // 租户编码
-	Name         string `json:"name" example:"上海分公司"`                   // 租户名称
-	Description  string `json:"description" example:"上海地区业务运营"`         // 租户描述
-	ContactName  string `json:"contact_name" example:"张三"`              // 联系人姓名
-	ContactPhone string `json:"contact_phone" example:"13800138000"`    // 联系人手机号
-	Status       int    `json:"status" example:"1"`                     // 状态：1-正常，2-禁用
-	CreatedAt    int64  `json:"created_at" example:"1703123456789"`     // 创建时间
-	UpdatedAt    int64  `json:"updated_at" example:"1703123456789"`     // 更新时间
+	TenantID         string `json:"tenant_id" example:"123456789012345678"`       // 租户ID
+	TenantCode       string `json:"tenant_code" example:"tenant_shanghai"`        // 租户编码
+	Name             string `json:"name" example:"上海分公司"`                         // 租户名称
+	Description      string `json:"description" example:"上海地区业务运营"`               // 租户描述
+	ContactName      string `json:"contact_name" example:"张三"`                    // 联系人姓名
+	ContactPhone     string `json:"contact_phone" example:"13800138000"`          // 联系人手机号
+	ContactEmail     string `json:"contact_email" example:"contact@shanghai.com"` // 联系人邮箱
+	Status           int    `json:"status" example:"1"`                           // 状态：1-正常，2-禁用
+	LifecycleStatus  string `json:"lifecycle_status" example:"ACTIVE"`            // 生命周期状态：TRIAL/ACTIVE/GRACE/EXPIRED/ARCHIVED
+	ExpiresAt        int64  `json:"expires_at" example:"1767225600000"`           // 到期时间（毫秒时间戳），0 表示永不过期
+	LoginRiskPolicy  string `json:"login_risk_policy" example:"ALLOW"`            // 登录风险策略：ALLOW/MFA/BLOCK
+	AllowSharedEmail int    `json:"allow_shared_email" example:"2"`               // 是否允许与其他租户共享邮箱：1-是，2-否
+	ProvisionStatus  string `json:"provision_status" example:"READY"`             // 初始化状态：PROVISIONING/READY
+	ProvisionError   string `json:"provision_error,omitempty" example:""`         // 最近一次初始化失败原因
+	PlanID           string `json:"plan_id" example:"123456789012345678"`         // 套餐ID，为空表示不限制
+	CreatedAt        int64  `json:"created_at" example:"1703123456789"`           // 创建时间
+	UpdatedAt        int64  `json:"updated_at" example:"1703123456789"`           // 更新时间
+}
+
+// TenantDomainCreateRequest 绑定自定义域名请求
+type TenantDomainCreateRequest struct {
+	TenantID string `json:"tenant_id" binding:"omitempty" example:"123456789012345678"`      // 租户ID（仅超级管理员可指定，默认当前租户）
+	Domain   string `json:"domain" binding:"required,fqdn,max=255" example:"admin.acme.com"` // 自定义域名
+}
+
+// TenantDomainListRequest 自定义域名列表请求
+type TenantDomainListRequest struct {
+	TenantID string `form:"tenant_id" binding:"omitempty" example:"123456789012345678"` // 租户ID（仅超级管理员可指定，默认当前租户）
+}
+
+// TenantDomainRequest 自定义域名操作请求（验证、删除）
+type TenantDomainRequest struct {
+	DomainID string `json:"domain_id" form:"domain_id" binding:"required" example:"123456789012345678"` // 域名绑定ID
+}
+
+// TenantDomainInfo 自定义域名信息
+type TenantDomainInfo struct {
+	DomainID   string `json:"domain_id" example:"123456789012345678"`          // 域名绑定ID
+	TenantID   string `json:"tenant_id" example:"123456789012345678"`          // 租户ID
+	Domain     string `json:"domain" example:"admin.acme.com"`                 // 自定义域名
+	Verified   bool   `json:"verified" example:"false"`                        // 是否已验证
+	VerifiedAt int64  `json:"verified_at" example:"0"`                         // 验证通过时间
+	TXTName    string `json:"txt_name" example:"_admin-verify.admin.acme.com"` // 需添加的 DNS TXT 记录名
+	TXTValue   string `json:"txt_value" example:"admin-verify=3f9a..."`        // 需添加的 DNS TXT 记录值
+	CreatedAt  int64  `json:"created_at" example:"1703123456789"`              // 创建时间
+}
+
+// TenantRestoreRequest 恢复已删除租户请求
+type TenantRestoreRequest struct {
+	TenantID string `json:"tenant_id" binding:"required" example:"123456789012345678"` // 租户ID
+}
+
+// TenantPurgeListRequest 租户彻底清除记录列表请求
+type TenantPurgeListRequest struct {
+	pagination.Request `json:",inline"`
+	TenantCode         string `form:"tenant_code" example:"tenant_shanghai"`                                                            // 租户编码（模糊查询）
+	Status             string `form:"status" binding:"omitempty,oneof=SCHEDULED RUNNING FAILED COMPLETED CANCELED" example:"SCHEDULED"` // 状态筛选
+}
+
+// TenantPurgeInfo 租户彻底清除记录
+type TenantPurgeInfo struct {
+	PurgeID      string           `json:"purge_id" example:"123456789012345678"`  // 清除记录ID
+	TenantID     string           `json:"tenant_id" example:"123456789012345678"` // 租户ID
+	TenantCode   string           `json:"tenant_code" example:"tenant_shanghai"`  // 租户编码
+	TenantName   string           `json:"tenant_name" example:"上海分公司"`            // 租户名称
+	Status       string           `json:"status" example:"SCHEDULED"`             // 状态（SCHEDULED/RUNNING/FAILED/COMPLETED/CANCELED）
+	PurgeAfter   int64            `json:"purge_after" example:"1705715456789"`    // 保留期结束时间，之前可恢复
+	CurrentTable string           `json:"current_table" example:"users"`          // 正在清除的表
+	DeletedRows  int64            `json:"deleted_rows" example:"0"`               // 已删除总行数
+	Report       map[string]int64 `json:"report"`                                 // 各表删除行数
+	ErrorMessage string           `json:"error_message" example:""`               // 失败原因
+	StartedAt    int64            `json:"started_at" example:"0"`                 // 开始清除时间
+	FinishedAt   int64            `json:"finished_at" example:"0"`                // 结束时间
+	CreatedAt    int64            `json:"created_at" example:"1703123456789"`     // 删除时间
+}
+
+// TenantPurgeListResponse 租户彻底清除记录列表响应
+type TenantPurgeListResponse struct {
+	pagination.Response `json:",inline"`
+	List                []*TenantPurgeInfo `json:"list"` // 列表数据
 }
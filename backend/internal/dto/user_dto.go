@@ -30,22 +30,24 @@ type UpdateUserRequest struct {
 
 // UserInfo 用户基础信息（可复用）
 type UserInfo struct {
-	UserID             string      `json:"user_id" example:"123456789012345678"`            // 用户ID
-	UserName           string      `json:"username" example:"admin"`                        // 用户名
-	Nickname           string      `json:"nickname" example:"系统管理员"`                        // 昵称/显示名称
-	Avatar             string      `json:"avatar" example:"https://example.com/avatar.jpg"` // 头像URL
-	Phone              string      `json:"phone" example:"13800138000"`                     // 手机号
-	Email              string      `json:"email" example:"admin@example.com"`               // 邮箱
-	Description        string      `json:"description" example:"用户描述"`                      // 用户描述
-	Remark             string      `json:"remark" example:"备注信息"`                           // 备注信息
-	Status             int         `json:"status" example:"1" enum:"1,2"`                   // 状态 1:正常 2:禁用
-	TenantID           string      `json:"tenant_id" example:"123456789012345678"`          // 租户ID
-	LastLoginTime      int64       `json:"last_login_time" example:"1735206400"`            // 最后登录时间（Unix时间戳）
-	MustChangePassword int16       `json:"must_change_password" example:"1" enum:"1,2"`     // 是否必须修改密码 1:是 2:否
-	CreatedAt          int64       `json:"created_at" example:"1735200000"`                 // 创建时间（Unix时间戳）
-	UpdatedAt          int64       `json:"updated_at" example:"1735206400"`                 // 更新时间（Unix时间戳）
-	Roles              []*RoleInfo `json:"roles"`                                           // 角色列表
-	Tenant             *TenantInfo `json:"tenant"`                                          // 租户信息
+	UserID             string              `json:"user_id" example:"123456789012345678"`            // 用户ID
+	UserName           string              `json:"username" example:"admin"`                        // 用户名
+	Nickname           string              `json:"nickname" example:"系统管理员"`                        // 昵称/显示名称
+	Avatar             string              `json:"avatar" example:"https://example.com/avatar.jpg"` // 头像URL
+	Phone              string              `json:"phone" example:"13800138000"`                     // 手机号
+	Email              string              `json:"email" example:"admin@example.com"`               // 邮箱
+	Description        string              `json:"description" example:"用户描述"`                      // 用户描述
+	Remark             string              `json:"remark" example:"备注信息"`                           // 备注信息
+	Status             int                 `json:"status" example:"1" enum:"1,2"`                   // 状态 1:正常 2:禁用
+	TenantID           string              `json:"tenant_id" example:"123456789012345678"`          // 租户ID
+	LastLoginTime      int64               `json:"last_login_time" example:"1735206400"`            // 最后登录时间（Unix时间戳）
+	MustChangePassword int16               `json:"must_change_password" example:"1" enum:"1,2"`     // 是否必须修改密码 1:是 2:否
+	IsServiceAccount   int16               `json:"is_service_account" example:"2" enum:"1,2"`       // 是否服务账号 1:是 2:否
+	CreatedAt          int64               `json:"created_at" example:"1735200000"`                 // 创建时间（Unix时间戳）
+	UpdatedAt          int64               `json:"updated_at" example:"1735206400"`                 // 更新时间（Unix时间戳）
+	Roles              []*RoleInfo         `json:"roles"`                                           // 角色列表
+	Tenant             *TenantInfo         `json:"tenant"`                                          // 租户信息
+	Positions          []*UserPositionInfo `json:"positions,omitempty"`                             // 岗位列表（主岗位在前，仅用户详情返回）
 }
 
 // ListUsersRequest 用户列表请求
@@ -64,9 +66,17 @@ type ListUsersResponse struct {
 
 // ProfileResponse 用户档案响应（含角色和租户信息）
 type ProfileResponse struct {
-	User   *UserInfo   `json:"user"`   // 当前用户信息
-	Tenant *TenantInfo `json:"tenant"` // 当前租户信息
-	Roles  []*RoleInfo `json:"roles"`  // 用户角色列表
+	User          *UserInfo          `json:"user"`                    // 当前用户信息
+	Tenant        *TenantInfo        `json:"tenant"`                  // 当前租户信息
+	Roles         []*RoleInfo        `json:"roles"`                   // 用户角色列表
+	Impersonation *ImpersonationInfo `json:"impersonation,omitempty"` // 模拟登录信息（非模拟登录时为空，前端据此显示提示横幅）
+}
+
+// ImpersonationInfo 模拟登录信息
+type ImpersonationInfo struct {
+	Active           bool   `json:"active" example:"true"`                        // 是否处于模拟登录状态
+	ImpersonatorID   string `json:"impersonator_id" example:"123456789012345678"` // 实际操作者ID
+	ImpersonatorName string `json:"impersonator_name" example:"admin"`            // 实际操作者用户名
 }
 
 // AssignRolesRequest 为用户分配角色请求
@@ -133,3 +143,63 @@ type UserStatusRequest struct {
 	UserID string `json:"user_id" binding:"required" example:"123456789012345678"` // 用户ID
 	Status int    `json:"status" binding:"required" example:"1"`                   // 状态值
 }
+
+// UpdateProfileRequest 修改个人资料请求
+// 只包含允许用户自行修改的字段，不传的字段保持不变；邮箱和手机号需验证后通过单独接口修改
+type UpdateProfileRequest struct {
+	Nickname    *string `json:"nickname" binding:"omitempty,min=1,max=50" example:"张三"` // 昵称
+	Description *string `json:"description" binding:"omitempty,max=500" example:"个人简介"` // 个人简介
+}
+
+// UploadAvatarResponse 上传头像响应
+type UploadAvatarResponse struct {
+	Avatar string `json:"avatar" example:"/uploads/avatars/123456789012345678/123456789012345679.png"` // 头像URL
+}
+
+// SendContactCodeRequest 发送邮箱/手机号变更验证码请求
+type SendContactCodeRequest struct {
+	Type  string `json:"type" binding:"required,oneof=email phone" example:"email"`  // 变更类型 email:邮箱 phone:手机号
+	Value string `json:"value" binding:"required,max=100" example:"new@example.com"` // 新的邮箱或手机号，验证码发送到该地址
+}
+
+// SendContactCodeResponse 发送邮箱/手机号变更验证码响应
+type SendContactCodeResponse struct {
+	Target    string `json:"target" example:"n***@example.com"` // 验证码接收地址（脱敏）
+	ExpiresIn int    `json:"expires_in" example:"600"`          // 验证码有效期(秒)
+}
+
+// VerifyContactRequest 验证并修改邮箱/手机号请求
+type VerifyContactRequest struct {
+	Type string `json:"type" binding:"required,oneof=email phone" example:"email"` // 变更类型 email:邮箱 phone:手机号
+	Code string `json:"code" binding:"required,len=6,numeric" example:"123456"`    // 验证码
+}
+
+// UserPositionInfo 用户担任的岗位
+type UserPositionInfo struct {
+	PositionID   string `json:"position_id" example:"123456789012345678"` // 岗位ID
+	PositionCode string `json:"position_code" example:"SENIOR_ENGINEER"`  // 岗位编码
+	PositionName string `json:"position_name" example:"高级工程师"`            // 岗位名称
+	Level        int    `json:"level" example:"7"`                        // 职级
+	Status       int    `json:"status" example:"1" enum:"1,2"`            // 岗位状态 1:启用 2:禁用
+	IsPrimary    int16  `json:"is_primary" example:"1" enum:"1,2"`        // 是否主岗位 1:是 2:否
+}
+
+// AssignPositionsRequest 为用户设置岗位请求（覆盖式）
+type AssignPositionsRequest struct {
+	UserID            string   `json:"user_id" binding:"required" example:"123456789012345678"`              // 用户ID
+	PositionIDs       []string `json:"position_ids" binding:"omitempty,max=20,dive,required"`                // 岗位ID列表，为空表示清空岗位
+	PrimaryPositionID string   `json:"primary_position_id" binding:"omitempty" example:"123456789012345678"` // 主岗位ID，须包含在岗位列表中，为空时取列表第一个
+}
+
+// UserPositionsResponse 用户岗位响应
+type UserPositionsResponse struct {
+	UserID    string              `json:"user_id" example:"123456789012345678"` // 用户ID
+	UserName  string              `json:"username" example:"admin"`             // 用户名
+	Positions []*UserPositionInfo `json:"positions"`                            // 岗位列表（主岗位在前）
+}
+
+// ListPositionUsersRequest 岗位下的用户列表请求
+type ListPositionUsersRequest struct {
+	pagination.Request `json:",inline"`
+	PositionID         string `form:"position_id" binding:"required" example:"123456789012345678"` // 岗位ID
+}
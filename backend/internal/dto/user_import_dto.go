@@ -0,0 +1,35 @@
+package dto
+
+// ImportUsersRequest 批量导入用户请求（multipart/form-data，文件字段名为 file）
+type ImportUsersRequest struct {
+	DryRun bool `form:"dry_run" example:"true"` // 预检：只校验不写入，结果文件不含初始密码
+}
+
+// ImportUserRow 单行导入结果
+type ImportUserRow struct {
+	Line         int      `json:"line"`          // 文件中的行号（表头为第 1 行）
+	UserName     string   `json:"user_name"`     // 用户名（为空时使用邮箱）
+	Nickname     string   `json:"nickname"`      // 昵称（为空时使用邮箱）
+	Email        string   `json:"email"`         // 邮箱
+	Phone        string   `json:"phone"`         // 手机号
+	Department   string   `json:"department"`    // 部门名称
+	Position     string   `json:"position"`      // 岗位名称或编码
+	RoleCodes    []string `json:"role_codes"`    // 角色编码列表
+	Description  string   `json:"description"`   // 描述
+	Remark       string   `json:"remark"`        // 备注
+	DepartmentID string   `json:"department_id"` // 解析后的部门ID
+	PositionID   string   `json:"position_id"`   // 解析后的岗位ID
+	RoleIDs      []string `json:"role_ids"`      // 解析后的角色ID列表
+	Errors       []string `json:"errors"`        // 校验错误，为空表示校验通过
+	UserID       string   `json:"user_id"`       // 创建的用户ID
+	Password     string   `json:"-"`             // 生成的初始密码，仅写入结果文件
+}
+
+// ImportUsersReport 批量导入用户报告
+type ImportUsersReport struct {
+	DryRun    bool             `json:"dry_run"`   // 是否为预检
+	Total     int              `json:"total"`     // 数据行数
+	Succeeded int              `json:"succeeded"` // 创建成功（预检时为校验通过）的行数
+	Failed    int              `json:"failed"`    // 校验失败的行数
+	Rows      []*ImportUserRow `json:"rows"`      // 各行结果
+}
@@ -0,0 +1,19 @@
+package accesstoken
+
+import (
+	"admin/internal/rbac"
+	accesstokensvc "admin/internal/service/accesstoken"
+	"admin/pkg/audit"
+
+	"gorm.io/gorm"
+)
+
+// Handler 访问令牌处理器
+type Handler struct {
+	svc *accesstokensvc.Service
+}
+
+// NewHandler 创建访问令牌处理器
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache) *Handler {
+	return &Handler{svc: accesstokensvc.NewService(db, recorder, cache)}
+}
@@ -0,0 +1,87 @@
+package accesstoken
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPIKey 创建租户 API Key
+// @Summary 创建租户 API Key
+// @Description 为当前租户创建 API Key，授权范围须为创建人权限的子集，密钥明文仅返回一次
+// @Tags 访问令牌
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreateAccessTokenRequest true "创建访问令牌请求参数"
+// @Success 200 {object} response.Response{data=dto.CreateAccessTokenResponse} "创建成功"
+// @Router /api/v1/api-keys [post]
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	var req dto.CreateAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CreateAPIKey(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListAPIKeys 获取租户 API Key 列表
+// @Summary 获取租户 API Key 列表
+// @Description 分页获取当前租户的 API Key
+// @Tags 访问令牌
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param name query string false "名称(模糊匹配)"
+// @Success 200 {object} response.Response{data=dto.ListAccessTokensResponse} "获取成功"
+// @Router /api/v1/api-keys [get]
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	var req dto.ListAccessTokensRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListAPIKeys(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// RevokeAPIKey 吊销租户 API Key
+// @Summary 吊销租户 API Key
+// @Description 吊销当前租户的 API Key，吊销后立即失效
+// @Tags 访问令牌
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.AccessTokenDeleteRequest true "吊销访问令牌请求参数"
+// @Success 200 {object} response.Response "吊销成功"
+// @Router /api/v1/api-keys [delete]
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	var req dto.AccessTokenDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.RevokeAPIKey(c.Request.Context(), req.TokenID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"revoked": true})
+}
@@ -0,0 +1,87 @@
+package accesstoken
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePersonalToken 创建个人访问令牌
+// @Summary 创建个人访问令牌
+// @Description 为当前用户创建个人访问令牌（PAT），授权范围须为本人权限的子集，令牌明文仅返回一次
+// @Tags 访问令牌
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreateAccessTokenRequest true "创建访问令牌请求参数"
+// @Success 200 {object} response.Response{data=dto.CreateAccessTokenResponse} "创建成功"
+// @Router /api/v1/user/tokens [post]
+func (h *Handler) CreatePersonalToken(c *gin.Context) {
+	var req dto.CreateAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CreatePersonalToken(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListPersonalTokens 获取个人访问令牌列表
+// @Summary 获取个人访问令牌列表
+// @Description 分页获取当前用户的个人访问令牌
+// @Tags 访问令牌
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param name query string false "令牌名称(模糊匹配)"
+// @Success 200 {object} response.Response{data=dto.ListAccessTokensResponse} "获取成功"
+// @Router /api/v1/user/tokens [get]
+func (h *Handler) ListPersonalTokens(c *gin.Context) {
+	var req dto.ListAccessTokensRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListPersonalTokens(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// RevokePersonalToken 吊销个人访问令牌
+// @Summary 吊销个人访问令牌
+// @Description 吊销当前用户的个人访问令牌，吊销后立即失效
+// @Tags 访问令牌
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.AccessTokenDeleteRequest true "吊销访问令牌请求参数"
+// @Success 200 {object} response.Response "吊销成功"
+// @Router /api/v1/user/tokens [delete]
+func (h *Handler) RevokePersonalToken(c *gin.Context) {
+	var req dto.AccessTokenDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.RevokePersonalToken(c.Request.Context(), req.TokenID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"revoked": true})
+}
@@ -1,10 +1,13 @@
 package auth
 
 import (
+	"admin/internal/rbac"
 	authsvc "admin/internal/service/auth"
 	"admin/pkg/audit"
 	"admin/pkg/config"
+	"admin/pkg/utils/geoip"
 	"admin/pkg/utils/jwt"
+	"admin/pkg/utils/notify"
 	"admin/pkg/utils/rsapwd"
 
 	"github.com/redis/go-redis/v9"
@@ -18,6 +21,6 @@ type Handler struct {
 }
 
 // NewHandler 创建认证处理器
-func NewHandler(db *gorm.DB, jwtMgr *jwt.Manager, rdb redis.UniversalClient, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cfg *config.Config) *Handler {
-	return &Handler{svc: authsvc.NewService(db, jwtMgr, rdb, recorder, rsaCipher, cfg)}
+func NewHandler(db *gorm.DB, jwtMgr *jwt.Manager, rdb redis.UniversalClient, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cfg *config.Config, geo geoip.Resolver, notifier notify.Sender, permCache *rbac.PermissionCache) *Handler {
+	return &Handler{svc: authsvc.NewService(db, jwtMgr, rdb, recorder, rsaCipher, cfg, geo, notifier, permCache)}
 }
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Impersonate 处理模拟登录请求
+// @Summary 模拟用户登录
+// @Description 以目标用户身份签发限时访问令牌（不可刷新），用于复现用户看到的菜单、按钮和数据。令牌 act 声明记录实际操作者，期间的操作日志同时记录双方身份，修改密码等敏感操作被禁止。使用该令牌调用登出接口即结束模拟
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.ImpersonateRequest true "模拟登录请求参数"
+// @Success 200 {object} response.Response{data=dto.ImpersonateResponse} "模拟成功"
+// @Router /api/v1/auth/impersonate [post]
+func (h *Handler) Impersonate(c *gin.Context) {
+	var req dto.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.Impersonate(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VerifyLoginMFA 处理登录二次验证请求
+// @Summary 登录二次验证
+// @Description 登录被识别为异常且租户策略要求二次验证时，使用登录接口返回的挑战ID和邮箱验证码完成登录
+// @Tags 认证
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginMFARequest true "二次验证请求参数"
+// @Success 200 {object} response.Response{data=dto.LoginResponse} "登录成功"
+// @Router /api/v1/auth/login/mfa [post]
+func (h *Handler) VerifyLoginMFA(c *gin.Context) {
+	var req dto.LoginMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.VerifyLoginMFA(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
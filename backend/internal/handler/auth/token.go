@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/xerr"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Token 处理 OAuth2 令牌请求
+// @Summary 服务账号换取访问令牌
+// @Description OAuth2 client_credentials 授权（RFC 6749 4.4）。客户端凭证可通过表单字段或 HTTP Basic 认证传递，响应为标准 OAuth2 格式（不包裹统一响应结构）
+// @Tags 认证
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "授权类型" Enums(client_credentials)
+// @Param client_id formData string false "客户端ID（未使用 HTTP Basic 时必填）"
+// @Param client_secret formData string false "客户端密钥（未使用 HTTP Basic 时必填）"
+// @Success 200 {object} dto.ClientCredentialsResponse "签发成功"
+// @Failure 400 {object} dto.OAuthErrorResponse "请求无效"
+// @Failure 401 {object} dto.OAuthErrorResponse "客户端认证失败"
+// @Router /api/v1/auth/token [post]
+func (h *Handler) Token(c *gin.Context) {
+	// RFC 6749 5.1：令牌响应不得被缓存
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var req dto.ClientCredentialsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &dto.OAuthErrorResponse{
+			Error:            "invalid_request",
+			ErrorDescription: err.Error(),
+		})
+		return
+	}
+	if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+		req.ClientID, req.ClientSecret = clientID, clientSecret
+	}
+
+	resp, err := h.svc.ClientCredentials(c.Request.Context(), &req)
+	if err != nil {
+		status, body := oauthError(err)
+		if status == http.StatusUnauthorized {
+			c.Header("WWW-Authenticate", `Basic realm="token"`)
+		}
+		c.JSON(status, body)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// oauthError 将业务错误映射为 OAuth2 错误响应（RFC 6749 5.2）
+func oauthError(err error) (int, *dto.OAuthErrorResponse) {
+	var appErr *xerr.AppError
+	if !errors.As(err, &appErr) {
+		return http.StatusInternalServerError, &dto.OAuthErrorResponse{Error: "server_error", ErrorDescription: "服务器内部错误"}
+	}
+
+	switch appErr.Code {
+	case xerr.ErrUnsupportedGrantType.Code:
+		return http.StatusBadRequest, &dto.OAuthErrorResponse{Error: "unsupported_grant_type", ErrorDescription: appErr.Message}
+	case xerr.ErrInvalidClient.Code:
+		return http.StatusUnauthorized, &dto.OAuthErrorResponse{Error: "invalid_client", ErrorDescription: appErr.Message}
+	case xerr.ErrUserDisabled.Code, xerr.ErrUserNoRoles.Code, xerr.ErrTenantDisabled.Code, xerr.ErrTenantNotFound.Code,
+		xerr.ErrTenantExpired.Code, xerr.ErrTenantArchived.Code:
+		return http.StatusBadRequest, &dto.OAuthErrorResponse{Error: "unauthorized_client", ErrorDescription: appErr.Message}
+	default:
+		return http.StatusInternalServerError, &dto.OAuthErrorResponse{Error: "server_error", ErrorDescription: appErr.Message}
+	}
+}
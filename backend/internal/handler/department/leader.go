@@ -0,0 +1,104 @@
+package department
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDepartmentLeaders 获取部门负责人
+// @Summary 获取部门负责人
+// @Description 获取指定部门的负责人列表，按排序返回，第一位为主要负责人
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param department_id query string true "部门ID"
+// @Success 200 {object} response.Response{data=dto.DepartmentLeadersResponse} "获取成功"
+// @Router /api/v1/departments/leaders [get]
+func (h *Handler) GetDepartmentLeaders(c *gin.Context) {
+	var req dto.DepartmentDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetDepartmentLeaders(c.Request.Context(), req.DepartmentID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// SetDepartmentLeaders 设置部门负责人
+// @Summary 设置部门负责人
+// @Description 设置部门负责人（覆盖式），负责人须为当前租户的用户或成员，可以不在本部门；列表为空表示清空
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.SetDepartmentLeadersRequest true "设置部门负责人请求参数"
+// @Success 200 {object} response.Response "设置成功"
+// @Router /api/v1/departments/leaders [put]
+func (h *Handler) SetDepartmentLeaders(c *gin.Context) {
+	var req dto.SetDepartmentLeadersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.SetDepartmentLeaders(c.Request.Context(), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"assigned": true})
+}
+
+// GetOrgChart 获取组织架构图
+// @Summary 获取组织架构图
+// @Description 获取部门树，每个部门附带本部门人数、含子部门的总人数和负责人列表
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response{data=dto.OrgChartResponse} "获取成功"
+// @Router /api/v1/departments/org-chart [get]
+func (h *Handler) GetOrgChart(c *gin.Context) {
+	resp, err := h.svc.GetOrgChart(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetUserManager 获取用户的直属上级
+// @Summary 获取用户直属上级
+// @Description 从用户所在部门沿部门树向上查找负责人，跳过本人担任负责人的部门和职级不高于本人的负责人；没有上级时 manager 为空
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string true "用户ID"
+// @Success 200 {object} response.Response{data=dto.UserManagerResponse} "获取成功"
+// @Router /api/v1/users/manager [get]
+func (h *Handler) GetUserManager(c *gin.Context) {
+	var req dto.UserDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetManager(c.Request.Context(), req.UserID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,256 @@
+package export
+
+import (
+	"admin/internal/dto"
+	"admin/internal/rbac"
+	exportsvc "admin/internal/service/export"
+	tasksvc "admin/internal/service/task"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/response"
+	"admin/pkg/utils/rsapwd"
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// Handler 列表导出处理器
+type Handler struct {
+	svc *exportsvc.Service
+}
+
+// NewHandler 创建列表导出处理器
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cache *rbac.PermissionCache, worker *tasksvc.Worker, cfg config.ExportConfig) *Handler {
+	return &Handler{
+		svc: exportsvc.NewService(db, recorder, rsaCipher, cache, worker, cfg),
+	}
+}
+
+// ExportUsers 导出用户列表
+// @Summary 导出用户列表
+// @Description 按用户列表的筛选条件导出 CSV/XLSX；行数超过阈值时转为后台任务并返回 JSON 任务信息（dto.ExportJobInfo），完成后通过下载地址获取
+// @Tags 用户管理
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv,json
+// @Security ApiKeyAuth
+// @Param format query string false "文件格式" Enums(csv,xlsx)
+// @Param lang query string false "表头语言" Enums(zh-CN,en-US)
+// @Param nickname query string false "昵称模糊搜索"
+// @Param status query int false "状态筛选" Enums(1,2)
+// @Param tenant_id query string false "租户ID筛选"
+// @Success 200 {file} file "导出文件"
+// @Router /api/v1/users/export [get]
+func (h *Handler) ExportUsers(c *gin.Context) {
+	var req dto.ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+	h.export(c, exportsvc.ResourceUser, &req)
+}
+
+// ExportRoles 导出角色列表
+// @Summary 导出角色列表
+// @Description 按角色列表的筛选条件导出 CSV/XLSX；行数超过阈值时转为后台任务并返回 JSON 任务信息（dto.ExportJobInfo），完成后通过下载地址获取
+// @Tags 角色管理
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv,json
+// @Security ApiKeyAuth
+// @Param format query string false "文件格式" Enums(csv,xlsx)
+// @Param lang query string false "表头语言" Enums(zh-CN,en-US)
+// @Param role_name query string false "角色名称"
+// @Param role_code query string false "角色编码"
+// @Param status query int false "状态筛选" Enums(1,2)
+// @Success 200 {file} file "导出文件"
+// @Router /api/v1/roles/export [get]
+func (h *Handler) ExportRoles(c *gin.Context) {
+	var req dto.ListRolesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+	h.export(c, exportsvc.ResourceRole, &req)
+}
+
+// ExportDepartments 导出部门列表
+// @Summary 导出部门列表
+// @Description 按部门列表的筛选条件导出 CSV/XLSX；行数超过阈值时转为后台任务并返回 JSON 任务信息（dto.ExportJobInfo），完成后通过下载地址获取
+// @Tags 部门管理
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv,json
+// @Security ApiKeyAuth
+// @Param format query string false "文件格式" Enums(csv,xlsx)
+// @Param lang query string false "表头语言" Enums(zh-CN,en-US)
+// @Param department_name query string false "部门名称"
+// @Param status query int false "状态筛选" Enums(1,2)
+// @Param parent_id query string false "父部门ID"
+// @Success 200 {file} file "导出文件"
+// @Router /api/v1/departments/export [get]
+func (h *Handler) ExportDepartments(c *gin.Context) {
+	var req dto.ListDepartmentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+	h.export(c, exportsvc.ResourceDepartment, &req)
+}
+
+// ExportPositions 导出岗位列表
+// @Summary 导出岗位列表
+// @Description 按岗位列表的筛选条件导出 CSV/XLSX；行数超过阈值时转为后台任务并返回 JSON 任务信息（dto.ExportJobInfo），完成后通过下载地址获取
+// @Tags 岗位管理
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv,json
+// @Security ApiKeyAuth
+// @Param format query string false "文件格式" Enums(csv,xlsx)
+// @Param lang query string false "表头语言" Enums(zh-CN,en-US)
+// @Param position_name query string false "岗位名称"
+// @Param position_code query string false "岗位编码"
+// @Param status query int false "状态筛选" Enums(1,2)
+// @Success 200 {file} file "导出文件"
+// @Router /api/v1/positions/export [get]
+func (h *Handler) ExportPositions(c *gin.Context) {
+	var req dto.ListPositionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+	h.export(c, exportsvc.ResourcePosition, &req)
+}
+
+// ExportLoginLogs 导出登录日志
+// @Summary 导出登录日志
+// @Description 按登录日志列表的筛选条件导出 CSV/XLSX；行数超过阈值时转为后台任务并返回 JSON 任务信息（dto.ExportJobInfo），完成后通过下载地址获取
+// @Tags 登录日志管理
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv,json
+// @Security ApiKeyAuth
+// @Param format query string false "文件格式" Enums(csv,xlsx)
+// @Param lang query string false "表头语言" Enums(zh-CN,en-US)
+// @Param user_id query string false "用户ID筛选"
+// @Param user_name query string false "用户名筛选"
+// @Param login_type query string false "登录类型筛选"
+// @Param status query int false "状态筛选(0:失败,1:成功)" Enums(0,1)
+// @Param start_date query int false "开始时间(毫秒时间戳)"
+// @Param end_date query int false "结束时间(毫秒时间戳)"
+// @Param ip_address query string false "IP地址筛选"
+// @Success 200 {file} file "导出文件"
+// @Router /api/v1/logs/login/export [get]
+func (h *Handler) ExportLoginLogs(c *gin.Context) {
+	var req dto.ListLoginLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+	h.export(c, exportsvc.ResourceLoginLog, &req)
+}
+
+// ExportOperationLogs 导出操作日志
+// @Summary 导出操作日志
+// @Description 按操作日志列表的筛选条件导出 CSV/XLSX；行数超过阈值时转为后台任务并返回 JSON 任务信息（dto.ExportJobInfo），完成后通过下载地址获取
+// @Tags 操作日志管理
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv,json
+// @Security ApiKeyAuth
+// @Param format query string false "文件格式" Enums(csv,xlsx)
+// @Param lang query string false "表头语言" Enums(zh-CN,en-US)
+// @Param module query string false "模块筛选"
+// @Param operation_type query string false "操作类型筛选"
+// @Param resource_type query string false "资源类型筛选"
+// @Param status query int false "状态筛选" Enums(1,2)
+// @Param user_name query string false "用户名筛选"
+// @Param start_date query int false "开始时间"
+// @Param end_date query int false "结束时间"
+// @Success 200 {file} file "导出文件"
+// @Router /api/v1/logs/operation/export [get]
+func (h *Handler) ExportOperationLogs(c *gin.Context) {
+	var req dto.ListOperationLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+	h.export(c, exportsvc.ResourceOperationLog, &req)
+}
+
+// export 按筛选条件导出，同步导出时直接写出文件，转为后台任务时返回任务信息
+func (h *Handler) export(c *gin.Context, resource string, filter any) {
+	var req dto.ExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+	if req.Lang == "" {
+		req.Lang = exportsvc.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	}
+
+	job, err := h.svc.Export(c.Request.Context(), resource, filter, &req, func(filename, contentType string) io.Writer {
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Status(200)
+		return c.Writer
+	})
+	if err != nil {
+		// 数据已开始写出时无法再返回错误响应，只能中断连接
+		if c.Writer.Written() {
+			log.Error().Err(err).Str("resource", resource).Msg("导出文件写出中断")
+			c.Abort()
+			return
+		}
+		// 尚未写出数据时撤销文件响应头，改为返回错误
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		response.Error(c, err)
+		return
+	}
+	if job != nil {
+		response.Success(c, job)
+	}
+}
+
+// ListExports 获取我的导出任务
+// @Summary 获取我的导出任务
+// @Description 分页获取当前用户发起的后台导出任务，用于查看进度和下载
+// @Tags 列表导出
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Success 200 {object} response.Response{data=dto.ListExportJobsResponse} "获取成功"
+// @Router /api/v1/exports [get]
+func (h *Handler) ListExports(c *gin.Context) {
+	var req dto.ListExportJobsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListJobs(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// DownloadExport 下载导出文件
+// @Summary 下载导出文件
+// @Description 下载已完成的后台导出文件，只有任务发起人可以下载
+// @Tags 列表导出
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv,json
+// @Security ApiKeyAuth
+// @Param export_id query string true "导出任务ID"
+// @Success 200 {file} file "导出文件"
+// @Router /api/v1/exports/download [get]
+func (h *Handler) DownloadExport(c *gin.Context) {
+	var req dto.ExportDownloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	path, filename, err := h.svc.GetDownloadFile(c.Request.Context(), req.ExportID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.FileAttachment(path, filename)
+}
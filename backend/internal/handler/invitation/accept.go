@@ -0,0 +1,58 @@
+package invitation
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewInvitation 查看邀请
+// @Summary 查看邀请
+// @Description 校验邀请链接并返回邀请租户信息，existing_account 为 true 时无需设置密码（无需认证）
+// @Tags 成员邀请
+// @Accept json
+// @Produce json
+// @Param token query string true "邀请链接中的令牌"
+// @Success 200 {object} response.Response{data=dto.InvitationPreviewResponse} "获取成功"
+// @Router /api/v1/auth/invitation [get]
+func (h *Handler) PreviewInvitation(c *gin.Context) {
+	var req dto.InvitationTokenRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.PreviewInvitation(c.Request.Context(), req.Token)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// AcceptInvitation 接受邀请
+// @Summary 接受邀请
+// @Description 新用户设置密码（RSA 加密）后创建账号；已在其他租户有账号的用户获得该租户的角色，登录后通过切换租户访问（无需认证）
+// @Tags 成员邀请
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptInvitationRequest true "接受邀请请求参数"
+// @Success 200 {object} response.Response{data=dto.AcceptInvitationResponse} "接受成功"
+// @Router /api/v1/auth/invitation/accept [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.AcceptInvitation(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,23 @@
+package invitation
+
+import (
+	invitationsvc "admin/internal/service/invitation"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/utils/notify"
+	"admin/pkg/utils/rsapwd"
+
+	"gorm.io/gorm"
+)
+
+// Handler 成员邀请处理器
+type Handler struct {
+	svc *invitationsvc.Service
+}
+
+// NewHandler 创建成员邀请处理器
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, notifier notify.Sender, cfg *config.Config) *Handler {
+	return &Handler{
+		svc: invitationsvc.NewService(db, recorder, rsaCipher, notifier, cfg),
+	}
+}
@@ -0,0 +1,114 @@
+package invitation
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateInvitation 邀请成员
+// @Summary 邀请成员
+// @Description 按邮箱邀请成员并预分配角色、部门和岗位，向被邀请邮箱发送接受邀请链接；同一邮箱已有待接受邀请时旧邀请自动撤销
+// @Tags 成员邀请
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreateInvitationRequest true "邀请成员请求参数"
+// @Success 200 {object} response.Response{data=dto.CreateInvitationResponse} "邀请成功"
+// @Router /api/v1/invitations [post]
+func (h *Handler) CreateInvitation(c *gin.Context) {
+	var req dto.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CreateInvitation(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListInvitations 获取邀请列表
+// @Summary 获取邀请列表
+// @Description 分页获取当前租户的成员邀请
+// @Tags 成员邀请
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param email query string false "邮箱(模糊匹配)"
+// @Param status query string false "状态(PENDING/ACCEPTED/REVOKED/EXPIRED)"
+// @Success 200 {object} response.Response{data=dto.ListInvitationsResponse} "获取成功"
+// @Router /api/v1/invitations [get]
+func (h *Handler) ListInvitations(c *gin.Context) {
+	var req dto.ListInvitationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListInvitations(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ResendInvitation 重新发送邀请
+// @Summary 重新发送邀请
+// @Description 重新发送待接受的邀请并重新计算有效期，之前发出的链接失效
+// @Tags 成员邀请
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.InvitationIDRequest true "邀请ID"
+// @Success 200 {object} response.Response{data=dto.CreateInvitationResponse} "发送成功"
+// @Router /api/v1/invitations/resend [post]
+func (h *Handler) ResendInvitation(c *gin.Context) {
+	var req dto.InvitationIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ResendInvitation(c.Request.Context(), req.InvitationID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// RevokeInvitation 撤销邀请
+// @Summary 撤销邀请
+// @Description 撤销待接受的邀请，撤销后链接立即失效
+// @Tags 成员邀请
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.InvitationIDRequest true "邀请ID"
+// @Success 200 {object} response.Response "撤销成功"
+// @Router /api/v1/invitations [delete]
+func (h *Handler) RevokeInvitation(c *gin.Context) {
+	var req dto.InvitationIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.RevokeInvitation(c.Request.Context(), req.InvitationID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
@@ -0,0 +1,126 @@
+package member
+
+import (
+	"admin/internal/dto"
+	membersvc "admin/internal/service/member"
+	"admin/pkg/audit"
+	"admin/pkg/response"
+	"admin/pkg/utils/jwt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Handler 租户成员处理器
+type Handler struct {
+	svc *membersvc.Service
+}
+
+// NewHandler 创建租户成员处理器
+func NewHandler(db *gorm.DB, jwtMgr *jwt.Manager, recorder *audit.Recorder) *Handler {
+	return &Handler{svc: membersvc.NewService(db, jwtMgr, recorder)}
+}
+
+// ListMembers 获取租户成员列表
+// @Summary 获取租户成员列表
+// @Description 分页获取从其他租户加入当前租户的成员，成员的账号信息来自其主租户
+// @Tags 租户成员
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param department_id query string false "部门ID"
+// @Param status query int false "状态(1:正常 2:禁用)"
+// @Success 200 {object} response.Response{data=dto.ListMembersResponse} "获取成功"
+// @Router /api/v1/members [get]
+func (h *Handler) ListMembers(c *gin.Context) {
+	var req dto.ListMembersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListMembers(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// UpdateMember 更新租户成员
+// @Summary 更新租户成员
+// @Description 更新成员在当前租户的部门和岗位，不影响其在主租户的信息
+// @Tags 租户成员
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.UpdateMemberRequest true "更新租户成员请求参数"
+// @Success 200 {object} response.Response "更新成功"
+// @Router /api/v1/members [put]
+func (h *Handler) UpdateMember(c *gin.Context) {
+	var req dto.UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.UpdateMember(c.Request.Context(), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// UpdateMemberStatus 更新租户成员状态
+// @Summary 更新租户成员状态
+// @Description 禁用后成员不能再切换到当前租户，已切换到当前租户的会话立即失效
+// @Tags 租户成员
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.MemberStatusRequest true "更新租户成员状态请求参数"
+// @Success 200 {object} response.Response "更新成功"
+// @Router /api/v1/members/status [put]
+func (h *Handler) UpdateMemberStatus(c *gin.Context) {
+	var req dto.MemberStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.UpdateMemberStatus(c.Request.Context(), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// RemoveMember 移除租户成员
+// @Summary 移除租户成员
+// @Description 将成员移出当前租户并删除其在当前租户的角色，不影响其主租户账号
+// @Tags 租户成员
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.MemberDeleteRequest true "移除租户成员请求参数"
+// @Success 200 {object} response.Response "移除成功"
+// @Router /api/v1/members [delete]
+func (h *Handler) RemoveMember(c *gin.Context) {
+	var req dto.MemberDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.RemoveMember(c.Request.Context(), req.UserID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
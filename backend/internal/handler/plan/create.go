@@ -0,0 +1,34 @@
+package plan
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePlan 创建套餐
+// @Summary 创建套餐
+// @Description 创建租户套餐，套餐权限需另行配置
+// @Tags 套餐管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreatePlanRequest true "创建套餐请求参数"
+// @Success 200 {object} response.Response{data=dto.PlanInfo} "创建成功"
+// @Router /api/v1/plans [post]
+func (h *Handler) CreatePlan(c *gin.Context) {
+	var req dto.CreatePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CreatePlan(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
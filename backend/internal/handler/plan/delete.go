@@ -0,0 +1,33 @@
+package plan
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeletePlan 删除套餐
+// @Summary 删除套餐
+// @Description 删除套餐（软删除），仍有租户使用的套餐不允许删除
+// @Tags 套餐管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.PlanDeleteRequest true "删除套餐请求参数"
+// @Success 200 {object} response.Response "删除成功"
+// @Router /api/v1/plans [delete]
+func (h *Handler) DeletePlan(c *gin.Context) {
+	var req dto.PlanDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.DeletePlan(c.Request.Context(), req.PlanID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"deleted": true})
+}
@@ -0,0 +1,21 @@
+package plan
+
+import (
+	"admin/internal/rbac"
+	plansvc "admin/internal/service/plan"
+	"admin/pkg/audit"
+
+	"gorm.io/gorm"
+)
+
+// Handler 租户套餐处理器
+type Handler struct {
+	svc *plansvc.Service
+}
+
+// NewHandler 创建租户套餐处理器
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache) *Handler {
+	return &Handler{
+		svc: plansvc.NewService(db, recorder, cache),
+	}
+}
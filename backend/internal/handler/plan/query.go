@@ -0,0 +1,90 @@
+package plan
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPlan 获取套餐详情
+// @Summary 获取套餐详情
+// @Description 根据ID获取套餐详情，包含使用该套餐的租户数
+// @Tags 套餐管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param plan_id query string true "套餐ID"
+// @Success 200 {object} response.Response{data=dto.PlanInfo} "获取成功"
+// @Router /api/v1/plans/detail [get]
+func (h *Handler) GetPlan(c *gin.Context) {
+	var req dto.PlanDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetPlan(c.Request.Context(), req.PlanID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListPlans 获取套餐列表
+// @Summary 获取套餐列表
+// @Description 分页获取套餐列表，支持按名称、编码和状态筛选
+// @Tags 套餐管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param name query string false "套餐名称(模糊匹配)"
+// @Param plan_code query string false "套餐编码(模糊匹配)"
+// @Param status query int false "状态筛选(1:启用,2:禁用)" Enums(1, 2)
+// @Success 200 {object} response.Response{data=dto.ListPlansResponse} "获取成功"
+// @Router /api/v1/plans [get]
+func (h *Handler) ListPlans(c *gin.Context) {
+	var req dto.ListPlansRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListPlans(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetPermissions 获取套餐权限
+// @Summary 获取套餐权限
+// @Description 获取套餐可用的菜单、按钮和接口权限
+// @Tags 套餐管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param plan_id query string true "套餐ID"
+// @Success 200 {object} response.Response{data=dto.PlanPermissionsResponse} "获取成功"
+// @Router /api/v1/plans/permissions [get]
+func (h *Handler) GetPermissions(c *gin.Context) {
+	var req dto.PlanDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetPlanPermissions(c.Request.Context(), req.PlanID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,60 @@
+package plan
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdatePlan 更新套餐
+// @Summary 更新套餐
+// @Description 更新套餐基本信息
+// @Tags 套餐管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.UpdatePlanRequest true "更新套餐请求参数"
+// @Success 200 {object} response.Response{data=dto.PlanInfo} "更新成功"
+// @Router /api/v1/plans [put]
+func (h *Handler) UpdatePlan(c *gin.Context) {
+	var req dto.UpdatePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.UpdatePlan(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// AssignPermissions 设置套餐权限
+// @Summary 设置套餐权限
+// @Description 设置套餐可用的菜单、按钮和接口权限，并回收使用该套餐的租户中超出范围的角色授权
+// @Tags 套餐管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.AssignPlanPermissionsRequest true "设置套餐权限请求参数"
+// @Success 200 {object} response.Response{data=dto.AssignPlanPermissionsResponse} "设置成功"
+// @Router /api/v1/plans/permissions [put]
+func (h *Handler) AssignPermissions(c *gin.Context) {
+	var req dto.AssignPlanPermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.AssignPlanPermissions(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -103,3 +103,22 @@ func (h *Handler) GetRoleMenus(c *gin.Context) {
 	// 旧接口只返回菜单ID
 	response.Success(c, &dto.RolePermissionsResponse{MenuPermIDs: permissions.MenuPermIDs})
 }
+
+// RebuildPermissionCache 提交重建权限缓存任务
+// @Summary 重建权限缓存
+// @Description 提交后台任务重新加载所有角色的权限（仅超级管理员），通过 /api/v1/tasks 查询任务进度和结果
+// @Tags 角色管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response{data=dto.TaskInfo} "任务已提交"
+// @Router /api/v1/roles/permission-cache/rebuild [post]
+func (h *Handler) RebuildPermissionCache(c *gin.Context) {
+	info, err := h.cacheSvc.StartRebuild(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, info)
+}
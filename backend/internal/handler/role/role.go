@@ -3,6 +3,7 @@ package role
 import (
 	"admin/internal/rbac"
 	rolesvc "admin/internal/service/role"
+	tasksvc "admin/internal/service/task"
 	"admin/pkg/audit"
 
 	"gorm.io/gorm"
@@ -10,12 +11,14 @@ import (
 
 // Handler 角色处理器
 type Handler struct {
-	svc *rolesvc.Service
+	svc      *rolesvc.Service
+	cacheSvc *rolesvc.CacheService
 }
 
 // NewHandler 创建角色处理器
-func NewHandler(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache) *Handler {
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache, worker *tasksvc.Worker) *Handler {
 	return &Handler{
-		svc: rolesvc.NewService(db, recorder, cache),
+		svc:      rolesvc.NewService(db, recorder, cache),
+		cacheSvc: rolesvc.NewCacheService(db, cache, worker),
 	}
 }
@@ -0,0 +1,60 @@
+package roletemplate
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRoleTemplate 创建角色模板
+// @Summary 创建角色模板
+// @Description 创建全局角色模板，租户可由模板创建角色
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreateRoleTemplateRequest true "创建角色模板请求参数"
+// @Success 200 {object} response.Response{data=dto.RoleTemplateInfo} "创建成功"
+// @Router /api/v1/role-templates [post]
+func (h *Handler) CreateRoleTemplate(c *gin.Context) {
+	var req dto.CreateRoleTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CreateRoleTemplate(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// InstantiateRoleTemplate 由模板创建角色
+// @Summary 由模板创建角色
+// @Description 复制模板权限在租户下创建角色，超级管理员可指定目标租户
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.InstantiateRoleTemplateRequest true "由模板创建角色请求参数"
+// @Success 200 {object} response.Response{data=dto.RoleInfo} "创建成功"
+// @Router /api/v1/role-templates/instantiate [post]
+func (h *Handler) InstantiateRoleTemplate(c *gin.Context) {
+	var req dto.InstantiateRoleTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.InstantiateRoleTemplate(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,33 @@
+package roletemplate
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteRoleTemplate 删除角色模板
+// @Summary 删除角色模板
+// @Description 删除角色模板（软删除），派生角色保留但解除关联
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.RoleTemplateDeleteRequest true "删除角色模板请求参数"
+// @Success 200 {object} response.Response "删除成功"
+// @Router /api/v1/role-templates [delete]
+func (h *Handler) DeleteRoleTemplate(c *gin.Context) {
+	var req dto.RoleTemplateDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.DeleteRoleTemplate(c.Request.Context(), req.TemplateID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"deleted": true})
+}
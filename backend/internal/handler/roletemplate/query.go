@@ -0,0 +1,90 @@
+package roletemplate
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRoleTemplate 获取角色模板详情
+// @Summary 获取角色模板详情
+// @Description 根据ID获取角色模板详情，包含派生角色数量
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param template_id query string true "模板ID"
+// @Success 200 {object} response.Response{data=dto.RoleTemplateInfo} "获取成功"
+// @Router /api/v1/role-templates/detail [get]
+func (h *Handler) GetRoleTemplate(c *gin.Context) {
+	var req dto.RoleTemplateDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetRoleTemplate(c.Request.Context(), req.TemplateID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListRoleTemplates 获取角色模板列表
+// @Summary 获取角色模板列表
+// @Description 分页获取角色模板列表，支持按名称、编码和状态筛选
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param name query string false "模板名称(模糊匹配)"
+// @Param template_code query string false "模板编码(模糊匹配)"
+// @Param status query int false "状态筛选(1:启用,2:禁用)" Enums(1, 2)
+// @Success 200 {object} response.Response{data=dto.ListRoleTemplatesResponse} "获取成功"
+// @Router /api/v1/role-templates [get]
+func (h *Handler) ListRoleTemplates(c *gin.Context) {
+	var req dto.ListRoleTemplatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListRoleTemplates(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetPermissions 获取角色模板权限
+// @Summary 获取角色模板权限
+// @Description 获取角色模板的菜单、按钮和接口权限
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param template_id query string true "模板ID"
+// @Success 200 {object} response.Response{data=dto.RoleTemplatePermissionsResponse} "获取成功"
+// @Router /api/v1/role-templates/permissions [get]
+func (h *Handler) GetPermissions(c *gin.Context) {
+	var req dto.RoleTemplateDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetRoleTemplatePermissions(c.Request.Context(), req.TemplateID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
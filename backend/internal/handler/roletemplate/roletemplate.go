@@ -0,0 +1,21 @@
+package roletemplate
+
+import (
+	"admin/internal/rbac"
+	roletemplatesvc "admin/internal/service/roletemplate"
+	"admin/pkg/audit"
+
+	"gorm.io/gorm"
+)
+
+// Handler 角色模板处理器
+type Handler struct {
+	svc *roletemplatesvc.Service
+}
+
+// NewHandler 创建角色模板处理器
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache) *Handler {
+	return &Handler{
+		svc: roletemplatesvc.NewService(db, recorder, cache),
+	}
+}
@@ -0,0 +1,60 @@
+package roletemplate
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewSync 预览模板同步
+// @Summary 预览模板同步
+// @Description 列出模板权限同步到派生角色后各角色新增和移除的权限，不做修改
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param template_id query string true "模板ID"
+// @Success 200 {object} response.Response{data=dto.RoleTemplateSyncPreviewResponse} "获取成功"
+// @Router /api/v1/role-templates/sync-preview [get]
+func (h *Handler) PreviewSync(c *gin.Context) {
+	var req dto.RoleTemplateSyncRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.PreviewRoleTemplateSync(c.Request.Context(), req.TemplateID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Sync 同步模板到派生角色
+// @Summary 同步模板到派生角色
+// @Description 将模板权限同步到所有派生角色，派生角色权限被重置为与模板一致
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.RoleTemplateSyncRequest true "模板同步请求参数"
+// @Success 200 {object} response.Response{data=dto.RoleTemplateSyncResponse} "同步成功"
+// @Router /api/v1/role-templates/sync [post]
+func (h *Handler) Sync(c *gin.Context) {
+	var req dto.RoleTemplateSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.SyncRoleTemplate(c.Request.Context(), req.TemplateID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,59 @@
+package roletemplate
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateRoleTemplate 更新角色模板
+// @Summary 更新角色模板
+// @Description 更新角色模板基本信息
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.UpdateRoleTemplateRequest true "更新角色模板请求参数"
+// @Success 200 {object} response.Response{data=dto.RoleTemplateInfo} "更新成功"
+// @Router /api/v1/role-templates [put]
+func (h *Handler) UpdateRoleTemplate(c *gin.Context) {
+	var req dto.UpdateRoleTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.UpdateRoleTemplate(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// AssignPermissions 设置角色模板权限
+// @Summary 设置角色模板权限
+// @Description 设置角色模板的菜单、按钮和接口权限，派生角色需通过同步接口更新
+// @Tags 角色模板
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.AssignRoleTemplatePermissionsRequest true "设置权限请求参数"
+// @Success 200 {object} response.Response "设置成功"
+// @Router /api/v1/role-templates/permissions [put]
+func (h *Handler) AssignPermissions(c *gin.Context) {
+	var req dto.AssignRoleTemplatePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.AssignRoleTemplatePermissions(c.Request.Context(), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"assigned": true})
+}
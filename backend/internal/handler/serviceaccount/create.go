@@ -0,0 +1,34 @@
+package serviceaccount
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateServiceAccount 创建服务账号
+// @Summary 创建服务账号
+// @Description 在当前租户创建服务账号，返回 client_id 与 client_secret，密钥明文仅返回一次。角色通过用户角色接口分配
+// @Tags 服务账号
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreateServiceAccountRequest true "创建服务账号请求参数"
+// @Success 200 {object} response.Response{data=dto.CreateServiceAccountResponse} "创建成功"
+// @Router /api/v1/service-accounts [post]
+func (h *Handler) CreateServiceAccount(c *gin.Context) {
+	var req dto.CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CreateServiceAccount(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
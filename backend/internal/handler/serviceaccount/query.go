@@ -0,0 +1,36 @@
+package serviceaccount
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListServiceAccounts 获取服务账号列表
+// @Summary 获取服务账号列表
+// @Description 分页获取当前租户的服务账号
+// @Tags 服务账号
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param keyword query string false "账号名称/显示名称(模糊匹配)"
+// @Success 200 {object} response.Response{data=dto.ListServiceAccountsResponse} "获取成功"
+// @Router /api/v1/service-accounts [get]
+func (h *Handler) ListServiceAccounts(c *gin.Context) {
+	var req dto.ListServiceAccountsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListServiceAccounts(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
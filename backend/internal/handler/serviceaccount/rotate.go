@@ -0,0 +1,34 @@
+package serviceaccount
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RotateSecret 轮换服务账号密钥
+// @Summary 轮换服务账号密钥
+// @Description 生成新的客户端密钥，旧密钥及已签发的访问令牌立即失效，新密钥明文仅返回一次
+// @Tags 服务账号
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.RotateServiceAccountSecretRequest true "轮换密钥请求参数"
+// @Success 200 {object} response.Response{data=dto.RotateServiceAccountSecretResponse} "轮换成功"
+// @Router /api/v1/service-accounts/secret/rotate [post]
+func (h *Handler) RotateSecret(c *gin.Context) {
+	var req dto.RotateServiceAccountSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.RotateSecret(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,19 @@
+package serviceaccount
+
+import (
+	serviceaccountsvc "admin/internal/service/serviceaccount"
+	"admin/pkg/audit"
+	"admin/pkg/utils/jwt"
+
+	"gorm.io/gorm"
+)
+
+// Handler 服务账号处理器
+type Handler struct {
+	svc *serviceaccountsvc.Service
+}
+
+// NewHandler 创建服务账号处理器
+func NewHandler(db *gorm.DB, jwtMgr *jwt.Manager, recorder *audit.Recorder) *Handler {
+	return &Handler{svc: serviceaccountsvc.NewService(db, jwtMgr, recorder)}
+}
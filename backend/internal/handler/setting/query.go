@@ -0,0 +1,45 @@
+package setting
+
+import (
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListSettings 获取租户设置
+// @Summary 获取租户设置
+// @Description 获取当前租户的全部设置项，包含类型、校验规则、生效值及其来源（default 租户返回平台默认值）
+// @Tags 租户设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response{data=dto.ListSettingsResponse} "获取成功"
+// @Router /api/v1/settings [get]
+func (h *Handler) ListSettings(c *gin.Context) {
+	resp, err := h.svc.ListSettings(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetBranding 获取登录页品牌信息
+// @Summary 获取登录页品牌信息
+// @Description 无需认证，按请求头、自定义域名或子域名识别租户，返回 Logo、主题色、登录页文案等公开设置
+// @Tags 租户设置
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Code header string false "租户编码"
+// @Success 200 {object} response.Response{data=dto.BrandingResponse} "获取成功"
+// @Router /api/v1/settings/branding [get]
+func (h *Handler) GetBranding(c *gin.Context) {
+	resp, err := h.svc.GetBranding(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,20 @@
+package setting
+
+import (
+	settingsvc "admin/internal/service/setting"
+	"admin/pkg/audit"
+
+	"gorm.io/gorm"
+)
+
+// Handler 租户设置处理器
+type Handler struct {
+	svc *settingsvc.Service
+}
+
+// NewHandler 创建租户设置处理器
+func NewHandler(db *gorm.DB, recorder *audit.Recorder) *Handler {
+	return &Handler{
+		svc: settingsvc.NewService(db, recorder),
+	}
+}
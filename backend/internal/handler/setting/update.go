@@ -0,0 +1,58 @@
+package setting
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateSettings 修改租户设置
+// @Summary 修改租户设置
+// @Description 批量修改当前租户的设置，每项需提交读取时的版本号，已被他人修改时返回冲突（default 租户修改平台默认值）
+// @Tags 租户设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.UpdateSettingsRequest true "修改设置请求参数"
+// @Success 200 {object} response.Response "修改成功"
+// @Router /api/v1/settings [put]
+func (h *Handler) UpdateSettings(c *gin.Context) {
+	var req dto.UpdateSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.UpdateSettings(c.Request.Context(), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"updated": true})
+}
+
+// ResetSetting 恢复默认设置
+// @Summary 恢复默认设置
+// @Description 删除当前租户对该设置项的修改，恢复使用平台默认值（default 租户恢复使用内置默认值）
+// @Tags 租户设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.SettingKeyRequest true "设置项键请求参数"
+// @Success 200 {object} response.Response "恢复成功"
+// @Router /api/v1/settings [delete]
+func (h *Handler) ResetSetting(c *gin.Context) {
+	var req dto.SettingKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.ResetSetting(c.Request.Context(), req.Key); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"reset": true})
+}
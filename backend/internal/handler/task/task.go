@@ -0,0 +1,128 @@
+package task
+
+import (
+	"admin/internal/dto"
+	tasksvc "admin/internal/service/task"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Handler 后台任务处理器
+type Handler struct {
+	svc *tasksvc.Service
+}
+
+// NewHandler 创建后台任务处理器
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, cfg config.TaskConfig) *Handler {
+	return &Handler{
+		svc: tasksvc.NewService(db, recorder, cfg),
+	}
+}
+
+// ListTasks 获取我的后台任务
+// @Summary 获取我的后台任务
+// @Description 分页获取当前用户发起的后台任务（导入、导出等），用于轮询进度和结果
+// @Tags 后台任务
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param type query string false "任务类型筛选"
+// @Param status query string false "状态筛选" Enums(PENDING,RUNNING,CANCELING,SUCCEEDED,FAILED,CANCELED)
+// @Success 200 {object} response.Response{data=dto.ListTasksResponse} "获取成功"
+// @Router /api/v1/tasks [get]
+func (h *Handler) ListTasks(c *gin.Context) {
+	var req dto.ListTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListTasks(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetTask 获取后台任务详情
+// @Summary 获取后台任务详情
+// @Description 获取当前用户发起的后台任务的状态、进度和结果
+// @Tags 后台任务
+// @Produce json
+// @Security ApiKeyAuth
+// @Param task_id query string true "任务ID"
+// @Success 200 {object} response.Response{data=dto.TaskInfo} "获取成功"
+// @Router /api/v1/tasks/detail [get]
+func (h *Handler) GetTask(c *gin.Context) {
+	var req dto.TaskDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetTask(c.Request.Context(), req.TaskID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// DownloadTaskFile 下载任务结果文件
+// @Summary 下载任务结果文件
+// @Description 下载已成功的后台任务生成的文件（如用户导入结果、租户数据包），只有任务发起人可以下载
+// @Tags 后台任务
+// @Produce application/octet-stream
+// @Security ApiKeyAuth
+// @Param task_id query string true "任务ID"
+// @Success 200 {file} file "任务结果文件"
+// @Router /api/v1/tasks/download [get]
+func (h *Handler) DownloadTaskFile(c *gin.Context) {
+	var req dto.TaskDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	path, filename, err := h.svc.GetDownloadFile(c.Request.Context(), req.TaskID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.FileAttachment(path, filename)
+}
+
+// CancelTask 取消后台任务
+// @Summary 取消后台任务
+// @Description 等待中的任务直接取消；执行中的任务状态变为 CANCELING，处理程序退出后变为 CANCELED
+// @Tags 后台任务
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CancelTaskRequest true "取消任务请求参数"
+// @Success 200 {object} response.Response{data=dto.TaskInfo} "取消成功"
+// @Router /api/v1/tasks/cancel [post]
+func (h *Handler) CancelTask(c *gin.Context) {
+	var req dto.CancelTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CancelTask(c.Request.Context(), req.TaskID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,79 @@
+package tenant
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+	"admin/pkg/xerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportTenant 导出租户数据
+// @Summary 导出租户数据
+// @Description 将租户全部业务数据导出为版本化的 zip 数据包；超级管理员可指定租户并选择包含密码哈希。
+// @Description 导出提交为后台任务并返回任务信息，任务成功后通过结果中的下载地址获取数据包
+// @Tags 租户管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Param tenant_id query string false "租户ID（默认当前租户）"
+// @Param include_logs query bool false "是否包含登录日志和操作日志"
+// @Param include_credentials query bool false "是否包含用户密码哈希"
+// @Success 200 {object} response.Response{data=dto.TaskInfo} "已提交后台导出任务"
+// @Router /api/v1/tenants/export [get]
+func (h *Handler) ExportTenant(c *gin.Context) {
+	var req dto.TenantExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.archiveSvc.StartExport(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ImportTenant 导入租户数据
+// @Summary 导入租户数据
+// @Description 将数据包导入为新租户（仅超级管理员），所有ID重新生成；dry_run=true 时只做预检。
+// @Description 导入提交为后台任务并返回任务信息，任务结果为导入报告（dto.TenantImportReport）
+// @Tags 租户管理
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "租户数据包"
+// @Param tenant_code formData string false "新租户编码（默认使用数据包中的编码）"
+// @Param name formData string false "新租户名称（默认使用数据包中的名称）"
+// @Param dry_run formData bool false "是否只预检"
+// @Success 200 {object} response.Response{data=dto.TaskInfo} "已提交后台导入任务"
+// @Router /api/v1/tenants/import [post]
+func (h *Handler) ImportTenant(c *gin.Context) {
+	var req dto.TenantImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, xerr.ErrInvalidParams)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, xerr.ErrTenantArchiveInvalid)
+		return
+	}
+	defer file.Close()
+
+	resp, err := h.archiveSvc.StartImport(c.Request.Context(), &req, file)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
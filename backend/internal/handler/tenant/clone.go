@@ -0,0 +1,34 @@
+package tenant
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CloneTenant 克隆租户
+// @Summary 克隆租户
+// @Description 以源租户为参照创建新租户，复制角色及授权、部门和岗位树、字典覆盖，可选复制脱敏后的用户；复制的授权按新租户套餐裁剪
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantCloneRequest true "克隆租户请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantCloneResponse} "克隆成功"
+// @Router /api/v1/tenants/clone [post]
+func (h *Handler) CloneTenant(c *gin.Context) {
+	var req dto.TenantCloneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.CloneTenant(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
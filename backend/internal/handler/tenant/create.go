@@ -9,13 +9,13 @@ import (
 
 // CreateTenant 创建租户
 // @Summary 创建租户
-// @Description 超级管理员创建新租户
+// @Description 超级管理员创建新租户，同时初始化租户管理员角色、根部门、默认岗位和初始管理员，返回管理员一次性密码。初始化中途失败时可使用相同参数重新提交继续初始化
 // @Tags 租户管理
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param request body dto.TenantCreateRequest true "创建租户请求参数"
-// @Success 200 {object} response.Response{data=dto.TenantInfo} "创建成功"
+// @Success 200 {object} response.Response{data=dto.TenantCreateResponse} "创建成功"
 // @Router /api/v1/tenants [post]
 func (h *Handler) CreateTenant(c *gin.Context) {
 	var req dto.TenantCreateRequest
@@ -32,3 +32,29 @@ func (h *Handler) CreateTenant(c *gin.Context) {
 
 	response.Success(c, resp)
 }
+
+// ProvisionTenant 重试租户初始化
+// @Summary 重试租户初始化
+// @Description 初始化中途失败的租户可重新执行初始化，已完成的步骤会被跳过
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantProvisionRequest true "重试初始化请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantCreateResponse} "初始化成功"
+// @Router /api/v1/tenants/provision [post]
+func (h *Handler) ProvisionTenant(c *gin.Context) {
+	var req dto.TenantProvisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ProvisionTenant(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -9,7 +9,7 @@ import (
 
 // DeleteTenant 删除租户
 // @Summary 删除租户
-// @Description 软删除租户（只有租户下无用户时才能删除）
+// @Description 软删除租户并立即吊销租户下所有会话，保留期结束后彻底清除全部数据，保留期内可恢复
 // @Tags 租户管理
 // @Accept json
 // @Produce json
@@ -34,7 +34,7 @@ func (h *Handler) DeleteTenant(c *gin.Context) {
 
 // BatchDeleteTenants 批量删除租户
 // @Summary 批量删除租户
-// @Description 批量软删除租户（只有租户下无用户时才能删除）
+// @Description 批量软删除租户（只有租户下无用户时才能删除），保留期结束后彻底清除，保留期内可恢复
 // @Tags 租户管理
 // @Accept json
 // @Produce json
@@ -0,0 +1,111 @@
+package tenant
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddTenantDomain 绑定自定义域名
+// @Summary 绑定自定义域名
+// @Description 为租户绑定自定义域名，返回需要添加的 DNS TXT 记录，验证通过后可通过该域名识别租户
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantDomainCreateRequest true "绑定域名请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantDomainInfo} "绑定成功"
+// @Router /api/v1/tenants/domains [post]
+func (h *Handler) AddTenantDomain(c *gin.Context) {
+	var req dto.TenantDomainCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.AddTenantDomain(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListTenantDomains 获取自定义域名列表
+// @Summary 获取自定义域名列表
+// @Description 获取租户绑定的自定义域名及验证状态，超级管理员可指定租户
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param tenant_id query string false "租户ID（默认当前租户）"
+// @Success 200 {object} response.Response{data=[]dto.TenantDomainInfo} "获取成功"
+// @Router /api/v1/tenants/domains [get]
+func (h *Handler) ListTenantDomains(c *gin.Context) {
+	var req dto.TenantDomainListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListTenantDomains(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// VerifyTenantDomain 验证自定义域名
+// @Summary 验证自定义域名
+// @Description 查询域名的 DNS TXT 记录，匹配验证令牌后标记为已验证
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantDomainRequest true "验证域名请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantDomainInfo} "验证成功"
+// @Router /api/v1/tenants/domains/verify [post]
+func (h *Handler) VerifyTenantDomain(c *gin.Context) {
+	var req dto.TenantDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.VerifyTenantDomain(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// DeleteTenantDomain 解绑自定义域名
+// @Summary 解绑自定义域名
+// @Description 解绑租户的自定义域名
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantDomainRequest true "解绑域名请求参数"
+// @Success 200 {object} response.Response "解绑成功"
+// @Router /api/v1/tenants/domains [delete]
+func (h *Handler) DeleteTenantDomain(c *gin.Context) {
+	var req dto.TenantDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.DeleteTenantDomain(c.Request.Context(), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
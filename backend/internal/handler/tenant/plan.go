@@ -0,0 +1,34 @@
+package tenant
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangeTenantPlan 变更租户套餐
+// @Summary 变更租户套餐
+// @Description 变更租户套餐，回收超出新套餐范围的角色授权并刷新权限缓存
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantPlanRequest true "变更套餐请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantPlanResponse} "变更成功"
+// @Router /api/v1/tenants/plan [put]
+func (h *Handler) ChangeTenantPlan(c *gin.Context) {
+	var req dto.TenantPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ChangeTenantPlan(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
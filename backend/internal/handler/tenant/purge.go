@@ -0,0 +1,63 @@
+package tenant
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RestoreTenant 恢复已删除的租户
+// @Summary 恢复已删除的租户
+// @Description 恢复保留期内的已删除租户，彻底清除开始后无法恢复
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantRestoreRequest true "恢复租户请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantInfo} "恢复成功"
+// @Router /api/v1/tenants/restore [post]
+func (h *Handler) RestoreTenant(c *gin.Context) {
+	var req dto.TenantRestoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.RestoreTenant(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListTenantPurges 获取租户彻底清除记录
+// @Summary 获取租户彻底清除记录
+// @Description 分页获取已删除租户的清除计划、进度和各表删除行数
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Param tenant_code query string false "租户编码"
+// @Param status query string false "状态(SCHEDULED/RUNNING/FAILED/COMPLETED/CANCELED)"
+// @Success 200 {object} response.Response{data=dto.TenantPurgeListResponse} "获取成功"
+// @Router /api/v1/tenants/purges [get]
+func (h *Handler) ListTenantPurges(c *gin.Context) {
+	var req dto.TenantPurgeListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListTenantPurges(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
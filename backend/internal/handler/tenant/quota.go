@@ -0,0 +1,60 @@
+package tenant
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTenantUsage 获取租户资源用量
+// @Summary 获取租户资源用量
+// @Description 获取租户用户数、角色数、部门数和存储空间的当前用量与配额，超级管理员可指定租户
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param tenant_id query string false "租户ID（默认当前租户）"
+// @Success 200 {object} response.Response{data=dto.TenantUsageResponse} "获取成功"
+// @Router /api/v1/tenants/usage [get]
+func (h *Handler) GetTenantUsage(c *gin.Context) {
+	var req dto.TenantUsageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetTenantUsage(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// SetTenantQuotas 设置租户配额
+// @Summary 设置租户配额
+// @Description 为租户单独设置资源配额，覆盖套餐默认值；limit 不传表示恢复套餐默认
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantQuotaRequest true "设置配额请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantUsageResponse} "设置成功"
+// @Router /api/v1/tenants/quotas [put]
+func (h *Handler) SetTenantQuotas(c *gin.Context) {
+	var req dto.TenantQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.SetTenantQuotas(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -1,20 +1,27 @@
 package tenant
 
 import (
+	"admin/internal/rbac"
+	tasksvc "admin/internal/service/task"
 	tenantsvc "admin/internal/service/tenant"
+	"admin/internal/service/tenantarchive"
 	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/utils/jwt"
 
 	"gorm.io/gorm"
 )
 
 // Handler 租户处理器
 type Handler struct {
-	svc *tenantsvc.Service
+	svc        *tenantsvc.Service
+	archiveSvc *tenantarchive.Service
 }
 
 // NewHandler 创建租户处理器
-func NewHandler(db *gorm.DB, recorder *audit.Recorder) *Handler {
+func NewHandler(db *gorm.DB, jwtMgr *jwt.Manager, recorder *audit.Recorder, cache *rbac.PermissionCache, worker *tasksvc.Worker, cfg config.TenantConfig, taskCfg config.TaskConfig) *Handler {
 	return &Handler{
-		svc: tenantsvc.NewService(db, recorder),
+		svc:        tenantsvc.NewService(db, jwtMgr, recorder, cache, cfg),
+		archiveSvc: tenantarchive.NewService(db, recorder, cache, worker, taskCfg),
 	}
 }
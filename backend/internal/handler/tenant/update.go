@@ -57,3 +57,29 @@ func (h *Handler) UpdateTenantStatus(c *gin.Context) {
 
 	response.Success(c, gin.H{"updated": true})
 }
+
+// UpdateTenantSubscription 变更租户订阅
+// @Summary 变更租户订阅
+// @Description 设置租户到期时间和生命周期状态（续期、试用转正、归档），未指定状态时按到期时间自动判断
+// @Tags 租户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.TenantSubscriptionRequest true "变更订阅请求参数"
+// @Success 200 {object} response.Response{data=dto.TenantInfo} "变更成功"
+// @Router /api/v1/tenants/subscription [put]
+func (h *Handler) UpdateTenantSubscription(c *gin.Context) {
+	var req dto.TenantSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.UpdateTenantSubscription(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
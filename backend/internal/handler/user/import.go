@@ -0,0 +1,72 @@
+package user
+
+import (
+	"admin/internal/dto"
+	usersvc "admin/internal/service/user"
+	"admin/pkg/response"
+	"admin/pkg/xerr"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize 导入文件的最大字节数
+const maxImportFileSize = 10 << 20
+
+// ImportUsers 批量导入用户
+// @Summary 批量导入用户
+// @Description 从 CSV 或 XLSX 文件批量创建当前租户的用户，表头支持：用户名、昵称、邮箱（必填）、手机号、部门（名称）、岗位（名称或编码）、角色编码（多个用逗号分隔）、描述、备注。
+// @Description 文件上传后提交为后台任务并返回任务信息，通过 /api/v1/tasks/detail 查询进度；逐行校验后，校验通过的行在一个事务中创建，失败的行跳过。
+// @Description 任务成功后结果中包含汇总行数和下载地址，结果文件与上传格式相同，包含新用户的初始密码（首次登录需修改）；dry_run=true 时只校验不写入。
+// @Tags 用户管理
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "导入文件（.csv 或 .xlsx，最大 10MB，最多 1000 行）"
+// @Param dry_run formData bool false "是否只预检"
+// @Success 200 {object} response.Response{data=dto.TaskInfo} "已提交后台导入任务"
+// @Router /api/v1/users/import [post]
+func (h *Handler) ImportUsers(c *gin.Context) {
+	var req dto.ImportUsersRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, xerr.ErrInvalidParams)
+		return
+	}
+	if header.Size > maxImportFileSize {
+		response.Error(c, xerr.New(xerr.ErrUserImportTooLarge.Code, "导入文件不能超过 10MB"))
+		return
+	}
+
+	var format string
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".csv":
+		format = usersvc.ImportFormatCSV
+	case ".xlsx":
+		format = usersvc.ImportFormatXLSX
+	default:
+		response.Error(c, xerr.New(xerr.ErrUserImportInvalid.Code, "仅支持 .csv 和 .xlsx 文件"))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, xerr.ErrUserImportInvalid)
+		return
+	}
+	defer file.Close()
+
+	resp, err := h.importSvc.StartImport(c.Request.Context(), &req, format, file)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
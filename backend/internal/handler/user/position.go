@@ -0,0 +1,87 @@
+package user
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AssignPositions 为用户设置岗位
+// @Summary 为用户设置岗位
+// @Description 为指定用户设置岗位（覆盖式，会替换用户现有的所有岗位），主岗位同步为用户的岗位；岗位列表为空表示清空。从其他租户加入的成员请在成员管理中设置岗位
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.AssignPositionsRequest true "设置岗位请求参数"
+// @Success 200 {object} response.Response "设置成功"
+// @Router /api/v1/users/positions [put]
+func (h *Handler) AssignPositions(c *gin.Context) {
+	var req dto.AssignPositionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.svc.AssignPositions(c.Request.Context(), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"assigned": true})
+}
+
+// GetUserPositions 获取用户的岗位列表
+// @Summary 获取用户岗位
+// @Description 获取指定用户在当前租户担任的岗位，主岗位在前
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string true "用户ID"
+// @Success 200 {object} response.Response{data=dto.UserPositionsResponse} "获取成功"
+// @Router /api/v1/users/positions [get]
+func (h *Handler) GetUserPositions(c *gin.Context) {
+	var req dto.UserDetailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.GetUserPositions(c.Request.Context(), req.UserID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListPositionUsers 获取岗位下的用户
+// @Summary 获取岗位下的用户
+// @Description 分页获取担任指定岗位的用户（含兼任该岗位的用户），主岗在前
+// @Tags 岗位管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param position_id query string true "岗位ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(10)
+// @Success 200 {object} response.Response{data=dto.ListUsersResponse} "获取成功"
+// @Router /api/v1/positions/users [get]
+func (h *Handler) ListPositionUsers(c *gin.Context) {
+	var req dto.ListPositionUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.svc.ListPositionUsers(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
@@ -0,0 +1,132 @@
+package user
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/response"
+	"admin/pkg/xerr"
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateProfile 修改个人资料
+// @Summary 修改个人资料
+// @Description 当前用户修改自己的昵称和简介，不传的字段保持不变；邮箱和手机号需通过验证码接口修改
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.UpdateProfileRequest true "修改个人资料请求参数"
+// @Success 200 {object} response.Response{data=dto.UserInfo} "修改成功"
+// @Router /api/v1/user/profile/update [post]
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	user, err := h.profileSvc.UpdateProfile(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, user)
+}
+
+// UploadAvatar 上传头像
+// @Summary 上传头像
+// @Description 当前用户上传头像，支持 JPEG、PNG、GIF、WebP，按内容识别格式；图片居中裁剪为正方形并缩放后保存，占用租户存储配额
+// @Tags 用户管理
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "头像图片"
+// @Success 200 {object} response.Response{data=dto.UploadAvatarResponse} "上传成功"
+// @Router /api/v1/user/profile/avatar [post]
+func (h *Handler) UploadAvatar(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, xerr.ErrInvalidParams)
+		return
+	}
+	if header.Size > h.avatarMaxSize {
+		response.Error(c, xerr.ErrAvatarTooLarge)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, xerr.ErrAvatarInvalid)
+		return
+	}
+	defer file.Close()
+
+	// 多读一个字节，超出上限时由服务层拒绝
+	data, err := io.ReadAll(io.LimitReader(file, h.avatarMaxSize+1))
+	if err != nil {
+		response.Error(c, xerr.ErrAvatarInvalid)
+		return
+	}
+
+	resp, err := h.profileSvc.UploadAvatar(c.Request.Context(), data)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// SendContactCode 发送邮箱/手机号变更验证码
+// @Summary 发送联系方式变更验证码
+// @Description 向新的邮箱或手机号发送验证码，同一类型 1 分钟内只能发送一次；模拟登录时不可用
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.SendContactCodeRequest true "发送验证码请求参数"
+// @Success 200 {object} response.Response{data=dto.SendContactCodeResponse} "发送成功"
+// @Router /api/v1/user/profile/contact/code [post]
+func (h *Handler) SendContactCode(c *gin.Context) {
+	var req dto.SendContactCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	resp, err := h.profileSvc.SendContactCode(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// VerifyContact 验证并修改邮箱/手机号
+// @Summary 验证并修改联系方式
+// @Description 校验发送到新地址的验证码，通过后修改邮箱或手机号；连续 5 次错误后验证码作废
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.VerifyContactRequest true "验证请求参数"
+// @Success 200 {object} response.Response{data=dto.UserInfo} "修改成功"
+// @Router /api/v1/user/profile/contact/verify [post]
+func (h *Handler) VerifyContact(c *gin.Context) {
+	var req dto.VerifyContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	user, err := h.profileSvc.VerifyContact(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, user)
+}
@@ -2,25 +2,35 @@ package user
 
 import (
 	"admin/internal/rbac"
+	tasksvc "admin/internal/service/task"
 	usersvc "admin/internal/service/user"
 	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/utils/notify"
 	"admin/pkg/utils/rsapwd"
 
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 // Handler 用户处理器
 type Handler struct {
-	svc     *usersvc.Service
-	roleSvc *usersvc.RoleService
-	menuSvc *usersvc.MenuService
+	svc           *usersvc.Service
+	roleSvc       *usersvc.RoleService
+	menuSvc       *usersvc.MenuService
+	profileSvc    *usersvc.ProfileService
+	importSvc     *usersvc.ImportService
+	avatarMaxSize int64
 }
 
 // NewHandler 创建用户处理器
-func NewHandler(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cache *rbac.PermissionCache) *Handler {
+func NewHandler(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cache *rbac.PermissionCache, rdb redis.UniversalClient, notifier notify.Sender, worker *tasksvc.Worker, profileCfg config.ProfileConfig, taskCfg config.TaskConfig) *Handler {
 	return &Handler{
-		svc:     usersvc.NewService(db, recorder, rsaCipher),
-		roleSvc: usersvc.NewRoleService(db, recorder),
-		menuSvc: usersvc.NewMenuService(db, cache),
+		svc:           usersvc.NewService(db, recorder, rsaCipher),
+		roleSvc:       usersvc.NewRoleService(db, recorder),
+		menuSvc:       usersvc.NewMenuService(db, cache),
+		profileSvc:    usersvc.NewProfileService(db, recorder, rsaCipher, rdb, notifier, profileCfg),
+		importSvc:     usersvc.NewImportService(db, recorder, rsaCipher, worker, taskCfg),
+		avatarMaxSize: profileCfg.GetAvatarMaxSize(),
 	}
 }
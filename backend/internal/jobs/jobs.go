@@ -1,19 +1,75 @@
 package jobs
 
 import (
+	"admin/internal/service/export"
+	"admin/internal/service/task"
+	"admin/internal/service/tenant"
+	"admin/pkg/config"
+	"admin/pkg/utils/notify"
 	"admin/pkg/utils/xcron"
+	"admin/pkg/xcontext"
+	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
+const (
+	defaultTenantLifecycleCron = "0 0 2 * * *"  // 租户生命周期流转默认执行时间（每天凌晨2点）
+	defaultTenantPurgeCron     = "0 30 3 * * *" // 已删除租户彻底清除默认执行时间（每天凌晨3点半）
+	defaultExportCleanupCron   = "0 0 * * * *"  // 过期导出文件清理默认执行时间（每小时）
+	defaultTaskCleanupCron     = "0 15 4 * * *" // 过期任务记录清理默认执行时间（每天凌晨4点15分）
+)
+
 // Init 初始化并注册所有定时任务
-func Init(cronMgr *xcron.Manager, db *gorm.DB) error {
+// 清除等耗时操作提交到后台任务工作进程执行，定时任务只负责调度
+func Init(cronMgr *xcron.Manager, db *gorm.DB, notifier notify.Sender, worker *task.Worker, cfg *config.Config) error {
 	// 测试任务 - 每5秒执行一次
 	if err := cronMgr.Add("test_job", "*/5 * * * * ?", testJob); err != nil {
 		return err
 	}
 
+	// 租户订阅生命周期流转与到期提醒 - 每天执行
+	spec := cfg.Tenant.LifecycleCron
+	if spec == "" {
+		spec = defaultTenantLifecycleCron
+	}
+	lifecycle := tenant.NewLifecycleManager(db, notifier, cfg.Tenant)
+	if err := cronMgr.Add("tenant_lifecycle", spec, func() { tenantLifecycleJob(lifecycle) }); err != nil {
+		return err
+	}
+
+	// 已删除租户保留期结束后彻底清除 - 每天执行
+	purgeSpec := cfg.Tenant.PurgeCron
+	if purgeSpec == "" {
+		purgeSpec = defaultTenantPurgeCron
+	}
+	purge := tenant.NewPurgeManager(db, worker, cfg.Tenant)
+	if err := cronMgr.Add("tenant_purge", purgeSpec, func() { tenantPurgeJob(purge) }); err != nil {
+		return err
+	}
+
+	// 过期导出文件清理 - 每小时执行
+	exportSpec := cfg.Export.CleanupCron
+	if exportSpec == "" {
+		exportSpec = defaultExportCleanupCron
+	}
+	exportCleanup := export.NewCleanupManager(db, cfg.Export)
+	if err := cronMgr.Add("export_cleanup", exportSpec, func() { exportCleanupJob(exportCleanup) }); err != nil {
+		return err
+	}
+
+	// 过期任务记录清理 - 每天执行
+	taskSpec := cfg.Task.CleanupCron
+	if taskSpec == "" {
+		taskSpec = defaultTaskCleanupCron
+	}
+	taskCleanup := task.NewCleanupManager(db, cfg.Task)
+	if err := cronMgr.Add("task_cleanup", taskSpec, func() { taskCleanupJob(taskCleanup) }); err != nil {
+		return err
+	}
+
 	log.Info().Msg("定时任务注册完成")
 	return nil
 }
@@ -22,11 +78,61 @@ func Init(cronMgr *xcron.Manager, db *gorm.DB) error {
 // 定时任务实现
 // ============================================
 
+// jobContext 定时任务的上下文：不属于任何租户，数据库行级安全切换到旁路角色
+//
+//tenantscope:allow 定时任务按条件处理所有租户的数据
+func jobContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(xcontext.SetPlatformScope(context.Background()), timeout)
+}
+
 // testJob 测试任务 - 每5秒执行
 func testJob() {
 	log.Info().Msg("🕐 定时任务测试：每5秒执行一次")
 }
 
+// tenantLifecycleJob 租户订阅生命周期流转：到期进入宽限期、过期、归档，并发送到期提醒
+func tenantLifecycleJob(lifecycle *tenant.LifecycleManager) {
+	ctx, cancel := jobContext(10 * time.Minute)
+	defer cancel()
+
+	log.Info().Msg("开始执行租户生命周期流转...")
+	if err := lifecycle.Run(ctx); err != nil {
+		log.Error().Err(err).Msg("租户生命周期流转失败")
+		return
+	}
+	log.Info().Msg("租户生命周期流转完成")
+}
+
+// tenantPurgeJob 为保留期已结束的已删除租户提交彻底清除任务
+func tenantPurgeJob(purge *tenant.PurgeManager) {
+	ctx, cancel := jobContext(10 * time.Minute)
+	defer cancel()
+
+	if err := purge.Run(ctx); err != nil {
+		log.Error().Err(err).Msg("提交租户彻底清除任务失败")
+	}
+}
+
+// exportCleanupJob 清理过期的导出文件和任务记录
+func exportCleanupJob(cleanup *export.CleanupManager) {
+	ctx, cancel := jobContext(10 * time.Minute)
+	defer cancel()
+
+	if err := cleanup.Run(ctx); err != nil {
+		log.Error().Err(err).Msg("清理过期导出文件失败")
+	}
+}
+
+// taskCleanupJob 清理超过保留期的已结束任务记录
+func taskCleanupJob(cleanup *task.CleanupManager) {
+	ctx, cancel := jobContext(10 * time.Minute)
+	defer cancel()
+
+	if err := cleanup.Run(ctx); err != nil {
+		log.Error().Err(err).Msg("清理过期任务记录失败")
+	}
+}
+
 // cleanupLogs 清理过期日志
 func cleanupLogs() {
 	log.Info().Msg("开始清理过期日志...")
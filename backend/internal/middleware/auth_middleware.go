@@ -2,11 +2,13 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
-	"admin/pkg/utils/jwt"
+	"admin/internal/service/accesstoken"
 	"admin/pkg/response"
+	"admin/pkg/utils/jwt"
 	"admin/pkg/xcontext"
 	"admin/pkg/xerr"
 
@@ -14,8 +16,9 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// AuthMiddleware JWT认证中间件
-func AuthMiddleware(jwtManager *jwt.Manager) gin.HandlerFunc {
+// AuthMiddleware 认证中间件
+// 支持 Bearer JWT 与访问令牌（个人访问令牌 pat_ / 租户 API Key ak_）
+func AuthMiddleware(jwtManager *jwt.Manager, tokenSvc *accesstoken.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
 		if token == "" {
@@ -33,7 +36,32 @@ func AuthMiddleware(jwtManager *jwt.Manager) gin.HandlerFunc {
 		}
 		tokenString := parts[1]
 
-		// 验证 token（签名、过期、黑名单）
+		// 访问令牌（PAT / API Key）
+		if accesstoken.IsAccessToken(tokenString) {
+			identity, err := tokenSvc.Authenticate(c.Request.Context(), tokenString, c.ClientIP())
+			if err != nil {
+				log.Warn().Err(err).Msg("[AuthMiddleware] access token rejected")
+				response.ErrorWithHttpCode(c, http.StatusUnauthorized, err)
+				c.Abort()
+				return
+			}
+
+			requestCtx := SetAuthContext(c.Request.Context(), identity.Claims)
+			requestCtx = xcontext.SetAccessKeyID(requestCtx, identity.KeyID)
+			requestCtx = xcontext.SetScopes(requestCtx, identity.Scopes)
+			c.Request = c.Request.WithContext(requestCtx)
+
+			log.Debug().
+				Str("access_key_id", identity.KeyID).
+				Str("tenant_id", identity.Claims.TenantID).
+				Str("user_id", identity.Claims.UserID).
+				Msg("[AuthMiddleware] access token resolved")
+
+			c.Next()
+			return
+		}
+
+		// 验证 token（签名、过期、黑名单、会话空闲超时）
 		claims, err := jwtManager.VerifyAccessToken(c.Request.Context(), tokenString)
 		if err != nil {
 			if err == xerr.ErrTokenExpired {
@@ -41,6 +69,11 @@ func AuthMiddleware(jwtManager *jwt.Manager) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
+			if errors.Is(err, jwt.ErrSessionIdle) {
+				response.ErrorWithHttpCode(c, http.StatusUnauthorized, xerr.ErrSessionIdleTimeout)
+				c.Abort()
+				return
+			}
 			response.ErrorWithHttpCode(c, http.StatusUnauthorized, xerr.ErrTokenInvalid)
 			c.Abort()
 			return
@@ -79,6 +112,9 @@ func SetAuthContext(ctx context.Context, claims *jwt.Claims) context.Context {
 	ctx = xcontext.SetRoles(ctx, claims.Roles)
 	ctx = xcontext.SetRoleIDs(ctx, claims.RoleIDs)
 	ctx = xcontext.SetTokenID(ctx, claims.TokenID)
+	if claims.Act != nil {
+		ctx = xcontext.SetImpersonator(ctx, claims.Act.UserID, claims.Act.UserName)
+	}
 
 	return ctx
 }
@@ -16,6 +16,22 @@ import (
 func RBACMiddleware(cache *rbac.PermissionCache) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
+		path := c.Request.URL.Path
+		method := c.Request.Method
+
+		// 访问令牌（PAT / API Key）只能访问授权范围内的接口，超管令牌同样受限
+		if keyID := xcontext.GetAccessKeyID(ctx); keyID != "" {
+			if !rbac.MatchScopes(xcontext.GetScopes(ctx), path, method) {
+				log.Warn().
+					Str("access_key_id", keyID).
+					Str("path", path).
+					Str("method", method).
+					Msg("[RBACMiddleware] 超出访问令牌授权范围")
+				response.Error(c, xerr.ErrForbidden)
+				c.Abort()
+				return
+			}
+		}
 
 		// 超管跳过权限检查
 		if xcontext.HasRole(ctx, constants.SuperAdmin) {
@@ -30,9 +46,6 @@ func RBACMiddleware(cache *rbac.PermissionCache) gin.HandlerFunc {
 			return
 		}
 
-		path := c.Request.URL.Path
-		method := c.Request.Method
-
 		if !cache.CheckAPI(roleIDs, path, method) {
 			log.Warn().
 				Strs("role_ids", roleIDs).
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	tenantsvc "admin/internal/service/tenant"
+	"admin/pkg/constants"
+	"admin/pkg/response"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// tenantLifecycleCacheTTL 租户生命周期状态缓存时长
+// 变更订阅后最长延迟该时长生效
+const tenantLifecycleCacheTTL = 30 * time.Second
+
+// readOnlyExemptPaths 宽限期内仍允许的写请求（退出登录、切换到其他租户）
+var readOnlyExemptPaths = map[string]bool{
+	"/api/v1/auth/logout":        true,
+	"/api/v1/auth/switch-tenant": true,
+}
+
+// TenantLifecycleMiddleware 租户订阅生命周期中间件
+// 已过期、已归档租户的令牌拒绝访问；宽限期租户只允许只读请求（GET/HEAD/OPTIONS）
+func TenantLifecycleMiddleware(db *gorm.DB) gin.HandlerFunc {
+	cache := &tenantLifecycleCache{
+		tenantRepo: repository.NewTenantRepo(db),
+		entries:    make(map[string]*tenantLifecycleEntry),
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		tenantID := xcontext.GetTenantID(ctx)
+		if tenantID == "" {
+			c.Next()
+			return
+		}
+
+		tenant, err := cache.get(ctx, tenantID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				response.ErrorWithHttpCode(c, http.StatusUnauthorized, xerr.ErrTenantNotFound)
+				c.Abort()
+				return
+			}
+			log.Error().Err(err).Str("tenant_id", tenantID).Msg("[TenantLifecycleMiddleware] 查询租户失败")
+			response.Error(c, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err))
+			c.Abort()
+			return
+		}
+
+		if err := tenantsvc.CheckLifecycle(tenant); err != nil {
+			response.ErrorWithHttpCode(c, http.StatusForbidden, err)
+			c.Abort()
+			return
+		}
+
+		status := tenantsvc.EffectiveLifecycle(tenant, time.Now().UnixMilli())
+		if tenantsvc.IsReadOnlyLifecycle(status) && !isReadOnlyMethod(c.Request.Method) && !readOnlyExemptPaths[c.Request.URL.Path] {
+			// 超级管理员需要在宽限期内为租户续期，不受只读限制
+			if !xcontext.HasRole(ctx, constants.SuperAdmin) {
+				response.ErrorWithHttpCode(c, http.StatusForbidden, xerr.ErrTenantReadOnly)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// isReadOnlyMethod 判断请求方法是否为只读
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// tenantLifecycleEntry 租户生命周期缓存项
+type tenantLifecycleEntry struct {
+	tenant   *model.Tenant
+	loadedAt time.Time
+}
+
+// tenantLifecycleCache 租户生命周期状态缓存，避免每个请求都查询数据库
+type tenantLifecycleCache struct {
+	tenantRepo *repository.TenantRepo
+	mu         sync.RWMutex
+	entries    map[string]*tenantLifecycleEntry
+}
+
+// get 获取租户信息，缓存过期后重新加载
+func (c *tenantLifecycleCache) get(ctx context.Context, tenantID string) (*model.Tenant, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[tenantID]
+	c.mu.RUnlock()
+	if ok && time.Since(entry.loadedAt) < tenantLifecycleCacheTTL {
+		return entry.tenant, nil
+	}
+
+	tenant, err := c.tenantRepo.GetByIDManual(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[tenantID] = &tenantLifecycleEntry{tenant: tenant, loadedAt: time.Now()}
+	c.mu.Unlock()
+	return tenant, nil
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"admin/internal/repository"
+	"admin/pkg/response"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// TenantCodeHeader 指定租户编码的请求头
+const TenantCodeHeader = "X-Tenant-Code"
+
+const (
+	tenantResolveCacheTTL = time.Minute // 租户识别结果缓存时长（含未命中结果）
+	tenantResolveCacheMax = 10000       // 缓存条目上限，Host/请求头可由客户端任意构造，超出后整体清空
+)
+
+// TenantResolverMiddleware 租户识别中间件
+// 按 X-Tenant-Code 请求头、已验证的自定义域名、子域名（tenant_code.baseDomain）的顺序识别租户，
+// 识别结果写入 context 供登录等未认证接口限定查询范围；未识别到租户时不做处理
+func TenantResolverMiddleware(db *gorm.DB, baseDomain string) gin.HandlerFunc {
+	resolver := &tenantResolver{
+		tenantRepo: repository.NewTenantRepo(db),
+		domainRepo: repository.NewTenantDomainRepo(db),
+		baseDomain: strings.ToLower(strings.TrimPrefix(baseDomain, ".")),
+		entries:    make(map[string]*tenantResolveEntry),
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		// 请求头显式指定的租户必须存在
+		if code := strings.TrimSpace(c.GetHeader(TenantCodeHeader)); code != "" {
+			entry, err := resolver.byCode(ctx, code)
+			if err != nil {
+				log.Error().Err(err).Str("tenant_code", code).Msg("[TenantResolverMiddleware] 识别租户失败")
+				response.Error(c, xerr.Wrap(xerr.ErrInternal.Code, "识别租户失败", err))
+				c.Abort()
+				return
+			}
+			if entry.tenantID == "" {
+				response.ErrorWithHttpCode(c, http.StatusBadRequest, xerr.ErrTenantNotFound)
+				c.Abort()
+				return
+			}
+			c.Request = c.Request.WithContext(xcontext.SetResolvedTenant(ctx, entry.tenantID, entry.tenantCode))
+			c.Next()
+			return
+		}
+
+		entry, err := resolver.byHost(ctx, requestHost(c.Request))
+		if err != nil {
+			// 按域名识别失败不阻断请求，退化为未识别租户
+			log.Warn().Err(err).Str("host", c.Request.Host).Msg("[TenantResolverMiddleware] 按域名识别租户失败")
+		} else if entry.tenantID != "" {
+			c.Request = c.Request.WithContext(xcontext.SetResolvedTenant(ctx, entry.tenantID, entry.tenantCode))
+		}
+
+		c.Next()
+	}
+}
+
+// requestHost 获取请求域名（小写，不含端口）
+func requestHost(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// tenantResolveEntry 租户识别结果，tenantID 为空表示未命中
+type tenantResolveEntry struct {
+	tenantID   string
+	tenantCode string
+	loadedAt   time.Time
+}
+
+// tenantResolver 租户识别器，缓存域名/编码到租户的映射
+type tenantResolver struct {
+	tenantRepo *repository.TenantRepo
+	domainRepo *repository.TenantDomainRepo
+	baseDomain string
+	mu         sync.RWMutex
+	entries    map[string]*tenantResolveEntry
+}
+
+// byCode 根据租户编码识别
+func (r *tenantResolver) byCode(ctx context.Context, code string) (*tenantResolveEntry, error) {
+	return r.cached("code:"+code, func() (*tenantResolveEntry, error) {
+		tenant, err := r.tenantRepo.GetByCodeManual(ctx, code)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &tenantResolveEntry{}, nil
+			}
+			return nil, err
+		}
+		return &tenantResolveEntry{tenantID: tenant.TenantID, tenantCode: tenant.TenantCode}, nil
+	})
+}
+
+// byHost 根据请求域名识别：优先匹配已验证的自定义域名，其次按子域名匹配租户编码
+// 域名绑定属于各个租户，识别前还不知道租户，查询标记为平台级操作
+//
+//tenantscope:allow 按请求域名查找绑定的租户，此时还没有当前租户
+func (r *tenantResolver) byHost(ctx context.Context, host string) (*tenantResolveEntry, error) {
+	ctx = xcontext.SetPlatformScope(ctx)
+	if host == "" || net.ParseIP(host) != nil {
+		return &tenantResolveEntry{}, nil
+	}
+
+	entry, err := r.cached("domain:"+host, func() (*tenantResolveEntry, error) {
+		domain, err := r.domainRepo.GetVerifiedByDomainManual(ctx, host)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &tenantResolveEntry{}, nil
+			}
+			return nil, err
+		}
+		tenant, err := r.tenantRepo.GetByIDManual(ctx, domain.TenantID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &tenantResolveEntry{}, nil
+			}
+			return nil, err
+		}
+		return &tenantResolveEntry{tenantID: tenant.TenantID, tenantCode: tenant.TenantCode}, nil
+	})
+	if err != nil || entry.tenantID != "" {
+		return entry, err
+	}
+
+	// 仅识别主域名下的一级子域名
+	if r.baseDomain == "" || !strings.HasSuffix(host, "."+r.baseDomain) {
+		return entry, nil
+	}
+	code := strings.TrimSuffix(host, "."+r.baseDomain)
+	if code == "" || strings.Contains(code, ".") {
+		return entry, nil
+	}
+	return r.byCode(ctx, code)
+}
+
+// cached 读取缓存，过期或不存在时调用 load 加载
+func (r *tenantResolver) cached(key string, load func() (*tenantResolveEntry, error)) (*tenantResolveEntry, error) {
+	r.mu.RLock()
+	entry, ok := r.entries[key]
+	r.mu.RUnlock()
+	if ok && time.Since(entry.loadedAt) < tenantResolveCacheTTL {
+		return entry, nil
+	}
+
+	entry, err := load()
+	if err != nil {
+		return nil, err
+	}
+	entry.loadedAt = time.Now()
+
+	r.mu.Lock()
+	if len(r.entries) >= tenantResolveCacheMax {
+		r.entries = make(map[string]*tenantResolveEntry)
+	}
+	r.entries[key] = entry
+	r.mu.Unlock()
+	return entry, nil
+}
@@ -1,6 +1,7 @@
 package rbac
 
 import (
+	"admin/pkg/xcontext"
 	"context"
 	"strings"
 	"sync"
@@ -17,7 +18,7 @@ type APIPermission struct {
 }
 
 // PermissionCache 权限缓存
-// 基于 role_ancestors 递归 CTE 实现角色继承的权限查询
+// 基于 role_ancestors 递归 CTE 实现角色继承的权限查询，并按角色所属租户的套餐裁剪权限
 type PermissionCache struct {
 	mu          sync.RWMutex
 	apiPerms    map[string][]APIPermission // roleID → API 权限列表
@@ -58,6 +59,23 @@ func (c *PermissionCache) NotifyRefresh() {
 	}
 }
 
+// RoleCount 返回缓存中拥有权限（API、菜单或按钮）的角色数
+func (c *PermissionCache) RoleCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	roles := make(map[string]struct{}, len(c.apiPerms))
+	for _, perms := range []map[string][]string{c.menuPerms, c.buttonPerms} {
+		for roleID := range perms {
+			roles[roleID] = struct{}{}
+		}
+	}
+	for roleID := range c.apiPerms {
+		roles[roleID] = struct{}{}
+	}
+	return len(roles)
+}
+
 // Stop 停止后台刷新协程
 func (c *PermissionCache) Stop() {
 	close(c.stopCh)
@@ -107,8 +125,25 @@ WITH RECURSIVE role_ancestors AS (
 )
 `
 
+// planCeilingJoin 关联角色所属租户，用于套餐上限过滤
+const planCeilingJoin = `
+		JOIN roles r ON r.role_id = ra.role_id
+		LEFT JOIN tenants t ON t.tenant_id = r.tenant_id AND t.deleted_at = 0`
+
+// planCeilingCond 套餐上限过滤条件
+// 租户绑定套餐时只保留套餐内的权限（包括从父角色继承的权限），未绑定套餐不限制
+const planCeilingCond = `
+		AND (COALESCE(t.plan_id, '') = '' OR EXISTS (
+			SELECT 1 FROM plan_permissions pp
+			WHERE pp.plan_id = t.plan_id AND pp.permission_id = p.permission_id
+		))`
+
 // Refresh 刷新权限缓存
+// 缓存包含所有租户的角色，查询标记为平台级操作，不受调用方租户的行级安全约束
+//
+//tenantscope:allow 权限缓存加载所有租户的角色授权
 func (c *PermissionCache) Refresh(ctx context.Context) error {
+	ctx = xcontext.SetPlatformScope(ctx)
 	newAPIPerms := make(map[string][]APIPermission)
 	newMenuPerms := make(map[string][]string)
 	newButtonPerms := make(map[string][]string)
@@ -121,11 +156,10 @@ func (c *PermissionCache) Refresh(ctx context.Context) error {
 	}
 	err := c.db.WithContext(ctx).Raw(roleAncestorsCTE + `
 		SELECT DISTINCT ra.role_id, p.resource, p.action
-		FROM role_ancestors ra
+		FROM role_ancestors ra` + planCeilingJoin + `
 		JOIN role_permissions rp ON rp.role_id = ra.ancestor_role_id
 		JOIN permissions p ON p.permission_id = rp.permission_id
-		WHERE p.deleted_at = 0 AND p.status = 1 AND p.type = 'API'
-	`).Scan(&apiResults).Error
+		WHERE p.deleted_at = 0 AND p.status = 1 AND p.type = 'API'` + planCeilingCond).Scan(&apiResults).Error
 	if err != nil {
 		return err
 	}
@@ -144,11 +178,10 @@ func (c *PermissionCache) Refresh(ctx context.Context) error {
 	}
 	err = c.db.WithContext(ctx).Raw(roleAncestorsCTE + `
 		SELECT DISTINCT ra.role_id, p.resource
-		FROM role_ancestors ra
+		FROM role_ancestors ra` + planCeilingJoin + `
 		JOIN role_permissions rp ON rp.role_id = ra.ancestor_role_id
 		JOIN permissions p ON p.permission_id = rp.permission_id
-		WHERE p.deleted_at = 0 AND p.status = 1 AND p.type = 'MENU'
-	`).Scan(&menuResults).Error
+		WHERE p.deleted_at = 0 AND p.status = 1 AND p.type = 'MENU'` + planCeilingCond).Scan(&menuResults).Error
 	if err != nil {
 		return err
 	}
@@ -165,11 +198,10 @@ func (c *PermissionCache) Refresh(ctx context.Context) error {
 	}
 	err = c.db.WithContext(ctx).Raw(roleAncestorsCTE + `
 		SELECT DISTINCT ra.role_id, p.permission_id
-		FROM role_ancestors ra
+		FROM role_ancestors ra` + planCeilingJoin + `
 		JOIN role_permissions rp ON rp.role_id = ra.ancestor_role_id
 		JOIN permissions p ON p.permission_id = rp.permission_id
-		WHERE p.deleted_at = 0 AND p.status = 1 AND p.type = 'BUTTON'
-	`).Scan(&buttonResults).Error
+		WHERE p.deleted_at = 0 AND p.status = 1 AND p.type = 'BUTTON'` + planCeilingCond).Scan(&buttonResults).Error
 	if err != nil {
 		return err
 	}
@@ -196,6 +228,50 @@ func (c *PermissionCache) CheckAPI(roleIDs []string, path, method string) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	return c.checkAPI(roleIDs, path, method)
+}
+
+// CoveredBy 检查 roleIDs 的全部权限（API、菜单、按钮）是否都包含在 ofRoleIDs 的权限中
+// 用于防止越权，如模拟登录时目标用户的权限不能超出操作者；API 权限按操作者的路径和方法规则匹配
+func (c *PermissionCache) CoveredBy(roleIDs, ofRoleIDs []string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, roleID := range roleIDs {
+		for _, perm := range c.apiPerms[roleID] {
+			if !c.checkAPI(ofRoleIDs, perm.Path, perm.Method) {
+				return false
+			}
+		}
+	}
+
+	menus := make(map[string]bool)
+	buttons := make(map[string]bool)
+	for _, roleID := range ofRoleIDs {
+		for _, menuID := range c.menuPerms[roleID] {
+			menus[menuID] = true
+		}
+		for _, permID := range c.buttonPerms[roleID] {
+			buttons[permID] = true
+		}
+	}
+	for _, roleID := range roleIDs {
+		for _, menuID := range c.menuPerms[roleID] {
+			if !menus[menuID] {
+				return false
+			}
+		}
+		for _, permID := range c.buttonPerms[roleID] {
+			if !buttons[permID] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkAPI 检查角色是否有指定 API 权限，调用方需持有读锁
+func (c *PermissionCache) checkAPI(roleIDs []string, path, method string) bool {
 	for _, roleID := range roleIDs {
 		for _, perm := range c.apiPerms[roleID] {
 			if matchPath(perm.Path, path) && matchMethod(perm.Method, method) {
@@ -0,0 +1,77 @@
+package rbac
+
+import "testing"
+
+func testCache() *PermissionCache {
+	return &PermissionCache{
+		apiPerms: map[string][]APIPermission{
+			"role_admin": {
+				{Path: "/api/v1/**", Method: "*"},
+			},
+			"role_user_manager": {
+				{Path: "/api/v1/users", Method: "GET"},
+				{Path: "/api/v1/users/:id", Method: "*"},
+				{Path: "/api/v1/auth/impersonate", Method: "POST"},
+			},
+			"role_viewer": {
+				{Path: "/api/v1/users", Method: "GET"},
+				{Path: "/api/v1/users/*", Method: "GET"},
+			},
+			"role_auditor": {
+				{Path: "/api/v1/operation-logs", Method: "GET"},
+			},
+		},
+		menuPerms: map[string][]string{
+			"role_admin":        {"menu_users", "menu_system"},
+			"role_user_manager": {"menu_users"},
+			"role_viewer":       {"menu_users"},
+			"role_auditor":      {"menu_system"},
+		},
+		buttonPerms: map[string][]string{
+			"role_admin":        {"btn_user_add", "btn_user_delete"},
+			"role_user_manager": {"btn_user_add"},
+			"role_viewer":       {},
+		},
+	}
+}
+
+func TestCoveredBy(t *testing.T) {
+	c := testCache()
+
+	tests := []struct {
+		name   string
+		roles  []string
+		ofRole []string
+		want   bool
+	}{
+		{"subset", []string{"role_viewer"}, []string{"role_user_manager"}, true},
+		{"same roles", []string{"role_user_manager"}, []string{"role_user_manager"}, true},
+		{"wildcard operator", []string{"role_user_manager", "role_auditor"}, []string{"role_admin"}, true},
+		{"escalate to admin", []string{"role_admin"}, []string{"role_user_manager"}, false},
+		{"extra api", []string{"role_auditor"}, []string{"role_user_manager"}, false},
+		{"extra button", []string{"role_user_manager"}, []string{"role_viewer"}, false},
+		{"no roles", nil, []string{"role_viewer"}, true},
+		{"operator without roles", []string{"role_viewer"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.CoveredBy(tt.roles, tt.ofRole); got != tt.want {
+				t.Fatalf("CoveredBy(%v, %v) = %v, want %v", tt.roles, tt.ofRole, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAPI(t *testing.T) {
+	c := testCache()
+
+	if !c.CheckAPI([]string{"role_user_manager"}, "/api/v1/users/u1", "DELETE") {
+		t.Fatal("CheckAPI should match single segment wildcard with any method")
+	}
+	if c.CheckAPI([]string{"role_viewer"}, "/api/v1/users/u1", "DELETE") {
+		t.Fatal("CheckAPI should not match a different method")
+	}
+	if !c.CheckAPI([]string{"role_admin"}, "/api/v1/roles/r1/permissions", "PUT") {
+		t.Fatal("CheckAPI should match multi segment wildcard")
+	}
+}
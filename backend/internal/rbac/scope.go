@@ -0,0 +1,23 @@
+package rbac
+
+import "strings"
+
+// FormatScope 格式化访问令牌授权范围（格式："METHOD /path"）
+func FormatScope(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// MatchScopes 检查请求是否落在访问令牌授权范围内
+// 路径与方法的匹配规则与角色 API 权限一致
+func MatchScopes(scopes []string, path, method string) bool {
+	for _, scope := range scopes {
+		scopeMethod, scopePath, ok := strings.Cut(scope, " ")
+		if !ok {
+			continue
+		}
+		if matchPath(scopePath, path) && matchMethod(scopeMethod, method) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AccessTokenRepo 访问令牌仓储（PAT / API Key）
+type AccessTokenRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewAccessTokenRepo 创建访问令牌仓储
+func NewAccessTokenRepo(db *gorm.DB) *AccessTokenRepo {
+	return &AccessTokenRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建访问令牌
+func (r *AccessTokenRepo) Create(ctx context.Context, token *model.AccessToken) error {
+	token.TenantID = xcontext.GetTenantID(ctx)
+	return r.q.AccessToken.WithContext(ctx).Create(token)
+}
+
+// GetByID 根据ID获取当前租户的访问令牌
+func (r *AccessTokenRepo) GetByID(ctx context.Context, tokenID string) (*model.AccessToken, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.AccessToken.WithContext(ctx).
+		Where(r.q.AccessToken.TenantID.Eq(tenantID)).
+		Where(r.q.AccessToken.TokenID.Eq(tokenID)).
+		First()
+}
+
+// GetByIDManual 根据ID获取访问令牌（跨租户，用于认证）
+//
+//tenantscope:allow 令牌认证时按令牌ID查找，此时尚未确定租户
+func (r *AccessTokenRepo) GetByIDManual(ctx context.Context, tokenID string) (*model.AccessToken, error) {
+	return r.q.AccessToken.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.AccessToken.TokenID.Eq(tokenID)).
+		First()
+}
+
+// ListWithFilters 根据筛选条件分页获取当前租户的访问令牌
+// userID 为空时不按所有者过滤
+func (r *AccessTokenRepo) ListWithFilters(ctx context.Context, offset, limit int, tokenType, userID, name string) ([]*model.AccessToken, int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	query := r.q.AccessToken.WithContext(ctx).
+		Where(r.q.AccessToken.TenantID.Eq(tenantID)).
+		Where(r.q.AccessToken.TokenType.Eq(tokenType))
+
+	if userID != "" {
+		query = query.Where(r.q.AccessToken.UserID.Eq(userID))
+	}
+	if name != "" {
+		query = query.Where(r.q.AccessToken.Name.Like("%" + name + "%"))
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tokens, err := query.Order(r.q.AccessToken.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return tokens, total, err
+}
+
+// Delete 删除（吊销）访问令牌(软删除)
+func (r *AccessTokenRepo) Delete(ctx context.Context, tokenID string) error {
+	tenantID := xcontext.GetTenantID(ctx)
+	_, err := r.q.AccessToken.WithContext(ctx).
+		Where(r.q.AccessToken.TenantID.Eq(tenantID)).
+		Where(r.q.AccessToken.TokenID.Eq(tokenID)).
+		Delete()
+	return err
+}
+
+// UpdateManual 更新访问令牌（跨租户，用于记录最后使用信息）
+//
+//tenantscope:allow 认证成功后记录令牌最后使用信息，与认证查询保持一致
+func (r *AccessTokenRepo) UpdateManual(ctx context.Context, tokenID string, updates map[string]interface{}) error {
+	_, err := r.q.AccessToken.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.AccessToken.TokenID.Eq(tokenID)).
+		Updates(updates)
+	return err
+}
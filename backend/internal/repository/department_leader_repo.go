@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// DepartmentLeaderRepo 部门负责人仓储
+type DepartmentLeaderRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewDepartmentLeaderRepo 创建部门负责人仓储
+func NewDepartmentLeaderRepo(db *gorm.DB) *DepartmentLeaderRepo {
+	return &DepartmentLeaderRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// ListByDepartment 获取部门的负责人，按排序升序
+func (r *DepartmentLeaderRepo) ListByDepartment(ctx context.Context, departmentID string) ([]*model.DepartmentLeader, error) {
+	dl := r.q.DepartmentLeader
+	tenantID := xcontext.GetTenantID(ctx)
+	return dl.WithContext(ctx).
+		Where(dl.TenantID.Eq(tenantID), dl.DepartmentID.Eq(departmentID)).
+		Order(dl.Sort, dl.CreatedAt).
+		Find()
+}
+
+// ListAll 获取当前租户所有部门的负责人，按部门和排序升序
+func (r *DepartmentLeaderRepo) ListAll(ctx context.Context) ([]*model.DepartmentLeader, error) {
+	dl := r.q.DepartmentLeader
+	tenantID := xcontext.GetTenantID(ctx)
+	return dl.WithContext(ctx).
+		Where(dl.TenantID.Eq(tenantID)).
+		Order(dl.DepartmentID, dl.Sort, dl.CreatedAt).
+		Find()
+}
+
+// ReplaceByDepartment 覆盖式设置部门负责人，userIDs 的顺序即排序，为空时清空
+func (r *DepartmentLeaderRepo) ReplaceByDepartment(ctx context.Context, departmentID string, userIDs []string) error {
+	if err := r.DeleteByDepartments(ctx, []string{departmentID}); err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	tenantID := xcontext.GetTenantID(ctx)
+	leaders := make([]*model.DepartmentLeader, len(userIDs))
+	for i, userID := range userIDs {
+		leaders[i] = &model.DepartmentLeader{
+			TenantID:     tenantID,
+			DepartmentID: departmentID,
+			UserID:       userID,
+			Sort:         int32(i),
+		}
+	}
+	return r.q.DepartmentLeader.WithContext(ctx).Create(leaders...)
+}
+
+// DeleteByDepartments 删除部门的全部负责人（用于删除部门）
+func (r *DepartmentLeaderRepo) DeleteByDepartments(ctx context.Context, departmentIDs []string) error {
+	dl := r.q.DepartmentLeader
+	tenantID := xcontext.GetTenantID(ctx)
+	_, err := dl.WithContext(ctx).
+		Where(dl.TenantID.Eq(tenantID), dl.DepartmentID.In(departmentIDs...)).
+		Delete()
+	return err
+}
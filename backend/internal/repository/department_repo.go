@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 
@@ -86,18 +87,7 @@ func (r *DepartmentRepo) List(ctx context.Context) ([]*model.Department, error)
 
 // ListWithFilters 根据筛选条件分页获取部门列表
 func (r *DepartmentRepo) ListWithFilters(ctx context.Context, offset, limit int, departmentName string, statusFilter int, parentIDFilter string) ([]*model.Department, int64, error) {
-	tenantID := xcontext.GetTenantID(ctx)
-	q := r.q.Department.WithContext(ctx).Where(r.q.Department.TenantID.Eq(tenantID))
-
-	if departmentName != "" {
-		q = q.Where(r.q.Department.DepartmentName.Like("%" + departmentName + "%"))
-	}
-	if statusFilter != 0 {
-		q = q.Where(r.q.Department.Status.Eq(int16(statusFilter)))
-	}
-	if parentIDFilter != "" {
-		q = q.Where(r.q.Department.ParentID.Eq(parentIDFilter))
-	}
+	q := r.filterQuery(ctx, r.q, departmentName, statusFilter, parentIDFilter)
 
 	total, err := q.Count()
 	if err != nil {
@@ -108,6 +98,36 @@ func (r *DepartmentRepo) ListWithFilters(ctx context.Context, offset, limit int,
 	return depts, total, err
 }
 
+// CountWithFilters 按与 ListWithFilters 相同的筛选条件统计部门数
+func (r *DepartmentRepo) CountWithFilters(ctx context.Context, departmentName string, statusFilter int, parentIDFilter string) (int64, error) {
+	return r.filterQuery(ctx, r.q, departmentName, statusFilter, parentIDFilter).Count()
+}
+
+// StreamWithFilters 按与 ListWithFilters 相同的筛选条件和排序，以游标方式分批读取部门（用于导出）
+func (r *DepartmentRepo) StreamWithFilters(ctx context.Context, departmentName string, statusFilter int, parentIDFilter string, batchSize int, fn func([]*model.Department) error) error {
+	return database.InTransactionWithCtx(ctx, r.db, func(ctx context.Context, tx *database.Tx) error {
+		q := r.filterQuery(ctx, tx.Q, departmentName, statusFilter, parentIDFilter).Order(tx.Q.Department.Sort.Asc())
+		return database.ScanInBatches(q.UnderlyingDB(), batchSize, fn)
+	})
+}
+
+// filterQuery 构造部门列表的筛选条件
+func (r *DepartmentRepo) filterQuery(ctx context.Context, q *query.Query, departmentName string, statusFilter int, parentIDFilter string) query.IDepartmentDo {
+	tenantID := xcontext.GetTenantID(ctx)
+	do := q.Department.WithContext(ctx).Where(q.Department.TenantID.Eq(tenantID))
+
+	if departmentName != "" {
+		do = do.Where(q.Department.DepartmentName.Like("%" + departmentName + "%"))
+	}
+	if statusFilter != 0 {
+		do = do.Where(q.Department.Status.Eq(int16(statusFilter)))
+	}
+	if parentIDFilter != "" {
+		do = do.Where(q.Department.ParentID.Eq(parentIDFilter))
+	}
+	return do
+}
+
 // GetChildren 获取子部门
 func (r *DepartmentRepo) GetChildren(ctx context.Context, parentID string) ([]*model.Department, error) {
 	tenantID := xcontext.GetTenantID(ctx)
@@ -166,3 +186,12 @@ func (r *DepartmentRepo) HasChildren(ctx context.Context, departmentID string) (
 	}
 	return count > 0, nil
 }
+
+// CountByTenantManual 统计租户下的部门数（跨租户）
+//
+//tenantscope:allow 配额用量按指定租户统计，超级管理员可查看其他租户
+func (r *DepartmentRepo) CountByTenantManual(ctx context.Context, tenantID string) (int64, error) {
+	return r.q.Department.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.Department.TenantID.Eq(tenantID)).
+		Count()
+}
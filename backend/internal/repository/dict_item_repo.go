@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 	"sort"
@@ -51,8 +52,10 @@ func (r *DictItemRepo) GetByID(ctx context.Context, itemID string) (*model.DictI
 }
 
 // GetByTypeAndTenant 获取指定类型和租户的字典项（跨租户查询）
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictItemRepo) GetByTypeAndTenant(ctx context.Context, typeID, tenantID string) ([]*model.DictItem, error) {
-	return r.q.DictItem.WithContext(ctx).
+	return r.q.DictItem.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.DictItem.TypeID.Eq(typeID)).
 		Where(r.q.DictItem.TenantID.Eq(tenantID)).
 		Order(r.q.DictItem.Sort).
@@ -60,8 +63,10 @@ func (r *DictItemRepo) GetByTypeAndTenant(ctx context.Context, typeID, tenantID
 }
 
 // GetByTypeAndValue 获取指定类型、租户、值的字典项（跨租户查询）
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictItemRepo) GetByTypeAndValue(ctx context.Context, typeID, tenantID, value string) (*model.DictItem, error) {
-	return r.q.DictItem.WithContext(ctx).
+	return r.q.DictItem.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.DictItem.TypeID.Eq(typeID)).
 		Where(r.q.DictItem.TenantID.Eq(tenantID)).
 		Where(r.q.DictItem.Value.Eq(value)).
@@ -69,8 +74,10 @@ func (r *DictItemRepo) GetByTypeAndValue(ctx context.Context, typeID, tenantID,
 }
 
 // DeleteByTypeAndValue 删除指定类型、租户、值的字典项（跨租户查询）
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictItemRepo) DeleteByTypeAndValue(ctx context.Context, typeID, tenantID, value string) error {
-	_, err := r.q.DictItem.WithContext(ctx).
+	_, err := r.q.DictItem.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.DictItem.TypeID.Eq(typeID)).
 		Where(r.q.DictItem.TenantID.Eq(tenantID)).
 		Where(r.q.DictItem.Value.Eq(value)).
@@ -128,9 +135,11 @@ func (r *DictItemRepo) ListByTypeID(ctx context.Context, typeID string) ([]*mode
 
 // GetMergedByTypeCode 根据字典编码获取合并后的字典项（系统+租户覆盖）
 // 这是字典系统的核心方法，实现了租户覆盖系统默认值的逻辑
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictItemRepo) GetMergedByTypeCode(ctx context.Context, typeCode string, defaultTenantID, currentTenantID string) ([]*model.DictItem, error) {
 	// 1. 获取字典类型
-	dictType, err := query.Use(r.db).DictType.WithContext(ctx).
+	dictType, err := query.Use(r.db).DictType.WithContext(database.WithTenant(ctx, defaultTenantID)).
 		Where(query.Use(r.db).DictType.TypeCode.Eq(typeCode)).
 		Where(query.Use(r.db).DictType.TenantID.Eq(defaultTenantID)).
 		First()
@@ -186,9 +195,11 @@ func (r *DictItemRepo) GetMergedByTypeCode(ctx context.Context, typeCode string,
 }
 
 // GetDictTypeWithItems 获取字典类型及其合并后的字典项
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictItemRepo) GetDictTypeWithItems(ctx context.Context, typeCode string, defaultTenantID, currentTenantID string) (*model.DictType, []*model.DictItem, error) {
 	// 获取字典类型
-	dictType, err := query.Use(r.db).DictType.WithContext(ctx).
+	dictType, err := query.Use(r.db).DictType.WithContext(database.WithTenant(ctx, defaultTenantID)).
 		Where(query.Use(r.db).DictType.TypeCode.Eq(typeCode)).
 		Where(query.Use(r.db).DictType.TenantID.Eq(defaultTenantID)).
 		First()
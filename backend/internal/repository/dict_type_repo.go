@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 
@@ -38,8 +39,10 @@ func (r *DictTypeRepo) GetByID(ctx context.Context, typeID string) (*model.DictT
 }
 
 // GetByCodeAndTenant 根据租户ID和字典编码获取字典类型（跨租户查询）
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictTypeRepo) GetByCodeAndTenant(ctx context.Context, typeCode, tenantID string) (*model.DictType, error) {
-	return r.q.DictType.WithContext(ctx).
+	return r.q.DictType.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.DictType.TenantID.Eq(tenantID)).
 		Where(r.q.DictType.TypeCode.Eq(typeCode)).
 		First()
@@ -93,6 +96,15 @@ func (r *DictTypeRepo) GetByIDs(ctx context.Context, typeIDs []string) ([]*model
 		Find()
 }
 
+// GetByIDsManual 根据ID列表获取字典类型（跨租户查询）
+//
+//tenantscope:allow 导出数据包时读取覆盖项引用的 default 租户字典类型
+func (r *DictTypeRepo) GetByIDsManual(ctx context.Context, typeIDs []string) ([]*model.DictType, error) {
+	return r.q.DictType.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.DictType.TypeID.In(typeIDs...)).
+		Find()
+}
+
 // List 分页获取字典类型列表
 func (r *DictTypeRepo) List(ctx context.Context, offset, limit int) ([]*model.DictType, int64, error) {
 	tenantID := xcontext.GetTenantID(ctx)
@@ -131,8 +143,10 @@ func (r *DictTypeRepo) ListAll(ctx context.Context) ([]*model.DictType, error) {
 }
 
 // CheckExists 检查字典类型是否存在
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictTypeRepo) CheckExists(ctx context.Context, tenantID, typeCode string) (bool, error) {
-	count, err := r.q.DictType.WithContext(ctx).
+	count, err := r.q.DictType.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.DictType.TenantID.Eq(tenantID)).
 		Where(r.q.DictType.TypeCode.Eq(typeCode)).
 		Count()
@@ -143,8 +157,10 @@ func (r *DictTypeRepo) CheckExists(ctx context.Context, tenantID, typeCode strin
 }
 
 // CheckExistsByID 检查字典编码是否存在（排除指定ID）
+//
+//tenantscope:allow 租户ID由字典服务传入，为当前租户或提供系统字典的默认租户
 func (r *DictTypeRepo) CheckExistsByID(ctx context.Context, tenantID, typeCode string, excludeTypeID string) (bool, error) {
-	count, err := r.q.DictType.WithContext(ctx).
+	count, err := r.q.DictType.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.DictType.TenantID.Eq(tenantID)).
 		Where(r.q.DictType.TypeCode.Eq(typeCode)).
 		Where(r.q.DictType.TypeID.Neq(excludeTypeID)).
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ExportJobRepo 导出任务仓储
+type ExportJobRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewExportJobRepo 创建导出任务仓储
+func NewExportJobRepo(db *gorm.DB) *ExportJobRepo {
+	return &ExportJobRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建导出任务
+func (r *ExportJobRepo) Create(ctx context.Context, job *model.ExportJob) error {
+	job.TenantID = xcontext.GetTenantID(ctx)
+	return r.q.ExportJob.WithContext(ctx).Create(job)
+}
+
+// GetByID 根据ID获取当前租户的导出任务
+func (r *ExportJobRepo) GetByID(ctx context.Context, exportID string) (*model.ExportJob, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.ExportJob.WithContext(ctx).
+		Where(r.q.ExportJob.TenantID.Eq(tenantID)).
+		Where(r.q.ExportJob.ExportID.Eq(exportID)).
+		First()
+}
+
+// ListByUser 分页获取当前租户中某个用户发起的导出任务
+func (r *ExportJobRepo) ListByUser(ctx context.Context, userID string, offset, limit int) ([]*model.ExportJob, int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	query := r.q.ExportJob.WithContext(ctx).
+		Where(r.q.ExportJob.TenantID.Eq(tenantID)).
+		Where(r.q.ExportJob.UserID.Eq(userID))
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, err := query.Order(r.q.ExportJob.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return jobs, total, err
+}
+
+// Update 更新当前租户的导出任务
+func (r *ExportJobRepo) Update(ctx context.Context, exportID string, updates map[string]interface{}) error {
+	tenantID := xcontext.GetTenantID(ctx)
+	_, err := r.q.ExportJob.WithContext(ctx).
+		Where(r.q.ExportJob.TenantID.Eq(tenantID)).
+		Where(r.q.ExportJob.ExportID.Eq(exportID)).
+		Updates(updates)
+	return err
+}
+
+// ListExpiredManual 获取文件已过期的导出任务（跨租户），未完成的任务没有过期时间，不在此列
+//
+//tenantscope:allow 过期导出文件由定时任务统一清理，需覆盖所有租户
+func (r *ExportJobRepo) ListExpiredManual(ctx context.Context, now int64, limit int) ([]*model.ExportJob, error) {
+	return r.q.ExportJob.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.ExportJob.ExpiresAt.Gt(0), r.q.ExportJob.ExpiresAt.Lte(now)).
+		Order(r.q.ExportJob.ExpiresAt).
+		Limit(limit).
+		Find()
+}
+
+// DeleteByIDsManual 批量删除导出任务记录（跨租户）
+//
+//tenantscope:allow 过期导出文件由定时任务统一清理，需覆盖所有租户
+func (r *ExportJobRepo) DeleteByIDsManual(ctx context.Context, exportIDs []string) error {
+	_, err := r.q.ExportJob.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.ExportJob.ExportID.In(exportIDs...)).
+		Delete()
+	return err
+}
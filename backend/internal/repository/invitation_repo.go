@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InvitationRepo 成员邀请仓储
+type InvitationRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewInvitationRepo 创建成员邀请仓储
+func NewInvitationRepo(db *gorm.DB) *InvitationRepo {
+	return &InvitationRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建邀请
+func (r *InvitationRepo) Create(ctx context.Context, invitation *model.Invitation) error {
+	invitation.TenantID = xcontext.GetTenantID(ctx)
+	return r.q.Invitation.WithContext(ctx).Create(invitation)
+}
+
+// GetByID 根据ID获取当前租户的邀请
+func (r *InvitationRepo) GetByID(ctx context.Context, invitationID string) (*model.Invitation, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.Invitation.WithContext(ctx).
+		Where(r.q.Invitation.TenantID.Eq(tenantID)).
+		Where(r.q.Invitation.InvitationID.Eq(invitationID)).
+		First()
+}
+
+// GetByIDManual 根据ID获取邀请（跨租户，用于校验邀请链接）
+//
+//tenantscope:allow 被邀请人打开链接时尚未登录，需按链接中的邀请ID确定所属租户
+func (r *InvitationRepo) GetByIDManual(ctx context.Context, invitationID string) (*model.Invitation, error) {
+	return r.q.Invitation.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.Invitation.InvitationID.Eq(invitationID)).
+		First()
+}
+
+// ListPendingByEmail 获取当前租户发给该邮箱的待接受邀请
+func (r *InvitationRepo) ListPendingByEmail(ctx context.Context, email string) ([]*model.Invitation, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.Invitation.WithContext(ctx).
+		Where(r.q.Invitation.TenantID.Eq(tenantID)).
+		Where(r.q.Invitation.Email.Eq(email)).
+		Where(r.q.Invitation.Status.Eq(constants.InvitationPending)).
+		Find()
+}
+
+// ListWithFilters 根据筛选条件分页获取当前租户的邀请
+// status 为 PENDING 时只返回未过期的待接受邀请，为 EXPIRED 时返回已过期的待接受邀请
+func (r *InvitationRepo) ListWithFilters(ctx context.Context, offset, limit int, email, status string) ([]*model.Invitation, int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	now := time.Now().UnixMilli()
+	query := r.q.Invitation.WithContext(ctx).
+		Where(r.q.Invitation.TenantID.Eq(tenantID))
+
+	if email != "" {
+		query = query.Where(r.q.Invitation.Email.Like("%" + email + "%"))
+	}
+	switch status {
+	case "":
+	case constants.InvitationPending:
+		query = query.Where(r.q.Invitation.Status.Eq(constants.InvitationPending), r.q.Invitation.ExpiresAt.Gte(now))
+	case constants.InvitationExpired:
+		query = query.Where(r.q.Invitation.Status.Eq(constants.InvitationPending), r.q.Invitation.ExpiresAt.Lt(now))
+	default:
+		query = query.Where(r.q.Invitation.Status.Eq(status))
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	invitations, err := query.Order(r.q.Invitation.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return invitations, total, err
+}
+
+// UpdatePending 更新当前租户的待接受邀请，邀请已不是待接受状态时不更新，返回影响行数
+func (r *InvitationRepo) UpdatePending(ctx context.Context, invitationID string, updates map[string]interface{}) (int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	info, err := r.q.Invitation.WithContext(ctx).
+		Where(r.q.Invitation.TenantID.Eq(tenantID)).
+		Where(r.q.Invitation.InvitationID.Eq(invitationID)).
+		Where(r.q.Invitation.Status.Eq(constants.InvitationPending)).
+		Updates(updates)
+	if err != nil {
+		return 0, err
+	}
+	return info.RowsAffected, nil
+}
+
+// MarkAccepted 将邀请标记为已接受
+// 仅当邀请仍为待接受状态且签名随机数未变化时更新，保证链接只能使用一次，返回影响行数
+func (r *InvitationRepo) MarkAccepted(ctx context.Context, invitationID, nonce, userID string) (int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	now := time.Now().UnixMilli()
+	i := r.q.Invitation
+	info, err := i.WithContext(ctx).
+		Where(i.TenantID.Eq(tenantID)).
+		Where(i.InvitationID.Eq(invitationID)).
+		Where(i.Nonce.Eq(nonce)).
+		Where(i.Status.Eq(constants.InvitationPending)).
+		UpdateSimple(
+			i.Status.Value(constants.InvitationAccepted),
+			i.AcceptedUserID.Value(userID),
+			i.AcceptedAt.Value(now),
+			i.UpdatedAt.Value(now),
+		)
+	if err != nil {
+		return 0, err
+	}
+	return info.RowsAffected, nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/constants"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 
@@ -30,50 +32,107 @@ func (r *LoginLogRepo) GetByID(ctx context.Context, logID string) (*model.LoginL
 		First()
 }
 
+// LoginLogFilter 登录日志筛选条件
+type LoginLogFilter struct {
+	TenantID      string // 为空时使用上下文中的租户，非空时跨租户查询（超管）
+	UserID        string
+	UserName      string
+	OperationType string
+	LoginType     string
+	IPAddress     string
+	Status        *int16
+	StartDate     *int64
+	EndDate       *int64
+}
+
 // ListWithFilters 根据筛选条件分页获取登录日志列表
 func (r *LoginLogRepo) ListWithFilters(ctx context.Context, tenantID string, offset, limit int, userID, userName, operationType, loginType, ipAddress string, status *int16, startDate, endDate *int64) ([]*model.LoginLog, int64, error) {
-	q := r.q.LoginLog.WithContext(ctx)
+	q := r.filterQuery(ctx, r.q, LoginLogFilter{
+		TenantID:      tenantID,
+		UserID:        userID,
+		UserName:      userName,
+		OperationType: operationType,
+		LoginType:     loginType,
+		IPAddress:     ipAddress,
+		Status:        status,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	})
+
+	// 获取总数
+	total, err := q.Count()
+	if err != nil {
+		return nil, 0, err
+	}
 
+	// 分页查询
+	logs, err := q.Order(r.q.LoginLog.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return logs, total, err
+}
+
+// CountWithFilters 按筛选条件统计登录日志数
+func (r *LoginLogRepo) CountWithFilters(ctx context.Context, filter LoginLogFilter) (int64, error) {
+	return r.filterQuery(ctx, r.q, filter).Count()
+}
+
+// StreamWithFilters 按与 ListWithFilters 相同的筛选条件和排序，以游标方式分批读取登录日志（用于导出）
+func (r *LoginLogRepo) StreamWithFilters(ctx context.Context, filter LoginLogFilter, batchSize int, fn func([]*model.LoginLog) error) error {
+	return database.InTransactionWithCtx(ctx, r.db, func(ctx context.Context, tx *database.Tx) error {
+		q := r.filterQuery(ctx, tx.Q, filter).Order(tx.Q.LoginLog.CreatedAt.Desc())
+		return database.ScanInBatches(q.UnderlyingDB(), batchSize, fn)
+	})
+}
+
+// filterQuery 构造登录日志列表的筛选条件
+//
+//tenantscope:allow 筛选租户由登录日志服务取自当前租户
+func (r *LoginLogRepo) filterQuery(ctx context.Context, q *query.Query, filter LoginLogFilter) query.ILoginLogDo {
 	// 租户过滤：如果传入了 tenantID 参数则使用它（超管跨租户），否则用上下文中的租户
+	tenantID := filter.TenantID
 	if tenantID != "" {
-		q = q.Where(r.q.LoginLog.TenantID.Eq(tenantID))
+		ctx = database.WithTenant(ctx, tenantID)
 	} else {
-		q = q.Where(r.q.LoginLog.TenantID.Eq(xcontext.GetTenantID(ctx)))
+		tenantID = xcontext.GetTenantID(ctx)
 	}
+	do := q.LoginLog.WithContext(ctx).Where(q.LoginLog.TenantID.Eq(tenantID))
 
 	// 应用筛选条件
-	if userID != "" {
-		q = q.Where(r.q.LoginLog.UserID.Eq(userID))
+	if filter.UserID != "" {
+		do = do.Where(q.LoginLog.UserID.Eq(filter.UserID))
 	}
-	if userName != "" {
-		q = q.Where(r.q.LoginLog.UserName.Like("%" + userName + "%"))
+	if filter.UserName != "" {
+		do = do.Where(q.LoginLog.UserName.Like("%" + filter.UserName + "%"))
 	}
-	if operationType != "" {
-		q = q.Where(r.q.LoginLog.OperationType.Eq(operationType))
+	if filter.OperationType != "" {
+		do = do.Where(q.LoginLog.OperationType.Eq(filter.OperationType))
 	}
-	if loginType != "" {
-		q = q.Where(r.q.LoginLog.LoginType.Eq(loginType))
+	if filter.LoginType != "" {
+		do = do.Where(q.LoginLog.LoginType.Eq(filter.LoginType))
 	}
-	if ipAddress != "" {
-		q = q.Where(r.q.LoginLog.LoginIP.Like("%" + ipAddress + "%"))
+	if filter.IPAddress != "" {
+		do = do.Where(q.LoginLog.LoginIP.Like("%" + filter.IPAddress + "%"))
 	}
-	if status != nil {
-		q = q.Where(r.q.LoginLog.Status.Eq(*status))
+	if filter.Status != nil {
+		do = do.Where(q.LoginLog.Status.Eq(*filter.Status))
 	}
-	if startDate != nil && *startDate > 0 {
-		q = q.Where(r.q.LoginLog.CreatedAt.Gte(*startDate))
+	if filter.StartDate != nil && *filter.StartDate > 0 {
+		do = do.Where(q.LoginLog.CreatedAt.Gte(*filter.StartDate))
 	}
-	if endDate != nil && *endDate > 0 {
-		q = q.Where(r.q.LoginLog.CreatedAt.Lte(*endDate))
-	}
-
-	// 获取总数
-	total, err := q.Count()
-	if err != nil {
-		return nil, 0, err
+	if filter.EndDate != nil && *filter.EndDate > 0 {
+		do = do.Where(q.LoginLog.CreatedAt.Lte(*filter.EndDate))
 	}
+	return do
+}
 
-	// 分页查询
-	logs, err := q.Order(r.q.LoginLog.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
-	return logs, total, err
+// ListRecentSuccessManual 获取用户最近的成功登录记录（跨租户，用于登录风险比对）
+//
+//tenantscope:allow 登录风险检测在认证完成前按用户读取历史登录，此时 context 中没有租户
+func (r *LoginLogRepo) ListRecentSuccessManual(ctx context.Context, userID string, limit int) ([]*model.LoginLog, error) {
+	return r.q.LoginLog.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.LoginLog.UserID.Eq(userID)).
+		Where(r.q.LoginLog.OperationType.Eq(constants.OperationLogin)).
+		Where(r.q.LoginLog.Status.Eq(constants.OperationStatusSuccess)).
+		Order(r.q.LoginLog.CreatedAt.Desc()).
+		Limit(limit).
+		Find()
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 
@@ -30,47 +31,90 @@ func (r *OperationLogRepo) GetByID(ctx context.Context, logID string) (*model.Op
 		First()
 }
 
+// OperationLogFilter 操作日志筛选条件
+type OperationLogFilter struct {
+	TenantID      string // 为空时使用上下文中的租户，非空时跨租户查询（超管）
+	Module        string
+	OperationType string
+	ResourceType  string
+	UserName      string
+	Status        int
+	StartDate     int64
+	EndDate       int64
+}
+
 // ListWithFilters 根据筛选条件分页获取操作日志列表
 func (r *OperationLogRepo) ListWithFilters(ctx context.Context, tenantID string, offset, limit int, module, operationType, resourceType, userName string, status int, startDate, endDate int64) ([]*model.OperationLog, int64, error) {
-	q := r.q.OperationLog.WithContext(ctx)
+	q := r.filterQuery(ctx, r.q, OperationLogFilter{
+		TenantID:      tenantID,
+		Module:        module,
+		OperationType: operationType,
+		ResourceType:  resourceType,
+		UserName:      userName,
+		Status:        status,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	})
+
+	// 获取总数
+	total, err := q.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 分页查询
+	logs, err := q.Order(r.q.OperationLog.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return logs, total, err
+}
 
+// CountWithFilters 按筛选条件统计操作日志数
+func (r *OperationLogRepo) CountWithFilters(ctx context.Context, filter OperationLogFilter) (int64, error) {
+	return r.filterQuery(ctx, r.q, filter).Count()
+}
+
+// StreamWithFilters 按与 ListWithFilters 相同的筛选条件和排序，以游标方式分批读取操作日志（用于导出）
+func (r *OperationLogRepo) StreamWithFilters(ctx context.Context, filter OperationLogFilter, batchSize int, fn func([]*model.OperationLog) error) error {
+	return database.InTransactionWithCtx(ctx, r.db, func(ctx context.Context, tx *database.Tx) error {
+		q := r.filterQuery(ctx, tx.Q, filter).Order(tx.Q.OperationLog.CreatedAt.Desc())
+		return database.ScanInBatches(q.UnderlyingDB(), batchSize, fn)
+	})
+}
+
+// filterQuery 构造操作日志列表的筛选条件
+//
+//tenantscope:allow 筛选租户由操作日志服务取自当前租户
+func (r *OperationLogRepo) filterQuery(ctx context.Context, q *query.Query, filter OperationLogFilter) query.IOperationLogDo {
 	// 租户过滤：如果传入了 tenantID 参数则使用它（超管跨租户），否则用上下文中的租户
+	tenantID := filter.TenantID
 	if tenantID != "" {
-		q = q.Where(r.q.OperationLog.TenantID.Eq(tenantID))
+		ctx = database.WithTenant(ctx, tenantID)
 	} else {
-		q = q.Where(r.q.OperationLog.TenantID.Eq(xcontext.GetTenantID(ctx)))
+		tenantID = xcontext.GetTenantID(ctx)
 	}
+	do := q.OperationLog.WithContext(ctx).Where(q.OperationLog.TenantID.Eq(tenantID))
 
 	// 应用筛选条件
-	if module != "" {
-		q = q.Where(r.q.OperationLog.Module.Eq(module))
+	if filter.Module != "" {
+		do = do.Where(q.OperationLog.Module.Eq(filter.Module))
 	}
-	if operationType != "" {
-		q = q.Where(r.q.OperationLog.OperationType.Eq(operationType))
+	if filter.OperationType != "" {
+		do = do.Where(q.OperationLog.OperationType.Eq(filter.OperationType))
 	}
-	if resourceType != "" {
-		q = q.Where(r.q.OperationLog.ResourceType.Eq(resourceType))
+	if filter.ResourceType != "" {
+		do = do.Where(q.OperationLog.ResourceType.Eq(filter.ResourceType))
 	}
-	if userName != "" {
-		q = q.Where(r.q.OperationLog.UserName.Like("%" + userName + "%"))
+	if filter.UserName != "" {
+		do = do.Where(q.OperationLog.UserName.Like("%" + filter.UserName + "%"))
 	}
-	if status != 0 {
-		q = q.Where(r.q.OperationLog.Status.Eq(int16(status)))
+	if filter.Status != 0 {
+		do = do.Where(q.OperationLog.Status.Eq(int16(filter.Status)))
 	}
-	if startDate > 0 {
-		q = q.Where(r.q.OperationLog.CreatedAt.Gte(startDate))
+	if filter.StartDate > 0 {
+		do = do.Where(q.OperationLog.CreatedAt.Gte(filter.StartDate))
 	}
-	if endDate > 0 {
-		q = q.Where(r.q.OperationLog.CreatedAt.Lte(endDate))
+	if filter.EndDate > 0 {
+		do = do.Where(q.OperationLog.CreatedAt.Lte(filter.EndDate))
 	}
+	return do
 
-	// 获取总数
-	total, err := q.Count()
-	if err != nil {
-		return nil, 0, err
-	}
-
-	// 分页查询
-	logs, err := q.Order(r.q.OperationLog.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
-	return logs, total, err
 }
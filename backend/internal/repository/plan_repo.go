@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// PlanRepo 租户套餐仓储（全局，不区分租户）
+//
+//tenantscope:allow 套餐为平台级数据，套餐上限按指定租户读取
+type PlanRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewPlanRepo 创建套餐仓储
+func NewPlanRepo(db *gorm.DB) *PlanRepo {
+	return &PlanRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建套餐
+func (r *PlanRepo) Create(ctx context.Context, plan *model.Plan) error {
+	return r.q.Plan.WithContext(ctx).Create(plan)
+}
+
+// GetByID 根据ID获取套餐
+func (r *PlanRepo) GetByID(ctx context.Context, planID string) (*model.Plan, error) {
+	return r.q.Plan.WithContext(ctx).
+		Where(r.q.Plan.PlanID.Eq(planID)).
+		First()
+}
+
+// GetByCode 根据编码获取套餐
+func (r *PlanRepo) GetByCode(ctx context.Context, planCode string) (*model.Plan, error) {
+	return r.q.Plan.WithContext(ctx).
+		Where(r.q.Plan.PlanCode.Eq(planCode)).
+		First()
+}
+
+// GetByIDs 根据ID列表获取套餐
+func (r *PlanRepo) GetByIDs(ctx context.Context, planIDs []string) ([]*model.Plan, error) {
+	return r.q.Plan.WithContext(ctx).
+		Where(r.q.Plan.PlanID.In(planIDs...)).
+		Find()
+}
+
+// Update 更新套餐
+func (r *PlanRepo) Update(ctx context.Context, planID string, updates map[string]interface{}) error {
+	_, err := r.q.Plan.WithContext(ctx).
+		Where(r.q.Plan.PlanID.Eq(planID)).
+		Updates(updates)
+	return err
+}
+
+// Delete 删除套餐（软删除）
+func (r *PlanRepo) Delete(ctx context.Context, planID string) error {
+	_, err := r.q.Plan.WithContext(ctx).
+		Where(r.q.Plan.PlanID.Eq(planID)).
+		Delete()
+	return err
+}
+
+// ListWithFilters 条件查询套餐列表
+func (r *PlanRepo) ListWithFilters(ctx context.Context, offset, limit int, name, code string, status int) ([]*model.Plan, int64, error) {
+	q := r.q.Plan.WithContext(ctx)
+
+	if name != "" {
+		q = q.Where(r.q.Plan.Name.Like("%" + name + "%"))
+	}
+	if code != "" {
+		q = q.Where(r.q.Plan.PlanCode.Like("%" + code + "%"))
+	}
+	if status != 0 {
+		q = q.Where(r.q.Plan.Status.Eq(int16(status)))
+	}
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	plans, err := q.
+		Order(r.q.Plan.Sort, r.q.Plan.CreatedAt.Desc()).
+		Offset(offset).
+		Limit(limit).
+		Find()
+
+	return plans, total, err
+}
+
+// CheckExists 检查套餐编码是否存在
+func (r *PlanRepo) CheckExists(ctx context.Context, planCode string, excludePlanID ...string) (bool, error) {
+	q := r.q.Plan.WithContext(ctx).Where(r.q.Plan.PlanCode.Eq(planCode))
+
+	if len(excludePlanID) > 0 && excludePlanID[0] != "" {
+		q = q.Where(r.q.Plan.PlanID.Neq(excludePlanID[0]))
+	}
+
+	count, err := q.Count()
+	return count > 0, err
+}
+
+// GetPermissionIDs 获取套餐的权限ID列表
+func (r *PlanRepo) GetPermissionIDs(ctx context.Context, planID string) ([]string, error) {
+	items, err := r.q.PlanPermission.WithContext(ctx).
+		Where(r.q.PlanPermission.PlanID.Eq(planID)).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	permIDs := make([]string, len(items))
+	for i, item := range items {
+		permIDs[i] = item.PermissionID
+	}
+	return permIDs, nil
+}
+
+// ReplacePermissions 替换套餐的权限（先删后增，需在事务中调用）
+func (r *PlanRepo) ReplacePermissions(ctx context.Context, planID string, permIDs []string) error {
+	if _, err := r.q.PlanPermission.WithContext(ctx).
+		Where(r.q.PlanPermission.PlanID.Eq(planID)).
+		Delete(); err != nil {
+		return err
+	}
+	if len(permIDs) == 0 {
+		return nil
+	}
+
+	items := make([]*model.PlanPermission, len(permIDs))
+	for i, permID := range permIDs {
+		items[i] = &model.PlanPermission{
+			PlanID:       planID,
+			PermissionID: permID,
+		}
+	}
+	return r.q.PlanPermission.WithContext(ctx).Create(items...)
+}
+
+// DeletePermissions 删除套餐的所有权限
+func (r *PlanRepo) DeletePermissions(ctx context.Context, planID string) error {
+	_, err := r.q.PlanPermission.WithContext(ctx).
+		Where(r.q.PlanPermission.PlanID.Eq(planID)).
+		Delete()
+	return err
+}
+
+// GetTenantCeilingManual 获取租户套餐允许的权限ID列表（跨租户）
+// limited 为 false 表示租户未绑定套餐，不限制权限范围
+func (r *PlanRepo) GetTenantCeilingManual(ctx context.Context, tenantID string) (permIDs []string, limited bool, err error) {
+	tenant, err := r.q.Tenant.WithContext(ctx).
+		Where(r.q.Tenant.TenantID.Eq(tenantID)).
+		First()
+	if err != nil {
+		return nil, false, err
+	}
+	if tenant.PlanID == "" {
+		return nil, false, nil
+	}
+
+	permIDs, err = r.GetPermissionIDs(ctx, tenant.PlanID)
+	return permIDs, true, err
+}
+
+// FilterByTenantCeilingManual 按租户套餐裁剪权限ID列表，租户未绑定套餐时原样返回
+func (r *PlanRepo) FilterByTenantCeilingManual(ctx context.Context, tenantID string, permIDs []string) ([]string, error) {
+	allowed, limited, err := r.GetTenantCeilingManual(ctx, tenantID)
+	if err != nil || !limited {
+		return permIDs, err
+	}
+
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, id := range allowed {
+		allowedSet[id] = true
+	}
+	filtered := make([]string, 0, len(permIDs))
+	for _, id := range permIDs {
+		if allowedSet[id] {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 
@@ -46,8 +47,10 @@ func (r *PositionRepo) GetByCode(ctx context.Context, positionCode string) (*mod
 }
 
 // GetByCodeWithTenant 根据租户ID和岗位编码获取岗位（跨租户查询）
+//
+//tenantscope:allow 租户ID为当前租户、已查出岗位的所属租户或开通中的新租户
 func (r *PositionRepo) GetByCodeWithTenant(ctx context.Context, tenantID, positionCode string) (*model.Position, error) {
-	return r.q.Position.WithContext(ctx).
+	return r.q.Position.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.Position.TenantID.Eq(tenantID)).
 		Where(r.q.Position.PositionCode.Eq(positionCode)).
 		First()
@@ -102,18 +105,7 @@ func (r *PositionRepo) List(ctx context.Context, offset, limit int) ([]*model.Po
 
 // ListWithFilters 根据筛选条件分页获取岗位列表
 func (r *PositionRepo) ListWithFilters(ctx context.Context, offset, limit int, positionName, positionCode string, statusFilter int) ([]*model.Position, int64, error) {
-	tenantID := xcontext.GetTenantID(ctx)
-	query := r.q.Position.WithContext(ctx).Where(r.q.Position.TenantID.Eq(tenantID))
-
-	if positionName != "" {
-		query = query.Where(r.q.Position.PositionName.Like("%" + positionName + "%"))
-	}
-	if positionCode != "" {
-		query = query.Where(r.q.Position.PositionCode.Like("%" + positionCode + "%"))
-	}
-	if statusFilter != 0 {
-		query = query.Where(r.q.Position.Status.Eq(int16(statusFilter)))
-	}
+	query := r.filterQuery(ctx, r.q, positionName, positionCode, statusFilter)
 
 	total, err := query.Count()
 	if err != nil {
@@ -124,6 +116,36 @@ func (r *PositionRepo) ListWithFilters(ctx context.Context, offset, limit int, p
 	return positions, total, err
 }
 
+// CountWithFilters 按与 ListWithFilters 相同的筛选条件统计岗位数
+func (r *PositionRepo) CountWithFilters(ctx context.Context, positionName, positionCode string, statusFilter int) (int64, error) {
+	return r.filterQuery(ctx, r.q, positionName, positionCode, statusFilter).Count()
+}
+
+// StreamWithFilters 按与 ListWithFilters 相同的筛选条件和排序，以游标方式分批读取岗位（用于导出）
+func (r *PositionRepo) StreamWithFilters(ctx context.Context, positionName, positionCode string, statusFilter int, batchSize int, fn func([]*model.Position) error) error {
+	return database.InTransactionWithCtx(ctx, r.db, func(ctx context.Context, tx *database.Tx) error {
+		query := r.filterQuery(ctx, tx.Q, positionName, positionCode, statusFilter).Order(tx.Q.Position.Sort.Asc())
+		return database.ScanInBatches(query.UnderlyingDB(), batchSize, fn)
+	})
+}
+
+// filterQuery 构造岗位列表的筛选条件
+func (r *PositionRepo) filterQuery(ctx context.Context, q *query.Query, positionName, positionCode string, statusFilter int) query.IPositionDo {
+	tenantID := xcontext.GetTenantID(ctx)
+	do := q.Position.WithContext(ctx).Where(q.Position.TenantID.Eq(tenantID))
+
+	if positionName != "" {
+		do = do.Where(q.Position.PositionName.Like("%" + positionName + "%"))
+	}
+	if positionCode != "" {
+		do = do.Where(q.Position.PositionCode.Like("%" + positionCode + "%"))
+	}
+	if statusFilter != 0 {
+		do = do.Where(q.Position.Status.Eq(int16(statusFilter)))
+	}
+	return do
+}
+
 // UpdateStatus 更新岗位状态
 func (r *PositionRepo) UpdateStatus(ctx context.Context, positionID string, status int) error {
 	tenantID := xcontext.GetTenantID(ctx)
@@ -135,8 +157,10 @@ func (r *PositionRepo) UpdateStatus(ctx context.Context, positionID string, stat
 }
 
 // CheckExists 检查岗位是否存在
+//
+//tenantscope:allow 租户ID为当前租户、已查出岗位的所属租户或开通中的新租户
 func (r *PositionRepo) CheckExists(ctx context.Context, tenantID, positionCode string) (bool, error) {
-	count, err := r.q.Position.WithContext(ctx).
+	count, err := r.q.Position.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.Position.TenantID.Eq(tenantID)).
 		Where(r.q.Position.PositionCode.Eq(positionCode)).
 		Count()
@@ -147,8 +171,10 @@ func (r *PositionRepo) CheckExists(ctx context.Context, tenantID, positionCode s
 }
 
 // CheckExistsByID 检查岗位编码是否存在（排除指定ID）
+//
+//tenantscope:allow 租户ID为当前租户、已查出岗位的所属租户或开通中的新租户
 func (r *PositionRepo) CheckExistsByID(ctx context.Context, tenantID, positionCode string, excludePositionID string) (bool, error) {
-	count, err := r.q.Position.WithContext(ctx).
+	count, err := r.q.Position.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.Position.TenantID.Eq(tenantID)).
 		Where(r.q.Position.PositionCode.Eq(positionCode)).
 		Where(r.q.Position.PositionID.Neq(excludePositionID)).
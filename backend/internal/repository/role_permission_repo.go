@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"context"
 
 	"gorm.io/gorm"
@@ -41,8 +42,10 @@ func (r *RolePermissionRepo) AddPermissions(ctx context.Context, items []*model.
 }
 
 // DeleteByRole 删除角色的所有权限
+//
+//tenantscope:allow 租户ID取自已查出角色的所属租户，开通租户时为默认租户
 func (r *RolePermissionRepo) DeleteByRole(ctx context.Context, roleID, tenantID string) error {
-	_, err := r.q.RolePermission.WithContext(ctx).
+	_, err := r.q.RolePermission.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.RolePermission.RoleID.Eq(roleID)).
 		Where(r.q.RolePermission.TenantID.Eq(tenantID)).
 		Delete()
@@ -50,17 +53,38 @@ func (r *RolePermissionRepo) DeleteByRole(ctx context.Context, roleID, tenantID
 }
 
 // DeleteByRoles 批量删除多个角色的所有权限
+//
+//tenantscope:allow 租户ID取自已查出角色的所属租户，开通租户时为默认租户
 func (r *RolePermissionRepo) DeleteByRoles(ctx context.Context, roleIDs []string, tenantID string) error {
-	_, err := r.q.RolePermission.WithContext(ctx).
+	_, err := r.q.RolePermission.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.RolePermission.RoleID.In(roleIDs...)).
 		Where(r.q.RolePermission.TenantID.Eq(tenantID)).
 		Delete()
 	return err
 }
 
+// DeleteOutsidePermissionsManual 删除租户内不在允许列表中的角色权限（跨租户）
+// 用于套餐变更后回收超出套餐范围的授权，返回删除的记录数
+//
+//tenantscope:allow 套餐变更后一次回收多个租户超出套餐范围的授权
+func (r *RolePermissionRepo) DeleteOutsidePermissionsManual(ctx context.Context, tenantIDs []string, allowedPermIDs []string) (int64, error) {
+	if len(tenantIDs) == 0 {
+		return 0, nil
+	}
+	q := r.q.RolePermission.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.RolePermission.TenantID.In(tenantIDs...))
+	if len(allowedPermIDs) > 0 {
+		q = q.Where(r.q.RolePermission.PermissionID.NotIn(allowedPermIDs...))
+	}
+	info, err := q.Delete()
+	return info.RowsAffected, err
+}
+
 // GetPermissionIDsByRole 获取角色的权限ID列表
+//
+//tenantscope:allow 租户ID取自已查出角色的所属租户，开通租户时为默认租户
 func (r *RolePermissionRepo) GetPermissionIDsByRole(ctx context.Context, roleID, tenantID string) ([]string, error) {
-	rps, err := r.q.RolePermission.WithContext(ctx).
+	rps, err := r.q.RolePermission.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.RolePermission.RoleID.Eq(roleID)).
 		Where(r.q.RolePermission.TenantID.Eq(tenantID)).
 		Find()
@@ -76,8 +100,10 @@ func (r *RolePermissionRepo) GetPermissionIDsByRole(ctx context.Context, roleID,
 }
 
 // GetPermissionIDsByRoles 批量获取角色的权限ID列表
+//
+//tenantscope:allow 租户ID取自已查出角色的所属租户，开通租户时为默认租户
 func (r *RolePermissionRepo) GetPermissionIDsByRoles(ctx context.Context, roleIDs []string, tenantID string) ([]string, error) {
-	rps, err := r.q.RolePermission.WithContext(ctx).
+	rps, err := r.q.RolePermission.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.RolePermission.RoleID.In(roleIDs...)).
 		Where(r.q.RolePermission.TenantID.Eq(tenantID)).
 		Find()
@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 
@@ -46,8 +47,10 @@ func (r *RoleRepo) GetByCode(ctx context.Context, roleCode string) (*model.Role,
 }
 
 // GetByCodeWithTenant 根据租户ID和角色编码获取角色（跨租户查询）
+//
+//tenantscope:allow 租户ID为当前租户、默认租户或调用方已校验的目标租户
 func (r *RoleRepo) GetByCodeWithTenant(ctx context.Context, tenantID, roleCode string) (*model.Role, error) {
-	return r.q.Role.WithContext(ctx).
+	return r.q.Role.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.Role.TenantID.Eq(tenantID)).
 		Where(r.q.Role.RoleCode.Eq(roleCode)).
 		First()
@@ -129,8 +132,10 @@ func (r *RoleRepo) UpdateStatus(ctx context.Context, roleID string, status int)
 }
 
 // CheckExists 检查角色是否存在
+//
+//tenantscope:allow 租户ID为当前租户、默认租户或调用方已校验的目标租户
 func (r *RoleRepo) CheckExists(ctx context.Context, tenantID, roleCode string) (bool, error) {
-	count, err := r.q.Role.WithContext(ctx).
+	count, err := r.q.Role.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.Role.TenantID.Eq(tenantID)).
 		Where(r.q.Role.RoleCode.Eq(roleCode)).
 		Count()
@@ -157,8 +162,10 @@ func (r *RoleRepo) ListByCodes(ctx context.Context, roleCodes []string) ([]*mode
 }
 
 // ListByCodesWithTenant 根据租户ID和角色编码列表获取角色列表（跨租户查询）
+//
+//tenantscope:allow 租户ID为当前租户、默认租户或调用方已校验的目标租户
 func (r *RoleRepo) ListByCodesWithTenant(ctx context.Context, tenantID string, roleCodes []string) ([]*model.Role, error) {
-	return r.q.Role.WithContext(ctx).
+	return r.q.Role.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.Role.TenantID.Eq(tenantID)).
 		Where(r.q.Role.RoleCode.In(roleCodes...)).
 		Find()
@@ -184,8 +191,10 @@ func (r *RoleRepo) ListAll(ctx context.Context, roleName, roleCode string, statu
 }
 
 // ListByTenant 根据租户ID获取所有角色（跨租户查询）
+//
+//tenantscope:allow 租户ID为当前租户、默认租户或调用方已校验的目标租户
 func (r *RoleRepo) ListByTenant(ctx context.Context, tenantID, roleName, roleCode string, statusFilter int) ([]*model.Role, error) {
-	query := r.q.Role.WithContext(ctx).Where(r.q.Role.TenantID.Eq(tenantID))
+	query := r.q.Role.WithContext(database.WithTenant(ctx, tenantID)).Where(r.q.Role.TenantID.Eq(tenantID))
 
 	if roleName != "" {
 		query = query.Where(r.q.Role.Name.Like("%" + roleName + "%"))
@@ -202,17 +211,7 @@ func (r *RoleRepo) ListByTenant(ctx context.Context, tenantID, roleName, roleCod
 
 // ListByTenantWithFilters 根据租户ID分页获取角色列表（跨租户查询）
 func (r *RoleRepo) ListByTenantWithFilters(ctx context.Context, tenantID string, offset, limit int, roleName, roleCode string, statusFilter int) ([]*model.Role, int64, error) {
-	query := r.q.Role.WithContext(ctx).Where(r.q.Role.TenantID.Eq(tenantID))
-
-	if roleName != "" {
-		query = query.Where(r.q.Role.Name.Like("%" + roleName + "%"))
-	}
-	if roleCode != "" {
-		query = query.Where(r.q.Role.RoleCode.Like("%" + roleCode + "%"))
-	}
-	if statusFilter != 0 {
-		query = query.Where(r.q.Role.Status.Eq(int16(statusFilter)))
-	}
+	query := r.filterQuery(ctx, r.q, tenantID, roleName, roleCode, statusFilter)
 
 	// 获取总数
 	total, err := query.Count()
@@ -225,9 +224,42 @@ func (r *RoleRepo) ListByTenantWithFilters(ctx context.Context, tenantID string,
 	return roles, total, err
 }
 
+// CountByTenantWithFilters 按与 ListByTenantWithFilters 相同的筛选条件统计角色数
+func (r *RoleRepo) CountByTenantWithFilters(ctx context.Context, tenantID, roleName, roleCode string, statusFilter int) (int64, error) {
+	return r.filterQuery(ctx, r.q, tenantID, roleName, roleCode, statusFilter).Count()
+}
+
+// StreamByTenantWithFilters 按与 ListByTenantWithFilters 相同的筛选条件和排序，以游标方式分批读取角色（用于导出）
+func (r *RoleRepo) StreamByTenantWithFilters(ctx context.Context, tenantID, roleName, roleCode string, statusFilter int, batchSize int, fn func([]*model.Role) error) error {
+	return database.InTransactionWithCtx(ctx, r.db, func(ctx context.Context, tx *database.Tx) error {
+		query := r.filterQuery(ctx, tx.Q, tenantID, roleName, roleCode, statusFilter).Order(tx.Q.Role.CreatedAt.Desc())
+		return database.ScanInBatches(query.UnderlyingDB(), batchSize, fn)
+	})
+}
+
+// filterQuery 构造角色列表的筛选条件
+//
+//tenantscope:allow 租户ID为当前租户、默认租户或调用方已校验的目标租户
+func (r *RoleRepo) filterQuery(ctx context.Context, q *query.Query, tenantID, roleName, roleCode string, statusFilter int) query.IRoleDo {
+	do := q.Role.WithContext(database.WithTenant(ctx, tenantID)).Where(q.Role.TenantID.Eq(tenantID))
+
+	if roleName != "" {
+		do = do.Where(q.Role.Name.Like("%" + roleName + "%"))
+	}
+	if roleCode != "" {
+		do = do.Where(q.Role.RoleCode.Like("%" + roleCode + "%"))
+	}
+	if statusFilter != 0 {
+		do = do.Where(q.Role.Status.Eq(int16(statusFilter)))
+	}
+	return do
+}
+
 // CheckExistsByID 检查角色编码是否存在（排除指定ID）
+//
+//tenantscope:allow 租户ID为当前租户、默认租户或调用方已校验的目标租户
 func (r *RoleRepo) CheckExistsByID(ctx context.Context, tenantID, roleCode string, excludeRoleID string) (bool, error) {
-	count, err := r.q.Role.WithContext(ctx).
+	count, err := r.q.Role.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.Role.TenantID.Eq(tenantID)).
 		Where(r.q.Role.RoleCode.Eq(roleCode)).
 		Where(r.q.Role.RoleID.Neq(excludeRoleID)).
@@ -239,6 +271,46 @@ func (r *RoleRepo) CheckExistsByID(ctx context.Context, tenantID, roleCode strin
 }
 
 // GetByIDs 根据角色ID列表获取角色信息（跨租户查询，用于权限缓存）
+//
+//tenantscope:allow 角色ID来自已按租户查询的用户角色，登录、模拟登录和切换租户时需读取目标租户的角色
 func (r *RoleRepo) GetByIDs(ctx context.Context, roleIDs []string) ([]*model.Role, error) {
-	return r.q.Role.WithContext(ctx).Where(r.q.Role.RoleID.In(roleIDs...)).Find()
+	return r.q.Role.WithContext(database.SkipTenant(ctx)).Where(r.q.Role.RoleID.In(roleIDs...)).Find()
+}
+
+// ListByTemplateManual 获取由指定模板派生的所有角色（跨租户）
+//
+//tenantscope:allow 角色模板由平台维护，同步时需列出所有租户中由该模板创建的角色
+func (r *RoleRepo) ListByTemplateManual(ctx context.Context, templateID string) ([]*model.Role, error) {
+	return r.q.Role.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.Role.TemplateID.Eq(templateID)).
+		Order(r.q.Role.TenantID, r.q.Role.CreatedAt).
+		Find()
+}
+
+// CountByTemplateManual 统计由指定模板派生的角色数量（跨租户）
+//
+//tenantscope:allow 删除角色模板前统计所有租户中仍关联该模板的角色
+func (r *RoleRepo) CountByTemplateManual(ctx context.Context, templateID string) (int64, error) {
+	return r.q.Role.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.Role.TemplateID.Eq(templateID)).
+		Count()
+}
+
+// UnlinkTemplateManual 解除所有角色与指定模板的关联（跨租户）
+//
+//tenantscope:allow 删除角色模板时解除所有租户中角色与模板的关联
+func (r *RoleRepo) UnlinkTemplateManual(ctx context.Context, templateID string) error {
+	_, err := r.q.Role.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.Role.TemplateID.Eq(templateID)).
+		Update(r.q.Role.TemplateID, "")
+	return err
+}
+
+// CountByTenantManual 统计租户下的角色数（跨租户）
+//
+//tenantscope:allow 配额用量按指定租户统计，超级管理员可查看其他租户
+func (r *RoleRepo) CountByTenantManual(ctx context.Context, tenantID string) (int64, error) {
+	return r.q.Role.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.Role.TenantID.Eq(tenantID)).
+		Count()
 }
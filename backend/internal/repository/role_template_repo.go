@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// RoleTemplateRepo 角色模板仓储（全局，不区分租户）
+type RoleTemplateRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewRoleTemplateRepo 创建角色模板仓储
+func NewRoleTemplateRepo(db *gorm.DB) *RoleTemplateRepo {
+	return &RoleTemplateRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建角色模板
+func (r *RoleTemplateRepo) Create(ctx context.Context, template *model.RoleTemplate) error {
+	return r.q.RoleTemplate.WithContext(ctx).Create(template)
+}
+
+// GetByID 根据ID获取角色模板
+func (r *RoleTemplateRepo) GetByID(ctx context.Context, templateID string) (*model.RoleTemplate, error) {
+	return r.q.RoleTemplate.WithContext(ctx).
+		Where(r.q.RoleTemplate.TemplateID.Eq(templateID)).
+		First()
+}
+
+// GetByCode 根据编码获取角色模板
+func (r *RoleTemplateRepo) GetByCode(ctx context.Context, templateCode string) (*model.RoleTemplate, error) {
+	return r.q.RoleTemplate.WithContext(ctx).
+		Where(r.q.RoleTemplate.TemplateCode.Eq(templateCode)).
+		First()
+}
+
+// Update 更新角色模板
+func (r *RoleTemplateRepo) Update(ctx context.Context, templateID string, updates map[string]interface{}) error {
+	_, err := r.q.RoleTemplate.WithContext(ctx).
+		Where(r.q.RoleTemplate.TemplateID.Eq(templateID)).
+		Updates(updates)
+	return err
+}
+
+// Delete 删除角色模板（软删除）
+func (r *RoleTemplateRepo) Delete(ctx context.Context, templateID string) error {
+	_, err := r.q.RoleTemplate.WithContext(ctx).
+		Where(r.q.RoleTemplate.TemplateID.Eq(templateID)).
+		Delete()
+	return err
+}
+
+// ListWithFilters 条件查询角色模板列表
+func (r *RoleTemplateRepo) ListWithFilters(ctx context.Context, offset, limit int, name, code string, status int) ([]*model.RoleTemplate, int64, error) {
+	q := r.q.RoleTemplate.WithContext(ctx)
+
+	if name != "" {
+		q = q.Where(r.q.RoleTemplate.Name.Like("%" + name + "%"))
+	}
+	if code != "" {
+		q = q.Where(r.q.RoleTemplate.TemplateCode.Like("%" + code + "%"))
+	}
+	if status != 0 {
+		q = q.Where(r.q.RoleTemplate.Status.Eq(int16(status)))
+	}
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	templates, err := q.
+		Order(r.q.RoleTemplate.CreatedAt.Desc()).
+		Offset(offset).
+		Limit(limit).
+		Find()
+
+	return templates, total, err
+}
+
+// CheckExists 检查模板编码是否存在
+func (r *RoleTemplateRepo) CheckExists(ctx context.Context, templateCode string, excludeTemplateID ...string) (bool, error) {
+	q := r.q.RoleTemplate.WithContext(ctx).Where(r.q.RoleTemplate.TemplateCode.Eq(templateCode))
+
+	if len(excludeTemplateID) > 0 && excludeTemplateID[0] != "" {
+		q = q.Where(r.q.RoleTemplate.TemplateID.Neq(excludeTemplateID[0]))
+	}
+
+	count, err := q.Count()
+	return count > 0, err
+}
+
+// GetPermissionIDs 获取模板的权限ID列表
+func (r *RoleTemplateRepo) GetPermissionIDs(ctx context.Context, templateID string) ([]string, error) {
+	items, err := r.q.RoleTemplatePermission.WithContext(ctx).
+		Where(r.q.RoleTemplatePermission.TemplateID.Eq(templateID)).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	permIDs := make([]string, len(items))
+	for i, item := range items {
+		permIDs[i] = item.PermissionID
+	}
+	return permIDs, nil
+}
+
+// ReplacePermissions 替换模板的权限（先删后增，需在事务中调用）
+func (r *RoleTemplateRepo) ReplacePermissions(ctx context.Context, templateID string, permIDs []string) error {
+	if _, err := r.q.RoleTemplatePermission.WithContext(ctx).
+		Where(r.q.RoleTemplatePermission.TemplateID.Eq(templateID)).
+		Delete(); err != nil {
+		return err
+	}
+	if len(permIDs) == 0 {
+		return nil
+	}
+
+	items := make([]*model.RoleTemplatePermission, len(permIDs))
+	for i, permID := range permIDs {
+		items[i] = &model.RoleTemplatePermission{
+			TemplateID:   templateID,
+			PermissionID: permID,
+		}
+	}
+	return r.q.RoleTemplatePermission.WithContext(ctx).Create(items...)
+}
+
+// DeletePermissions 删除模板的所有权限
+func (r *RoleTemplateRepo) DeletePermissions(ctx context.Context, templateID string) error {
+	_, err := r.q.RoleTemplatePermission.WithContext(ctx).
+		Where(r.q.RoleTemplatePermission.TemplateID.Eq(templateID)).
+		Delete()
+	return err
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ServiceAccountRepo 服务账号客户端凭证仓储
+type ServiceAccountRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewServiceAccountRepo 创建服务账号客户端凭证仓储
+func NewServiceAccountRepo(db *gorm.DB) *ServiceAccountRepo {
+	return &ServiceAccountRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建客户端凭证
+func (r *ServiceAccountRepo) Create(ctx context.Context, cred *model.ServiceAccountCredential) error {
+	cred.TenantID = xcontext.GetTenantID(ctx)
+	return r.q.ServiceAccountCredential.WithContext(ctx).Create(cred)
+}
+
+// GetByUserID 根据服务账号用户ID获取当前租户的客户端凭证
+func (r *ServiceAccountRepo) GetByUserID(ctx context.Context, userID string) (*model.ServiceAccountCredential, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.ServiceAccountCredential.WithContext(ctx).
+		Where(r.q.ServiceAccountCredential.TenantID.Eq(tenantID)).
+		Where(r.q.ServiceAccountCredential.UserID.Eq(userID)).
+		First()
+}
+
+// GetByUserIDs 根据服务账号用户ID列表批量获取当前租户的客户端凭证
+func (r *ServiceAccountRepo) GetByUserIDs(ctx context.Context, userIDs []string) ([]*model.ServiceAccountCredential, error) {
+	if len(userIDs) == 0 {
+		return []*model.ServiceAccountCredential{}, nil
+	}
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.ServiceAccountCredential.WithContext(ctx).
+		Where(r.q.ServiceAccountCredential.TenantID.Eq(tenantID)).
+		Where(r.q.ServiceAccountCredential.UserID.In(userIDs...)).
+		Find()
+}
+
+// GetByClientIDManual 根据客户端ID获取凭证（跨租户，用于 client_credentials 授权）
+//
+//tenantscope:allow client_credentials 授权时按客户端ID查找，此时尚未确定租户
+func (r *ServiceAccountRepo) GetByClientIDManual(ctx context.Context, clientID string) (*model.ServiceAccountCredential, error) {
+	return r.q.ServiceAccountCredential.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.ServiceAccountCredential.ClientID.Eq(clientID)).
+		First()
+}
+
+// Update 更新当前租户的客户端凭证
+func (r *ServiceAccountRepo) Update(ctx context.Context, userID string, updates map[string]interface{}) error {
+	tenantID := xcontext.GetTenantID(ctx)
+	_, err := r.q.ServiceAccountCredential.WithContext(ctx).
+		Where(r.q.ServiceAccountCredential.TenantID.Eq(tenantID)).
+		Where(r.q.ServiceAccountCredential.UserID.Eq(userID)).
+		Updates(updates)
+	return err
+}
+
+// UpdateManual 更新客户端凭证（跨租户，用于记录最后使用时间）
+//
+//tenantscope:allow 授权成功后记录凭证最后使用时间，与授权查询保持一致
+func (r *ServiceAccountRepo) UpdateManual(ctx context.Context, userID string, updates map[string]interface{}) error {
+	_, err := r.q.ServiceAccountCredential.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.ServiceAccountCredential.UserID.Eq(userID)).
+		Updates(updates)
+	return err
+}
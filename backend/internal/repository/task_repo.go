@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskRepo 后台任务仓储
+type TaskRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewTaskRepo 创建后台任务仓储
+func NewTaskRepo(db *gorm.DB) *TaskRepo {
+	return &TaskRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// TaskFilter 任务列表筛选条件
+type TaskFilter struct {
+	UserID string
+	Type   string
+	Status string
+}
+
+// Create 创建任务，租户取自 context，平台级任务为空
+func (r *TaskRepo) Create(ctx context.Context, task *model.Task) error {
+	task.TenantID = xcontext.GetTenantID(ctx)
+	return r.q.Task.WithContext(ctx).Create(task)
+}
+
+// GetByID 根据ID获取当前租户的任务
+func (r *TaskRepo) GetByID(ctx context.Context, taskID string) (*model.Task, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.Task.WithContext(ctx).
+		Where(r.q.Task.TenantID.Eq(tenantID)).
+		Where(r.q.Task.TaskID.Eq(taskID)).
+		First()
+}
+
+// List 分页获取当前租户的任务
+func (r *TaskRepo) List(ctx context.Context, filter *TaskFilter, offset, limit int) ([]*model.Task, int64, error) {
+	t := r.q.Task
+	tenantID := xcontext.GetTenantID(ctx)
+	query := t.WithContext(ctx).Where(t.TenantID.Eq(tenantID))
+	if filter.UserID != "" {
+		query = query.Where(t.UserID.Eq(filter.UserID))
+	}
+	if filter.Type != "" {
+		query = query.Where(t.Type.Eq(filter.Type))
+	}
+	if filter.Status != "" {
+		query = query.Where(t.Status.Eq(filter.Status))
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tasks, err := query.Order(t.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return tasks, total, err
+}
+
+// CancelPending 取消当前租户中尚未开始执行的任务，返回是否取消成功
+func (r *TaskRepo) CancelPending(ctx context.Context, taskID string) (bool, error) {
+	t := r.q.Task
+	tenantID := xcontext.GetTenantID(ctx)
+	info, err := t.WithContext(ctx).
+		Where(t.TenantID.Eq(tenantID), t.TaskID.Eq(taskID), t.Status.Eq(constants.TaskPending)).
+		UpdateSimple(
+			t.Status.Value(constants.TaskCanceled),
+			t.FinishedAt.Value(time.Now().UnixMilli()),
+		)
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// RequestCancel 标记当前租户中执行中的任务为取消中，由工作进程续约时发现并中断执行，返回是否标记成功
+func (r *TaskRepo) RequestCancel(ctx context.Context, taskID string) (bool, error) {
+	t := r.q.Task
+	tenantID := xcontext.GetTenantID(ctx)
+	info, err := t.WithContext(ctx).
+		Where(t.TenantID.Eq(tenantID), t.TaskID.Eq(taskID), t.Status.Eq(constants.TaskRunning)).
+		UpdateSimple(t.Status.Value(constants.TaskCanceling))
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// ClaimManual 领取最多 limit 个可执行的任务（跨租户）：到期的等待任务，以及租约已过期且未达最大执行次数的执行中任务
+// 已达最大执行次数的过期任务由 RecoverExpiredManual 标记为失败，避免反复导致进程退出的任务被无限重试
+// 使用 FOR UPDATE SKIP LOCKED，多个实例并发领取时互不阻塞且不会领到同一任务
+//
+//tenantscope:allow 工作进程统一领取所有租户的任务，执行时再按任务记录的上下文恢复租户
+func (r *TaskRepo) ClaimManual(ctx context.Context, workerID string, now, lockedUntil int64, limit int) ([]*model.Task, error) {
+	var tasks []*model.Task
+	err := r.db.WithContext(database.SkipTenant(ctx)).Raw(`
+		UPDATE tasks SET status = ?, attempts = attempts + 1, locked_by = ?, locked_until = ?, started_at = ?, updated_at = ?
+		WHERE task_id IN (
+			SELECT task_id FROM tasks
+			WHERE (status = ? AND run_at <= ?) OR (status = ? AND locked_until < ? AND attempts < max_attempts)
+			ORDER BY run_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		constants.TaskRunning, workerID, lockedUntil, now, now,
+		constants.TaskPending, now, constants.TaskRunning, now,
+		limit,
+	).Scan(&tasks).Error
+	return tasks, err
+}
+
+// RecoverExpiredManual 处理租约过期、不会再被领取的任务（跨租户），返回影响行数
+// 取消中的任务直接标记为已取消，已达最大执行次数的任务标记为失败；其余过期任务由 ClaimManual 重新领取
+//
+//tenantscope:allow 工作进程统一领取所有租户的任务，执行时再按任务记录的上下文恢复租户
+func (r *TaskRepo) RecoverExpiredManual(ctx context.Context, now int64, message string) (int64, error) {
+	t := r.q.Task
+	ctx = database.SkipTenant(ctx)
+
+	canceled, err := t.WithContext(ctx).
+		Where(t.Status.Eq(constants.TaskCanceling), t.LockedUntil.Lt(now)).
+		UpdateSimple(
+			t.Status.Value(constants.TaskCanceled),
+			t.LockedBy.Value(""),
+			t.LockedUntil.Value(0),
+			t.FinishedAt.Value(now),
+		)
+	if err != nil {
+		return 0, err
+	}
+
+	failed, err := t.WithContext(ctx).
+		Where(t.Status.Eq(constants.TaskRunning), t.LockedUntil.Lt(now)).
+		Where(t.Attempts.GteCol(t.MaxAttempts)).
+		UpdateSimple(
+			t.Status.Value(constants.TaskFailed),
+			t.ErrorMessage.Value(message),
+			t.LockedBy.Value(""),
+			t.LockedUntil.Value(0),
+			t.FinishedAt.Value(now),
+		)
+	if err != nil {
+		return canceled.RowsAffected, err
+	}
+	return canceled.RowsAffected + failed.RowsAffected, nil
+}
+
+// RenewLeaseManual 续约任务（跨租户），返回任务当前状态；租约已被其他实例接管或任务已结束时返回空字符串
+//
+//tenantscope:allow 工作进程统一领取所有租户的任务，执行时再按任务记录的上下文恢复租户
+func (r *TaskRepo) RenewLeaseManual(ctx context.Context, taskID, workerID string, lockedUntil int64) (string, error) {
+	var statuses []string
+	err := r.db.WithContext(database.SkipTenant(ctx)).Raw(`
+		UPDATE tasks SET locked_until = ?, updated_at = ?
+		WHERE task_id = ? AND locked_by = ? AND status IN (?, ?)
+		RETURNING status`,
+		lockedUntil, time.Now().UnixMilli(),
+		taskID, workerID, constants.TaskRunning, constants.TaskCanceling,
+	).Scan(&statuses).Error
+	if err != nil || len(statuses) == 0 {
+		return "", err
+	}
+	return statuses[0], nil
+}
+
+// UpdateByWorkerManual 更新工作进程持有租约的任务（跨租户），返回是否更新成功
+// 租约已被其他实例接管时不更新，避免覆盖新的执行结果
+//
+//tenantscope:allow 工作进程统一领取所有租户的任务，执行时再按任务记录的上下文恢复租户
+func (r *TaskRepo) UpdateByWorkerManual(ctx context.Context, taskID, workerID string, updates map[string]interface{}) (bool, error) {
+	t := r.q.Task
+	info, err := t.WithContext(database.SkipTenant(ctx)).
+		Where(t.TaskID.Eq(taskID), t.LockedBy.Eq(workerID)).
+		Where(t.Status.In(constants.TaskRunning, constants.TaskCanceling)).
+		Updates(updates)
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// DeleteFinishedManual 删除结束时间早于 before 的一批任务记录（跨租户），返回删除行数
+//
+//tenantscope:allow 已结束任务由定时任务统一清理，需覆盖所有租户
+func (r *TaskRepo) DeleteFinishedManual(ctx context.Context, before int64, limit int) (int64, error) {
+	result := r.db.WithContext(database.SkipTenant(ctx)).Exec(
+		"DELETE FROM tasks WHERE task_id IN (SELECT task_id FROM tasks WHERE status IN (?, ?, ?) AND finished_at > 0 AND finished_at < ? LIMIT ?)",
+		constants.TaskSucceeded, constants.TaskFailed, constants.TaskCanceled, before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,92 @@
+//go:build integration
+
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 运行方式（数据库需已执行全部迁移）：
+//
+//	DB_TEST_DSN="host=127.0.0.1 user=app password=app dbname=admin_db sslmode=disable" \
+//	    go test -tags integration ./internal/repository/ -run TestTaskRepo -v
+
+// errRollback 测试结束后回滚事务，不留下测试数据
+var errRollback = errors.New("rollback")
+
+func setupTaskDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("未设置 DB_TEST_DSN，跳过数据库集成测试")
+	}
+	db, err := database.Connect(database.Config{
+		DSN:          dsn,
+		MaxIdleConns: 2,
+		MaxOpenConns: 5,
+		LogLevel:     "silent",
+	})
+	if err != nil {
+		t.Fatalf("连接数据库失败: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestTaskRepoClaim(t *testing.T) {
+	db := setupTaskDB(t)
+	ctx := context.Background()
+	now := time.Now().UnixMilli()
+	expired := now - time.Minute.Milliseconds()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// run_at 早于任何真实任务，保证在 LIMIT 范围内被优先考虑
+		tasks := []*model.Task{
+			{TaskID: "test_task_pending", Type: "test", Status: constants.TaskPending, MaxAttempts: 3, RunAt: 1},
+			{TaskID: "test_task_retry", Type: "test", Status: constants.TaskRunning, Attempts: 1, MaxAttempts: 3, RunAt: 2, LockedBy: "dead", LockedUntil: expired},
+			{TaskID: "test_task_exhausted", Type: "test", Status: constants.TaskRunning, Attempts: 3, MaxAttempts: 3, RunAt: 3, LockedBy: "dead", LockedUntil: expired},
+		}
+		if err := tx.Create(tasks).Error; err != nil {
+			t.Fatalf("准备测试数据失败: %v", err)
+		}
+
+		claimed, err := NewTaskRepo(tx).ClaimManual(ctx, "test_worker", now, now+time.Minute.Milliseconds(), len(tasks))
+		if err != nil {
+			t.Fatalf("领取任务失败: %v", err)
+		}
+		got := make(map[string]*model.Task, len(claimed))
+		for _, task := range claimed {
+			got[task.TaskID] = task
+		}
+
+		if _, ok := got["test_task_pending"]; !ok {
+			t.Error("期望领取到期的等待任务")
+		}
+		if task, ok := got["test_task_retry"]; !ok {
+			t.Error("期望重新领取租约过期且未达最大执行次数的任务")
+		} else if task.Attempts != 2 || task.LockedBy != "test_worker" {
+			t.Errorf("期望执行次数为 2 且由 test_worker 持有，实际 %d %s", task.Attempts, task.LockedBy)
+		}
+		if _, ok := got["test_task_exhausted"]; ok {
+			t.Error("已达最大执行次数的过期任务不应被重新领取")
+		}
+		return errRollback
+	})
+	if err != nil && err != errRollback {
+		t.Fatalf("事务执行失败: %v", err)
+	}
+}
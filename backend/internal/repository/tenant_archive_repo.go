@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/database"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// archiveBatchSize 租户数据导入导出的批大小
+const archiveBatchSize = 500
+
+// TenantArchiveRepo 租户数据导入导出仓储
+// 按表整体读写租户数据，所有方法均为跨租户操作，仅供租户数据迁移（导入导出、克隆）使用
+//
+//tenantscope:allow 租户数据迁移按参数中的租户整体读写，只供超级管理员使用
+type TenantArchiveRepo struct {
+	db *gorm.DB
+}
+
+// NewTenantArchiveRepo 创建租户数据导入导出仓储
+func NewTenantArchiveRepo(db *gorm.DB) *TenantArchiveRepo {
+	return &TenantArchiveRepo{db: db}
+}
+
+// EachByTenantManual 按主键分批读取租户数据
+// dest 为模型切片指针（如 *[]*model.User），每读取一批调用一次 fn，fn 返回错误时中止
+func (r *TenantArchiveRepo) EachByTenantManual(ctx context.Context, dest interface{}, tenantID string, fn func() error) error {
+	return r.db.WithContext(database.WithTenant(ctx, tenantID)).
+		Where("tenant_id = ?", tenantID).
+		FindInBatches(dest, archiveBatchSize, func(tx *gorm.DB, batch int) error {
+			return fn()
+		}).Error
+}
+
+// FindByTenantManual 读取租户在某张表中的全部数据，dest 为模型切片指针
+func (r *TenantArchiveRepo) FindByTenantManual(ctx context.Context, dest interface{}, tenantID string) error {
+	return r.db.WithContext(database.WithTenant(ctx, tenantID)).
+		Where("tenant_id = ?", tenantID).
+		Find(dest).Error
+}
+
+// ListUserPositionsManual 获取租户用户的岗位关联
+func (r *TenantArchiveRepo) ListUserPositionsManual(ctx context.Context, tenantID string) ([]*model.UserPosition, error) {
+	var list []*model.UserPosition
+	err := r.db.WithContext(database.WithTenant(ctx, tenantID)).
+		Where("user_id IN (SELECT user_id FROM users WHERE tenant_id = ? AND deleted_at = 0)", tenantID).
+		Find(&list).Error
+	return list, err
+}
+
+// CreateInBatches 分批写入导入的数据，records 为模型切片
+func (r *TenantArchiveRepo) CreateInBatches(ctx context.Context, records interface{}) error {
+	return r.db.WithContext(ctx).CreateInBatches(records, archiveBatchSize).Error
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/database"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// TenantDomainRepo 租户自定义域名仓储
+// 域名全局唯一，用于在登录前识别租户，所有方法均为跨租户查询
+//
+//tenantscope:allow 域名全局唯一，登录前按域名识别租户时尚无认证租户
+type TenantDomainRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewTenantDomainRepo 创建租户自定义域名仓储
+func NewTenantDomainRepo(db *gorm.DB) *TenantDomainRepo {
+	return &TenantDomainRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建域名绑定
+func (r *TenantDomainRepo) Create(ctx context.Context, domain *model.TenantDomain) error {
+	return r.q.TenantDomain.WithContext(ctx).Create(domain)
+}
+
+// GetByIDManual 根据ID获取域名绑定
+func (r *TenantDomainRepo) GetByIDManual(ctx context.Context, domainID string) (*model.TenantDomain, error) {
+	return r.q.TenantDomain.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.TenantDomain.DomainID.Eq(domainID)).
+		First()
+}
+
+// GetByDomainManual 根据域名获取绑定（含未验证）
+func (r *TenantDomainRepo) GetByDomainManual(ctx context.Context, domain string) (*model.TenantDomain, error) {
+	return r.q.TenantDomain.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.TenantDomain.Domain.Eq(domain)).
+		First()
+}
+
+// GetVerifiedByDomainManual 根据域名获取已验证的绑定（租户识别使用）
+func (r *TenantDomainRepo) GetVerifiedByDomainManual(ctx context.Context, domain string) (*model.TenantDomain, error) {
+	return r.q.TenantDomain.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.TenantDomain.Domain.Eq(domain)).
+		Where(r.q.TenantDomain.VerifiedAt.Gt(0)).
+		First()
+}
+
+// ListByTenantManual 获取租户绑定的域名列表
+func (r *TenantDomainRepo) ListByTenantManual(ctx context.Context, tenantID string) ([]*model.TenantDomain, error) {
+	return r.q.TenantDomain.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantDomain.TenantID.Eq(tenantID)).
+		Order(r.q.TenantDomain.CreatedAt).
+		Find()
+}
+
+// MarkVerifiedManual 标记域名验证通过
+func (r *TenantDomainRepo) MarkVerifiedManual(ctx context.Context, domainID string, verifiedAt int64) error {
+	_, err := r.q.TenantDomain.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.TenantDomain.DomainID.Eq(domainID)).
+		UpdateSimple(r.q.TenantDomain.VerifiedAt.Value(verifiedAt), r.q.TenantDomain.UpdatedAt.Value(verifiedAt))
+	return err
+}
+
+// DeleteManual 删除域名绑定（软删除）
+func (r *TenantDomainRepo) DeleteManual(ctx context.Context, domainID string) error {
+	_, err := r.q.TenantDomain.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.TenantDomain.DomainID.Eq(domainID)).
+		Delete()
+	return err
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TenantMemberRepo 租户成员仓储
+// 记录用户在主租户以外加入的租户，主租户的成员身份即 users 记录本身
+type TenantMemberRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewTenantMemberRepo 创建租户成员仓储
+func NewTenantMemberRepo(db *gorm.DB) *TenantMemberRepo {
+	return &TenantMemberRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Upsert 加入租户，已是成员时更新部门和岗位并恢复为正常状态
+//
+//tenantscope:allow 成员记录写入邀请所属的租户，租户ID来自已校验的邀请
+func (r *TenantMemberRepo) Upsert(ctx context.Context, member *model.TenantMember) error {
+	return r.q.TenantMember.WithContext(database.WithTenant(ctx, member.TenantID)).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "department_id", "position_id", "updated_at"}),
+		}).
+		Create(member)
+}
+
+// GetByUser 获取用户在当前租户的成员身份
+func (r *TenantMemberRepo) GetByUser(ctx context.Context, userID string) (*model.TenantMember, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.TenantMember.WithContext(ctx).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID)).
+		Where(r.q.TenantMember.UserID.Eq(userID)).
+		First()
+}
+
+// GetByTenantAndUserManual 获取用户在指定租户的成员身份（跨租户）
+//
+//tenantscope:allow 切换租户时需在进入目标租户前校验成员身份
+func (r *TenantMemberRepo) GetByTenantAndUserManual(ctx context.Context, tenantID, userID string) (*model.TenantMember, error) {
+	return r.q.TenantMember.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID)).
+		Where(r.q.TenantMember.UserID.Eq(userID)).
+		First()
+}
+
+// ListEnabledByUserManual 获取用户状态正常的全部成员身份（跨租户）
+//
+//tenantscope:allow 用户可切换的租户分布在多个租户中
+func (r *TenantMemberRepo) ListEnabledByUserManual(ctx context.Context, userID string) ([]*model.TenantMember, error) {
+	return r.q.TenantMember.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.TenantMember.UserID.Eq(userID)).
+		Where(r.q.TenantMember.Status.Eq(int16(constants.StatusEnabled))).
+		Order(r.q.TenantMember.CreatedAt).
+		Find()
+}
+
+// ListUserIDsByTenantManual 获取加入指定租户的全部用户ID（跨租户）
+//
+//tenantscope:allow 删除租户时吊销该租户全部成员的会话
+func (r *TenantMemberRepo) ListUserIDsByTenantManual(ctx context.Context, tenantID string) ([]string, error) {
+	var userIDs []string
+	err := r.q.TenantMember.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID)).
+		Pluck(r.q.TenantMember.UserID, &userIDs)
+	return userIDs, err
+}
+
+// ListWithFilters 根据筛选条件分页获取当前租户的成员
+func (r *TenantMemberRepo) ListWithFilters(ctx context.Context, offset, limit int, departmentID string, statusFilter int) ([]*model.TenantMember, int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	query := r.q.TenantMember.WithContext(ctx).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID))
+
+	if departmentID != "" {
+		query = query.Where(r.q.TenantMember.DepartmentID.Eq(departmentID))
+	}
+	if statusFilter != 0 {
+		query = query.Where(r.q.TenantMember.Status.Eq(int16(statusFilter)))
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members, err := query.Order(r.q.TenantMember.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return members, total, err
+}
+
+// ListByUsers 获取指定用户在当前租户的成员身份
+func (r *TenantMemberRepo) ListByUsers(ctx context.Context, userIDs []string) ([]*model.TenantMember, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.TenantMember.WithContext(ctx).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID)).
+		Where(r.q.TenantMember.UserID.In(userIDs...)).
+		Find()
+}
+
+// CountByDepartments 按部门统计当前租户的成员数，返回 部门ID -> 成员数
+func (r *TenantMemberRepo) CountByDepartments(ctx context.Context) (map[string]int64, error) {
+	m := r.q.TenantMember
+	var rows []struct {
+		DepartmentID string
+		Count        int64
+	}
+	err := m.WithContext(ctx).
+		Select(m.DepartmentID, m.UserID.Count().As("count")).
+		Where(m.TenantID.Eq(xcontext.GetTenantID(ctx)), m.DepartmentID.Neq("")).
+		Group(m.DepartmentID).
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.DepartmentID] = row.Count
+	}
+	return counts, nil
+}
+
+// CountByPositions 统计当前租户中岗位为指定岗位之一的成员数
+func (r *TenantMemberRepo) CountByPositions(ctx context.Context, positionIDs []string) (int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.TenantMember.WithContext(ctx).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID)).
+		Where(r.q.TenantMember.PositionID.In(positionIDs...)).
+		Count()
+}
+
+// Update 更新用户在当前租户的成员身份
+func (r *TenantMemberRepo) Update(ctx context.Context, userID string, updates map[string]interface{}) error {
+	tenantID := xcontext.GetTenantID(ctx)
+	_, err := r.q.TenantMember.WithContext(ctx).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID)).
+		Where(r.q.TenantMember.UserID.Eq(userID)).
+		Updates(updates)
+	return err
+}
+
+// Delete 将用户移出当前租户
+func (r *TenantMemberRepo) Delete(ctx context.Context, userID string) error {
+	tenantID := xcontext.GetTenantID(ctx)
+	_, err := r.q.TenantMember.WithContext(ctx).
+		Where(r.q.TenantMember.TenantID.Eq(tenantID)).
+		Where(r.q.TenantMember.UserID.Eq(userID)).
+		Delete()
+	return err
+}
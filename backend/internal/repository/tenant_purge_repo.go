@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/constants"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// purgeTable 彻底清除时删除的表及其租户条件
+type purgeTable struct {
+	name  string
+	key   string // 分批删除使用的主键列
+	where string // 租户条件，每个占位符的参数均为租户ID
+}
+
+// tenantMemberWhere 租户自身的数据，以及该租户用户加入其他租户后留下的数据
+// 用户删除后这些数据没有外键约束兜底，必须在删除 users 之前清除
+const tenantMemberWhere = "tenant_id = ? OR user_id IN (SELECT user_id FROM users WHERE tenant_id = ?)"
+
+// tenantPurgeTables 按依赖顺序排列的租户数据表，关联表先于主表删除，租户本身最后删除
+var tenantPurgeTables = []purgeTable{
+	{name: "user_positions", key: "ctid", where: "user_id IN (SELECT user_id FROM users WHERE tenant_id = ?)"},
+	{name: "department_leaders", key: "ctid", where: tenantMemberWhere},
+	{name: "user_roles", key: "id", where: tenantMemberWhere},
+	{name: "tenant_members", key: "id", where: tenantMemberWhere},
+	{name: "role_permissions", key: "id", where: "tenant_id = ?"},
+	{name: "access_tokens", key: "token_id", where: tenantMemberWhere},
+	{name: "service_account_credentials", key: "user_id", where: "tenant_id = ?"},
+	{name: "users", key: "user_id", where: "tenant_id = ?"},
+	{name: "roles", key: "role_id", where: "tenant_id = ?"},
+	{name: "departments", key: "department_id", where: "tenant_id = ?"},
+	{name: "positions", key: "position_id", where: "tenant_id = ?"},
+	{name: "dict_items", key: "item_id", where: "tenant_id = ?"},
+	{name: "dict_types", key: "type_id", where: "tenant_id = ?"},
+	{name: "tenant_quotas", key: "ctid", where: "tenant_id = ?"},
+	{name: "tenant_domains", key: "domain_id", where: "tenant_id = ?"},
+	{name: "tenant_settings", key: "setting_id", where: "tenant_id = ?"},
+	{name: "invitations", key: "invitation_id", where: "tenant_id = ?"},
+	{name: "export_jobs", key: "export_id", where: "tenant_id = ?"},
+	{name: "tasks", key: "task_id", where: "tenant_id = ?"},
+	{name: "login_logs", key: "log_id", where: "tenant_id = ?"},
+	{name: "operation_logs", key: "log_id", where: "tenant_id = ?"},
+	{name: "tenants", key: "tenant_id", where: "tenant_id = ?"},
+}
+
+// TenantPurgeRepo 租户彻底清除仓储
+//
+//tenantscope:allow 清除记录为平台级数据，由超级管理员和定时任务跨租户处理
+type TenantPurgeRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewTenantPurgeRepo 创建租户彻底清除仓储
+func NewTenantPurgeRepo(db *gorm.DB) *TenantPurgeRepo {
+	return &TenantPurgeRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建清除记录
+func (r *TenantPurgeRepo) Create(ctx context.Context, purge *model.TenantPurge) error {
+	return r.q.TenantPurge.WithContext(ctx).Create(purge)
+}
+
+// GetByIDManual 根据ID获取清除记录（跨租户）
+func (r *TenantPurgeRepo) GetByIDManual(ctx context.Context, purgeID string) (*model.TenantPurge, error) {
+	return r.q.TenantPurge.WithContext(ctx).
+		Where(r.q.TenantPurge.PurgeID.Eq(purgeID)).
+		First()
+}
+
+// GetActiveByTenantManual 获取租户未结束的清除记录（跨租户）
+func (r *TenantPurgeRepo) GetActiveByTenantManual(ctx context.Context, tenantID string) (*model.TenantPurge, error) {
+	return r.q.TenantPurge.WithContext(ctx).
+		Where(r.q.TenantPurge.TenantID.Eq(tenantID)).
+		Where(r.q.TenantPurge.Status.In(constants.TenantPurgeScheduled, constants.TenantPurgeRunning, constants.TenantPurgeFailed)).
+		First()
+}
+
+// ListDueManual 获取保留期已结束待清除的记录，以及失败待重试的记录（跨租户）
+func (r *TenantPurgeRepo) ListDueManual(ctx context.Context, now int64) ([]*model.TenantPurge, error) {
+	p := r.q.TenantPurge
+	return p.WithContext(ctx).
+		Where(p.PurgeAfter.Lte(now)).
+		Where(p.Status.In(constants.TenantPurgeScheduled, constants.TenantPurgeFailed)).
+		Order(p.PurgeAfter).
+		Find()
+}
+
+// ResetStaleManual 将长时间没有进度更新的执行中记录置为失败（跨租户），用于进程中断后重试
+func (r *TenantPurgeRepo) ResetStaleManual(ctx context.Context, before int64) (int64, error) {
+	p := r.q.TenantPurge
+	info, err := p.WithContext(ctx).
+		Where(p.Status.Eq(constants.TenantPurgeRunning)).
+		Where(p.UpdatedAt.Lt(before)).
+		Updates(map[string]interface{}{
+			"status":        constants.TenantPurgeFailed,
+			"error_message": "清除任务中断",
+			"updated_at":    time.Now().UnixMilli(),
+		})
+	return info.RowsAffected, err
+}
+
+// ListWithFilters 分页获取清除记录
+func (r *TenantPurgeRepo) ListWithFilters(ctx context.Context, offset, limit int, tenantCode, status string) ([]*model.TenantPurge, int64, error) {
+	p := r.q.TenantPurge
+	q := p.WithContext(ctx)
+	if tenantCode != "" {
+		q = q.Where(p.TenantCode.Like("%" + tenantCode + "%"))
+	}
+	if status != "" {
+		q = q.Where(p.Status.Eq(status))
+	}
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+	list, err := q.Order(p.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return list, total, err
+}
+
+// TransitionManual 按条件更新清除状态（跨租户），返回是否更新成功
+// 仅当当前状态为 from 之一时更新，用于抢占执行和取消，避免与定时任务并发冲突
+func (r *TenantPurgeRepo) TransitionManual(ctx context.Context, purgeID string, from []string, updates map[string]interface{}) (bool, error) {
+	updates["updated_at"] = time.Now().UnixMilli()
+	info, err := r.q.TenantPurge.WithContext(ctx).
+		Where(r.q.TenantPurge.PurgeID.Eq(purgeID)).
+		Where(r.q.TenantPurge.Status.In(from...)).
+		Updates(updates)
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// UpdateProgressManual 更新清除进度（跨租户）
+func (r *TenantPurgeRepo) UpdateProgressManual(ctx context.Context, purgeID string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UnixMilli()
+	_, err := r.q.TenantPurge.WithContext(ctx).
+		Where(r.q.TenantPurge.PurgeID.Eq(purgeID)).
+		Updates(updates)
+	return err
+}
+
+// ListUnscheduledDeletedTenantsManual 获取已软删除但没有清除计划的租户（如功能上线前删除的租户）
+func (r *TenantPurgeRepo) ListUnscheduledDeletedTenantsManual(ctx context.Context) ([]*model.Tenant, error) {
+	var tenants []*model.Tenant
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at > 0").
+		Where("NOT EXISTS (SELECT 1 FROM tenant_purges p WHERE p.tenant_id = tenants.tenant_id AND p.status IN ?)",
+			[]string{constants.TenantPurgeScheduled, constants.TenantPurgeRunning, constants.TenantPurgeFailed}).
+		Find(&tenants).Error
+	return tenants, err
+}
+
+// PurgeTables 返回彻底清除的表名，按删除顺序排列
+func (r *TenantPurgeRepo) PurgeTables() []string {
+	names := make([]string, len(tenantPurgeTables))
+	for i, t := range tenantPurgeTables {
+		names[i] = t.name
+	}
+	return names
+}
+
+// PurgeBatchManual 物理删除租户在指定表中的一批数据（包括已软删除的行），返回删除行数
+func (r *TenantPurgeRepo) PurgeBatchManual(ctx context.Context, table, tenantID string, limit int) (int64, error) {
+	for _, t := range tenantPurgeTables {
+		if t.name != table {
+			continue
+		}
+		sql := fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT %s FROM %s WHERE %s LIMIT ?)",
+			t.name, t.key, t.key, t.name, t.where)
+		args := make([]interface{}, 0, 2)
+		for range strings.Count(t.where, "?") {
+			args = append(args, tenantID)
+		}
+		result := r.db.WithContext(ctx).Exec(sql, append(args, limit)...)
+		return result.RowsAffected, result.Error
+	}
+	return 0, fmt.Errorf("unknown purge table %q", table)
+}
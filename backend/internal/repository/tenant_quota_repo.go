@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/database"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TenantQuotaRepo 租户配额覆盖仓储
+//
+//tenantscope:allow 配额由超级管理员按指定租户维护
+type TenantQuotaRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewTenantQuotaRepo 创建租户配额覆盖仓储
+func NewTenantQuotaRepo(db *gorm.DB) *TenantQuotaRepo {
+	return &TenantQuotaRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// ListByTenantManual 获取租户单独设置的配额（跨租户）
+func (r *TenantQuotaRepo) ListByTenantManual(ctx context.Context, tenantID string) ([]*model.TenantQuota, error) {
+	return r.q.TenantQuota.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantQuota.TenantID.Eq(tenantID)).
+		Find()
+}
+
+// GetManual 获取租户单独设置的某项配额（跨租户）
+func (r *TenantQuotaRepo) GetManual(ctx context.Context, tenantID, resource string) (*model.TenantQuota, error) {
+	return r.q.TenantQuota.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantQuota.TenantID.Eq(tenantID)).
+		Where(r.q.TenantQuota.Resource.Eq(resource)).
+		First()
+}
+
+// Upsert 设置租户配额（存在则更新）
+func (r *TenantQuotaRepo) Upsert(ctx context.Context, quota *model.TenantQuota) error {
+	return r.q.TenantQuota.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "resource"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quota_limit", "updated_at"}),
+		}).
+		Create(quota)
+}
+
+// Delete 删除租户单独设置的配额（恢复使用套餐配额）
+func (r *TenantQuotaRepo) Delete(ctx context.Context, tenantID, resource string) error {
+	_, err := r.q.TenantQuota.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantQuota.TenantID.Eq(tenantID)).
+		Where(r.q.TenantQuota.Resource.Eq(resource)).
+		Delete()
+	return err
+}
@@ -5,10 +5,13 @@ import (
 	"admin/internal/dal/query"
 	"admin/pkg/constants"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+//
+//tenantscope:allow 租户为平台级数据，超级管理员和登录前的租户识别需访问所有租户
 type TenantRepo struct {
 	db *gorm.DB
 	q  *query.Query
@@ -144,6 +147,24 @@ func (r *TenantRepo) GetByIDManual(ctx context.Context, tenantID string) (*model
 		First()
 }
 
+// GetDeletedByIDManual 根据租户ID获取已软删除的租户
+// 使用场景：恢复已删除的租户
+func (r *TenantRepo) GetDeletedByIDManual(ctx context.Context, tenantID string) (*model.Tenant, error) {
+	return r.q.Tenant.WithContext(ctx).Unscoped().
+		Where(r.q.Tenant.TenantID.Eq(tenantID)).
+		Where(r.q.Tenant.DeletedAt.Gt(0)).
+		First()
+}
+
+// RestoreManual 恢复已软删除的租户
+func (r *TenantRepo) RestoreManual(ctx context.Context, tenantID string) error {
+	_, err := r.q.Tenant.WithContext(ctx).Unscoped().
+		Where(r.q.Tenant.TenantID.Eq(tenantID)).
+		Where(r.q.Tenant.DeletedAt.Gt(0)).
+		UpdateSimple(r.q.Tenant.DeletedAt.Value(0), r.q.Tenant.UpdatedAt.Value(time.Now().UnixMilli()))
+	return err
+}
+
 // GetByCodes 根据租户编码列表批量获取租户信息
 func (r *TenantRepo) GetByCodes(ctx context.Context, tenantCodes []string) ([]*model.Tenant, error) {
 	return r.q.Tenant.WithContext(ctx).Where(r.q.Tenant.TenantCode.In(tenantCodes...)).Find()
@@ -171,3 +192,61 @@ func (r *TenantRepo) FindTenantIDsByName(ctx context.Context, name string) ([]st
 	}
 	return tenantIDs, nil
 }
+
+// ListByPlanManual 获取绑定指定套餐的租户列表
+func (r *TenantRepo) ListByPlanManual(ctx context.Context, planID string) ([]*model.Tenant, error) {
+	return r.q.Tenant.WithContext(ctx).
+		Where(r.q.Tenant.PlanID.Eq(planID)).
+		Find()
+}
+
+// CountByPlanManual 统计绑定指定套餐的租户数量
+func (r *TenantRepo) CountByPlanManual(ctx context.Context, planID string) (int64, error) {
+	return r.q.Tenant.WithContext(ctx).
+		Where(r.q.Tenant.PlanID.Eq(planID)).
+		Count()
+}
+
+// AddStorageUsedManual 增减租户已用存储空间（字节）
+// maxBytes 大于 0 时只在增加后不超过上限的情况下更新，返回是否更新成功
+func (r *TenantRepo) AddStorageUsedManual(ctx context.Context, tenantID string, delta, maxBytes int64) (bool, error) {
+	q := r.q.Tenant.WithContext(ctx).Where(r.q.Tenant.TenantID.Eq(tenantID))
+	if delta > 0 && maxBytes > 0 {
+		q = q.Where(r.q.Tenant.StorageUsed.Lte(maxBytes - delta))
+	}
+	info, err := q.UpdateSimple(r.q.Tenant.StorageUsed.Add(delta))
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// ListExpiringManual 获取指定生命周期状态下到期时间不晚于 before 的租户（跨租户，定时任务使用）
+// 未设置到期时间（expires_at = 0）的租户永不过期，不会被返回
+func (r *TenantRepo) ListExpiringManual(ctx context.Context, statuses []string, before int64) ([]*model.Tenant, error) {
+	return r.q.Tenant.WithContext(ctx).
+		Where(r.q.Tenant.LifecycleStatus.In(statuses...)).
+		Where(r.q.Tenant.ExpiresAt.Gt(0)).
+		Where(r.q.Tenant.ExpiresAt.Lte(before)).
+		Find()
+}
+
+// UpdateLifecycleManual 按原状态条件更新租户生命周期状态（跨租户）
+// 状态已被其他操作修改时不更新，返回是否更新成功
+func (r *TenantRepo) UpdateLifecycleManual(ctx context.Context, tenantID, from, to string) (bool, error) {
+	info, err := r.q.Tenant.WithContext(ctx).
+		Where(r.q.Tenant.TenantID.Eq(tenantID), r.q.Tenant.LifecycleStatus.Eq(from)).
+		UpdateSimple(r.q.Tenant.LifecycleStatus.Value(to))
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// MarkExpiryRemindedManual 记录租户到期提醒发送时间（跨租户）
+func (r *TenantRepo) MarkExpiryRemindedManual(ctx context.Context, tenantID string, remindedAt int64) error {
+	_, err := r.q.Tenant.WithContext(ctx).
+		Where(r.q.Tenant.TenantID.Eq(tenantID)).
+		UpdateSimple(r.q.Tenant.ExpiryRemindedAt.Value(remindedAt))
+	return err
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/database"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TenantSettingRepo 租户设置仓储
+// default 租户的记录为平台默认值，读取时需按参数指定租户
+type TenantSettingRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewTenantSettingRepo 创建租户设置仓储
+func NewTenantSettingRepo(db *gorm.DB) *TenantSettingRepo {
+	return &TenantSettingRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// Create 创建设置记录
+//
+//tenantscope:allow 租户ID由设置服务取自当前租户
+func (r *TenantSettingRepo) Create(ctx context.Context, setting *model.TenantSetting) error {
+	return r.q.TenantSetting.WithContext(database.WithTenant(ctx, setting.TenantID)).Create(setting)
+}
+
+// ListByTenant 获取租户的全部设置记录
+//
+//tenantscope:allow 租户ID由设置服务取自当前租户
+func (r *TenantSettingRepo) ListByTenant(ctx context.Context, tenantID string) ([]*model.TenantSetting, error) {
+	return r.q.TenantSetting.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantSetting.TenantID.Eq(tenantID)).
+		Find()
+}
+
+// GetByKey 获取租户的某项设置记录
+//
+//tenantscope:allow 租户ID由设置服务取自当前租户
+func (r *TenantSettingRepo) GetByKey(ctx context.Context, tenantID, key string) (*model.TenantSetting, error) {
+	return r.q.TenantSetting.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantSetting.TenantID.Eq(tenantID)).
+		Where(r.q.TenantSetting.SettingKey.Eq(key)).
+		First()
+}
+
+// UpdateValue 按版本号更新设置值并递增版本，版本不一致时不更新，返回影响行数
+//
+//tenantscope:allow 租户ID由设置服务取自当前租户
+func (r *TenantSettingRepo) UpdateValue(ctx context.Context, tenantID, settingID string, version int32, value, updatedBy string) (int64, error) {
+	s := r.q.TenantSetting
+	info, err := s.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(s.TenantID.Eq(tenantID)).
+		Where(s.SettingID.Eq(settingID)).
+		Where(s.Version.Eq(version)).
+		UpdateSimple(
+			s.SettingValue.Value(value),
+			s.Version.Add(1),
+			s.UpdatedBy.Value(updatedBy),
+			s.UpdatedAt.Value(time.Now().UnixMilli()),
+		)
+	return info.RowsAffected, err
+}
+
+// DeleteByKey 删除租户的某项设置记录（软删除），恢复使用上一级默认值
+//
+//tenantscope:allow 租户ID由设置服务取自当前租户
+func (r *TenantSettingRepo) DeleteByKey(ctx context.Context, tenantID, key string) error {
+	_, err := r.q.TenantSetting.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.TenantSetting.TenantID.Eq(tenantID)).
+		Where(r.q.TenantSetting.SettingKey.Eq(key)).
+		Delete()
+	return err
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dal/query"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// UserPositionRepo 用户多岗关联仓储
+// user_positions 没有 tenant_id，查询时按当前租户的用户过滤；主岗位与 users.position_id 保持一致
+type UserPositionRepo struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+// NewUserPositionRepo 创建用户多岗关联仓储
+func NewUserPositionRepo(db *gorm.DB) *UserPositionRepo {
+	return &UserPositionRepo{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+// tenantUsers 当前租户未删除用户的ID子查询
+func (r *UserPositionRepo) tenantUsers(ctx context.Context) query.IUserDo {
+	u := r.q.User
+	return u.WithContext(ctx).Select(u.UserID).Where(u.TenantID.Eq(xcontext.GetTenantID(ctx)))
+}
+
+// ListByUser 获取当前租户用户的岗位关联，主岗位在前
+func (r *UserPositionRepo) ListByUser(ctx context.Context, userID string) ([]*model.UserPosition, error) {
+	up := r.q.UserPosition
+	return up.WithContext(ctx).
+		Where(up.UserID.Eq(userID)).
+		Where(up.Columns(up.UserID).In(r.tenantUsers(ctx))).
+		Order(up.IsPrimary, up.PositionID).
+		Find()
+}
+
+// ReplaceByUser 覆盖式设置用户的岗位，primaryPositionID 为主岗位（须包含在 positionIDs 中，positionIDs 为空时清空）
+// 调用方需在事务中同时更新 users.position_id
+func (r *UserPositionRepo) ReplaceByUser(ctx context.Context, userID string, positionIDs []string, primaryPositionID string) error {
+	up := r.q.UserPosition
+	if _, err := up.WithContext(ctx).Where(up.UserID.Eq(userID)).Delete(); err != nil {
+		return err
+	}
+	if len(positionIDs) == 0 {
+		return nil
+	}
+
+	list := make([]*model.UserPosition, len(positionIDs))
+	for i, positionID := range positionIDs {
+		isPrimary := int16(constants.False)
+		if positionID == primaryPositionID {
+			isPrimary = int16(constants.True)
+		}
+		list[i] = &model.UserPosition{UserID: userID, PositionID: positionID, IsPrimary: isPrimary}
+	}
+	return up.WithContext(ctx).Create(list...)
+}
+
+// CreateInBatches 批量写入岗位关联（用于新建用户时写入主岗位）
+func (r *UserPositionRepo) CreateInBatches(ctx context.Context, list []*model.UserPosition, batchSize int) error {
+	return r.q.UserPosition.WithContext(ctx).CreateInBatches(list, batchSize)
+}
+
+// ListUserIDsByPosition 分页获取担任指定岗位的当前租户用户ID（主岗在前），返回总数
+func (r *UserPositionRepo) ListUserIDsByPosition(ctx context.Context, positionID string, offset, limit int) ([]string, int64, error) {
+	up := r.q.UserPosition
+	query := up.WithContext(ctx).
+		Where(up.PositionID.Eq(positionID)).
+		Where(up.Columns(up.UserID).In(r.tenantUsers(ctx)))
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var userIDs []string
+	err = query.Order(up.IsPrimary, up.UserID).Offset(offset).Limit(limit).Pluck(up.UserID, &userIDs)
+	return userIDs, total, err
+}
+
+// CountByPositions 统计当前租户中担任指定岗位的用户数，返回 岗位ID -> 用户数（无用户的岗位不在结果中）
+func (r *UserPositionRepo) CountByPositions(ctx context.Context, positionIDs []string) (map[string]int64, error) {
+	up := r.q.UserPosition
+	var rows []struct {
+		PositionID string
+		Count      int64
+	}
+	err := up.WithContext(ctx).
+		Select(up.PositionID, up.UserID.Count().As("count")).
+		Where(up.PositionID.In(positionIDs...)).
+		Where(up.Columns(up.UserID).In(r.tenantUsers(ctx))).
+		Group(up.PositionID).
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.PositionID] = row.Count
+	}
+	return counts, nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/constants"
+	"admin/pkg/database"
 	"admin/pkg/xcontext"
 	"context"
 
@@ -27,6 +29,24 @@ func (r *UserRepo) Create(ctx context.Context, user *model.User) error {
 	return r.q.User.WithContext(ctx).Create(user)
 }
 
+// CreateInBatches 分批创建当前租户的用户
+func (r *UserRepo) CreateInBatches(ctx context.Context, users []*model.User, batchSize int) error {
+	tenantID := xcontext.GetTenantID(ctx)
+	for _, user := range users {
+		user.TenantID = tenantID
+	}
+	return r.q.User.WithContext(ctx).CreateInBatches(users, batchSize)
+}
+
+// ListByUserNames 获取当前租户中使用指定用户名的用户
+func (r *UserRepo) ListByUserNames(ctx context.Context, userNames []string) ([]*model.User, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	return r.q.User.WithContext(ctx).
+		Where(r.q.User.TenantID.Eq(tenantID)).
+		Where(r.q.User.UserName.In(userNames...)).
+		Find()
+}
+
 // GetByID 根据ID获取用户
 func (r *UserRepo) GetByID(ctx context.Context, userID string) (*model.User, error) {
 	tenantID := xcontext.GetTenantID(ctx)
@@ -36,24 +56,80 @@ func (r *UserRepo) GetByID(ctx context.Context, userID string) (*model.User, err
 		First()
 }
 
+// GetByIDManual 根据ID获取用户（跨租户，用于访问令牌认证等场景）
+//
+//tenantscope:allow 访问令牌认证、模拟登录、切换租户和跨租户成员需按ID读取任意租户的用户（主租户账号）
+func (r *UserRepo) GetByIDManual(ctx context.Context, userID string) (*model.User, error) {
+	return r.q.User.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.User.UserID.Eq(userID)).
+		First()
+}
+
 // GetByTenantAndUserName 根据租户ID和用户名获取用户（用于登录，跨租户查询）
+//
+//tenantscope:allow 租户ID为当前租户，或经 TargetTenantID 校验的超级管理员指定租户
 func (r *UserRepo) GetByTenantAndUserName(ctx context.Context, tenantID, userName string) (*model.User, error) {
-	return r.q.User.WithContext(ctx).
+	return r.q.User.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.User.TenantID.Eq(tenantID)).
 		Where(r.q.User.UserName.Eq(userName)).
 		First()
 }
 
-// GetByEmail 根据邮箱获取用户（用于登录，跨租户查询）
-func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*model.User, error) {
-	return r.q.User.WithContext(ctx).
+// GetByTenantAndEmailManual 根据租户和邮箱获取用户（用于识别租户后的登录）
+//
+//tenantscope:allow 识别租户后的登录按请求中的租户查找，此时 context 中尚无认证租户
+func (r *UserRepo) GetByTenantAndEmailManual(ctx context.Context, tenantID, email string) (*model.User, error) {
+	return r.q.User.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.User.TenantID.Eq(tenantID)).
 		Where(r.q.User.Email.Eq(email)).
 		First()
 }
 
+// ListByEmailManual 获取使用该邮箱的所有用户（跨租户，开启共享邮箱的租户间可能存在多个）
+//
+//tenantscope:allow 邮箱登录和邮箱唯一性校验需列出所有租户中使用该邮箱的用户
+func (r *UserRepo) ListByEmailManual(ctx context.Context, email string) ([]*model.User, error) {
+	return r.q.User.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.User.Email.Eq(email)).
+		Find()
+}
+
+// ListByEmailsManual 批量获取使用指定邮箱的用户（跨租户）
+//
+//tenantscope:allow 导入租户数据包前检查邮箱与现有租户的用户冲突
+func (r *UserRepo) ListByEmailsManual(ctx context.Context, emails []string) ([]*model.User, error) {
+	return r.q.User.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.User.Email.In(emails...)).
+		Find()
+}
+
+// ListByPhonesManual 批量获取使用指定手机号的用户（跨租户）
+//
+//tenantscope:allow 导入租户数据包前检查手机号与现有租户的用户冲突
+func (r *UserRepo) ListByPhonesManual(ctx context.Context, phones []string) ([]*model.User, error) {
+	return r.q.User.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.User.Phone.In(phones...)).
+		Find()
+}
+
+// CountSharedEmailsManual 统计租户内与其他租户用户邮箱重复的用户数（跨租户）
+//
+//tenantscope:allow 关闭共享邮箱前统计该租户与其他租户重复的邮箱，跨租户部分由原生子查询完成
+func (r *UserRepo) CountSharedEmailsManual(ctx context.Context, tenantID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(database.WithTenant(ctx, tenantID)).
+		Model(&model.User{}).
+		Where("tenant_id = ? AND email <> ''", tenantID).
+		Where("EXISTS (SELECT 1 FROM users o WHERE o.email = users.email AND o.tenant_id <> users.tenant_id AND o.deleted_at = 0)").
+		Count(&count).Error
+	return count, err
+}
+
 // GetByPhone 根据手机号获取用户（用于手机号登录，跨租户查询）
+//
+//tenantscope:allow 手机号登录需在所有租户中查找用户
 func (r *UserRepo) GetByPhone(ctx context.Context, phone string) (*model.User, error) {
-	return r.q.User.WithContext(ctx).
+	return r.q.User.WithContext(database.SkipTenant(ctx)).
 		Where(r.q.User.Phone.Eq(phone)).
 		First()
 }
@@ -69,8 +145,10 @@ func (r *UserRepo) Update(ctx context.Context, userID string, updates map[string
 }
 
 // UpdateManual 更新用户（跨租户，用于登录等场景）
+//
+//tenantscope:allow 登录成功后更新最后登录信息，此时 context 中尚无认证租户
 func (r *UserRepo) UpdateManual(ctx context.Context, userID string, updates map[string]interface{}) error {
-	_, err := r.q.User.WithContext(ctx).Where(r.q.User.UserID.Eq(userID)).Updates(updates)
+	_, err := r.q.User.WithContext(database.SkipTenant(ctx)).Where(r.q.User.UserID.Eq(userID)).Updates(updates)
 	return err
 }
 
@@ -110,30 +188,72 @@ func (r *UserRepo) ListWithFilters(ctx context.Context, offset, limit int, nickn
 // ListWithFiltersAndTenant 根据筛选条件和租户ID分页获取用户列表
 // tenantID 为空时使用当前上下文租户，非空时跨租户查询
 func (r *UserRepo) ListWithFiltersAndTenant(ctx context.Context, offset, limit int, nicknameFilter string, statusFilter int, tenantID string) ([]*model.User, int64, error) {
-	query := r.q.User.WithContext(ctx)
+	query := r.filterQuery(ctx, r.q, nicknameFilter, statusFilter, tenantID)
+
+	// 获取总数
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
 
+	// 分页查询
+	users, err := query.Order(r.q.User.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
+	return users, total, err
+}
+
+// CountWithFiltersAndTenant 按与 ListWithFiltersAndTenant 相同的筛选条件统计用户数
+func (r *UserRepo) CountWithFiltersAndTenant(ctx context.Context, nicknameFilter string, statusFilter int, tenantID string) (int64, error) {
+	return r.filterQuery(ctx, r.q, nicknameFilter, statusFilter, tenantID).Count()
+}
+
+// StreamWithFiltersAndTenant 按与 ListWithFiltersAndTenant 相同的筛选条件和排序，以游标方式分批读取用户（用于导出）
+func (r *UserRepo) StreamWithFiltersAndTenant(ctx context.Context, nicknameFilter string, statusFilter int, tenantID string, batchSize int, fn func([]*model.User) error) error {
+	return database.InTransactionWithCtx(ctx, r.db, func(ctx context.Context, tx *database.Tx) error {
+		query := r.filterQuery(ctx, tx.Q, nicknameFilter, statusFilter, tenantID).Order(tx.Q.User.CreatedAt.Desc())
+		return database.ScanInBatches(query.UnderlyingDB(), batchSize, fn)
+	})
+}
+
+// filterQuery 构造用户列表的筛选条件，tenantID 非空时跨租户查询
+//
+//tenantscope:allow 租户ID为当前租户，或经 TargetTenantID 校验的超级管理员指定租户
+func (r *UserRepo) filterQuery(ctx context.Context, q *query.Query, nicknameFilter string, statusFilter int, tenantID string) query.IUserDo {
 	// 如果指定了租户ID，跨租户查询
 	if tenantID != "" {
-		query = query.Where(r.q.User.TenantID.Eq(tenantID))
+		ctx = database.WithTenant(ctx, tenantID)
 	} else {
-		query = query.Where(r.q.User.TenantID.Eq(xcontext.GetTenantID(ctx)))
+		tenantID = xcontext.GetTenantID(ctx)
 	}
+	do := q.User.WithContext(ctx).Where(q.User.TenantID.Eq(tenantID))
 
 	// 应用筛选条件
 	if nicknameFilter != "" {
-		query = query.Where(r.q.User.Nickname.Like("%" + nicknameFilter + "%"))
+		do = do.Where(q.User.Nickname.Like("%" + nicknameFilter + "%"))
 	}
 	if statusFilter != 0 {
-		query = query.Where(r.q.User.Status.Eq(int16(statusFilter)))
+		do = do.Where(q.User.Status.Eq(int16(statusFilter)))
+	}
+	return do
+}
+
+// ListServiceAccounts 分页获取当前租户的服务账号
+func (r *UserRepo) ListServiceAccounts(ctx context.Context, offset, limit int, keyword string) ([]*model.User, int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	query := r.q.User.WithContext(ctx).
+		Where(r.q.User.TenantID.Eq(tenantID)).
+		Where(r.q.User.IsServiceAccount.Eq(int16(constants.True)))
+
+	if keyword != "" {
+		query = query.Where(r.q.User.WithContext(ctx).
+			Where(r.q.User.UserName.Like("%" + keyword + "%")).
+			Or(r.q.User.Nickname.Like("%" + keyword + "%")))
 	}
 
-	// 获取总数
 	total, err := query.Count()
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// 分页查询
 	users, err := query.Order(r.q.User.CreatedAt.Desc()).Offset(offset).Limit(limit).Find()
 	return users, total, err
 }
@@ -149,8 +269,10 @@ func (r *UserRepo) UpdateStatus(ctx context.Context, userID string, status int)
 }
 
 // CheckExists 检查用户是否存在
+//
+//tenantscope:allow 租户ID为当前租户，或经 TargetTenantID 校验的超级管理员指定租户
 func (r *UserRepo) CheckExists(ctx context.Context, tenantID, userName string) (bool, error) {
-	count, err := r.q.User.WithContext(ctx).
+	count, err := r.q.User.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.User.TenantID.Eq(tenantID)).
 		Where(r.q.User.UserName.Eq(userName)).
 		Count()
@@ -196,13 +318,49 @@ func (r *UserRepo) CountByDept(ctx context.Context, departmentID string) (int64,
 		Count()
 }
 
+// CountByDepartments 按部门统计当前租户的用户数，返回 部门ID -> 用户数
+func (r *UserRepo) CountByDepartments(ctx context.Context) (map[string]int64, error) {
+	u := r.q.User
+	var rows []struct {
+		DepartmentID string
+		Count        int64
+	}
+	err := u.WithContext(ctx).
+		Select(u.DepartmentID, u.UserID.Count().As("count")).
+		Where(u.TenantID.Eq(xcontext.GetTenantID(ctx)), u.DepartmentID.Neq("")).
+		Group(u.DepartmentID).
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.DepartmentID] = row.Count
+	}
+	return counts, nil
+}
+
 // CountByTenantID 统计租户下的用户数（跨租户查询）
+//
+//tenantscope:allow 租户ID为当前租户，或经 TargetTenantID 校验的超级管理员指定租户
 func (r *UserRepo) CountByTenantID(ctx context.Context, tenantID string) (int64, error) {
-	return r.q.User.WithContext(ctx).
+	return r.q.User.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.User.TenantID.Eq(tenantID)).
 		Count()
 }
 
+// ListIDsByTenantManual 获取租户下全部用户ID（跨租户查询）
+//
+//tenantscope:allow 删除租户时吊销该租户全部用户的会话
+func (r *UserRepo) ListIDsByTenantManual(ctx context.Context, tenantID string) ([]string, error) {
+	var userIDs []string
+	err := r.q.User.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.User.TenantID.Eq(tenantID)).
+		Pluck(r.q.User.UserID, &userIDs)
+	return userIDs, err
+}
+
 // CountByTenantIDs 批量统计多个租户下的用户数（跨租户查询）
 // 返回 map[tenantID]userCount
 func (r *UserRepo) CountByTenantIDs(ctx context.Context, tenantIDs []string) (map[string]int64, error) {
@@ -267,6 +425,15 @@ func (r *UserRepo) GetByIDs(ctx context.Context, userIDs []string) ([]*model.Use
 		Find()
 }
 
+// ListByIDsManual 根据用户ID列表获取用户信息（跨租户）
+//
+//tenantscope:allow 从其他租户加入的成员，账号信息记录在其主租户
+func (r *UserRepo) ListByIDsManual(ctx context.Context, userIDs []string) ([]*model.User, error) {
+	return r.q.User.WithContext(database.SkipTenant(ctx)).
+		Where(r.q.User.UserID.In(userIDs...)).
+		Find()
+}
+
 // FindUserIDsByName 根据昵称模糊匹配获取用户ID列表（支持同名用户）
 func (r *UserRepo) FindUserIDsByName(ctx context.Context, nickname string) ([]string, error) {
 	tenantID := xcontext.GetTenantID(ctx)
@@ -3,6 +3,7 @@ package repository
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dal/query"
+	"admin/pkg/database"
 	"context"
 
 	"gorm.io/gorm"
@@ -23,8 +24,10 @@ func NewUserRoleRepo(db *gorm.DB) *UserRoleRepo {
 }
 
 // GetUserRoleIDs 获取用户在指定租户下的角色ID列表
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) GetUserRoleIDs(ctx context.Context, userID, tenantID string) ([]string, error) {
-	userRoles, err := r.q.UserRole.WithContext(ctx).
+	userRoles, err := r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.UserRole.UserID.Eq(userID)).
 		Where(r.q.UserRole.TenantID.Eq(tenantID)).
 		Find()
@@ -39,19 +42,33 @@ func (r *UserRoleRepo) GetUserRoleIDs(ctx context.Context, userID, tenantID stri
 	return roleIDs, nil
 }
 
+// ListByUserIDs 批量获取多个用户在租户中的角色关联
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
+func (r *UserRoleRepo) ListByUserIDs(ctx context.Context, userIDs []string, tenantID string) ([]*model.UserRole, error) {
+	return r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
+		Where(r.q.UserRole.UserID.In(userIDs...)).
+		Where(r.q.UserRole.TenantID.Eq(tenantID)).
+		Find()
+}
+
 // AddUserRole 为用户添加角色
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) AddUserRole(ctx context.Context, userID, roleID, tenantID string) error {
 	userRole := &model.UserRole{
 		UserID:   userID,
 		RoleID:   roleID,
 		TenantID: tenantID,
 	}
-	return r.q.UserRole.WithContext(ctx).Create(userRole)
+	return r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).Create(userRole)
 }
 
 // DeleteUserRole 删除用户的指定角色
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) DeleteUserRole(ctx context.Context, userID, roleID, tenantID string) error {
-	_, err := r.q.UserRole.WithContext(ctx).
+	_, err := r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.UserRole.UserID.Eq(userID)).
 		Where(r.q.UserRole.RoleID.Eq(roleID)).
 		Where(r.q.UserRole.TenantID.Eq(tenantID)).
@@ -60,8 +77,10 @@ func (r *UserRoleRepo) DeleteUserRole(ctx context.Context, userID, roleID, tenan
 }
 
 // DeleteUserRoles 删除用户在指定租户下的所有角色
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) DeleteUserRoles(ctx context.Context, userID, tenantID string) error {
-	_, err := r.q.UserRole.WithContext(ctx).
+	_, err := r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.UserRole.UserID.Eq(userID)).
 		Where(r.q.UserRole.TenantID.Eq(tenantID)).
 		Delete()
@@ -69,8 +88,10 @@ func (r *UserRoleRepo) DeleteUserRoles(ctx context.Context, userID, tenantID str
 }
 
 // CheckUserRole 检查用户是否拥有指定角色
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) CheckUserRole(ctx context.Context, userID, roleID, tenantID string) (bool, error) {
-	count, err := r.q.UserRole.WithContext(ctx).
+	count, err := r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.UserRole.UserID.Eq(userID)).
 		Where(r.q.UserRole.RoleID.Eq(roleID)).
 		Where(r.q.UserRole.TenantID.Eq(tenantID)).
@@ -82,8 +103,10 @@ func (r *UserRoleRepo) CheckUserRole(ctx context.Context, userID, roleID, tenant
 }
 
 // GetRoleUsers 获取指定角色下的所有用户ID
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) GetRoleUsers(ctx context.Context, roleID, tenantID string) ([]string, error) {
-	userRoles, err := r.q.UserRole.WithContext(ctx).
+	userRoles, err := r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.UserRole.RoleID.Eq(roleID)).
 		Where(r.q.UserRole.TenantID.Eq(tenantID)).
 		Find()
@@ -98,6 +121,11 @@ func (r *UserRoleRepo) GetRoleUsers(ctx context.Context, roleID, tenantID string
 	return userIDs, nil
 }
 
+// CreateInBatches 分批写入用户角色关联（用于批量导入新用户）
+func (r *UserRoleRepo) CreateInBatches(ctx context.Context, userRoles []*model.UserRole, batchSize int) error {
+	return r.q.UserRole.WithContext(ctx).CreateInBatches(userRoles, batchSize)
+}
+
 // AssignRoles 为用户批量分配角色（覆盖式）
 func (r *UserRoleRepo) AssignRoles(ctx context.Context, userID string, roleIDs []string, tenantID string) error {
 	// 1. 删除用户在该租户下的所有现有角色
@@ -149,16 +177,20 @@ func (r *UserRoleRepo) RemoveRoles(ctx context.Context, userID string, roleIDs [
 }
 
 // GetUserRolesByRoleIDs 根据角色ID列表获取所有用户角色关联
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) GetUserRolesByRoleIDs(ctx context.Context, roleIDs []string, tenantID string) ([]*model.UserRole, error) {
-	return r.q.UserRole.WithContext(ctx).
+	return r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.UserRole.RoleID.In(roleIDs...)).
 		Where(r.q.UserRole.TenantID.Eq(tenantID)).
 		Find()
 }
 
 // DeleteRoles 批量删除指定角色的所有关联
+//
+//tenantscope:allow 租户ID取自用户所属租户、当前租户或已校验成员关系的切换目标租户
 func (r *UserRoleRepo) DeleteRoles(ctx context.Context, roleIDs []string, tenantID string) error {
-	_, err := r.q.UserRole.WithContext(ctx).
+	_, err := r.q.UserRole.WithContext(database.WithTenant(ctx, tenantID)).
 		Where(r.q.UserRole.RoleID.In(roleIDs...)).
 		Where(r.q.UserRole.TenantID.Eq(tenantID)).
 		Delete()
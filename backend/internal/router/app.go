@@ -1,28 +1,41 @@
 package router
 
 import (
+	"admin/internal/handler/accesstoken"
 	"admin/internal/handler/auth"
 	"admin/internal/handler/captcha"
 	"admin/internal/handler/department"
 	"admin/internal/handler/dict"
+	"admin/internal/handler/export"
 	"admin/internal/handler/health"
+	"admin/internal/handler/invitation"
 	"admin/internal/handler/loginlog"
+	"admin/internal/handler/member"
 	"admin/internal/handler/menu"
 	"admin/internal/handler/operationlog"
+	"admin/internal/handler/plan"
 	"admin/internal/handler/position"
 	"admin/internal/handler/role"
+	"admin/internal/handler/roletemplate"
+	"admin/internal/handler/serviceaccount"
+	"admin/internal/handler/setting"
+	taskhandler "admin/internal/handler/task"
 	"admin/internal/handler/tenant"
 	"admin/internal/handler/user"
 	"admin/internal/jobs"
 
 	"admin/internal/rbac"
+	accesstokensvc "admin/internal/service/accesstoken"
+	tasksvc "admin/internal/service/task"
 	"admin/pkg/audit"
 	"admin/pkg/cache"
 	"admin/pkg/config"
 	"admin/pkg/constants"
 	"admin/pkg/database"
+	"admin/pkg/utils/geoip"
 	"admin/pkg/utils/jwt"
 	"admin/pkg/utils/logger"
+	"admin/pkg/utils/notify"
 	"admin/pkg/utils/rsapwd"
 	"admin/pkg/utils/xcron"
 	"admin/pkg/utils/xredis"
@@ -46,21 +59,38 @@ type App struct {
 	Cron      *xcron.Manager
 	Handlers  *Handlers
 	Audit     *audit.Recorder
+	// AccessToken 访问令牌认证（PAT / API Key），供认证中间件使用
+	AccessToken *accesstokensvc.Service
+	// GeoIP IP 地理位置解析，未配置或加载失败时为 nil
+	GeoIP geoip.Resolver
+	// Notifier 邮件/短信通知发送器
+	Notifier notify.Sender
+	// Tasks 后台任务工作进程，各业务服务在创建处理器时注册任务类型
+	Tasks *tasksvc.Worker
 }
 
 type Handlers struct {
-	HealthHandler       *health.Handler
-	CaptchaHandler      *captcha.Handler
-	AuthHandler         *auth.Handler
-	UserHandler         *user.Handler
-	TenantHandler       *tenant.Handler
-	RoleHandler         *role.Handler
-	MenuHandler         *menu.Handler
-	LoginLogHandler     *loginlog.Handler
-	OperationLogHandler *operationlog.Handler
-	DepartmentHandler   *department.Handler
-	PositionHandler     *position.Handler
-	DictHandler         *dict.Handler
+	HealthHandler         *health.Handler
+	CaptchaHandler        *captcha.Handler
+	AuthHandler           *auth.Handler
+	UserHandler           *user.Handler
+	TenantHandler         *tenant.Handler
+	RoleHandler           *role.Handler
+	RoleTemplateHandler   *roletemplate.Handler
+	MenuHandler           *menu.Handler
+	LoginLogHandler       *loginlog.Handler
+	OperationLogHandler   *operationlog.Handler
+	DepartmentHandler     *department.Handler
+	PositionHandler       *position.Handler
+	DictHandler           *dict.Handler
+	AccessTokenHandler    *accesstoken.Handler
+	ServiceAccountHandler *serviceaccount.Handler
+	PlanHandler           *plan.Handler
+	SettingHandler        *setting.Handler
+	InvitationHandler     *invitation.Handler
+	MemberHandler         *member.Handler
+	ExportHandler         *export.Handler
+	TaskHandler           *taskhandler.Handler
 }
 
 func NewApp() (*App, error) {
@@ -105,20 +135,33 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("failed to init rsa cipher: %w", err)
 	}
 
-	// 6.6 初始化定时任务
+	// 6.6 初始化 IP 地理位置解析与通知发送器
+	app.initGeoIP(app.Config)
+	app.Notifier = notify.NewLogSender()
+
+	// 6.7 创建后台任务工作进程
+	app.Tasks = tasksvc.NewWorker(app.DB, app.Config.Task)
+
+	// 6.8 初始化定时任务（租户到期提醒依赖通知发送器，租户清除提交到后台任务）
 	if err := app.initCron(); err != nil {
 		return nil, fmt.Errorf("failed to init cron: %w", err)
 	}
 
 	// 7. 创建审计 Recorder
-	auditDB := audit.NewDB(app.DB)
+	auditDB := audit.NewDB(app.DB, app.GeoIP)
 	app.Audit = audit.NewRecorder(auditDB)
 
+	// 7.5 创建访问令牌认证服务
+	app.AccessToken = accesstokensvc.NewService(app.DB, app.Audit, app.RBAC)
+
 	// 8. 初始化处理器层
 	if err := app.initHandlers(); err != nil {
 		return nil, fmt.Errorf("failed to init handlers: %w", err)
 	}
 
+	// 8.5 任务类型已在定时任务和处理器初始化时注册，开始执行后台任务
+	app.Tasks.Start()
+
 	// 9. 初始化路由
 	if err := app.initRouter(); err != nil {
 		return nil, fmt.Errorf("failed to init router: %w", err)
@@ -139,6 +182,27 @@ func (s *App) initConfig() error {
 	return nil
 }
 
+// initGeoIP 加载 IP 地理位置库
+// 地理位置仅用于日志展示和登录风险识别等辅助功能，未配置或加载失败时不影响启动
+func (s *App) initGeoIP(cfg *config.Config) {
+	if cfg.GeoIP.DBPath == "" {
+		log.Info().Msg("GeoIP database not configured, skip")
+		return
+	}
+
+	open := func(path string) (geoip.Resolver, error) {
+		return geoip.Open(path, cfg.GeoIP.Format)
+	}
+	resolver, err := geoip.NewReloadingResolver(cfg.GeoIP.DBPath, open, cfg.GeoIP.GetReloadInterval())
+	if err != nil {
+		log.Warn().Err(err).Str("path", cfg.GeoIP.DBPath).Msg("Failed to load GeoIP database, location based checks disabled")
+		return
+	}
+	s.GeoIP = resolver
+
+	log.Info().Str("path", cfg.GeoIP.DBPath).Msg("GeoIP database loaded")
+}
+
 func (s *App) initLogger(cfg *config.Config) error {
 	logger.Init(logger.Config{
 		Level:  cfg.Log.Level,
@@ -156,6 +220,8 @@ func (s *App) initDatabase(cfg *config.Config) error {
 		MaxOpenConns:    cfg.Database.MaxOpenConns,
 		ConnMaxLifetime: cfg.Database.GetConnMaxLifetime(),
 		LogLevel:        cfg.Log.Level,
+		RLS:             cfg.Database.RLS,
+		RLSBypassRole:   cfg.Database.GetRLSBypassRole(),
 	})
 	if err != nil {
 		return err
@@ -225,7 +291,7 @@ func (a *App) initCron() error {
 	}
 	a.Cron = cronMgr
 
-	if err := jobs.Init(cronMgr, a.DB); err != nil {
+	if err := jobs.Init(cronMgr, a.DB, a.Notifier, a.Tasks, a.Config); err != nil {
 		return fmt.Errorf("failed to register jobs: %w", err)
 	}
 
@@ -236,18 +302,27 @@ func (a *App) initCron() error {
 
 func (s *App) initHandlers() error {
 	s.Handlers = &Handlers{
-		HealthHandler:       health.NewHandler(),
-		CaptchaHandler:      captcha.NewHandler(s.Redis),
-		AuthHandler:         auth.NewHandler(s.DB, s.JWT, s.Redis, s.Audit, s.RSACipher, s.Config),
-		UserHandler:         user.NewHandler(s.DB, s.Audit, s.RSACipher, s.RBAC),
-		TenantHandler:       tenant.NewHandler(s.DB, s.Audit),
-		RoleHandler:         role.NewHandler(s.DB, s.Audit, s.RBAC),
-		MenuHandler:         menu.NewHandler(s.DB, s.Audit, s.RBAC),
-		LoginLogHandler:     loginlog.NewHandler(s.DB),
-		OperationLogHandler: operationlog.NewHandler(s.DB),
-		DepartmentHandler:   department.NewHandler(s.DB, s.Audit),
-		PositionHandler:     position.NewHandler(s.DB, s.Audit),
-		DictHandler:         dict.NewHandler(s.DB, s.Audit),
+		HealthHandler:         health.NewHandler(),
+		CaptchaHandler:        captcha.NewHandler(s.Redis),
+		AuthHandler:           auth.NewHandler(s.DB, s.JWT, s.Redis, s.Audit, s.RSACipher, s.Config, s.GeoIP, s.Notifier, s.RBAC),
+		UserHandler:           user.NewHandler(s.DB, s.Audit, s.RSACipher, s.RBAC, s.Redis, s.Notifier, s.Tasks, s.Config.Profile, s.Config.Task),
+		TenantHandler:         tenant.NewHandler(s.DB, s.JWT, s.Audit, s.RBAC, s.Tasks, s.Config.Tenant, s.Config.Task),
+		RoleHandler:           role.NewHandler(s.DB, s.Audit, s.RBAC, s.Tasks),
+		RoleTemplateHandler:   roletemplate.NewHandler(s.DB, s.Audit, s.RBAC),
+		MenuHandler:           menu.NewHandler(s.DB, s.Audit, s.RBAC),
+		LoginLogHandler:       loginlog.NewHandler(s.DB),
+		OperationLogHandler:   operationlog.NewHandler(s.DB),
+		DepartmentHandler:     department.NewHandler(s.DB, s.Audit),
+		PositionHandler:       position.NewHandler(s.DB, s.Audit),
+		DictHandler:           dict.NewHandler(s.DB, s.Audit),
+		AccessTokenHandler:    accesstoken.NewHandler(s.DB, s.Audit, s.RBAC),
+		ServiceAccountHandler: serviceaccount.NewHandler(s.DB, s.JWT, s.Audit),
+		PlanHandler:           plan.NewHandler(s.DB, s.Audit, s.RBAC),
+		SettingHandler:        setting.NewHandler(s.DB, s.Audit),
+		InvitationHandler:     invitation.NewHandler(s.DB, s.Audit, s.RSACipher, s.Notifier, s.Config),
+		MemberHandler:         member.NewHandler(s.DB, s.JWT, s.Audit),
+		ExportHandler:         export.NewHandler(s.DB, s.Audit, s.RSACipher, s.RBAC, s.Tasks, s.Config.Export),
+		TaskHandler:           taskhandler.NewHandler(s.DB, s.Audit, s.Config.Task),
 	}
 	return nil
 }
@@ -257,7 +332,7 @@ func (s *App) initRouter() error {
 
 	r := gin.New()
 
-	Setup(r, s.Handlers, s.Config, s.JWT, s.RBAC)
+	Setup(r, s.Handlers, s.Config, s.DB, s.JWT, s.RBAC, s.AccessToken)
 	s.Router = r
 
 	log.Info().Str("mode", s.Config.Server.Mode).Msg("Router initialized")
@@ -288,10 +363,19 @@ func (s *App) Close() error {
 		s.RBAC.Stop()
 	}
 
+	if s.GeoIP != nil {
+		s.GeoIP.Close()
+	}
+
 	if s.Cron != nil {
 		s.Cron.Stop()
 	}
 
+	// 等待执行中的后台任务退出后再关闭数据库
+	if s.Tasks != nil {
+		s.Tasks.Stop()
+	}
+
 	if err := database.Close(); err != nil {
 		return err
 	}
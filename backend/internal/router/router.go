@@ -3,6 +3,7 @@ package router
 import (
 	"admin/internal/middleware"
 	"admin/internal/rbac"
+	"admin/internal/service/accesstoken"
 	"admin/pkg/audit"
 	"admin/pkg/config"
 	"admin/pkg/utils/jwt"
@@ -13,10 +14,11 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"gorm.io/gorm"
 )
 
 // Setup 设置路由
-func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Manager, rbacCache *rbac.PermissionCache) {
+func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, db *gorm.DB, jwtMgr *jwt.Manager, rbacCache *rbac.PermissionCache, tokenSvc *accesstoken.Service) {
 
 	r.Use(middleware.RequestIDMiddleware())
 	r.Use(middleware.LoggerMiddleware())
@@ -43,6 +45,8 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 
 	// API v1 路由组
 	v1 := r.Group("/api/v1")
+	// 按请求头/自定义域名/子域名识别租户，登录时据此限定用户查找范围
+	v1.Use(middleware.TenantResolverMiddleware(db, cfg.Tenant.BaseDomain))
 	{
 
 		// 登录/注册相关接口
@@ -50,12 +54,20 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 		{
 			authGroup.GET("/captcha", handlers.CaptchaHandler.Get)
 			authGroup.POST("/login", audit.AuditMiddleware(), handlers.AuthHandler.Login)
+			authGroup.POST("/login/mfa", audit.AuditMiddleware(), handlers.AuthHandler.VerifyLoginMFA)
 			authGroup.POST("/refresh", handlers.AuthHandler.Refresh)
+			authGroup.POST("/token", audit.AuditMiddleware(), handlers.AuthHandler.Token)
+			authGroup.GET("/invitation", handlers.InvitationHandler.PreviewInvitation)
+			authGroup.POST("/invitation/accept", audit.AuditMiddleware(), handlers.InvitationHandler.AcceptInvitation)
 		}
 
+		// 登录页品牌信息（按识别出的租户返回公开设置）
+		v1.GET("/settings/branding", handlers.SettingHandler.GetBranding)
+
 		// 需要认证 + RBAC 权限检查的路由
 		authorized := v1.Group("")
-		authorized.Use(middleware.AuthMiddleware(jwtMgr))
+		authorized.Use(middleware.AuthMiddleware(jwtMgr, tokenSvc))
+		authorized.Use(middleware.TenantLifecycleMiddleware(db))
 		authorized.Use(middleware.RBACMiddleware(rbacCache))
 		authorized.Use(audit.AuditMiddleware())
 		{
@@ -64,8 +76,15 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 			{
 				userSelf.GET("/profile", handlers.UserHandler.GetProfile)
 				userSelf.POST("/password/change", handlers.UserHandler.ChangePassword)
+				userSelf.POST("/profile/update", handlers.UserHandler.UpdateProfile)
+				userSelf.POST("/profile/avatar", handlers.UserHandler.UploadAvatar)
+				userSelf.POST("/profile/contact/code", handlers.UserHandler.SendContactCode)
+				userSelf.POST("/profile/contact/verify", handlers.UserHandler.VerifyContact)
 				userSelf.GET("/menus", handlers.UserHandler.GetUserMenu)
 				userSelf.GET("/buttons", handlers.UserHandler.GetUserButtons)
+				userSelf.POST("/tokens", handlers.AccessTokenHandler.CreatePersonalToken)
+				userSelf.GET("/tokens", handlers.AccessTokenHandler.ListPersonalTokens)
+				userSelf.DELETE("/tokens", handlers.AccessTokenHandler.RevokePersonalToken)
 			}
 
 			// 认证接口
@@ -74,26 +93,71 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 				authGroup.POST("/logout", handlers.AuthHandler.Logout)
 				authGroup.POST("/switch-tenant", handlers.AuthHandler.SwitchTenant)
 				authGroup.GET("/available-tenants", handlers.AuthHandler.GetAvailableTenants)
+				authGroup.POST("/impersonate", handlers.AuthHandler.Impersonate)
 			}
 
 			// 租户管理
 			tenant := authorized.Group("/tenants")
 			{
 				tenant.POST("", handlers.TenantHandler.CreateTenant)
+				tenant.POST("/provision", handlers.TenantHandler.ProvisionTenant)
+				tenant.POST("/clone", handlers.TenantHandler.CloneTenant)
 				tenant.GET("", handlers.TenantHandler.ListTenants)
 				tenant.GET("/all", handlers.TenantHandler.ListAllTenants)
 				tenant.GET("/detail", handlers.TenantHandler.GetTenant)
 				tenant.PUT("", handlers.TenantHandler.UpdateTenant)
 				tenant.DELETE("", handlers.TenantHandler.DeleteTenant)
 				tenant.DELETE("/batch-delete", handlers.TenantHandler.BatchDeleteTenants)
+				tenant.POST("/restore", handlers.TenantHandler.RestoreTenant)
+				tenant.GET("/purges", handlers.TenantHandler.ListTenantPurges)
 				tenant.PUT("/status", handlers.TenantHandler.UpdateTenantStatus)
+				tenant.PUT("/plan", handlers.TenantHandler.ChangeTenantPlan)
+				tenant.PUT("/subscription", handlers.TenantHandler.UpdateTenantSubscription)
+				tenant.GET("/usage", handlers.TenantHandler.GetTenantUsage)
+				tenant.PUT("/quotas", handlers.TenantHandler.SetTenantQuotas)
+				tenant.POST("/domains", handlers.TenantHandler.AddTenantDomain)
+				tenant.GET("/domains", handlers.TenantHandler.ListTenantDomains)
+				tenant.POST("/domains/verify", handlers.TenantHandler.VerifyTenantDomain)
+				tenant.DELETE("/domains", handlers.TenantHandler.DeleteTenantDomain)
+				tenant.GET("/export", handlers.TenantHandler.ExportTenant)
+				tenant.POST("/import", handlers.TenantHandler.ImportTenant)
+			}
+
+			// 租户套餐管理
+			plans := authorized.Group("/plans")
+			{
+				plans.POST("", handlers.PlanHandler.CreatePlan)
+				plans.GET("", handlers.PlanHandler.ListPlans)
+				plans.GET("/detail", handlers.PlanHandler.GetPlan)
+				plans.PUT("", handlers.PlanHandler.UpdatePlan)
+				plans.DELETE("", handlers.PlanHandler.DeletePlan)
+				plans.PUT("/permissions", handlers.PlanHandler.AssignPermissions)
+				plans.GET("/permissions", handlers.PlanHandler.GetPermissions)
+			}
+
+			// 租户 API Key 管理
+			apiKeys := authorized.Group("/api-keys")
+			{
+				apiKeys.POST("", handlers.AccessTokenHandler.CreateAPIKey)
+				apiKeys.GET("", handlers.AccessTokenHandler.ListAPIKeys)
+				apiKeys.DELETE("", handlers.AccessTokenHandler.RevokeAPIKey)
+			}
+
+			// 服务账号管理（角色分配复用 PUT /users/roles）
+			serviceAccounts := authorized.Group("/service-accounts")
+			{
+				serviceAccounts.POST("", handlers.ServiceAccountHandler.CreateServiceAccount)
+				serviceAccounts.GET("", handlers.ServiceAccountHandler.ListServiceAccounts)
+				serviceAccounts.POST("/secret/rotate", handlers.ServiceAccountHandler.RotateSecret)
 			}
 
 			// 用户管理
 			userGroup := authorized.Group("/users")
 			{
 				userGroup.POST("", handlers.UserHandler.CreateUser)
+				userGroup.POST("/import", handlers.UserHandler.ImportUsers)
 				userGroup.GET("", handlers.UserHandler.ListUsers)
+				userGroup.GET("/export", handlers.ExportHandler.ExportUsers)
 				userGroup.GET("/detail", handlers.UserHandler.GetUser)
 				userGroup.PUT("", handlers.UserHandler.UpdateUser)
 				userGroup.DELETE("", handlers.UserHandler.DeleteUser)
@@ -101,14 +165,36 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 				userGroup.PUT("/status", handlers.UserHandler.UpdateUserStatus)
 				userGroup.GET("/roles", handlers.UserHandler.GetUserRoles)
 				userGroup.PUT("/roles", handlers.UserHandler.AssignRoles)
+				userGroup.GET("/positions", handlers.UserHandler.GetUserPositions)
+				userGroup.PUT("/positions", handlers.UserHandler.AssignPositions)
+				userGroup.GET("/manager", handlers.DepartmentHandler.GetUserManager)
 				userGroup.POST("/password/reset", handlers.UserHandler.ResetPassword)
 			}
 
+			// 成员邀请
+			invitations := authorized.Group("/invitations")
+			{
+				invitations.POST("", handlers.InvitationHandler.CreateInvitation)
+				invitations.GET("", handlers.InvitationHandler.ListInvitations)
+				invitations.POST("/resend", handlers.InvitationHandler.ResendInvitation)
+				invitations.DELETE("", handlers.InvitationHandler.RevokeInvitation)
+			}
+
+			// 租户成员（从其他租户加入的用户，角色分配复用 PUT /users/roles）
+			members := authorized.Group("/members")
+			{
+				members.GET("", handlers.MemberHandler.ListMembers)
+				members.PUT("", handlers.MemberHandler.UpdateMember)
+				members.PUT("/status", handlers.MemberHandler.UpdateMemberStatus)
+				members.DELETE("", handlers.MemberHandler.RemoveMember)
+			}
+
 			// 角色管理
 			roleGroup := authorized.Group("/roles")
 			{
 				roleGroup.POST("", handlers.RoleHandler.CreateRole)
 				roleGroup.GET("", handlers.RoleHandler.ListRoles)
+				roleGroup.GET("/export", handlers.ExportHandler.ExportRoles)
 				roleGroup.GET("/all", handlers.RoleHandler.GetAllRoles)
 				roleGroup.GET("/detail", handlers.RoleHandler.GetRole)
 				roleGroup.PUT("", handlers.RoleHandler.UpdateRole)
@@ -116,6 +202,22 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 				roleGroup.PUT("/status", handlers.RoleHandler.UpdateRoleStatus)
 				roleGroup.PUT("/permissions", handlers.RoleHandler.AssignPermissions)
 				roleGroup.GET("/permissions", handlers.RoleHandler.GetRolePermissions)
+				roleGroup.POST("/permission-cache/rebuild", handlers.RoleHandler.RebuildPermissionCache)
+			}
+
+			// 角色模板管理
+			roleTemplate := authorized.Group("/role-templates")
+			{
+				roleTemplate.POST("", handlers.RoleTemplateHandler.CreateRoleTemplate)
+				roleTemplate.GET("", handlers.RoleTemplateHandler.ListRoleTemplates)
+				roleTemplate.GET("/detail", handlers.RoleTemplateHandler.GetRoleTemplate)
+				roleTemplate.PUT("", handlers.RoleTemplateHandler.UpdateRoleTemplate)
+				roleTemplate.DELETE("", handlers.RoleTemplateHandler.DeleteRoleTemplate)
+				roleTemplate.PUT("/permissions", handlers.RoleTemplateHandler.AssignPermissions)
+				roleTemplate.GET("/permissions", handlers.RoleTemplateHandler.GetPermissions)
+				roleTemplate.POST("/instantiate", handlers.RoleTemplateHandler.InstantiateRoleTemplate)
+				roleTemplate.GET("/sync-preview", handlers.RoleTemplateHandler.PreviewSync)
+				roleTemplate.POST("/sync", handlers.RoleTemplateHandler.Sync)
 			}
 
 			// 菜单接口
@@ -136,12 +238,16 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 			{
 				dept.POST("", handlers.DepartmentHandler.CreateDepartment)
 				dept.GET("", handlers.DepartmentHandler.ListDepartments)
+				dept.GET("/export", handlers.ExportHandler.ExportDepartments)
 				dept.GET("/tree", handlers.DepartmentHandler.GetDepartmentTree)
+				dept.GET("/org-chart", handlers.DepartmentHandler.GetOrgChart)
 				dept.GET("/detail", handlers.DepartmentHandler.GetDepartment)
 				dept.PUT("", handlers.DepartmentHandler.UpdateDepartment)
 				dept.DELETE("", handlers.DepartmentHandler.DeleteDepartment)
 				dept.PUT("/status", handlers.DepartmentHandler.UpdateDepartmentStatus)
 				dept.GET("/children", handlers.DepartmentHandler.GetChildren)
+				dept.GET("/leaders", handlers.DepartmentHandler.GetDepartmentLeaders)
+				dept.PUT("/leaders", handlers.DepartmentHandler.SetDepartmentLeaders)
 			}
 
 			// 岗位管理
@@ -149,8 +255,10 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 			{
 				position.POST("", handlers.PositionHandler.CreatePosition)
 				position.GET("", handlers.PositionHandler.ListPositions)
+				position.GET("/export", handlers.ExportHandler.ExportPositions)
 				position.GET("/all", handlers.PositionHandler.ListAllPositions)
 				position.GET("/detail", handlers.PositionHandler.GetPosition)
+				position.GET("/users", handlers.UserHandler.ListPositionUsers)
 				position.PUT("", handlers.PositionHandler.UpdatePosition)
 				position.DELETE("", handlers.PositionHandler.DeletePosition)
 				position.PUT("/status", handlers.PositionHandler.UpdatePositionStatus)
@@ -173,15 +281,41 @@ func Setup(r *gin.Engine, handlers *Handlers, cfg *config.Config, jwtMgr *jwt.Ma
 				systemDict.DELETE("/items", handlers.DictHandler.DeleteSystemDictItem)
 			}
 
+			// 租户设置（default 租户修改平台默认值）
+			settings := authorized.Group("/settings")
+			{
+				settings.GET("", handlers.SettingHandler.ListSettings)
+				settings.PUT("", handlers.SettingHandler.UpdateSettings)
+				settings.DELETE("", handlers.SettingHandler.ResetSetting)
+			}
+
 			// 审计日志接口
 			logs := authorized.Group("/logs")
 			{
 				logs.GET("/login", handlers.LoginLogHandler.ListLoginLogs)
+				logs.GET("/login/export", handlers.ExportHandler.ExportLoginLogs)
 				logs.GET("/login/detail", handlers.LoginLogHandler.GetLoginLog)
 				logs.GET("/operation", handlers.OperationLogHandler.ListOperationLogs)
+				logs.GET("/operation/export", handlers.ExportHandler.ExportOperationLogs)
 				logs.GET("/operation/detail", handlers.OperationLogHandler.GetOperationLog)
 			}
 
+			// 后台导出任务（列表导出超过行数阈值时转为后台任务）
+			exports := authorized.Group("/exports")
+			{
+				exports.GET("", handlers.ExportHandler.ListExports)
+				exports.GET("/download", handlers.ExportHandler.DownloadExport)
+			}
+
+			// 后台任务（查询自己发起的任务进度、下载结果文件、取消任务）
+			tasks := authorized.Group("/tasks")
+			{
+				tasks.GET("", handlers.TaskHandler.ListTasks)
+				tasks.GET("/detail", handlers.TaskHandler.GetTask)
+				tasks.GET("/download", handlers.TaskHandler.DownloadTaskFile)
+				tasks.POST("/cancel", handlers.TaskHandler.CancelTask)
+			}
+
 		}
 	}
 }
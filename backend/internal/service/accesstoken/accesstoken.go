@@ -0,0 +1,35 @@
+package accesstoken
+
+import (
+	"admin/internal/rbac"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+
+	"gorm.io/gorm"
+)
+
+// Service 访问令牌服务（个人访问令牌 PAT / 租户 API Key）
+type Service struct {
+	tokenRepo      *repository.AccessTokenRepo
+	permissionRepo *repository.PermissionRepo
+	userRepo       *repository.UserRepo
+	userRoleRepo   *repository.UserRoleRepo
+	roleRepo       *repository.RoleRepo
+	tenantRepo     *repository.TenantRepo
+	cache          *rbac.PermissionCache
+	recorder       *audit.Recorder
+}
+
+// NewService 创建访问令牌服务
+func NewService(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache) *Service {
+	return &Service{
+		tokenRepo:      repository.NewAccessTokenRepo(db),
+		permissionRepo: repository.NewPermissionRepo(db),
+		userRepo:       repository.NewUserRepo(db),
+		userRoleRepo:   repository.NewUserRoleRepo(db),
+		roleRepo:       repository.NewRoleRepo(db),
+		tenantRepo:     repository.NewTenantRepo(db),
+		cache:          cache,
+		recorder:       recorder,
+	}
+}
@@ -0,0 +1,132 @@
+package accesstoken
+
+import (
+	"admin/internal/rbac"
+	"admin/pkg/constants"
+	"admin/pkg/utils/jwt"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// lastUsedInterval 最后使用信息的最小更新间隔，避免每次请求都写库
+const lastUsedInterval = time.Minute
+
+// Identity 访问令牌认证结果
+type Identity struct {
+	Claims *jwt.Claims // 与 JWT 一致的身份信息（租户、用户、角色）
+	KeyID  string      // 访问令牌ID
+	Scopes []string    // 授权范围（格式："METHOD /path"）
+}
+
+// Authenticate 校验访问令牌并解析调用身份
+// 令牌的有效权限 = 所有者当前角色权限 ∩ 令牌授权范围
+// 认证前还没有当前租户，按令牌ID查找令牌及其所有者标记为平台级操作
+//
+//tenantscope:allow 认证前按令牌ID查找令牌，此时还没有当前租户
+func (s *Service) Authenticate(ctx context.Context, raw, clientIP string) (*Identity, error) {
+	ctx = xcontext.SetPlatformScope(ctx)
+	tokenType, tokenID, secret, ok := parseToken(raw)
+	if !ok {
+		return nil, xerr.ErrTokenInvalid
+	}
+
+	token, err := s.tokenRepo.GetByIDManual(ctx, tokenID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTokenInvalid
+		}
+		log.Error().Err(err).Str("token_id", tokenID).Msg("查询访问令牌失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询访问令牌失败", err)
+	}
+	if token.TokenType != tokenType || !passwordgen.VerifySecret(secret, token.SecretHash) {
+		log.Warn().Str("token_id", tokenID).Msg("访问令牌密钥校验失败")
+		return nil, xerr.ErrTokenInvalid
+	}
+
+	now := time.Now()
+	if token.ExpiresAt > 0 && token.ExpiresAt <= now.UnixMilli() {
+		return nil, xerr.ErrTokenExpired
+	}
+
+	// 校验所有者状态
+	user, err := s.userRepo.GetByIDManual(ctx, token.UserID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", token.UserID).Msg("查询令牌所有者失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询令牌所有者失败", err)
+	}
+	if user.Status != constants.StatusEnabled {
+		return nil, xerr.ErrUserDisabled
+	}
+
+	// 校验租户状态
+	tenant, err := s.tenantRepo.GetByIDManual(ctx, token.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", token.TenantID).Msg("查询租户信息失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户信息失败", err)
+	}
+	if tenant.Status != constants.StatusEnabled {
+		return nil, xerr.ErrTenantDisabled
+	}
+
+	// 所有者在令牌租户下的当前角色
+	roleIDs, err := s.userRoleRepo.GetUserRoleIDs(ctx, user.UserID, tenant.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询用户角色失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户角色失败", err)
+	}
+	if len(roleIDs) == 0 {
+		return nil, xerr.ErrUserNoRoles
+	}
+	roles, err := s.roleRepo.GetByIDs(ctx, roleIDs)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询角色详情失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询角色详情失败", err)
+	}
+	roleCodes := make([]string, len(roles))
+	for i, role := range roles {
+		roleCodes[i] = role.RoleCode
+	}
+
+	scopes := parseScopes(token.Scopes)
+	scopeStrs := make([]string, len(scopes))
+	for i, scope := range scopes {
+		scopeStrs[i] = rbac.FormatScope(scope.Method, scope.Path)
+	}
+
+	// 异步记录最后使用信息
+	if now.UnixMilli()-token.LastUsedAt >= lastUsedInterval.Milliseconds() || token.LastUsedIP != clientIP {
+		go func() {
+			if err := s.tokenRepo.UpdateManual(context.WithoutCancel(ctx), token.TokenID, map[string]interface{}{
+				"last_used_at": now.UnixMilli(),
+				"last_used_ip": clientIP,
+			}); err != nil {
+				log.Error().Err(err).Str("token_id", token.TokenID).Msg("更新访问令牌最后使用信息失败")
+			}
+		}()
+	}
+
+	return &Identity{
+		Claims: &jwt.Claims{
+			TenantID:   tenant.TenantID,
+			TenantCode: tenant.TenantCode,
+			UserID:     user.UserID,
+			UserName:   user.UserName,
+			Roles:      roleCodes,
+			RoleIDs:    roleIDs,
+		},
+		KeyID:  token.TokenID,
+		Scopes: scopeStrs,
+	}, nil
+}
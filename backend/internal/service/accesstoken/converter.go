@@ -0,0 +1,49 @@
+package accesstoken
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"encoding/json"
+)
+
+// parseScopes 解析令牌存储的授权范围
+func parseScopes(raw string) []*dto.AccessTokenScope {
+	scopes := make([]*dto.AccessTokenScope, 0)
+	if raw == "" {
+		return scopes
+	}
+	_ = json.Unmarshal([]byte(raw), &scopes)
+	return scopes
+}
+
+// modelToAccessTokenInfo 将数据库模型转换为访问令牌信息 DTO（不含密钥哈希）
+func modelToAccessTokenInfo(token *model.AccessToken) *dto.AccessTokenInfo {
+	if token == nil {
+		return nil
+	}
+
+	return &dto.AccessTokenInfo{
+		TokenID:    token.TokenID,
+		TokenType:  token.TokenType,
+		Name:       token.Name,
+		UserID:     token.UserID,
+		Scopes:     parseScopes(token.Scopes),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIP: token.LastUsedIP,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+// modelListToAccessTokenInfoList 批量将数据库模型转换为访问令牌信息 DTO
+func modelListToAccessTokenInfoList(tokens []*model.AccessToken) []*dto.AccessTokenInfo {
+	if len(tokens) == 0 {
+		return []*dto.AccessTokenInfo{}
+	}
+
+	result := make([]*dto.AccessTokenInfo, len(tokens))
+	for i, token := range tokens {
+		result[i] = modelToAccessTokenInfo(token)
+	}
+	return result
+}
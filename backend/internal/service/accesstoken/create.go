@@ -0,0 +1,159 @@
+package accesstoken
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CreatePersonalToken 为当前用户创建个人访问令牌
+func (s *Service) CreatePersonalToken(ctx context.Context, req *dto.CreateAccessTokenRequest) (*dto.CreateAccessTokenResponse, error) {
+	return s.createToken(ctx, constants.TokenTypePAT, req)
+}
+
+// CreateAPIKey 为当前租户创建 API Key
+// API Key 归属租户，以创建人当前的角色作为权限上限
+func (s *Service) CreateAPIKey(ctx context.Context, req *dto.CreateAccessTokenRequest) (*dto.CreateAccessTokenResponse, error) {
+	return s.createToken(ctx, constants.TokenTypeAPIKey, req)
+}
+
+// createToken 创建访问令牌
+func (s *Service) createToken(ctx context.Context, tokenType string, req *dto.CreateAccessTokenRequest) (resp *dto.CreateAccessTokenResponse, err error) {
+	var info *dto.AccessTokenInfo
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleAccessToken),
+				audit.WithError(err),
+			)
+		} else if info != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleAccessToken),
+				audit.WithResource(constants.ResourceTypeAccessToken, info.TokenID, info.Name),
+				audit.WithValue(nil, info),
+			)
+		}
+	}()
+
+	tenantID := xcontext.GetTenantID(ctx)
+	userID := xcontext.GetUserID(ctx)
+	if tenantID == "" || userID == "" {
+		return nil, xerr.ErrUnauthorized
+	}
+
+	// 不允许使用访问令牌再派生新的令牌
+	if xcontext.GetAccessKeyID(ctx) != "" {
+		return nil, xerr.New(xerr.ErrForbidden.Code, "访问令牌不能用于创建新的令牌")
+	}
+	// 模拟登录状态下不允许以被模拟用户身份创建长期凭证
+	if xcontext.IsImpersonating(ctx) {
+		return nil, xerr.ErrImpersonationForbidden
+	}
+
+	// 校验授权范围：仅允许 API 权限，且必须在所有者权限范围内
+	var scopes []*dto.AccessTokenScope
+	scopes, err = s.buildScopes(ctx, req.PermissionIDs)
+	if err != nil {
+		return nil, err
+	}
+	var scopesJSON []byte
+	scopesJSON, err = json.Marshal(scopes)
+	if err != nil {
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "序列化授权范围失败", err)
+	}
+
+	var tokenID string
+	tokenID, err = idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成令牌ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成令牌ID失败", err)
+	}
+	var secret string
+	secret, err = passwordgen.GenerateSecret(secretBytes)
+	if err != nil {
+		log.Error().Err(err).Msg("生成令牌密钥失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成令牌密钥失败", err)
+	}
+
+	var expiresAt int64
+	if req.ExpiresInDays > 0 {
+		expiresAt = time.Now().AddDate(0, 0, req.ExpiresInDays).UnixMilli()
+	}
+
+	token := &model.AccessToken{
+		TokenID:    tokenID,
+		UserID:     userID,
+		TokenType:  tokenType,
+		Name:       req.Name,
+		SecretHash: passwordgen.HashSecret(secret),
+		Scopes:     string(scopesJSON),
+		ExpiresAt:  expiresAt,
+	}
+	if err = s.tokenRepo.Create(ctx, token); err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("user_id", userID).Str("token_type", tokenType).Msg("创建访问令牌失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建访问令牌失败", err)
+	}
+
+	info = modelToAccessTokenInfo(token)
+	return &dto.CreateAccessTokenResponse{
+		AccessTokenInfo: *info,
+		Token:           formatToken(tokenType, tokenID, secret),
+		Message:         "请妥善保存令牌，关闭后将无法再次查看",
+	}, nil
+}
+
+// buildScopes 根据权限ID构建授权范围，并校验其为当前用户权限的子集
+func (s *Service) buildScopes(ctx context.Context, permissionIDs []string) ([]*dto.AccessTokenScope, error) {
+	permissions, err := s.permissionRepo.GetByIDs(ctx, permissionIDs)
+	if err != nil {
+		log.Error().Err(err).Strs("permission_ids", permissionIDs).Msg("查询权限失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询权限失败", err)
+	}
+
+	permMap := make(map[string]*model.Permission, len(permissions))
+	for _, p := range permissions {
+		permMap[p.PermissionID] = p
+	}
+
+	isSuperAdmin := xcontext.HasRole(ctx, constants.SuperAdmin)
+	roleIDs := xcontext.GetRoleIDs(ctx)
+
+	seen := make(map[string]bool, len(permissionIDs))
+	scopes := make([]*dto.AccessTokenScope, 0, len(permissionIDs))
+	for _, id := range permissionIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		p, ok := permMap[id]
+		if !ok {
+			return nil, xerr.New(xerr.ErrNotFound.Code, "权限不存在: "+id)
+		}
+		if p.Type != constants.TypeAPI {
+			return nil, xerr.New(xerr.ErrInvalidParams.Code, "访问令牌仅支持授权接口权限: "+p.Name)
+		}
+		if !isSuperAdmin && !s.cache.CheckAPI(roleIDs, p.Resource, p.Action) {
+			log.Warn().Str("permission_id", id).Str("resource", p.Resource).Str("action", p.Action).Msg("令牌授权范围超出所有者权限")
+			return nil, xerr.ErrAccessTokenScope
+		}
+
+		scopes = append(scopes, &dto.AccessTokenScope{
+			PermissionID: p.PermissionID,
+			Path:         p.Resource,
+			Method:       p.Action,
+		})
+	}
+	return scopes, nil
+}
@@ -0,0 +1,67 @@
+package accesstoken
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// RevokePersonalToken 吊销当前用户的个人访问令牌
+func (s *Service) RevokePersonalToken(ctx context.Context, tokenID string) error {
+	return s.revokeToken(ctx, constants.TokenTypePAT, xcontext.GetUserID(ctx), tokenID)
+}
+
+// RevokeAPIKey 吊销当前租户的 API Key
+func (s *Service) RevokeAPIKey(ctx context.Context, tokenID string) error {
+	return s.revokeToken(ctx, constants.TokenTypeAPIKey, "", tokenID)
+}
+
+// revokeToken 吊销访问令牌（软删除，认证时立即失效）
+// ownerID 不为空时只允许吊销本人的令牌
+func (s *Service) revokeToken(ctx context.Context, tokenType, ownerID, tokenID string) (err error) {
+	var token *model.AccessToken
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleAccessToken),
+				audit.WithError(err),
+			)
+		} else if token != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleAccessToken),
+				audit.WithResource(constants.ResourceTypeAccessToken, token.TokenID, token.Name),
+				audit.WithValue(modelToAccessTokenInfo(token), nil),
+			)
+			log.Info().Str("token_id", tokenID).Str("token_type", tokenType).Msg("吊销访问令牌成功")
+		}
+	}()
+
+	token, err = s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		token = nil
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("token_id", tokenID).Msg("访问令牌不存在")
+			return xerr.ErrAccessTokenNotFound
+		}
+		log.Error().Err(err).Str("token_id", tokenID).Msg("查询访问令牌失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询访问令牌失败", err)
+	}
+	if token.TokenType != tokenType || (ownerID != "" && token.UserID != ownerID) {
+		token = nil
+		return xerr.ErrAccessTokenNotFound
+	}
+
+	if err = s.tokenRepo.Delete(ctx, tokenID); err != nil {
+		log.Error().Err(err).Str("token_id", tokenID).Msg("吊销访问令牌失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "吊销访问令牌失败", err)
+	}
+
+	return nil
+}
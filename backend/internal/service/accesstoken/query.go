@@ -0,0 +1,36 @@
+package accesstoken
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/constants"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ListPersonalTokens 获取当前用户的个人访问令牌列表
+func (s *Service) ListPersonalTokens(ctx context.Context, req *dto.ListAccessTokensRequest) (*dto.ListAccessTokensResponse, error) {
+	return s.listTokens(ctx, constants.TokenTypePAT, xcontext.GetUserID(ctx), req)
+}
+
+// ListAPIKeys 获取当前租户的 API Key 列表
+func (s *Service) ListAPIKeys(ctx context.Context, req *dto.ListAccessTokensRequest) (*dto.ListAccessTokensResponse, error) {
+	return s.listTokens(ctx, constants.TokenTypeAPIKey, "", req)
+}
+
+// listTokens 分页查询访问令牌
+func (s *Service) listTokens(ctx context.Context, tokenType, userID string, req *dto.ListAccessTokensRequest) (*dto.ListAccessTokensResponse, error) {
+	tokens, total, err := s.tokenRepo.ListWithFilters(ctx, req.GetOffset(), req.GetLimit(), tokenType, userID, req.Name)
+	if err != nil {
+		log.Error().Err(err).Str("token_type", tokenType).Str("user_id", userID).Msg("查询访问令牌列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询访问令牌列表失败", err)
+	}
+
+	return &dto.ListAccessTokensResponse{
+		Response: pagination.NewResponse(req.Request, total),
+		List:     modelListToAccessTokenInfoList(tokens),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package accesstoken
+
+import (
+	"admin/pkg/constants"
+	"strings"
+)
+
+// 令牌明文格式：<前缀>_<令牌ID>_<密钥>
+// 前缀用于在 Authorization: Bearer 中与 JWT 区分
+const (
+	PrefixPAT    = "pat"
+	PrefixAPIKey = "ak"
+
+	secretBytes = 32
+)
+
+// tokenPrefixes 令牌类型 → 明文前缀
+var tokenPrefixes = map[string]string{
+	constants.TokenTypePAT:    PrefixPAT,
+	constants.TokenTypeAPIKey: PrefixAPIKey,
+}
+
+// IsAccessToken 判断 Bearer 凭证是否为访问令牌（而非 JWT）
+func IsAccessToken(raw string) bool {
+	return strings.HasPrefix(raw, PrefixPAT+"_") || strings.HasPrefix(raw, PrefixAPIKey+"_")
+}
+
+// formatToken 拼接令牌明文
+func formatToken(tokenType, tokenID, secret string) string {
+	return tokenPrefixes[tokenType] + "_" + tokenID + "_" + secret
+}
+
+// parseToken 解析令牌明文，返回令牌类型、令牌ID和密钥
+func parseToken(raw string) (tokenType, tokenID, secret string, ok bool) {
+	prefix, rest, found := strings.Cut(raw, "_")
+	if !found {
+		return "", "", "", false
+	}
+	for t, p := range tokenPrefixes {
+		if p == prefix {
+			tokenType = t
+		}
+	}
+	if tokenType == "" {
+		return "", "", "", false
+	}
+	tokenID, secret, found = strings.Cut(rest, "_")
+	if !found || tokenID == "" || secret == "" {
+		return "", "", "", false
+	}
+	return tokenType, tokenID, secret, true
+}
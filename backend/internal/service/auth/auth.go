@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"admin/internal/rbac"
 	"admin/internal/repository"
+	"admin/internal/service/setting"
 	"admin/pkg/audit"
 	"admin/pkg/config"
+	"admin/pkg/utils/geoip"
 	"admin/pkg/utils/jwt"
+	"admin/pkg/utils/notify"
 	"admin/pkg/utils/rsapwd"
 
 	"github.com/redis/go-redis/v9"
@@ -15,26 +19,40 @@ import (
 type Service struct {
 	userRepo     *repository.UserRepo
 	userRoleRepo *repository.UserRoleRepo
+	memberRepo   *repository.TenantMemberRepo
 	roleRepo     *repository.RoleRepo
 	tenantRepo   *repository.TenantRepo
+	credRepo     *repository.ServiceAccountRepo
+	loginLogRepo *repository.LoginLogRepo
+	settingSvc   *setting.Service // 登录方式、会话超时等租户设置
+	permCache    *rbac.PermissionCache
 	jwt          *jwt.Manager
 	rdb          redis.UniversalClient
 	recorder     *audit.Recorder
 	config       *config.Config
 	rsaCipher    *rsapwd.RSACipher
+	geo          geoip.Resolver // IP 地理位置解析，为 nil 时不做国家/移动速度比对
+	notifier     notify.Sender  // 异常登录提醒与二次验证码发送
 }
 
 // NewService 创建认证服务
-func NewService(db *gorm.DB, jwtMgr *jwt.Manager, rdb redis.UniversalClient, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cfg *config.Config) *Service {
+func NewService(db *gorm.DB, jwtMgr *jwt.Manager, rdb redis.UniversalClient, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cfg *config.Config, geo geoip.Resolver, notifier notify.Sender, permCache *rbac.PermissionCache) *Service {
 	return &Service{
 		userRepo:     repository.NewUserRepo(db),
 		userRoleRepo: repository.NewUserRoleRepo(db),
+		memberRepo:   repository.NewTenantMemberRepo(db),
 		roleRepo:     repository.NewRoleRepo(db),
 		tenantRepo:   repository.NewTenantRepo(db),
+		credRepo:     repository.NewServiceAccountRepo(db),
+		loginLogRepo: repository.NewLoginLogRepo(db),
+		settingSvc:   setting.NewService(db, recorder),
+		permCache:    permCache,
 		jwt:          jwtMgr,
 		rdb:          rdb,
 		recorder:     recorder,
 		config:       cfg,
 		rsaCipher:    rsaCipher,
+		geo:          geo,
+		notifier:     notifier,
 	}
 }
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/jwt"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// defaultImpersonationMinutes 模拟登录默认有效时长（分钟）
+const defaultImpersonationMinutes = 30
+
+// Impersonate 模拟目标用户登录
+// 签发以目标用户为身份、act 声明记录当前操作者的限时令牌，不签发 refresh token。
+// 超管可模拟任意租户的用户；其他有权限的角色只能模拟本租户的非超管用户，且目标用户的权限不能超出自己（防止借模拟提权）。
+// 结束模拟：使用模拟令牌调用登出接口，或等待令牌过期
+func (s *Service) Impersonate(ctx context.Context, req *dto.ImpersonateRequest) (resp *dto.ImpersonateResponse, err error) {
+	var target *model.User
+	var targetName string // 被拒绝的模拟也记录目标用户名，便于审计
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithImpersonate(constants.ModuleAuth),
+				audit.WithResource(constants.ResourceTypeUser, req.UserID, targetName),
+				audit.WithValue(nil, map[string]interface{}{"reason": req.Reason}),
+				audit.WithError(err),
+			)
+		} else if target != nil {
+			s.recorder.Log(ctx,
+				audit.WithImpersonate(constants.ModuleAuth),
+				audit.WithResource(constants.ResourceTypeUser, target.UserID, target.UserName),
+				audit.WithValue(nil, map[string]interface{}{
+					"reason":     req.Reason,
+					"tenant_id":  target.TenantID,
+					"expires_in": resp.ExpiresIn,
+				}),
+			)
+		}
+	}()
+
+	operatorID := xcontext.GetUserID(ctx)
+	if operatorID == "" {
+		return nil, xerr.ErrUnauthorized
+	}
+	// 不允许嵌套模拟，也不允许访问令牌发起模拟
+	if xcontext.IsImpersonating(ctx) || xcontext.GetAccessKeyID(ctx) != "" {
+		return nil, xerr.ErrImpersonationForbidden
+	}
+	if req.UserID == operatorID {
+		return nil, xerr.Wrap(xerr.ErrInvalidParams.Code, "不能模拟自己", nil)
+	}
+
+	isSuperAdmin := xcontext.HasRole(ctx, constants.SuperAdmin)
+
+	var user *model.User
+	if isSuperAdmin {
+		user, err = s.userRepo.GetByIDManual(ctx, req.UserID)
+	} else {
+		user, err = s.userRepo.GetByID(ctx, req.UserID)
+	}
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("target_user_id", req.UserID).Msg("目标用户不存在")
+			return nil, xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("target_user_id", req.UserID).Msg("查询目标用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询目标用户失败", err)
+	}
+	targetName = user.UserName
+	if user.Status != constants.StatusEnabled {
+		return nil, xerr.ErrUserDisabled
+	}
+
+	tenant, err := s.tenantRepo.GetByIDManual(ctx, user.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", user.TenantID).Msg("查询租户信息失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户信息失败", err)
+	}
+	if tenant.Status != constants.StatusEnabled {
+		return nil, xerr.ErrTenantDisabled
+	}
+
+	roleIDs, err := s.userRoleRepo.GetUserRoleIDs(ctx, user.UserID, user.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("target_user_id", user.UserID).Msg("查询用户角色失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户角色失败", err)
+	}
+	if len(roleIDs) == 0 {
+		return nil, xerr.ErrUserNoRoles
+	}
+	roles, err := s.roleRepo.GetByIDs(ctx, roleIDs)
+	if err != nil {
+		log.Error().Err(err).Str("target_user_id", user.UserID).Msg("查询角色详情失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询角色详情失败", err)
+	}
+	roleCodes := make([]string, len(roles))
+	for i, role := range roles {
+		roleCodes[i] = role.RoleCode
+	}
+
+	// 只有超管可以模拟超管
+	if slices.Contains(roleCodes, constants.SuperAdmin) && !isSuperAdmin {
+		log.Warn().Str("operator_id", operatorID).Str("target_user_id", user.UserID).Msg("无权模拟超级管理员")
+		return nil, xerr.ErrImpersonationDenied
+	}
+	if !isSuperAdmin && !s.permCache.CoveredBy(roleIDs, xcontext.GetRoleIDs(ctx)) {
+		log.Warn().Str("operator_id", operatorID).Str("target_user_id", user.UserID).Msg("目标用户的权限超出操作者，无权模拟")
+		return nil, xerr.New(xerr.ErrImpersonationDenied.Code, "目标用户的权限超出当前用户，无权模拟")
+	}
+
+	minutes := req.DurationMinutes
+	if minutes == 0 {
+		minutes = defaultImpersonationMinutes
+	}
+	actor := &jwt.Actor{
+		TenantID: xcontext.GetTenantID(ctx),
+		UserID:   operatorID,
+		UserName: xcontext.GetUserName(ctx),
+	}
+
+	tokenPair, err := s.jwt.GenerateImpersonationToken(ctx, tenant.TenantID, tenant.TenantCode, user.UserID, user.UserName, roleCodes, roleIDs, actor, int64(minutes)*60)
+	if err != nil {
+		log.Error().Err(err).Str("target_user_id", user.UserID).Msg("生成模拟登录令牌失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成模拟登录令牌失败", err)
+	}
+
+	target = user
+	log.Info().
+		Str("operator_id", operatorID).
+		Str("target_user_id", user.UserID).
+		Str("target_tenant_id", user.TenantID).
+		Int("minutes", minutes).
+		Msg("模拟登录成功")
+
+	return &dto.ImpersonateResponse{
+		AccessToken: tokenPair.AccessToken,
+		ExpiresIn:   tokenPair.ExpiresIn,
+		UserID:      user.UserID,
+		UserName:    user.UserName,
+		TenantID:    user.TenantID,
+	}, nil
+}
@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"admin/internal/dal/model"
 	"admin/internal/dto"
+	tenantconv "admin/internal/service/tenant"
+	"admin/pkg/audit"
 	"admin/pkg/utils/captcha"
 	"admin/pkg/constants"
 	"admin/pkg/utils/passwordgen"
@@ -16,6 +19,8 @@ import (
 
 // Login 用户登录
 func (s *Service) Login(ctx context.Context, r *http.Request, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	ctx = authContext(ctx)
+
 	// 验证码校验
 	captchaMgr := captcha.NewManager(s.rdb)
 	if !captchaMgr.Verify(req.CaptchaID, req.Captcha) {
@@ -29,17 +34,29 @@ func (s *Service) Login(ctx context.Context, r *http.Request, req *dto.LoginRequ
 		return nil, xerr.Wrap(xerr.ErrInvalidCredentials.Code, "密码解密失败", err)
 	}
 
-	// 查询用户（通过邮箱全局唯一）
-	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	// 查询用户（已识别租户时限定在该租户内）
+	user, err := s.findUserByEmail(ctx, req.Email, decryptedPassword)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			log.Error().Err(err).Str("email", req.Email).Msg("用户不存在")
 			return nil, xerr.ErrUserNotFound
 		}
+		if err == xerr.ErrInvalidCredentials {
+			return nil, err
+		}
+		if err == xerr.ErrUserHasMultipleTenants {
+			log.Warn().Str("email", req.Email).Msg("邮箱存在于多个租户，需指定租户登录")
+			return nil, err
+		}
 		log.Error().Err(err).Str("email", req.Email).Msg("查询用户失败")
 		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
 	}
 
+	// 服务账号不允许交互式登录
+	if user.IsServiceAccount == int16(constants.True) {
+		return nil, xerr.ErrServiceAccountLogin
+	}
+
 	// 验证密码
 	if !passwordgen.VerifyPassword(decryptedPassword, user.Password) {
 		return nil, xerr.ErrInvalidCredentials
@@ -67,55 +84,51 @@ func (s *Service) Login(ctx context.Context, r *http.Request, req *dto.LoginRequ
 		return nil, xerr.ErrTenantDisabled
 	}
 
-	// 获取用户角色ID列表（从 user_roles 表）
-	roleIDs, err := s.userRoleRepo.GetUserRoleIDs(ctx, user.UserID, user.TenantID)
-	if err != nil {
-		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询用户角色失败")
-		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户角色失败", err)
+	// 检查租户订阅生命周期（宽限期允许登录，已过期/已归档拒绝）
+	if err := tenantconv.CheckLifecycle(tenant); err != nil {
+		log.Warn().Str("tenant_id", tenant.TenantID).Msg("用户所属租户订阅已过期")
+		return nil, err
 	}
 
-	if len(roleIDs) == 0 {
-		return nil, xerr.ErrUserNoRoles
+	// 检查租户是否启用邮箱登录
+	if err := s.checkLoginMethod(ctx, tenant.TenantID, loginMethodEmail); err != nil {
+		s.recorder.LoginEmail(ctx, tenant.TenantID, user.UserID, user.UserName, err)
+		return nil, err
 	}
 
-	// 获取角色详情（用于提取角色编码）
-	roles, err := s.roleRepo.GetByIDs(ctx, roleIDs)
+	// 获取用户角色
+	roleCodes, roleIDs, err := s.getUserRoles(ctx, user)
 	if err != nil {
-		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询角色详情失败")
-		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询角色详情失败", err)
+		return nil, err
 	}
 
-	roleCodes := make([]string, len(roles))
-	for i, role := range roles {
-		roleCodes[i] = role.RoleCode
+	// 登录风险评估
+	risk := s.evaluateLoginRisk(ctx, user, tenant)
+	switch risk.Action {
+	case constants.LoginRiskPolicyBlock:
+		s.recorder.LoginEmail(ctx, tenant.TenantID, user.UserID, user.UserName, xerr.ErrLoginBlocked, audit.WithLoginRisk(risk))
+		s.notifyLoginRisk(ctx, user, risk)
+		return nil, xerr.ErrLoginBlocked
+	case constants.LoginRiskPolicyMFA:
+		return s.startLoginMFA(ctx, user, constants.LoginTypeEmail, risk)
 	}
 
-	// 生成JWT令牌（包含角色编码和角色ID）
-	tokenPair, err := s.jwt.GenerateTokenPair(ctx, tenant.TenantID, tenant.TenantCode, user.UserID, user.UserName, roleCodes, roleIDs)
+	resp, err := s.issueLoginToken(ctx, user, tenant, roleCodes, roleIDs)
 	if err != nil {
-		log.Error().Err(err).Str("user_id", user.UserID).Msg("生成JWT令牌失败")
 		return nil, err
 	}
 
-	// 更新最后登录时间
-	if err := s.userRepo.UpdateManual(ctx, user.UserID, map[string]interface{}{
-		"last_login_time": time.Now().UnixMilli(),
-	}); err != nil {
-		log.Error().Err(err).Str("user_id", user.UserID).Msg("更新最后登录时间失败")
-	}
-
 	// 记录登录日志
-	s.recorder.LoginEmail(ctx, tenant.TenantID, user.UserID, user.UserName, nil)
+	s.recorder.LoginEmail(ctx, tenant.TenantID, user.UserID, user.UserName, nil, audit.WithLoginRisk(risk))
+	s.notifyLoginRisk(ctx, user, risk)
 
-	return &dto.LoginResponse{
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		ExpiresIn:    tokenPair.ExpiresIn,
-	}, nil
+	return resp, nil
 }
 
 // LoginByPhone 手机号登录
 func (s *Service) LoginByPhone(ctx context.Context, req *dto.PhoneLoginRequest) (*dto.LoginResponse, error) {
+	ctx = authContext(ctx)
+
 	// 解密前端传来的加密密码
 	decryptedPassword, err := s.rsaCipher.DecryptPKCS1(req.Password)
 	if err != nil {
@@ -123,8 +136,8 @@ func (s *Service) LoginByPhone(ctx context.Context, req *dto.PhoneLoginRequest)
 		return nil, xerr.Wrap(xerr.ErrInvalidCredentials.Code, "密码解密失败", err)
 	}
 
-	// 查询用户（通过手机号全局唯一）
-	user, err := s.userRepo.GetByPhone(ctx, req.Phone)
+	// 查询用户（通过手机号全局唯一，已识别租户时限定在该租户内）
+	user, err := s.findUserByPhone(ctx, req.Phone)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			log.Error().Err(err).Str("phone", req.Phone).Msg("用户不存在")
@@ -134,6 +147,11 @@ func (s *Service) LoginByPhone(ctx context.Context, req *dto.PhoneLoginRequest)
 		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
 	}
 
+	// 服务账号不允许交互式登录
+	if user.IsServiceAccount == int16(constants.True) {
+		return nil, xerr.ErrServiceAccountLogin
+	}
+
 	// 验证密码
 	if !passwordgen.VerifyPassword(decryptedPassword, user.Password) {
 		return nil, xerr.ErrInvalidCredentials
@@ -161,42 +179,92 @@ func (s *Service) LoginByPhone(ctx context.Context, req *dto.PhoneLoginRequest)
 		return nil, xerr.ErrTenantDisabled
 	}
 
+	// 检查租户订阅生命周期（宽限期允许登录，已过期/已归档拒绝）
+	if err := tenantconv.CheckLifecycle(tenant); err != nil {
+		log.Warn().Str("tenant_id", tenant.TenantID).Msg("用户所属租户订阅已过期")
+		return nil, err
+	}
+
+	// 检查租户是否启用手机号登录
+	if err := s.checkLoginMethod(ctx, tenant.TenantID, loginMethodPhone); err != nil {
+		s.recorder.LoginPhone(ctx, tenant.TenantID, user.UserID, user.UserName, err)
+		return nil, err
+	}
+
 	// 获取用户角色
+	roleCodes, roleIDs, err := s.getUserRoles(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// 登录风险评估
+	risk := s.evaluateLoginRisk(ctx, user, tenant)
+	switch risk.Action {
+	case constants.LoginRiskPolicyBlock:
+		s.recorder.LoginPhone(ctx, tenant.TenantID, user.UserID, user.UserName, xerr.ErrLoginBlocked, audit.WithLoginRisk(risk))
+		s.notifyLoginRisk(ctx, user, risk)
+		return nil, xerr.ErrLoginBlocked
+	case constants.LoginRiskPolicyMFA:
+		return s.startLoginMFA(ctx, user, constants.LoginTypePhone, risk)
+	}
+
+	resp, err := s.issueLoginToken(ctx, user, tenant, roleCodes, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recorder.LoginPhone(ctx, tenant.TenantID, user.UserID, user.UserName, nil, audit.WithLoginRisk(risk))
+	s.notifyLoginRisk(ctx, user, risk)
+
+	return resp, nil
+}
+
+// getUserRoles 获取用户在所属租户下的角色编码和角色ID
+func (s *Service) getUserRoles(ctx context.Context, user *model.User) ([]string, []string, error) {
+	// 获取用户角色ID列表（从 user_roles 表）
 	roleIDs, err := s.userRoleRepo.GetUserRoleIDs(ctx, user.UserID, user.TenantID)
 	if err != nil {
 		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询用户角色失败")
-		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户角色失败", err)
+		return nil, nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户角色失败", err)
 	}
 
 	if len(roleIDs) == 0 {
-		return nil, xerr.ErrUserNoRoles
+		return nil, nil, xerr.ErrUserNoRoles
 	}
 
+	// 获取角色详情（用于提取角色编码）
 	roles, err := s.roleRepo.GetByIDs(ctx, roleIDs)
 	if err != nil {
 		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询角色详情失败")
-		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询角色详情失败", err)
+		return nil, nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询角色详情失败", err)
 	}
 
 	roleCodes := make([]string, len(roles))
 	for i, role := range roles {
 		roleCodes[i] = role.RoleCode
 	}
+	return roleCodes, roleIDs, nil
+}
 
+// issueLoginToken 签发登录令牌并更新最后登录时间
+func (s *Service) issueLoginToken(ctx context.Context, user *model.User, tenant *model.Tenant, roleCodes, roleIDs []string) (*dto.LoginResponse, error) {
+	// 生成JWT令牌（包含角色编码和角色ID）
 	tokenPair, err := s.jwt.GenerateTokenPair(ctx, tenant.TenantID, tenant.TenantCode, user.UserID, user.UserName, roleCodes, roleIDs)
 	if err != nil {
 		log.Error().Err(err).Str("user_id", user.UserID).Msg("生成JWT令牌失败")
 		return nil, err
 	}
+	if err := s.startSession(ctx, tenant.TenantID, tokenPair.TokenID); err != nil {
+		return nil, err
+	}
 
+	// 更新最后登录时间
 	if err := s.userRepo.UpdateManual(ctx, user.UserID, map[string]interface{}{
 		"last_login_time": time.Now().UnixMilli(),
 	}); err != nil {
 		log.Error().Err(err).Str("user_id", user.UserID).Msg("更新最后登录时间失败")
 	}
 
-	s.recorder.LoginPhone(ctx, tenant.TenantID, user.UserID, user.UserName, nil)
-
 	return &dto.LoginResponse{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
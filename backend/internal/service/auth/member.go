@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// checkMembership 校验用户是否为目标租户的成员
+// 用户账号所在的主租户始终可访问，其他租户需有状态正常的成员身份
+func (s *Service) checkMembership(ctx context.Context, userID, tenantID string) error {
+	user, err := s.userRepo.GetByIDManual(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+	if user.TenantID == tenantID {
+		return nil
+	}
+
+	member, err := s.memberRepo.GetByTenantAndUserManual(ctx, tenantID, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("user_id", userID).Str("target_tenant_id", tenantID).Msg("用户不是该租户成员")
+			return xerr.ErrUserTenantAccessDenied
+		}
+		log.Error().Err(err).Str("user_id", userID).Str("target_tenant_id", tenantID).Msg("查询租户成员失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询租户成员失败", err)
+	}
+	if member.Status != int16(constants.StatusEnabled) {
+		log.Warn().Str("user_id", userID).Str("target_tenant_id", tenantID).Msg("用户在该租户的成员身份已禁用")
+		return xerr.ErrUserTenantAccessDenied
+	}
+	return nil
+}
+
+// memberTenantIDs 获取用户所属的租户ID列表，主租户在前
+// 成员身份分布在各个租户，查询标记为平台级操作，只按用户ID查找
+//
+//tenantscope:allow 用户查看自己在所有租户的成员身份
+func (s *Service) memberTenantIDs(ctx context.Context, userID string) ([]string, error) {
+	ctx = xcontext.SetPlatformScope(ctx)
+	user, err := s.userRepo.GetByIDManual(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户失败", err)
+	}
+
+	members, err := s.memberRepo.ListEnabledByUserManual(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户所属租户失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户所属租户失败", err)
+	}
+
+	tenantIDs := make([]string, 0, len(members)+1)
+	tenantIDs = append(tenantIDs, user.TenantID)
+	for _, member := range members {
+		if member.TenantID != user.TenantID {
+			tenantIDs = append(tenantIDs, member.TenantID)
+		}
+	}
+	return tenantIDs, nil
+}
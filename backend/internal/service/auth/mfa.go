@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	tenantconv "admin/internal/service/tenant"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/notify"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	loginMFAKeyPrefix         = "login_mfa:"          // 二次验证挑战 Redis key 前缀
+	loginMFAAttemptsKeyPrefix = "login_mfa_attempts:" // 二次验证失败次数 Redis key 前缀
+	loginMFACodeDigits        = 6                     // 验证码位数
+	loginMFAMaxAttempts       = 5                     // 单个挑战允许的最大失败次数
+)
+
+// loginMFAChallenge 登录二次验证挑战
+// 仅保存验证码哈希，通过后重新加载用户与租户状态再签发令牌
+type loginMFAChallenge struct {
+	UserID    string           `json:"user_id"`
+	TenantID  string           `json:"tenant_id"`
+	LoginType string           `json:"login_type"`
+	CodeHash  string           `json:"code_hash"`
+	Risk      *audit.LoginRisk `json:"risk"`
+}
+
+// startLoginMFA 创建登录二次验证挑战并向用户邮箱发送验证码
+func (s *Service) startLoginMFA(ctx context.Context, user *model.User, loginType string, risk *audit.LoginRisk) (*dto.LoginResponse, error) {
+	if user.Email == "" || s.notifier == nil {
+		// 无法送达验证码时按拦截处理，避免风险登录绕过二次验证
+		s.recordLogin(ctx, loginType, user, xerr.ErrLoginBlocked, risk)
+		return nil, xerr.ErrLoginBlocked
+	}
+
+	code, err := passwordgen.GenerateNumericCode(loginMFACodeDigits)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("生成二次验证码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成二次验证码失败", err)
+	}
+	challengeID, err := passwordgen.GenerateSecret(16)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("生成二次验证挑战失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成二次验证挑战失败", err)
+	}
+
+	data, err := json.Marshal(&loginMFAChallenge{
+		UserID:    user.UserID,
+		TenantID:  user.TenantID,
+		LoginType: loginType,
+		CodeHash:  passwordgen.HashSecret(code),
+		Risk:      risk,
+	})
+	if err != nil {
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "序列化二次验证挑战失败", err)
+	}
+
+	ttl := s.config.LoginRisk.GetMFACodeTTL()
+	if err := s.rdb.Set(ctx, loginMFAKeyPrefix+challengeID, data, ttl).Err(); err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("保存二次验证挑战失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "保存二次验证挑战失败", err)
+	}
+
+	msg := &notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      user.Email,
+		Subject: "登录验证码",
+		Content: fmt.Sprintf("检测到您的账号 %s 存在异常登录，验证码：%s，%d 分钟内有效。如非本人操作，请立即修改密码。",
+			user.UserName, code, int(ttl.Minutes())),
+	}
+	if err := s.notifier.Send(ctx, msg); err != nil {
+		s.rdb.Del(ctx, loginMFAKeyPrefix+challengeID)
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("发送二次验证码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "发送二次验证码失败", err)
+	}
+
+	return &dto.LoginResponse{
+		MFARequired: true,
+		ChallengeID: challengeID,
+		Message:     "检测到异常登录，验证码已发送至 " + maskEmail(user.Email),
+	}, nil
+}
+
+// VerifyLoginMFA 校验登录二次验证码并签发令牌
+func (s *Service) VerifyLoginMFA(ctx context.Context, req *dto.LoginMFARequest) (*dto.LoginResponse, error) {
+	ctx = authContext(ctx)
+	key := loginMFAKeyPrefix + req.ChallengeID
+	attemptsKey := loginMFAAttemptsKeyPrefix + req.ChallengeID
+
+	data, err := s.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, xerr.ErrMFAChallengeInvalid
+		}
+		log.Error().Err(err).Msg("查询二次验证挑战失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询二次验证挑战失败", err)
+	}
+
+	var challenge loginMFAChallenge
+	if err := json.Unmarshal(data, &challenge); err != nil {
+		s.rdb.Del(ctx, key)
+		return nil, xerr.ErrMFAChallengeInvalid
+	}
+
+	user, err := s.userRepo.GetByIDManual(ctx, challenge.UserID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			s.rdb.Del(ctx, key, attemptsKey)
+			return nil, xerr.ErrMFAChallengeInvalid
+		}
+		log.Error().Err(err).Str("user_id", challenge.UserID).Msg("查询用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+
+	if !passwordgen.VerifySecret(req.Code, challenge.CodeHash) {
+		attempts, err := s.rdb.Incr(ctx, attemptsKey).Result()
+		if err == nil && attempts == 1 {
+			s.rdb.Expire(ctx, attemptsKey, s.config.LoginRisk.GetMFACodeTTL())
+		}
+		if attempts >= loginMFAMaxAttempts {
+			s.rdb.Del(ctx, key, attemptsKey)
+		}
+		s.recordLogin(ctx, challenge.LoginType, user, xerr.ErrMFACodeInvalid, challenge.Risk)
+		return nil, xerr.ErrMFACodeInvalid
+	}
+
+	// 挑战一次性使用
+	s.rdb.Del(ctx, key, attemptsKey)
+
+	// 重新校验用户与租户状态，避免挑战期间被禁用
+	if user.Status != constants.StatusEnabled {
+		return nil, xerr.ErrUserDisabled
+	}
+
+	tenant, err := s.tenantRepo.GetByIDManual(ctx, user.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", user.TenantID).Msg("查询租户信息失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户信息失败", err)
+	}
+	if tenant.Status != constants.StatusEnabled {
+		return nil, xerr.ErrTenantDisabled
+	}
+	if err := tenantconv.CheckLifecycle(tenant); err != nil {
+		return nil, err
+	}
+
+	roleCodes, roleIDs, err := s.getUserRoles(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.issueLoginToken(ctx, user, tenant, roleCodes, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordLogin(ctx, challenge.LoginType, user, nil, challenge.Risk)
+	return resp, nil
+}
+
+// recordLogin 按登录方式记录登录日志
+func (s *Service) recordLogin(ctx context.Context, loginType string, user *model.User, err error, risk *audit.LoginRisk) {
+	if loginType == constants.LoginTypePhone {
+		s.recorder.LoginPhone(ctx, user.TenantID, user.UserID, user.UserName, err, audit.WithLoginRisk(risk))
+		return
+	}
+	s.recorder.LoginEmail(ctx, user.TenantID, user.UserID, user.UserName, err, audit.WithLoginRisk(risk))
+}
+
+// maskEmail 邮箱脱敏，如 alice@example.com -> a***@example.com
+func maskEmail(email string) string {
+	at := strings.Index(email, "@")
+	if at <= 0 {
+		return email
+	}
+	return email[:1] + "***" + email[at:]
+}
@@ -4,9 +4,12 @@ import (
 	"admin/internal/dto"
 	tenantconv "admin/internal/service/tenant"
 	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/jwt"
 	"admin/pkg/xcontext"
 	"admin/pkg/xerr"
 	"context"
+	"errors"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -16,6 +19,9 @@ import (
 func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*dto.RefreshResponse, error) {
 	tokenPair, err := s.jwt.VerifyRefreshToken(ctx, refreshToken)
 	if err != nil {
+		if errors.Is(err, jwt.ErrSessionIdle) {
+			return nil, xerr.ErrSessionIdleTimeout
+		}
 		log.Error().Err(err).Msg("刷新token失败")
 		return nil, xerr.Wrap(xerr.ErrTokenInvalid.Code, "刷新token失败", err)
 	}
@@ -27,6 +33,8 @@ func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*dto.R
 }
 
 // SwitchTenant 切换租户
+//
+//tenantscope:allow 成员身份校验通过后按目标租户查询角色
 func (s *Service) SwitchTenant(ctx context.Context, req *dto.SwitchTenantRequest) (*dto.LoginResponse, error) {
 	userID := xcontext.GetUserID(ctx)
 	currentTenantCode := xcontext.GetTenantCode(ctx)
@@ -38,6 +46,10 @@ func (s *Service) SwitchTenant(ctx context.Context, req *dto.SwitchTenantRequest
 	if userID == "" {
 		return nil, xerr.ErrUnauthorized
 	}
+	// 模拟登录令牌不能换取目标用户的正式令牌
+	if xcontext.IsImpersonating(ctx) {
+		return nil, xerr.ErrImpersonationForbidden
+	}
 
 	isSuperAdmin := xcontext.HasRole(ctx, constants.SuperAdmin)
 
@@ -56,32 +68,43 @@ func (s *Service) SwitchTenant(ctx context.Context, req *dto.SwitchTenantRequest
 		log.Error().Str("target_tenant_id", req.TenantID).Msg("目标租户已禁用")
 		return nil, xerr.ErrTenantNotFound
 	}
+	if err := tenantconv.CheckLifecycle(targetTenant); err != nil {
+		log.Warn().Str("target_tenant_id", req.TenantID).Msg("目标租户订阅已过期")
+		return nil, err
+	}
 
 	switch {
 	case isSuperAdmin:
 		// 超管可以切换到任意租户
 
 	default:
-		// 其他角色检查是否有该租户的 user_roles 记录
+		// 其他角色需为目标租户的成员
 		if currentTenantID == req.TenantID {
 			return nil, xerr.Wrap(xerr.ErrInvalidParams.Code, "已在当前租户", nil)
 		}
-
-		targetRoleIDs, err := s.userRoleRepo.GetUserRoleIDs(ctx, userID, req.TenantID)
-		if err != nil || len(targetRoleIDs) == 0 {
-			log.Error().Str("user_id", userID).Str("target_tenant_id", req.TenantID).Msg("用户无该租户访问权限")
-			return nil, xerr.ErrUserTenantAccessDenied
+		if err := s.checkMembership(ctx, userID, req.TenantID); err != nil {
+			return nil, err
 		}
 
-		// 获取目标租户的角色信息
-		targetRoles, err := s.roleRepo.GetByIDs(ctx, targetRoleIDs)
+		// 目标租户的角色对当前租户不可见，按目标租户查询
+		targetCtx := database.WithTenant(ctx, req.TenantID)
+		targetRoleIDs, err := s.userRoleRepo.GetUserRoleIDs(targetCtx, userID, req.TenantID)
 		if err != nil {
-			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色失败", err)
+			log.Error().Err(err).Str("user_id", userID).Str("target_tenant_id", req.TenantID).Msg("查询用户角色失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户角色失败", err)
 		}
+
+		// 获取目标租户的角色信息
 		roleIDs = targetRoleIDs
-		roleCodes = make([]string, len(targetRoles))
-		for i, role := range targetRoles {
-			roleCodes[i] = role.RoleCode
+		roleCodes = []string{}
+		if len(targetRoleIDs) > 0 {
+			targetRoles, err := s.roleRepo.GetByIDs(targetCtx, targetRoleIDs)
+			if err != nil {
+				return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色失败", err)
+			}
+			for _, role := range targetRoles {
+				roleCodes = append(roleCodes, role.RoleCode)
+			}
 		}
 	}
 
@@ -91,6 +114,9 @@ func (s *Service) SwitchTenant(ctx context.Context, req *dto.SwitchTenantRequest
 		log.Error().Err(err).Str("user_id", userID).Msg("生成JWT令牌失败")
 		return nil, err
 	}
+	if err := s.startSession(ctx, targetTenant.TenantID, tokenPair.TokenID); err != nil {
+		return nil, err
+	}
 
 	log.Info().Str("user_id", userID).Str("username", userName).Str("from_tenant", currentTenantCode).Str("to_tenant", targetTenant.TenantCode).Msg("用户切换租户成功")
 
@@ -119,14 +145,27 @@ func (s *Service) GetAvailableTenants(ctx context.Context) (*dto.AvailableTenant
 		return &dto.AvailableTenantsResponse{Tenants: tenants}, nil
 	}
 
-	tenantID := xcontext.GetTenantID(ctx)
-	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	// 主租户在前，其后为加入的其他租户
+	userID := xcontext.GetUserID(ctx)
+	tenantIDs, err := s.memberTenantIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tenantList, err := s.tenantRepo.GetByIDsManual(ctx, tenantIDs)
 	if err != nil {
-		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户信息失败")
-		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询租户信息失败", err)
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户所属租户失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户所属租户失败", err)
+	}
+	tenantMap := make(map[string]*dto.TenantInfo, len(tenantList))
+	for _, tenant := range tenantList {
+		tenantMap[tenant.TenantID] = tenantconv.ModelToTenantInfo(tenant)
 	}
 
-	return &dto.AvailableTenantsResponse{
-		Tenants: []*dto.TenantInfo{tenantconv.ModelToTenantInfo(tenant)},
-	}, nil
+	tenants := make([]*dto.TenantInfo, 0, len(tenantIDs))
+	for _, id := range tenantIDs {
+		if info, ok := tenantMap[id]; ok {
+			tenants = append(tenants, info)
+		}
+	}
+	return &dto.AvailableTenantsResponse{Tenants: tenants}, nil
 }
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// authContext 认证前流程的上下文：尚无当前租户，按邮箱、手机号、客户端ID查找账号需要访问所有租户的数据，
+// 标记为平台级操作；租户条件仍由查询显式指定（已识别租户或账号所属租户）
+//
+//tenantscope:allow 认证前按登录凭据查找账号，此时还没有当前租户
+func authContext(ctx context.Context) context.Context {
+	return xcontext.SetPlatformScope(ctx)
+}
+
+// findUserByEmail 按邮箱查找登录用户
+// 已识别租户（子域名/自定义域名/X-Tenant-Code）时只在该租户内查找；
+// 未识别租户时跨租户查找，邮箱存在于多个共享邮箱租户时只保留密码匹配的账号，
+// 避免未验证密码就暴露邮箱在哪些租户存在；仍有多个账号匹配时才要求指定租户
+func (s *Service) findUserByEmail(ctx context.Context, email, password string) (*model.User, error) {
+	if tenantID := xcontext.GetResolvedTenantID(ctx); tenantID != "" {
+		return s.userRepo.GetByTenantAndEmailManual(ctx, tenantID, email)
+	}
+
+	users, err := s.userRepo.ListByEmailManual(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	switch len(users) {
+	case 0:
+		return nil, gorm.ErrRecordNotFound
+	case 1:
+		return users[0], nil
+	}
+
+	var matched []*model.User
+	for _, user := range users {
+		if passwordgen.VerifyPassword(password, user.Password) {
+			matched = append(matched, user)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return nil, xerr.ErrInvalidCredentials
+	case 1:
+		return matched[0], nil
+	default:
+		return nil, xerr.ErrUserHasMultipleTenants
+	}
+}
+
+// findUserByPhone 按手机号查找登录用户，已识别租户时只匹配该租户的用户
+func (s *Service) findUserByPhone(ctx context.Context, phone string) (*model.User, error) {
+	user, err := s.userRepo.GetByPhone(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	if tenantID := xcontext.GetResolvedTenantID(ctx); tenantID != "" && user.TenantID != tenantID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/geoip"
+	"admin/pkg/utils/notify"
+	"admin/pkg/utils/useragent"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// loginRiskWeights 风险标记对应的评分权重
+var loginRiskWeights = map[string]int{
+	constants.LoginRiskNewDevice:        30,
+	constants.LoginRiskNewCountry:       40,
+	constants.LoginRiskImpossibleTravel: 60,
+}
+
+// maxLoginRiskScore 风险评分上限
+const maxLoginRiskScore = 100
+
+// impossibleTravelMinDistance 触发不可能的旅行检测的最小距离（公里）
+// 城市级 GeoIP 定位存在误差，近距离跳变不参与速度判断
+const impossibleTravelMinDistance = 500.0
+
+// evaluateLoginRisk 评估本次登录风险
+// 与用户最近的成功登录记录比对设备指纹、国家和移动速度，无历史记录（首次登录）时不标记风险
+// 评分达到阈值时按租户登录风险策略给出处置动作，评估过程出错不阻断登录
+func (s *Service) evaluateLoginRisk(ctx context.Context, user *model.User, tenant *model.Tenant) *audit.LoginRisk {
+	risk := &audit.LoginRisk{Action: constants.LoginRiskPolicyAllow}
+	cfg := s.config.LoginRisk
+	if !cfg.Enabled {
+		return risk
+	}
+
+	if clientInfo := audit.GetClientInfo(ctx); clientInfo != nil {
+		risk.DeviceFingerprint = useragent.Fingerprint(useragent.ParseUserAgent(clientInfo.UserAgent))
+		if s.geo != nil {
+			if loc, err := s.geo.Lookup(clientInfo.IP); err == nil {
+				risk.CountryCode = loc.CountryCode
+				risk.Latitude = loc.Latitude
+				risk.Longitude = loc.Longitude
+			}
+		}
+	}
+
+	history, err := s.loginLogRepo.ListRecentSuccessManual(ctx, user.UserID, cfg.HistorySize)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", user.UserID).Msg("查询历史登录记录失败，跳过登录风险评估")
+		return risk
+	}
+
+	risk.Flags = detectLoginRisk(risk, history, time.Now().UnixMilli(), cfg.MaxTravelSpeed)
+	for _, flag := range risk.Flags {
+		risk.Score += loginRiskWeights[flag]
+	}
+	if risk.Score > maxLoginRiskScore {
+		risk.Score = maxLoginRiskScore
+	}
+
+	if len(risk.Flags) > 0 && risk.Score >= cfg.Threshold && tenant.LoginRiskPolicy != "" {
+		risk.Action = tenant.LoginRiskPolicy
+	}
+	return risk
+}
+
+// detectLoginRisk 比对历史登录记录，返回命中的风险标记
+// history 需按登录时间倒序排列
+func detectLoginRisk(current *audit.LoginRisk, history []*model.LoginLog, now int64, maxTravelSpeed float64) []string {
+	var (
+		flags           []string
+		hasDeviceRecord bool
+		knownDevice     bool
+		hasCountry      bool
+		knownCountry    bool
+		lastLocated     *model.LoginLog
+	)
+	for _, h := range history {
+		if h.DeviceFingerprint != "" {
+			hasDeviceRecord = true
+			if h.DeviceFingerprint == current.DeviceFingerprint {
+				knownDevice = true
+			}
+		}
+		if h.CountryCode != "" {
+			hasCountry = true
+			if h.CountryCode == current.CountryCode {
+				knownCountry = true
+			}
+		}
+		if lastLocated == nil && (h.Latitude != 0 || h.Longitude != 0) {
+			lastLocated = h
+		}
+	}
+
+	// 历史记录中没有指纹/国家信息时（如升级前的旧日志）不作为比对基准
+	if current.DeviceFingerprint != "" && hasDeviceRecord && !knownDevice {
+		flags = append(flags, constants.LoginRiskNewDevice)
+	}
+	if current.CountryCode != "" && hasCountry && !knownCountry {
+		flags = append(flags, constants.LoginRiskNewCountry)
+	}
+
+	if lastLocated != nil && (current.Latitude != 0 || current.Longitude != 0) {
+		distance := geoip.Distance(lastLocated.Latitude, lastLocated.Longitude, current.Latitude, current.Longitude)
+		hours := float64(now-lastLocated.CreatedAt) / float64(time.Hour.Milliseconds())
+		if distance >= impossibleTravelMinDistance && (hours <= 0 || distance/hours > maxTravelSpeed) {
+			flags = append(flags, constants.LoginRiskImpossibleTravel)
+		}
+	}
+	return flags
+}
+
+// notifyLoginRisk 向用户发送异常登录提醒
+// 异步发送，通知失败仅记录日志
+func (s *Service) notifyLoginRisk(ctx context.Context, user *model.User, risk *audit.LoginRisk) {
+	if s.notifier == nil || risk == nil || len(risk.Flags) == 0 || user.Email == "" {
+		return
+	}
+
+	var location string
+	if clientInfo := audit.GetClientInfo(ctx); clientInfo != nil {
+		location = clientInfo.IP
+	}
+	if risk.CountryCode != "" {
+		location = fmt.Sprintf("%s (%s)", location, risk.CountryCode)
+	}
+
+	var subject string
+	switch risk.Action {
+	case constants.LoginRiskPolicyBlock:
+		subject = "异常登录已被拦截"
+	case constants.LoginRiskPolicyMFA:
+		subject = "异常登录需要二次验证"
+	default:
+		subject = "账号异常登录提醒"
+	}
+
+	msg := &notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      user.Email,
+		Subject: subject,
+		Content: fmt.Sprintf("您的账号 %s 于 %s 在 %s 登录，风险标记：%s。如非本人操作，请立即修改密码。",
+			user.UserName, time.Now().Format(time.DateTime), location, strings.Join(risk.Flags, ",")),
+	}
+	go func() {
+		if err := s.notifier.Send(context.Background(), msg); err != nil {
+			log.Warn().Err(err).Str("user_id", user.UserID).Msg("发送异常登录提醒失败")
+		}
+	}()
+}
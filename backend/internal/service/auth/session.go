@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"admin/internal/service/setting"
+	"admin/pkg/xerr"
+	"context"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+// 登录方式，对应设置项 security.login_methods 的可选值
+const (
+	loginMethodEmail = "email"
+	loginMethodPhone = "phone"
+)
+
+// checkLoginMethod 校验租户是否启用了该登录方式
+func (s *Service) checkLoginMethod(ctx context.Context, tenantID, method string) error {
+	value, err := s.settingSvc.GetTenantValue(ctx, tenantID, setting.KeyLoginMethods)
+	if err != nil {
+		return err
+	}
+	methods, _ := value.([]string)
+	if !slices.Contains(methods, method) {
+		log.Warn().Str("tenant_id", tenantID).Str("method", method).Msg("租户未启用该登录方式")
+		return xerr.ErrLoginMethodDisabled
+	}
+	return nil
+}
+
+// startSession 按租户的会话超时设置为新签发的会话开启空闲检查
+// 超时时长在会话开始时确定并随刷新延续，修改设置后对新登录的会话生效
+func (s *Service) startSession(ctx context.Context, tenantID, tokenID string) error {
+	value, err := s.settingSvc.GetTenantValue(ctx, tenantID, setting.KeySessionTimeout)
+	if err != nil {
+		return err
+	}
+	minutes, _ := value.(int64)
+	if err := s.jwt.StartSession(ctx, tokenID, minutes*60); err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("开启会话空闲检查失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "开启会话失败", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"admin/internal/dto"
+	tenantconv "admin/internal/service/tenant"
+	"admin/pkg/constants"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// GrantTypeClientCredentials OAuth2 client_credentials 授权类型
+const GrantTypeClientCredentials = "client_credentials"
+
+// ClientCredentials 服务账号使用 client_id/client_secret 换取访问令牌（OAuth2 client_credentials）
+// 只签发 access token，不签发 refresh token，过期后重新换取
+func (s *Service) ClientCredentials(ctx context.Context, req *dto.ClientCredentialsRequest) (resp *dto.ClientCredentialsResponse, err error) {
+	var tenantID, userID, userName string
+	ctx = authContext(ctx)
+
+	defer func() {
+		if userID != "" {
+			s.recorder.LoginClientCredentials(ctx, tenantID, userID, userName, err)
+		}
+	}()
+
+	if req.GrantType != GrantTypeClientCredentials {
+		return nil, xerr.ErrUnsupportedGrantType
+	}
+	if req.ClientID == "" || req.ClientSecret == "" {
+		return nil, xerr.ErrInvalidClient
+	}
+
+	cred, err := s.credRepo.GetByClientIDManual(ctx, req.ClientID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("client_id", req.ClientID).Msg("客户端不存在")
+			return nil, xerr.ErrInvalidClient
+		}
+		log.Error().Err(err).Str("client_id", req.ClientID).Msg("查询客户端凭证失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询客户端凭证失败", err)
+	}
+
+	if !passwordgen.VerifySecret(req.ClientSecret, cred.SecretHash) {
+		log.Warn().Str("client_id", req.ClientID).Msg("客户端密钥错误")
+		return nil, xerr.ErrInvalidClient
+	}
+
+	user, err := s.userRepo.GetByIDManual(ctx, cred.UserID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("client_id", req.ClientID).Str("user_id", cred.UserID).Msg("服务账号不存在")
+			return nil, xerr.ErrInvalidClient
+		}
+		log.Error().Err(err).Str("user_id", cred.UserID).Msg("查询服务账号失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询服务账号失败", err)
+	}
+	tenantID, userID, userName = user.TenantID, user.UserID, user.UserName
+
+	if user.IsServiceAccount != int16(constants.True) {
+		return nil, xerr.ErrInvalidClient
+	}
+	if user.Status != constants.StatusEnabled {
+		return nil, xerr.ErrUserDisabled
+	}
+
+	tenant, err := s.tenantRepo.GetByIDManual(ctx, user.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", user.TenantID).Msg("查询租户信息失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户信息失败", err)
+	}
+	if tenant.Status != constants.StatusEnabled {
+		return nil, xerr.ErrTenantDisabled
+	}
+	if err := tenantconv.CheckLifecycle(tenant); err != nil {
+		return nil, err
+	}
+
+	roleIDs, err := s.userRoleRepo.GetUserRoleIDs(ctx, user.UserID, user.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询用户角色失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询用户角色失败", err)
+	}
+	if len(roleIDs) == 0 {
+		return nil, xerr.ErrUserNoRoles
+	}
+
+	roles, err := s.roleRepo.GetByIDs(ctx, roleIDs)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询角色详情失败")
+		return nil, xerr.Wrap(xerr.ErrQueryError.Code, "查询角色详情失败", err)
+	}
+	roleCodes := make([]string, len(roles))
+	for i, role := range roles {
+		roleCodes[i] = role.RoleCode
+	}
+
+	tokenPair, err := s.jwt.GenerateAccessToken(ctx, tenant.TenantID, tenant.TenantCode, user.UserID, user.UserName, roleCodes, roleIDs)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("生成访问令牌失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成访问令牌失败", err)
+	}
+
+	if err := s.credRepo.UpdateManual(ctx, user.UserID, map[string]interface{}{
+		"last_used_at": time.Now().UnixMilli(),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("更新服务账号最后使用时间失败")
+	}
+
+	return &dto.ClientCredentialsResponse{
+		AccessToken: tokenPair.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   tokenPair.ExpiresIn,
+	}, nil
+}
@@ -38,6 +38,11 @@ func (s *Service) CreateDepartment(ctx context.Context, req *dto.CreateDepartmen
 		return nil, xerr.ErrUnauthorized
 	}
 
+	// 检查租户部门数配额
+	if err = s.quotaSvc.Check(ctx, tenantID, constants.QuotaDepartments, 1); err != nil {
+		return nil, err
+	}
+
 	// 如果指定了父部门，验证父部门是否存在
 	if req.ParentID != "" {
 		parentDept, err := s.deptRepo.GetByID(ctx, req.ParentID)
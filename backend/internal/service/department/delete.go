@@ -70,6 +70,7 @@ func (s *Service) DeleteDepartment(ctx context.Context, departmentID string) (er
 		log.Error().Err(err).Str("department_id", departmentID).Str("department_name", dept.DepartmentName).Msg("删除部门失败")
 		return xerr.Wrap(xerr.ErrInternal.Code, "删除部门失败", err)
 	}
+	s.removeLeaders(ctx, []string{departmentID})
 
 	return nil
 }
@@ -153,6 +154,14 @@ func (s *Service) BatchDeleteDepartments(ctx context.Context, departmentIDs []st
 		log.Error().Err(err).Strs("department_ids", departmentIDs).Msg("批量删除部门失败")
 		return xerr.Wrap(xerr.ErrInternal.Code, "批量删除部门失败", err)
 	}
+	s.removeLeaders(ctx, departmentIDs)
 
 	return nil
 }
+
+// removeLeaders 清除已删除部门的负责人，失败只记录日志（已删除部门的负责人不会再被读取）
+func (s *Service) removeLeaders(ctx context.Context, departmentIDs []string) {
+	if err := s.leaderRepo.DeleteByDepartments(ctx, departmentIDs); err != nil {
+		log.Warn().Err(err).Strs("department_ids", departmentIDs).Msg("清除部门负责人失败")
+	}
+}
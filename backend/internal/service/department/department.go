@@ -2,6 +2,7 @@ package department
 
 import (
 	"admin/internal/repository"
+	"admin/internal/service/quota"
 	"admin/pkg/audit"
 
 	"gorm.io/gorm"
@@ -9,16 +10,26 @@ import (
 
 // Service 部门服务
 type Service struct {
-	deptRepo *repository.DepartmentRepo
-	userRepo *repository.UserRepo
-	recorder *audit.Recorder
+	db           *gorm.DB
+	deptRepo     *repository.DepartmentRepo
+	leaderRepo   *repository.DepartmentLeaderRepo
+	userRepo     *repository.UserRepo
+	memberRepo   *repository.TenantMemberRepo
+	positionRepo *repository.PositionRepo
+	quotaSvc     *quota.Service
+	recorder     *audit.Recorder
 }
 
 // NewService 创建部门服务
 func NewService(db *gorm.DB, recorder *audit.Recorder) *Service {
 	return &Service{
-		deptRepo: repository.NewDepartmentRepo(db),
-		userRepo: repository.NewUserRepo(db),
-		recorder: recorder,
+		db:           db,
+		deptRepo:     repository.NewDepartmentRepo(db),
+		leaderRepo:   repository.NewDepartmentLeaderRepo(db),
+		userRepo:     repository.NewUserRepo(db),
+		memberRepo:   repository.NewTenantMemberRepo(db),
+		positionRepo: repository.NewPositionRepo(db),
+		quotaSvc:     quota.NewService(db),
+		recorder:     recorder,
 	}
 }
@@ -0,0 +1,35 @@
+package department
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CountDepartments 按部门列表的筛选条件统计部门数（导出时用于判断同步或异步）
+func (s *Service) CountDepartments(ctx context.Context, req *dto.ListDepartmentsRequest) (int64, error) {
+	total, err := s.deptRepo.CountWithFilters(ctx, req.DepartmentName, req.Status, req.ParentID)
+	if err != nil {
+		log.Error().Err(err).Str("department_name", req.DepartmentName).Msg("统计部门数量失败")
+		return 0, xerr.Wrap(xerr.ErrInternal.Code, "统计部门数量失败", err)
+	}
+	return total, nil
+}
+
+// StreamDepartments 按部门列表的筛选条件分批读取部门（用于导出）
+func (s *Service) StreamDepartments(ctx context.Context, req *dto.ListDepartmentsRequest, batchSize int, fn func([]*dto.DepartmentInfo) error) error {
+	err := s.deptRepo.StreamWithFilters(ctx, req.DepartmentName, req.Status, req.ParentID, batchSize, func(depts []*model.Department) error {
+		return fn(modelListToDepartmentInfoList(depts))
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		log.Error().Err(err).Str("department_name", req.DepartmentName).Msg("读取导出部门数据失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "读取导出部门数据失败", err)
+	}
+	return nil
+}
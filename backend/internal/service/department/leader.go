@@ -0,0 +1,233 @@
+package department
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// orgUser 用户在当前租户的组织信息
+// 从其他租户加入的成员，部门、岗位和状态取成员身份上的值
+type orgUser struct {
+	user         *model.User
+	departmentID string
+	position     *model.Position // 主岗位，未设置时为空
+	status       int16
+}
+
+// level 主岗位职级，未设置岗位时为 0
+func (u *orgUser) level() int32 {
+	if u.position == nil {
+		return 0
+	}
+	return u.position.Level
+}
+
+// toLeaderInfo 转换为负责人信息
+func (u *orgUser) toLeaderInfo() *dto.DepartmentLeaderInfo {
+	info := &dto.DepartmentLeaderInfo{
+		UserID:       u.user.UserID,
+		UserName:     u.user.UserName,
+		Nickname:     u.user.Nickname,
+		Avatar:       u.user.Avatar,
+		DepartmentID: u.departmentID,
+	}
+	if u.position != nil {
+		info.PositionID = u.position.PositionID
+		info.PositionName = u.position.PositionName
+		info.Level = int(u.position.Level)
+	}
+	return info
+}
+
+// loadOrgUsers 批量加载当前租户用户（含从其他租户加入的成员）的组织信息，不存在的用户不在结果中
+func (s *Service) loadOrgUsers(ctx context.Context, userIDs []string) (map[string]*orgUser, error) {
+	result := make(map[string]*orgUser, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		result[user.UserID] = &orgUser{user: user, departmentID: user.DepartmentID, status: user.Status}
+	}
+
+	var missing []string
+	for _, id := range userIDs {
+		if _, ok := result[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		members, err := s.memberRepo.ListByUsers(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) > 0 {
+			memberUserIDs := make([]string, len(members))
+			for i, member := range members {
+				memberUserIDs[i] = member.UserID
+			}
+			accounts, err := s.userRepo.ListByIDsManual(ctx, memberUserIDs)
+			if err != nil {
+				return nil, err
+			}
+			accountMap := make(map[string]*model.User, len(accounts))
+			for _, account := range accounts {
+				accountMap[account.UserID] = account
+			}
+			for _, member := range members {
+				account, ok := accountMap[member.UserID]
+				if !ok {
+					continue
+				}
+				account.PositionID = member.PositionID
+				result[member.UserID] = &orgUser{user: account, departmentID: member.DepartmentID, status: member.Status}
+			}
+		}
+	}
+
+	var positionIDs []string
+	for _, u := range result {
+		if u.user.PositionID != "" {
+			positionIDs = append(positionIDs, u.user.PositionID)
+		}
+	}
+	if len(positionIDs) > 0 {
+		positions, err := s.positionRepo.ListByIDs(ctx, positionIDs)
+		if err != nil {
+			return nil, err
+		}
+		positionMap := make(map[string]*model.Position, len(positions))
+		for _, position := range positions {
+			positionMap[position.PositionID] = position
+		}
+		for _, u := range result {
+			u.position = positionMap[u.user.PositionID]
+		}
+	}
+	return result, nil
+}
+
+// GetDepartmentLeaders 获取部门负责人
+func (s *Service) GetDepartmentLeaders(ctx context.Context, departmentID string) (*dto.DepartmentLeadersResponse, error) {
+	if _, err := s.deptRepo.GetByID(ctx, departmentID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrDeptNotFound
+		}
+		log.Error().Err(err).Str("department_id", departmentID).Msg("查询部门失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门失败", err)
+	}
+
+	leaders, err := s.leaderRepo.ListByDepartment(ctx, departmentID)
+	if err != nil {
+		log.Error().Err(err).Str("department_id", departmentID).Msg("查询部门负责人失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门负责人失败", err)
+	}
+	userIDs := make([]string, len(leaders))
+	for i, leader := range leaders {
+		userIDs[i] = leader.UserID
+	}
+	users, err := s.loadOrgUsers(ctx, userIDs)
+	if err != nil {
+		log.Error().Err(err).Str("department_id", departmentID).Msg("查询部门负责人失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门负责人失败", err)
+	}
+
+	list := make([]*dto.DepartmentLeaderInfo, 0, len(userIDs))
+	for _, id := range userIDs {
+		// 已删除或已移出租户的用户不再显示
+		if u, ok := users[id]; ok {
+			list = append(list, u.toLeaderInfo())
+		}
+	}
+	return &dto.DepartmentLeadersResponse{
+		DepartmentID: departmentID,
+		Leaders:      list,
+	}, nil
+}
+
+// SetDepartmentLeaders 设置部门负责人（覆盖式），负责人须为当前租户的用户或成员，可以不在本部门
+func (s *Service) SetDepartmentLeaders(ctx context.Context, req *dto.SetDepartmentLeadersRequest) (err error) {
+	var department *model.Department
+	var oldUserIDs []string
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleDepartment),
+				audit.WithOperation("设置部门负责人"),
+				audit.WithError(err),
+			)
+		} else if department != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleDepartment),
+				audit.WithOperation("设置部门负责人"),
+				audit.WithResource(constants.ResourceTypeDepartment, department.DepartmentID, department.DepartmentName),
+				audit.WithValue(map[string]interface{}{"leader_ids": oldUserIDs}, map[string]interface{}{"leader_ids": req.UserIDs}),
+			)
+			log.Info().Str("department_id", department.DepartmentID).Strs("leader_ids", req.UserIDs).Msg("设置部门负责人成功")
+		}
+	}()
+
+	department, err = s.deptRepo.GetByID(ctx, req.DepartmentID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("department_id", req.DepartmentID).Msg("部门不存在")
+			return xerr.ErrDeptNotFound
+		}
+		log.Error().Err(err).Str("department_id", req.DepartmentID).Msg("查询部门失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询部门失败", err)
+	}
+
+	// 去重，保留首次出现的顺序
+	userIDs := make([]string, 0, len(req.UserIDs))
+	seen := make(map[string]bool, len(req.UserIDs))
+	for _, id := range req.UserIDs {
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+	req.UserIDs = userIDs
+
+	users, err := s.loadOrgUsers(ctx, userIDs)
+	if err != nil {
+		log.Error().Err(err).Strs("user_ids", userIDs).Msg("查询用户失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+	if len(users) != len(userIDs) {
+		log.Warn().Strs("user_ids", userIDs).Msg("部分负责人不是当前租户的用户")
+		return xerr.New(xerr.ErrUserNotFound.Code, "部分负责人不存在")
+	}
+
+	leaders, err := s.leaderRepo.ListByDepartment(ctx, department.DepartmentID)
+	if err != nil {
+		log.Error().Err(err).Str("department_id", department.DepartmentID).Msg("查询部门负责人失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询部门负责人失败", err)
+	}
+	oldUserIDs = make([]string, len(leaders))
+	for i, leader := range leaders {
+		oldUserIDs[i] = leader.UserID
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		return repository.NewDepartmentLeaderRepo(tx.DB).ReplaceByDepartment(ctx, department.DepartmentID, userIDs)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("department_id", department.DepartmentID).Msg("设置部门负责人失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "设置部门负责人失败", err)
+	}
+	return nil
+}
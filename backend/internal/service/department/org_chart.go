@@ -0,0 +1,171 @@
+package department
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/constants"
+	"admin/pkg/xerr"
+	"context"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetOrgChart 获取组织架构图：部门树附带各部门人数和负责人
+// 人数包含从其他租户加入的成员，子部门人数累加到上级部门的 total_head_count
+func (s *Service) GetOrgChart(ctx context.Context) (*dto.OrgChartResponse, error) {
+	tree, err := s.GetDepartmentTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userCounts, err := s.userRepo.CountByDepartments(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("统计部门人数失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "统计部门人数失败", err)
+	}
+	memberCounts, err := s.memberRepo.CountByDepartments(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("统计部门成员数失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "统计部门成员数失败", err)
+	}
+
+	leaders, err := s.leaderRepo.ListAll(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("查询部门负责人失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门负责人失败", err)
+	}
+	leaderIDs := make(map[string][]string)
+	var userIDs []string
+	seen := make(map[string]bool)
+	for _, leader := range leaders {
+		leaderIDs[leader.DepartmentID] = append(leaderIDs[leader.DepartmentID], leader.UserID)
+		if !seen[leader.UserID] {
+			seen[leader.UserID] = true
+			userIDs = append(userIDs, leader.UserID)
+		}
+	}
+	users, err := s.loadOrgUsers(ctx, userIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("查询部门负责人失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门负责人失败", err)
+	}
+
+	var build func(nodes []*dto.DepartmentTreeNode) []*dto.OrgChartNode
+	build = func(nodes []*dto.DepartmentTreeNode) []*dto.OrgChartNode {
+		if len(nodes) == 0 {
+			return nil
+		}
+		chart := make([]*dto.OrgChartNode, len(nodes))
+		for i, node := range nodes {
+			id := node.DepartmentID
+			item := &dto.OrgChartNode{
+				DepartmentInfo: node.DepartmentInfo,
+				HeadCount:      userCounts[id] + memberCounts[id],
+				Leaders:        make([]*dto.DepartmentLeaderInfo, 0, len(leaderIDs[id])),
+				Children:       build(node.Children),
+			}
+			item.TotalHeadCount = item.HeadCount
+			for _, child := range item.Children {
+				item.TotalHeadCount += child.TotalHeadCount
+			}
+			for _, userID := range leaderIDs[id] {
+				if u, ok := users[userID]; ok {
+					item.Leaders = append(item.Leaders, u.toLeaderInfo())
+				}
+			}
+			chart[i] = item
+		}
+		return chart
+	}
+
+	return &dto.OrgChartResponse{
+		Tree: build(tree.Tree),
+	}, nil
+}
+
+// GetManager 解析用户的直属上级
+// 从用户所在部门开始沿部门树向上查找负责人：跳过用户本人担任负责人的部门（同部门的其他负责人视为同级），
+// 负责人和用户都设置了岗位职级时只取职级高于用户的负责人；按负责人排序取第一位符合条件且状态正常的负责人。
+// 未设置部门或一直到根部门都没有符合条件的负责人时返回空上级。审批、通知等功能可直接调用本方法
+func (s *Service) GetManager(ctx context.Context, userID string) (*dto.UserManagerResponse, error) {
+	subjects, err := s.loadOrgUsers(ctx, []string{userID})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+	subject, ok := subjects[userID]
+	if !ok {
+		return nil, xerr.ErrUserNotFound
+	}
+
+	resp := &dto.UserManagerResponse{UserID: userID}
+	if subject.departmentID == "" {
+		return resp, nil
+	}
+
+	// 用户所在部门到根部门的链路
+	depts, err := s.deptRepo.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("查询部门列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门列表失败", err)
+	}
+	deptMap := make(map[string]*model.Department, len(depts))
+	for _, dept := range depts {
+		deptMap[dept.DepartmentID] = dept
+	}
+	var chain []string
+	visited := make(map[string]bool)
+	for id := subject.departmentID; id != "" && !visited[id]; {
+		dept, ok := deptMap[id]
+		if !ok {
+			break
+		}
+		visited[id] = true
+		chain = append(chain, id)
+		id = dept.ParentID
+	}
+	if len(chain) == 0 {
+		return resp, nil
+	}
+
+	leaders, err := s.leaderRepo.ListAll(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("查询部门负责人失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门负责人失败", err)
+	}
+	leaderIDs := make(map[string][]string)
+	var candidateIDs []string
+	for _, leader := range leaders {
+		if visited[leader.DepartmentID] {
+			leaderIDs[leader.DepartmentID] = append(leaderIDs[leader.DepartmentID], leader.UserID)
+			candidateIDs = append(candidateIDs, leader.UserID)
+		}
+	}
+	candidates, err := s.loadOrgUsers(ctx, candidateIDs)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("查询部门负责人失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门负责人失败", err)
+	}
+
+	subjectLevel := subject.level()
+	for _, deptID := range chain {
+		ids := leaderIDs[deptID]
+		if slices.Contains(ids, userID) {
+			continue
+		}
+		for _, id := range ids {
+			leader, ok := candidates[id]
+			if !ok || leader.status != int16(constants.StatusEnabled) {
+				continue
+			}
+			if subjectLevel > 0 && leader.level() > 0 && leader.level() <= subjectLevel {
+				continue
+			}
+			resp.DepartmentID = deptID
+			resp.Manager = leader.toLeaderInfo()
+			return resp, nil
+		}
+	}
+	return resp, nil
+}
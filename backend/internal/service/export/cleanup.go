@@ -0,0 +1,102 @@
+package export
+
+import (
+	"admin/internal/repository"
+	"admin/pkg/config"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// cleanupBatchSize 每批清理的过期任务数
+const cleanupBatchSize = 500
+
+// CleanupManager 过期导出文件清理
+// 由定时任务执行：删除过期任务的文件和记录，并清理没有任务记录的残留文件（如租户已被彻底清除、写入中断的临时文件）
+type CleanupManager struct {
+	exportRepo *repository.ExportJobRepo
+	cfg        config.ExportConfig
+}
+
+// NewCleanupManager 创建过期导出文件清理管理器
+func NewCleanupManager(db *gorm.DB, cfg config.ExportConfig) *CleanupManager {
+	return &CleanupManager{
+		exportRepo: repository.NewExportJobRepo(db),
+		cfg:        cfg,
+	}
+}
+
+// Run 执行一次清理
+func (m *CleanupManager) Run(ctx context.Context) error {
+	now := time.Now()
+	dir := m.cfg.GetDir()
+
+	var removed int
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		jobs, err := m.exportRepo.ListExpiredManual(ctx, now.UnixMilli(), cleanupBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			break
+		}
+
+		ids := make([]string, len(jobs))
+		for i, job := range jobs {
+			ids[i] = job.ExportID
+			if err := os.Remove(jobFilePath(dir, job)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Warn().Err(err).Str("export_id", job.ExportID).Msg("删除过期导出文件失败")
+			}
+		}
+		if err := m.exportRepo.DeleteByIDsManual(ctx, ids); err != nil {
+			return err
+		}
+		removed += len(jobs)
+		if len(jobs) < cleanupBatchSize {
+			break
+		}
+	}
+	if removed > 0 {
+		log.Info().Int("count", removed).Msg("已清理过期导出任务")
+	}
+
+	return m.removeOrphans(dir, now.Add(-m.cfg.GetRetention()-jobTimeout))
+}
+
+// removeOrphans 删除修改时间早于 before 的残留文件，正常任务的文件在此之前已随任务记录删除
+func (m *CleanupManager) removeOrphans(dir string, before time.Time) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().Before(before) {
+			if err := os.Remove(path); err != nil {
+				log.Warn().Err(err).Str("path", path).Msg("删除残留导出文件失败")
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
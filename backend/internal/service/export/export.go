@@ -0,0 +1,244 @@
+package export
+
+import (
+	"admin/internal/dto"
+	"admin/internal/rbac"
+	"admin/internal/repository"
+	"admin/internal/service/department"
+	"admin/internal/service/loginlog"
+	"admin/internal/service/operationlog"
+	"admin/internal/service/position"
+	"admin/internal/service/role"
+	"admin/internal/service/setting"
+	"admin/internal/service/task"
+	"admin/internal/service/user"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/utils/csv"
+	"admin/pkg/utils/rsapwd"
+	"admin/pkg/utils/xlsx"
+	"admin/pkg/xerr"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// 导出文件格式
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// 表头语言
+const (
+	LangZhCN = "zh-CN"
+	LangEnUS = "en-US"
+)
+
+const (
+	streamBatchSize = 500                   // 每批从数据库读取的行数
+	timeLayout      = "2006-01-02 15:04:05" // 导出文件中的时间格式
+	jobTimeout      = 30 * time.Minute      // 单次后台导出的最长执行时间
+	jobMaxAttempts  = 2                     // 后台导出的最大执行次数，失败后重试一次
+)
+
+// Service 列表导出服务
+// 复用各列表接口的筛选条件和数据范围，以游标方式分批读取并流式写出文件；
+// 行数超过阈值时提交到后台任务队列，完成后通过下载链接获取
+type Service struct {
+	db              *gorm.DB
+	exportRepo      *repository.ExportJobRepo
+	userSvc         *user.Service
+	roleSvc         *role.Service
+	deptSvc         *department.Service
+	positionSvc     *position.Service
+	loginLogSvc     *loginlog.Service
+	operationLogSvc *operationlog.Service
+	settingSvc      *setting.Service
+	recorder        *audit.Recorder
+	config          config.ExportConfig
+	resources       map[string]*resource
+}
+
+// NewService 创建列表导出服务，并向任务工作进程注册后台导出任务
+func NewService(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, cache *rbac.PermissionCache, worker *task.Worker, cfg config.ExportConfig) *Service {
+	s := &Service{
+		db:              db,
+		exportRepo:      repository.NewExportJobRepo(db),
+		userSvc:         user.NewService(db, recorder, rsaCipher),
+		roleSvc:         role.NewService(db, recorder, cache),
+		deptSvc:         department.NewService(db, recorder),
+		positionSvc:     position.NewService(db, recorder),
+		loginLogSvc:     loginlog.NewService(db),
+		operationLogSvc: operationlog.NewService(db),
+		settingSvc:      setting.NewService(db, recorder),
+		recorder:        recorder,
+		config:          cfg,
+	}
+	s.initResources()
+	task.Register(worker, TaskType, task.Handler[exportTaskPayload]{
+		Run:       s.runExportTask,
+		OnFailure: s.failJob,
+		Timeout:   jobTimeout,
+	})
+	return s
+}
+
+// exportSummary 导出操作的审计内容
+type exportSummary struct {
+	Resource string `json:"resource"`
+	Format   string `json:"format"`
+	Lang     string `json:"lang"`
+	Filters  any    `json:"filters"`
+	Total    int64  `json:"total"`
+	Rows     int64  `json:"rows"`
+	Async    bool   `json:"async"`
+	ExportID string `json:"export_id,omitempty"`
+}
+
+// Export 按列表筛选条件导出数据
+// filter 为对应列表接口的请求结构体指针；行数未超过阈值时调用 open 获取输出流同步写出并返回 nil，
+// open 的参数为建议的文件名和 Content-Type；超过阈值时创建后台任务并返回任务信息
+func (s *Service) Export(ctx context.Context, resourceName string, filter any, req *dto.ExportRequest, open func(filename, contentType string) io.Writer) (job *dto.ExportJobInfo, err error) {
+	res, ok := s.resources[resourceName]
+	if !ok {
+		return nil, xerr.Wrap(xerr.ErrInvalidParams.Code, "不支持导出该资源", nil)
+	}
+
+	summary := &exportSummary{
+		Resource: resourceName,
+		Format:   normalizeFormat(req.Format),
+		Lang:     s.resolveLanguage(ctx, req.Lang),
+		Filters:  filter,
+	}
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithExport(res.module),
+				audit.WithError(err),
+			)
+		} else {
+			s.recorder.Log(ctx,
+				audit.WithExport(res.module),
+				audit.WithResource(resourceName, summary.ExportID, res.filePrefix),
+				audit.WithValue(nil, summary),
+			)
+		}
+	}()
+
+	summary.Total, err = res.count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// 超过阈值转为后台任务，避免长时间占用请求连接
+	if summary.Total > s.config.GetAsyncThreshold() {
+		job, err = s.startJob(ctx, resourceName, filter, summary.Format, summary.Lang, summary.Total)
+		if err != nil {
+			return nil, err
+		}
+		summary.Async = true
+		summary.ExportID = job.ExportID
+		return job, nil
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", res.filePrefix, time.Now().Format("20060102150405"), summary.Format)
+	summary.Rows, err = s.writeFile(ctx, res, filter, summary.Format, summary.Lang, open(filename, contentType(summary.Format)), nil)
+	if err != nil {
+		log.Error().Err(err).Str("resource", resourceName).Msg("导出数据失败")
+		if xe, ok := err.(*xerr.AppError); ok {
+			return nil, xe
+		}
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "导出数据失败", err)
+	}
+	return nil, nil
+}
+
+// tableWriter 逐行写出的表格文件
+type tableWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// writeFile 写出表头和全部数据行，返回数据行数；progress 不为空时每写出一批数据调用一次
+func (s *Service) writeFile(ctx context.Context, res *resource, filter any, format, lang string, w io.Writer, progress func(rows int64)) (int64, error) {
+	var tw tableWriter
+	var err error
+	if format == FormatCSV {
+		tw, err = csv.NewStreamWriter(w)
+	} else {
+		tw, err = xlsx.NewStreamWriter(w, res.filePrefix)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tw.WriteRow(res.headers(lang)); err != nil {
+		return 0, err
+	}
+	var rows int64
+	err = res.stream(ctx, filter, lang, func(row []string) error {
+		rows++
+		if progress != nil && rows%streamBatchSize == 0 {
+			progress(rows)
+		}
+		return tw.WriteRow(row)
+	})
+	if err != nil {
+		return rows, err
+	}
+	return rows, tw.Close()
+}
+
+// resolveLanguage 确定表头语言：请求参数 > Accept-Language（由处理器填入） > 租户默认语言 > 中文
+func (s *Service) resolveLanguage(ctx context.Context, lang string) string {
+	if lang == LangZhCN || lang == LangEnUS {
+		return lang
+	}
+
+	value, err := s.settingSvc.GetValue(ctx, setting.KeyDefaultLanguage)
+	if err != nil {
+		log.Warn().Err(err).Msg("查询租户默认语言失败，使用中文表头")
+		return LangZhCN
+	}
+	if lang, ok := value.(string); ok && lang == LangEnUS {
+		return LangEnUS
+	}
+	return LangZhCN
+}
+
+// ParseAcceptLanguage 从 Accept-Language 请求头中按优先顺序选出支持的语言，均不支持时返回空
+func ParseAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.ToLower(strings.TrimSpace(strings.SplitN(part, ";", 2)[0]))
+		switch {
+		case strings.HasPrefix(tag, "zh"):
+			return LangZhCN
+		case strings.HasPrefix(tag, "en"):
+			return LangEnUS
+		}
+	}
+	return ""
+}
+
+// normalizeFormat 文件格式，默认 xlsx
+func normalizeFormat(format string) string {
+	if format == FormatCSV {
+		return FormatCSV
+	}
+	return FormatXLSX
+}
+
+// contentType 文件格式对应的 Content-Type
+func contentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return xlsx.ContentType
+}
@@ -0,0 +1,278 @@
+package export
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/internal/service/task"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// downloadPath 异步导出文件的下载地址
+const downloadPath = "/api/v1/exports/download?export_id="
+
+// TaskType 后台导出的任务类型
+const TaskType = "export"
+
+// exportTaskPayload 后台导出任务参数
+type exportTaskPayload struct {
+	ExportID string `json:"export_id"`
+	Total    int64  `json:"total"` // 入队时统计的行数，用于计算进度
+}
+
+// exportTaskResult 后台导出任务结果
+type exportTaskResult struct {
+	ExportID    string `json:"export_id"`
+	RowCount    int64  `json:"row_count"`
+	FileSize    int64  `json:"file_size"`
+	DownloadURL string `json:"download_url"`
+}
+
+// startJob 创建导出任务记录并提交后台任务，文件由任务工作进程生成
+func (s *Service) startJob(ctx context.Context, resourceName string, filter any, format, lang string, total int64) (*dto.ExportJobInfo, error) {
+	filters, err := json.Marshal(filter)
+	if err != nil {
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "序列化导出筛选条件失败", err)
+	}
+	exportID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成导出任务ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成导出任务ID失败", err)
+	}
+
+	job := &model.ExportJob{
+		ExportID: exportID,
+		UserID:   xcontext.GetUserID(ctx),
+		Resource: resourceName,
+		Format:   format,
+		Lang:     lang,
+		Filters:  string(filters),
+		Status:   constants.ExportPending,
+	}
+	// 导出记录与后台任务在同一事务中提交，避免出现没有任务执行的导出记录
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		t, err := task.NewQueue(tx.DB).Enqueue(ctx, TaskType, &exportTaskPayload{
+			ExportID: exportID,
+			Total:    total,
+		}, task.WithMaxAttempts(jobMaxAttempts))
+		if err != nil {
+			return err
+		}
+		job.TaskID = t.TaskID
+		return repository.NewExportJobRepo(tx.DB).Create(ctx, job)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("resource", resourceName).Msg("创建导出任务失败")
+		if xe, ok := err.(*xerr.AppError); ok {
+			return nil, xe
+		}
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建导出任务失败", err)
+	}
+
+	return modelToExportJobInfo(job), nil
+}
+
+// runExportTask 执行后台导出任务，context 已按发起人重建，数据范围与发起导出时一致
+func (s *Service) runExportTask(ctx context.Context, exec *task.Execution, p *exportTaskPayload) (any, error) {
+	job, err := s.exportRepo.GetByID(ctx, p.ExportID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, task.Permanent(xerr.ErrExportNotFound)
+		}
+		return nil, err
+	}
+	res, ok := s.resources[job.Resource]
+	if !ok {
+		return nil, task.Permanent(fmt.Errorf("不支持导出该资源: %s", job.Resource))
+	}
+	filter := res.newFilter()
+	if err := json.Unmarshal([]byte(job.Filters), filter); err != nil {
+		return nil, task.Permanent(fmt.Errorf("解析导出筛选条件失败: %w", err))
+	}
+
+	if err := s.exportRepo.Update(ctx, job.ExportID, map[string]interface{}{
+		"status": constants.ExportRunning,
+	}); err != nil {
+		return nil, err
+	}
+
+	rows, size, err := s.writeJobFile(ctx, job, res, filter, func(rows int64) {
+		if p.Total > 0 {
+			exec.Progress(int(min(rows*100/p.Total, 99)), fmt.Sprintf("已导出 %d/%d 行", rows, p.Total))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.exportRepo.Update(ctx, job.ExportID, map[string]interface{}{
+		"status":        constants.ExportSucceeded,
+		"row_count":     rows,
+		"file_size":     size,
+		"error_message": "",
+		"finished_at":   now.UnixMilli(),
+		"expires_at":    now.Add(s.config.GetRetention()).UnixMilli(),
+	}); err != nil {
+		return nil, err
+	}
+	log.Info().Str("export_id", job.ExportID).Int64("rows", rows).Int64("size", size).Msg("导出任务完成")
+
+	return &exportTaskResult{
+		ExportID:    job.ExportID,
+		RowCount:    rows,
+		FileSize:    size,
+		DownloadURL: downloadPath + job.ExportID,
+	}, nil
+}
+
+// writeJobFile 将导出数据写入任务文件，先写临时文件，完成后再改名，避免下载到不完整的文件
+// progress 每写出一批数据调用一次
+func (s *Service) writeJobFile(ctx context.Context, job *model.ExportJob, res *resource, filter any, progress func(rows int64)) (rows, size int64, err error) {
+	path := s.jobFilePath(job)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, 0, err
+	}
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp)
+		}
+	}()
+
+	rows, err = s.writeFile(ctx, res, filter, job.Format, job.Lang, f, progress)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	info, err := os.Stat(tmp)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, 0, err
+	}
+	return rows, info.Size(), nil
+}
+
+// failJob 后台任务最终失败或被取消时将导出任务标记为失败，失败的任务保留到文件保留期结束后一起清理
+func (s *Service) failJob(ctx context.Context, p *exportTaskPayload, cause error) {
+	now := time.Now()
+	message := cause.Error()
+	if xe, ok := cause.(*xerr.AppError); ok {
+		message = xe.Message
+	}
+
+	if err := s.exportRepo.Update(ctx, p.ExportID, map[string]interface{}{
+		"status":        constants.ExportFailed,
+		"error_message": message,
+		"finished_at":   now.UnixMilli(),
+		"expires_at":    now.Add(s.config.GetRetention()).UnixMilli(),
+	}); err != nil {
+		log.Error().Err(err).Str("export_id", p.ExportID).Msg("更新导出任务状态失败")
+	}
+}
+
+// jobFilePath 导出文件路径：<导出目录>/<租户ID>/<任务ID>.<格式>
+func (s *Service) jobFilePath(job *model.ExportJob) string {
+	return jobFilePath(s.config.GetDir(), job)
+}
+
+func jobFilePath(dir string, job *model.ExportJob) string {
+	return filepath.Join(dir, job.TenantID, job.ExportID+"."+job.Format)
+}
+
+// ListJobs 获取当前用户的导出任务
+func (s *Service) ListJobs(ctx context.Context, req *dto.ListExportJobsRequest) (*dto.ListExportJobsResponse, error) {
+	userID := xcontext.GetUserID(ctx)
+
+	jobs, total, err := s.exportRepo.ListByUser(ctx, userID, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("查询导出任务列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询导出任务列表失败", err)
+	}
+
+	list := make([]*dto.ExportJobInfo, len(jobs))
+	for i, job := range jobs {
+		list[i] = modelToExportJobInfo(job)
+	}
+	return &dto.ListExportJobsResponse{
+		Response: pagination.NewResponse(req.Request, total),
+		List:     list,
+	}, nil
+}
+
+// GetDownloadFile 获取导出文件路径和下载文件名，只有任务发起人可以下载
+func (s *Service) GetDownloadFile(ctx context.Context, exportID string) (path, filename string, err error) {
+	job, err := s.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", "", xerr.ErrExportNotFound
+		}
+		log.Error().Err(err).Str("export_id", exportID).Msg("查询导出任务失败")
+		return "", "", xerr.Wrap(xerr.ErrInternal.Code, "查询导出任务失败", err)
+	}
+	// 不暴露其他用户的任务是否存在
+	if job.UserID != xcontext.GetUserID(ctx) {
+		return "", "", xerr.ErrExportNotFound
+	}
+	if job.Status != constants.ExportSucceeded {
+		return "", "", xerr.ErrExportNotReady
+	}
+	if job.ExpiresAt <= time.Now().UnixMilli() {
+		return "", "", xerr.ErrExportExpired
+	}
+
+	path = s.jobFilePath(job)
+	if _, err := os.Stat(path); err != nil {
+		log.Warn().Err(err).Str("export_id", exportID).Msg("导出文件不存在")
+		return "", "", xerr.ErrExportExpired
+	}
+
+	prefix := job.Resource
+	if res, ok := s.resources[job.Resource]; ok {
+		prefix = res.filePrefix
+	}
+	filename = fmt.Sprintf("%s-%s.%s", prefix, time.UnixMilli(job.CreatedAt).Format("20060102150405"), job.Format)
+	return path, filename, nil
+}
+
+// modelToExportJobInfo 将导出任务模型转换为响应 DTO
+func modelToExportJobInfo(job *model.ExportJob) *dto.ExportJobInfo {
+	return &dto.ExportJobInfo{
+		ExportID:     job.ExportID,
+		TaskID:       job.TaskID,
+		Resource:     job.Resource,
+		Format:       job.Format,
+		Status:       job.Status,
+		RowCount:     job.RowCount,
+		FileSize:     job.FileSize,
+		ErrorMessage: job.ErrorMessage,
+		DownloadURL:  downloadPath + job.ExportID,
+		ExpiresAt:    job.ExpiresAt,
+		FinishedAt:   job.FinishedAt,
+		CreatedAt:    job.CreatedAt,
+	}
+}
@@ -0,0 +1,243 @@
+package export
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/constants"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 可导出的资源，与导出任务的 resource 字段一致
+const (
+	ResourceUser         = constants.ResourceTypeUser
+	ResourceRole         = constants.ResourceTypeRole
+	ResourceDepartment   = constants.ResourceTypeDepartment
+	ResourcePosition     = constants.ResourceTypePosition
+	ResourceLoginLog     = constants.ResourceTypeLoginLog
+	ResourceOperationLog = constants.ResourceTypeOperationLog
+)
+
+// column 导出列定义
+type column[T any] struct {
+	zh    string                            // 中文表头
+	en    string                            // 英文表头
+	value func(item *T, lang string) string // 单元格取值，按语言本地化枚举值
+}
+
+// resource 可导出资源
+// filter 为对应列表接口的请求结构体指针
+type resource struct {
+	module     string
+	filePrefix string
+	newFilter  func() any // 创建空的筛选条件，后台任务据此反序列化保存的筛选条件
+	headers    func(lang string) []string
+	count      func(ctx context.Context, filter any) (int64, error)
+	stream     func(ctx context.Context, filter any, lang string, write func([]string) error) error
+}
+
+// newResource 由列表服务的统计和分批读取方法构造可导出资源
+func newResource[F, T any](
+	module, filePrefix string,
+	columns []column[T],
+	count func(ctx context.Context, filter *F) (int64, error),
+	stream func(ctx context.Context, filter *F, batchSize int, fn func([]*T) error) error,
+) *resource {
+	return &resource{
+		module:     module,
+		filePrefix: filePrefix,
+		newFilter:  func() any { return new(F) },
+		headers: func(lang string) []string {
+			headers := make([]string, len(columns))
+			for i, col := range columns {
+				headers[i] = localize(lang, col.zh, col.en)
+			}
+			return headers
+		},
+		count: func(ctx context.Context, filter any) (int64, error) {
+			return count(ctx, filter.(*F))
+		},
+		stream: func(ctx context.Context, filter any, lang string, write func([]string) error) error {
+			return stream(ctx, filter.(*F), streamBatchSize, func(items []*T) error {
+				for _, item := range items {
+					row := make([]string, len(columns))
+					for i, col := range columns {
+						row[i] = col.value(item, lang)
+					}
+					if err := write(row); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+	}
+}
+
+// initResources 注册所有可导出资源，列顺序即导出文件中的列顺序
+func (s *Service) initResources() {
+	s.resources = map[string]*resource{
+		ResourceUser: newResource(constants.ModuleUser, "users", []column[dto.UserInfo]{
+			{"用户名", "Username", func(u *dto.UserInfo, _ string) string { return u.UserName }},
+			{"昵称", "Nickname", func(u *dto.UserInfo, _ string) string { return u.Nickname }},
+			{"邮箱", "Email", func(u *dto.UserInfo, _ string) string { return u.Email }},
+			{"手机号", "Phone", func(u *dto.UserInfo, _ string) string { return u.Phone }},
+			{"角色", "Roles", func(u *dto.UserInfo, _ string) string { return roleNames(u.Roles) }},
+			{"状态", "Status", func(u *dto.UserInfo, lang string) string {
+				return enumText(lang, u.Status, map[int][2]string{1: {"正常", "Active"}, 2: {"禁用", "Disabled"}})
+			}},
+			{"服务账号", "Service Account", func(u *dto.UserInfo, lang string) string { return yesNo(lang, int(u.IsServiceAccount)) }},
+			{"最后登录时间", "Last Login", func(u *dto.UserInfo, _ string) string { return formatTime(u.LastLoginTime) }},
+			{"描述", "Description", func(u *dto.UserInfo, _ string) string { return u.Description }},
+			{"备注", "Remark", func(u *dto.UserInfo, _ string) string { return u.Remark }},
+			{"创建时间", "Created At", func(u *dto.UserInfo, _ string) string { return formatTime(u.CreatedAt) }},
+		}, s.userSvc.CountUsers, s.userSvc.StreamUsers),
+
+		ResourceRole: newResource(constants.ModuleRole, "roles", []column[dto.RoleInfo]{
+			{"角色编码", "Role Code", func(r *dto.RoleInfo, _ string) string { return r.RoleCode }},
+			{"角色名称", "Role Name", func(r *dto.RoleInfo, _ string) string { return r.Name }},
+			{"父角色编码", "Parent Role Code", func(r *dto.RoleInfo, _ string) string {
+				if r.ParentRoleCode == nil {
+					return ""
+				}
+				return *r.ParentRoleCode
+			}},
+			{"状态", "Status", func(r *dto.RoleInfo, lang string) string { return enabledText(lang, r.Status) }},
+			{"描述", "Description", func(r *dto.RoleInfo, _ string) string { return r.Description }},
+			{"创建时间", "Created At", func(r *dto.RoleInfo, _ string) string { return formatTime(r.CreatedAt) }},
+		}, s.roleSvc.CountRoles, s.roleSvc.StreamRoles),
+
+		ResourceDepartment: newResource(constants.ModuleDepartment, "departments", []column[dto.DepartmentInfo]{
+			{"部门ID", "Department ID", func(d *dto.DepartmentInfo, _ string) string { return d.DepartmentID }},
+			{"部门名称", "Department Name", func(d *dto.DepartmentInfo, _ string) string { return d.DepartmentName }},
+			{"父部门ID", "Parent ID", func(d *dto.DepartmentInfo, _ string) string { return d.ParentID }},
+			{"排序", "Sort", func(d *dto.DepartmentInfo, _ string) string { return strconv.Itoa(d.Sort) }},
+			{"状态", "Status", func(d *dto.DepartmentInfo, lang string) string { return enabledText(lang, d.Status) }},
+			{"描述", "Description", func(d *dto.DepartmentInfo, _ string) string { return d.Description }},
+			{"创建时间", "Created At", func(d *dto.DepartmentInfo, _ string) string { return formatTime(d.CreatedAt) }},
+		}, s.deptSvc.CountDepartments, s.deptSvc.StreamDepartments),
+
+		ResourcePosition: newResource(constants.ModulePosition, "positions", []column[dto.PositionInfo]{
+			{"岗位编码", "Position Code", func(p *dto.PositionInfo, _ string) string { return p.PositionCode }},
+			{"岗位名称", "Position Name", func(p *dto.PositionInfo, _ string) string { return p.PositionName }},
+			{"职级", "Level", func(p *dto.PositionInfo, _ string) string { return strconv.Itoa(p.Level) }},
+			{"排序", "Sort", func(p *dto.PositionInfo, _ string) string { return strconv.Itoa(p.Sort) }},
+			{"状态", "Status", func(p *dto.PositionInfo, lang string) string { return enabledText(lang, p.Status) }},
+			{"描述", "Description", func(p *dto.PositionInfo, _ string) string { return p.Description }},
+			{"创建时间", "Created At", func(p *dto.PositionInfo, _ string) string { return formatTime(p.CreatedAt) }},
+		}, s.positionSvc.CountPositions, s.positionSvc.StreamPositions),
+
+		ResourceLoginLog: newResource(constants.ModuleLog, "login-logs", []column[dto.LoginLogInfo]{
+			{"时间", "Time", func(l *dto.LoginLogInfo, _ string) string { return formatTime(l.CreatedAt) }},
+			{"用户名", "Username", func(l *dto.LoginLogInfo, _ string) string { return l.UserName }},
+			{"操作类型", "Operation", func(l *dto.LoginLogInfo, lang string) string {
+				return codeText(lang, l.OperationType, map[string]string{
+					constants.OperationLogin:  constants.OperationTypeText[constants.OperationLogin],
+					constants.OperationLogout: constants.OperationTypeText[constants.OperationLogout],
+				})
+			}},
+			{"登录方式", "Login Type", func(l *dto.LoginLogInfo, lang string) string { return codeText(lang, l.LoginType, loginTypeText) }},
+			{"IP地址", "IP Address", func(l *dto.LoginLogInfo, _ string) string { return l.LoginIP }},
+			{"登录地点", "Location", func(l *dto.LoginLogInfo, _ string) string { return l.LoginLocation }},
+			{"状态", "Status", func(l *dto.LoginLogInfo, lang string) string {
+				return enumText(lang, int(l.Status), map[int][2]string{1: {"成功", "Success"}, 0: {"失败", "Failed"}})
+			}},
+			{"失败原因", "Failure Reason", func(l *dto.LoginLogInfo, _ string) string { return l.FailReason }},
+			{"风险评分", "Risk Score", func(l *dto.LoginLogInfo, _ string) string { return strconv.Itoa(int(l.RiskScore)) }},
+			{"风险标记", "Risk Flags", func(l *dto.LoginLogInfo, _ string) string { return l.RiskFlags }},
+			{"处置动作", "Risk Action", func(l *dto.LoginLogInfo, _ string) string { return l.RiskAction }},
+			{"用户代理", "User Agent", func(l *dto.LoginLogInfo, _ string) string { return l.UserAgent }},
+		}, s.loginLogSvc.CountLoginLogs, s.loginLogSvc.StreamLoginLogs),
+
+		ResourceOperationLog: newResource(constants.ModuleLog, "operation-logs", []column[dto.OperationLogInfo]{
+			{"时间", "Time", func(o *dto.OperationLogInfo, _ string) string { return formatTime(o.CreatedAt) }},
+			{"用户名", "Username", func(o *dto.OperationLogInfo, _ string) string { return o.UserName }},
+			{"模拟操作者", "Impersonator", func(o *dto.OperationLogInfo, _ string) string { return o.ImpersonatorName }},
+			{"模块", "Module", func(o *dto.OperationLogInfo, lang string) string {
+				return codeText(lang, o.Module, constants.ModuleText)
+			}},
+			{"操作类型", "Operation", func(o *dto.OperationLogInfo, lang string) string {
+				return codeText(lang, o.OperationType, constants.OperationTypeText)
+			}},
+			{"资源类型", "Resource Type", func(o *dto.OperationLogInfo, _ string) string { return o.ResourceType }},
+			{"资源名称", "Resource Name", func(o *dto.OperationLogInfo, _ string) string { return o.ResourceName }},
+			{"请求方法", "Method", func(o *dto.OperationLogInfo, _ string) string { return o.RequestMethod }},
+			{"请求路径", "Path", func(o *dto.OperationLogInfo, _ string) string { return o.RequestPath }},
+			{"状态", "Status", func(o *dto.OperationLogInfo, lang string) string {
+				return enumText(lang, o.Status, map[int][2]string{
+					constants.OperationStatusSuccess: {"成功", "Success"},
+					constants.OperationStatusFailed:  {"失败", "Failed"},
+				})
+			}},
+			{"错误信息", "Error Message", func(o *dto.OperationLogInfo, _ string) string { return o.ErrorMessage }},
+			{"IP地址", "IP Address", func(o *dto.OperationLogInfo, _ string) string { return o.IPAddress }},
+			{"地点", "Location", func(o *dto.OperationLogInfo, _ string) string { return o.Location }},
+		}, s.operationLogSvc.CountOperationLogs, s.operationLogSvc.StreamOperationLogs),
+	}
+}
+
+// loginTypeText 登录方式中文描述
+var loginTypeText = map[string]string{
+	constants.LoginTypePassword:          "密码",
+	constants.LoginTypeEmail:             "邮箱",
+	constants.LoginTypePhone:             "手机号",
+	constants.LoginTypeSSO:               "单点登录",
+	constants.LoginTypeOAuth:             "第三方登录",
+	constants.LoginTypeClientCredentials: "客户端凭证",
+}
+
+// localize 按语言选择文本
+func localize(lang, zh, en string) string {
+	if lang == LangEnUS {
+		return en
+	}
+	return zh
+}
+
+// enumText 数值枚举的本地化文本，未知值原样输出
+func enumText(lang string, value int, texts map[int][2]string) string {
+	text, ok := texts[value]
+	if !ok {
+		return strconv.Itoa(value)
+	}
+	return localize(lang, text[0], text[1])
+}
+
+// enabledText 启用/禁用状态（1:启用 2:禁用）
+func enabledText(lang string, status int) string {
+	return enumText(lang, status, map[int][2]string{1: {"启用", "Enabled"}, 2: {"禁用", "Disabled"}})
+}
+
+// yesNo 是/否（1:是 2:否）
+func yesNo(lang string, value int) string {
+	return enumText(lang, value, map[int][2]string{1: {"是", "Yes"}, 2: {"否", "No"}})
+}
+
+// codeText 编码类枚举：中文使用描述映射，英文直接输出编码
+func codeText(lang, code string, zhTexts map[string]string) string {
+	if lang == LangEnUS {
+		return code
+	}
+	if text, ok := zhTexts[code]; ok {
+		return text
+	}
+	return code
+}
+
+// roleNames 角色名称列表，以逗号分隔
+func roleNames(roles []*dto.RoleInfo) string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// formatTime 格式化毫秒时间戳，0 表示无
+func formatTime(ms int64) string {
+	if ms <= 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).Format(timeLayout)
+}
@@ -0,0 +1,263 @@
+package invitation
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// AcceptInvitation 接受邀请（无需认证）
+// 邮箱未注册时使用提交的密码创建本租户账号并分配预设的角色、部门和岗位；
+// 已在其他租户有账号时以该账号加入本租户并授予预设的角色、部门和岗位，登录原租户后通过切换租户访问
+func (s *Service) AcceptInvitation(ctx context.Context, req *dto.AcceptInvitationRequest) (resp *dto.AcceptInvitationResponse, err error) {
+	var invitation *model.Invitation
+	var user *model.User
+	var roleIDs []string
+
+	defer func() {
+		if invitation == nil {
+			return
+		}
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleUser),
+				audit.WithTenantID(invitation.TenantID),
+				audit.WithResource(constants.ResourceTypeInvitation, invitation.InvitationID, invitation.Email),
+				audit.WithError(err),
+			)
+		} else if user != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleUser),
+				audit.WithUser(invitation.TenantID, user.UserID, user.UserName),
+				audit.WithResource(constants.ResourceTypeInvitation, invitation.InvitationID, invitation.Email),
+				audit.WithValue(nil, map[string]any{"user_id": user.UserID, "role_ids": roleIDs}),
+			)
+		}
+	}()
+
+	invitation, tenant, err := s.loadByToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.findAccount(ctx, invitation.TenantID, invitation.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// 后续操作均在邀请租户内进行
+	ctx = xcontext.SetTenantID(ctx, invitation.TenantID)
+
+	// 邀请后角色可能已被删除，只分配仍存在的角色
+	roleIDs, err = s.availableRoleIDs(ctx, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	if account != nil {
+		if len(roleIDs) == 0 {
+			return nil, xerr.New(xerr.ErrInvitationInvalid.Code, "邀请预分配的角色已被删除，请联系管理员重新邀请")
+		}
+		user = account
+		err = s.joinTenant(ctx, invitation, account, roleIDs)
+	} else {
+		user, err = s.createUser(ctx, invitation, req, roleIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("tenant_id", invitation.TenantID).
+		Str("invitation_id", invitation.InvitationID).
+		Str("user_id", user.UserID).
+		Bool("existing_account", account != nil).
+		Msg("接受邀请成功")
+
+	return &dto.AcceptInvitationResponse{
+		TenantCode:      tenant.TenantCode,
+		UserID:          user.UserID,
+		ExistingAccount: account != nil,
+	}, nil
+}
+
+// joinTenant 其他租户的已有账号加入邀请租户
+func (s *Service) joinTenant(ctx context.Context, invitation *model.Invitation, account *model.User, roleIDs []string) error {
+	err := database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		departmentID, positionID, err := resolveOrg(ctx, tx, invitation)
+		if err != nil {
+			return err
+		}
+
+		affected, err := repository.NewInvitationRepo(tx.DB).MarkAccepted(ctx, invitation.InvitationID, invitation.Nonce, account.UserID)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return xerr.ErrInvitationInvalid
+		}
+
+		if err := repository.NewTenantMemberRepo(tx.DB).Upsert(ctx, &model.TenantMember{
+			TenantID:     invitation.TenantID,
+			UserID:       account.UserID,
+			Status:       int16(constants.StatusEnabled),
+			DepartmentID: departmentID,
+			PositionID:   positionID,
+			InvitedBy:    invitation.InvitedBy,
+		}); err != nil {
+			return err
+		}
+		return repository.NewUserRoleRepo(tx.DB).AddRoles(ctx, account.UserID, roleIDs, invitation.TenantID)
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		log.Error().Err(err).Str("invitation_id", invitation.InvitationID).Str("user_id", account.UserID).Msg("加入租户失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "加入租户失败", err)
+	}
+	return nil
+}
+
+// createUser 为被邀请人创建邀请租户的账号
+func (s *Service) createUser(ctx context.Context, invitation *model.Invitation, req *dto.AcceptInvitationRequest, roleIDs []string) (*model.User, error) {
+	if req.Password == "" {
+		return nil, xerr.New(xerr.ErrInvalidParams.Code, "请设置登录密码")
+	}
+
+	// 检查租户用户数配额
+	if err := s.quotaSvc.Check(ctx, invitation.TenantID, constants.QuotaUsers, 1); err != nil {
+		return nil, err
+	}
+
+	// 解密前端传来的密码
+	// 前端使用 JSEncrypt 库（PKCS#1 v1.5 填充）加密密码
+	// 因此后端必须使用 DecryptPKCS1 方法解密
+	decryptedPassword, err := s.rsaCipher.DecryptPKCS1(req.Password)
+	if err != nil {
+		log.Error().Err(err).Msg("密码解密失败")
+		return nil, xerr.Wrap(xerr.ErrInvalidCredentials.Code, "密码解密失败", err)
+	}
+	salt, err := passwordgen.GenerateSalt()
+	if err != nil {
+		log.Error().Err(err).Msg("生成盐值失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成盐值失败", err)
+	}
+	hashedPassword, err := passwordgen.Argon2Hash(decryptedPassword, salt)
+	if err != nil {
+		log.Error().Err(err).Msg("密码加密失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "密码加密失败", err)
+	}
+
+	userID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成用户ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成用户ID失败", err)
+	}
+
+	nickname := req.Nickname
+	if nickname == "" {
+		nickname = invitation.Email
+	}
+	user := &model.User{
+		UserID:             userID,
+		TenantID:           invitation.TenantID,
+		UserName:           invitation.Email,
+		Password:           hashedPassword,
+		Nickname:           nickname,
+		Email:              invitation.Email,
+		Status:             int16(constants.StatusEnabled),
+		MustChangePassword: constants.False, // 密码由本人设置，无需再次修改
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		departmentID, positionID, err := resolveOrg(ctx, tx, invitation)
+		if err != nil {
+			return err
+		}
+		user.DepartmentID, user.PositionID = departmentID, positionID
+
+		affected, err := repository.NewInvitationRepo(tx.DB).MarkAccepted(ctx, invitation.InvitationID, invitation.Nonce, userID)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return xerr.ErrInvitationInvalid
+		}
+
+		if err := repository.NewUserRepo(tx.DB).Create(ctx, user); err != nil {
+			errMsg := err.Error()
+			if strings.Contains(errMsg, "duplicate key") || strings.Contains(errMsg, "uk_users_email") {
+				return xerr.ErrAlreadyTenantMember
+			}
+			return err
+		}
+		if positionID != "" {
+			if err := repository.NewUserPositionRepo(tx.DB).ReplaceByUser(ctx, userID, []string{positionID}, positionID); err != nil {
+				return err
+			}
+		}
+
+		if len(roleIDs) == 0 {
+			return nil
+		}
+		return repository.NewUserRoleRepo(tx.DB).AssignRoles(ctx, userID, roleIDs, invitation.TenantID)
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return nil, xe
+		}
+		log.Error().Err(err).Str("invitation_id", invitation.InvitationID).Str("email", invitation.Email).Msg("创建邀请用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建邀请用户失败", err)
+	}
+	return user, nil
+}
+
+// availableRoleIDs 获取邀请预分配角色中仍存在的角色ID
+func (s *Service) availableRoleIDs(ctx context.Context, invitation *model.Invitation) ([]string, error) {
+	roleIDs := decodeRoleIDs(invitation)
+	if len(roleIDs) == 0 {
+		return nil, nil
+	}
+	roles, err := s.roleRepo.ListByIDs(ctx, roleIDs)
+	if err != nil {
+		log.Error().Err(err).Str("invitation_id", invitation.InvitationID).Msg("查询邀请角色失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询邀请角色失败", err)
+	}
+	available := make([]string, len(roles))
+	for i, role := range roles {
+		available[i] = role.RoleID
+	}
+	return available, nil
+}
+
+// resolveOrg 获取邀请预分配的部门和岗位，邀请后已被删除的不再设置
+func resolveOrg(ctx context.Context, tx *database.Tx, invitation *model.Invitation) (departmentID, positionID string, err error) {
+	if invitation.DepartmentID != "" {
+		if _, err := repository.NewDepartmentRepo(tx.DB).GetByID(ctx, invitation.DepartmentID); err == nil {
+			departmentID = invitation.DepartmentID
+		} else if err != gorm.ErrRecordNotFound {
+			return "", "", err
+		}
+	}
+	if invitation.PositionID != "" {
+		if _, err := repository.NewPositionRepo(tx.DB).GetByID(ctx, invitation.PositionID); err == nil {
+			positionID = invitation.PositionID
+		} else if err != gorm.ErrRecordNotFound {
+			return "", "", err
+		}
+	}
+	return departmentID, positionID, nil
+}
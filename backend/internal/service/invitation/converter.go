@@ -0,0 +1,52 @@
+package invitation
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/constants"
+	"encoding/json"
+	"time"
+)
+
+// displayStatus 获取邀请展示状态，已过期的待接受邀请显示为 EXPIRED
+func displayStatus(inv *model.Invitation) string {
+	if inv.Status == constants.InvitationPending && inv.ExpiresAt < time.Now().UnixMilli() {
+		return constants.InvitationExpired
+	}
+	return inv.Status
+}
+
+// decodeRoleIDs 解码预分配角色ID
+func decodeRoleIDs(inv *model.Invitation) []string {
+	roleIDs := []string{}
+	if inv.RoleIds != "" {
+		_ = json.Unmarshal([]byte(inv.RoleIds), &roleIDs)
+	}
+	return roleIDs
+}
+
+// modelToInvitationInfo 转换邀请信息
+func modelToInvitationInfo(inv *model.Invitation) *dto.InvitationInfo {
+	return &dto.InvitationInfo{
+		InvitationID:   inv.InvitationID,
+		Email:          inv.Email,
+		RoleIDs:        decodeRoleIDs(inv),
+		DepartmentID:   inv.DepartmentID,
+		PositionID:     inv.PositionID,
+		Status:         displayStatus(inv),
+		InvitedBy:      inv.InvitedBy,
+		AcceptedUserID: inv.AcceptedUserID,
+		ExpiresAt:      inv.ExpiresAt,
+		AcceptedAt:     inv.AcceptedAt,
+		CreatedAt:      inv.CreatedAt,
+	}
+}
+
+// modelListToInvitationInfoList 批量转换邀请信息
+func modelListToInvitationInfoList(invitations []*model.Invitation) []*dto.InvitationInfo {
+	list := make([]*dto.InvitationInfo, len(invitations))
+	for i, inv := range invitations {
+		list[i] = modelToInvitationInfo(inv)
+	}
+	return list
+}
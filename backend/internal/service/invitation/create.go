@@ -0,0 +1,282 @@
+package invitation
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/notify"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// CreateInvitation 邀请成员
+// 同一邮箱已有待接受的邀请时撤销旧邀请，旧链接随之失效
+func (s *Service) CreateInvitation(ctx context.Context, req *dto.CreateInvitationRequest) (resp *dto.CreateInvitationResponse, err error) {
+	var invitation *model.Invitation
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleUser),
+				audit.WithError(err),
+			)
+		} else if invitation != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleUser),
+				audit.WithResource(constants.ResourceTypeInvitation, invitation.InvitationID, invitation.Email),
+				audit.WithValue(nil, invitation),
+			)
+		}
+	}()
+
+	tenantID := xcontext.GetTenantID(ctx)
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	// 已是租户成员时无需邀请
+	if _, err := s.findAccount(ctx, tenantID, email); err != nil {
+		return nil, err
+	}
+
+	// 检查租户用户数配额，接受邀请时会再次检查
+	if err := s.quotaSvc.Check(ctx, tenantID, constants.QuotaUsers, 1); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roleRepo.ListByCodesWithTenant(ctx, tenantID, req.RoleCodes)
+	if err != nil {
+		log.Error().Err(err).Strs("role_codes", req.RoleCodes).Msg("查询角色失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色失败", err)
+	}
+	if len(roles) != len(req.RoleCodes) {
+		return nil, xerr.ErrRoleNotFound
+	}
+	roleIDs := make([]string, len(roles))
+	for i, role := range roles {
+		roleIDs[i] = role.RoleID
+	}
+	roleData, err := json.Marshal(roleIDs)
+	if err != nil {
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "编码角色失败", err)
+	}
+
+	if req.DepartmentID != "" {
+		if _, err := s.deptRepo.GetByID(ctx, req.DepartmentID); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, xerr.ErrDeptNotFound
+			}
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询部门失败", err)
+		}
+	}
+	if req.PositionID != "" {
+		if _, err := s.positionRepo.GetByID(ctx, req.PositionID); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, xerr.ErrPositionNotFound
+			}
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询岗位失败", err)
+		}
+	}
+
+	invitationID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成邀请ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成邀请ID失败", err)
+	}
+	nonce, err := passwordgen.GenerateSecret(nonceBytes)
+	if err != nil {
+		log.Error().Err(err).Msg("生成邀请随机数失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成邀请随机数失败", err)
+	}
+
+	invitation = &model.Invitation{
+		InvitationID: invitationID,
+		TenantID:     tenantID,
+		Email:        email,
+		RoleIds:      string(roleData),
+		DepartmentID: req.DepartmentID,
+		PositionID:   req.PositionID,
+		Nonce:        nonce,
+		Status:       constants.InvitationPending,
+		InvitedBy:    xcontext.GetUserID(ctx),
+		ExpiresAt:    time.Now().Add(s.config.Invitation.GetExpire()).UnixMilli(),
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		repo := repository.NewInvitationRepo(tx.DB)
+		pending, err := repo.ListPendingByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		for _, old := range pending {
+			if _, err := repo.UpdatePending(ctx, old.InvitationID, map[string]interface{}{
+				"status": constants.InvitationRevoked,
+			}); err != nil {
+				return err
+			}
+		}
+		return repo.Create(ctx, invitation)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("email", email).Msg("创建邀请失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建邀请失败", err)
+	}
+
+	link := s.buildLink(invitation)
+	s.sendInvitation(ctx, invitation, link)
+
+	log.Info().Str("tenant_id", tenantID).Str("invitation_id", invitationID).Str("email", email).Msg("邀请成员成功")
+	return &dto.CreateInvitationResponse{
+		Invitation: modelToInvitationInfo(invitation),
+		Link:       link,
+	}, nil
+}
+
+// ResendInvitation 重新发送邀请
+// 更换签名随机数并重新计算有效期，之前发出的链接失效
+func (s *Service) ResendInvitation(ctx context.Context, invitationID string) (resp *dto.CreateInvitationResponse, err error) {
+	var oldInvitation, newInvitation *model.Invitation
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithError(err),
+			)
+		} else if newInvitation != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithResource(constants.ResourceTypeInvitation, newInvitation.InvitationID, newInvitation.Email),
+				audit.WithValue(oldInvitation, newInvitation),
+			)
+		}
+	}()
+
+	oldInvitation, err = s.getPending(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := passwordgen.GenerateSecret(nonceBytes)
+	if err != nil {
+		log.Error().Err(err).Msg("生成邀请随机数失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成邀请随机数失败", err)
+	}
+	updated := *oldInvitation
+	updated.Nonce = nonce
+	updated.ExpiresAt = time.Now().Add(s.config.Invitation.GetExpire()).UnixMilli()
+
+	affected, err := s.invitationRepo.UpdatePending(ctx, invitationID, map[string]interface{}{
+		"nonce":      updated.Nonce,
+		"expires_at": updated.ExpiresAt,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("invitation_id", invitationID).Msg("更新邀请失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新邀请失败", err)
+	}
+	if affected == 0 {
+		return nil, xerr.ErrInvitationInvalid
+	}
+	newInvitation = &updated
+
+	link := s.buildLink(newInvitation)
+	s.sendInvitation(ctx, newInvitation, link)
+
+	log.Info().Str("invitation_id", invitationID).Str("email", newInvitation.Email).Msg("重新发送邀请成功")
+	return &dto.CreateInvitationResponse{
+		Invitation: modelToInvitationInfo(newInvitation),
+		Link:       link,
+	}, nil
+}
+
+// RevokeInvitation 撤销邀请，撤销后链接立即失效
+func (s *Service) RevokeInvitation(ctx context.Context, invitationID string) (err error) {
+	var invitation *model.Invitation
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleUser),
+				audit.WithError(err),
+			)
+		} else if invitation != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleUser),
+				audit.WithResource(constants.ResourceTypeInvitation, invitation.InvitationID, invitation.Email),
+				audit.WithValue(invitation, nil),
+			)
+		}
+	}()
+
+	invitation, err = s.getPending(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := s.invitationRepo.UpdatePending(ctx, invitationID, map[string]interface{}{
+		"status": constants.InvitationRevoked,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("invitation_id", invitationID).Msg("撤销邀请失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "撤销邀请失败", err)
+	}
+	if affected == 0 {
+		return xerr.ErrInvitationInvalid
+	}
+
+	log.Info().Str("invitation_id", invitationID).Str("email", invitation.Email).Msg("撤销邀请成功")
+	return nil
+}
+
+// getPending 获取当前租户待接受的邀请（含已过期）
+func (s *Service) getPending(ctx context.Context, invitationID string) (*model.Invitation, error) {
+	invitation, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrInvitationNotFound
+		}
+		log.Error().Err(err).Str("invitation_id", invitationID).Msg("查询邀请失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询邀请失败", err)
+	}
+	if invitation.Status != constants.InvitationPending {
+		return nil, xerr.New(xerr.ErrInvitationInvalid.Code, "邀请已被接受或撤销")
+	}
+	return invitation, nil
+}
+
+// sendInvitation 发送邀请邮件
+// 异步发送，通知失败仅记录日志，管理员可通过返回的链接手动转交
+func (s *Service) sendInvitation(ctx context.Context, invitation *model.Invitation, link string) {
+	if s.notifier == nil {
+		return
+	}
+
+	tenantName := xcontext.GetTenantCode(ctx)
+	if tenant, err := s.tenantRepo.GetByID(ctx, invitation.TenantID); err == nil {
+		tenantName = tenant.Name
+	}
+
+	msg := &notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("邀请您加入 %s", tenantName),
+		Content: fmt.Sprintf("您被邀请加入 %s，请在 %s 前打开以下链接完成注册：%s",
+			tenantName, time.UnixMilli(invitation.ExpiresAt).Format(time.DateTime), link),
+	}
+	go func() {
+		if err := s.notifier.Send(context.Background(), msg); err != nil {
+			log.Warn().Err(err).Str("invitation_id", invitation.InvitationID).Msg("发送邀请邮件失败")
+		}
+	}()
+}
@@ -0,0 +1,52 @@
+package invitation
+
+import (
+	"admin/internal/repository"
+	"admin/internal/service/quota"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/utils/notify"
+	"admin/pkg/utils/rsapwd"
+
+	"gorm.io/gorm"
+)
+
+// Service 成员邀请服务
+// 管理员按邮箱邀请成员并预分配角色、部门和岗位，被邀请人通过邮件中的签名链接接受邀请：
+// 邮箱未注册时设置密码创建本租户账号；已在其他租户有账号时以该账号加入本租户，通过切换租户访问
+type Service struct {
+	db             *gorm.DB
+	invitationRepo *repository.InvitationRepo
+	userRepo       *repository.UserRepo
+	userRoleRepo   *repository.UserRoleRepo
+	memberRepo     *repository.TenantMemberRepo
+	roleRepo       *repository.RoleRepo
+	deptRepo       *repository.DepartmentRepo
+	positionRepo   *repository.PositionRepo
+	tenantRepo     *repository.TenantRepo
+	quotaSvc       *quota.Service
+	recorder       *audit.Recorder
+	rsaCipher      *rsapwd.RSACipher
+	notifier       notify.Sender
+	config         *config.Config
+}
+
+// NewService 创建成员邀请服务
+func NewService(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, notifier notify.Sender, cfg *config.Config) *Service {
+	return &Service{
+		db:             db,
+		invitationRepo: repository.NewInvitationRepo(db),
+		userRepo:       repository.NewUserRepo(db),
+		userRoleRepo:   repository.NewUserRoleRepo(db),
+		memberRepo:     repository.NewTenantMemberRepo(db),
+		roleRepo:       repository.NewRoleRepo(db),
+		deptRepo:       repository.NewDepartmentRepo(db),
+		positionRepo:   repository.NewPositionRepo(db),
+		tenantRepo:     repository.NewTenantRepo(db),
+		quotaSvc:       quota.NewService(db),
+		recorder:       recorder,
+		rsaCipher:      rsaCipher,
+		notifier:       notifier,
+		config:         cfg,
+	}
+}
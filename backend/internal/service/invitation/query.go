@@ -0,0 +1,129 @@
+package invitation
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	tenantconv "admin/internal/service/tenant"
+	"admin/pkg/constants"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ListInvitations 分页获取当前租户的邀请列表
+func (s *Service) ListInvitations(ctx context.Context, req *dto.ListInvitationsRequest) (*dto.ListInvitationsResponse, error) {
+	invitations, total, err := s.invitationRepo.ListWithFilters(ctx, req.GetOffset(), req.GetLimit(), req.Email, req.Status)
+	if err != nil {
+		log.Error().Err(err).Msg("查询邀请列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询邀请列表失败", err)
+	}
+
+	return &dto.ListInvitationsResponse{
+		Response: pagination.NewResponse(req.Request, total),
+		List:     modelListToInvitationInfoList(invitations),
+	}, nil
+}
+
+// PreviewInvitation 校验邀请链接并返回邀请信息（无需认证）
+// 前端据此展示邀请租户，并判断是否需要设置密码
+func (s *Service) PreviewInvitation(ctx context.Context, token string) (*dto.InvitationPreviewResponse, error) {
+	invitation, tenant, err := s.loadByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.findAccount(ctx, invitation.TenantID, invitation.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.InvitationPreviewResponse{
+		TenantCode:      tenant.TenantCode,
+		TenantName:      tenant.Name,
+		Email:           invitation.Email,
+		ExistingAccount: account != nil,
+		ExpiresAt:       invitation.ExpiresAt,
+	}, nil
+}
+
+// loadByToken 校验邀请链接令牌，返回待接受的邀请及其租户
+// 打开邀请链接时还没有当前租户，按邀请ID查找标记为平台级操作
+//
+//tenantscope:allow 未认证时按签名校验的邀请ID查找邀请
+func (s *Service) loadByToken(ctx context.Context, token string) (*model.Invitation, *model.Tenant, error) {
+	ctx = xcontext.SetPlatformScope(ctx)
+	invitationID, signature, ok := parseToken(token)
+	if !ok {
+		return nil, nil, xerr.ErrInvitationInvalid
+	}
+
+	invitation, err := s.invitationRepo.GetByIDManual(ctx, invitationID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, xerr.ErrInvitationInvalid
+		}
+		log.Error().Err(err).Str("invitation_id", invitationID).Msg("查询邀请失败")
+		return nil, nil, xerr.Wrap(xerr.ErrInternal.Code, "查询邀请失败", err)
+	}
+	if !s.verify(invitation, signature) {
+		log.Warn().Str("invitation_id", invitationID).Msg("邀请链接签名无效")
+		return nil, nil, xerr.ErrInvitationInvalid
+	}
+	if invitation.Status != constants.InvitationPending {
+		return nil, nil, xerr.ErrInvitationInvalid
+	}
+	if invitation.ExpiresAt < time.Now().UnixMilli() {
+		return nil, nil, xerr.ErrInvitationExpired
+	}
+
+	tenant, err := s.tenantRepo.GetByIDManual(ctx, invitation.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, xerr.ErrInvitationInvalid
+		}
+		log.Error().Err(err).Str("tenant_id", invitation.TenantID).Msg("查询租户失败")
+		return nil, nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+	if tenant.Status != constants.StatusEnabled {
+		return nil, nil, xerr.ErrTenantDisabled
+	}
+	if err := tenantconv.CheckLifecycle(tenant); err != nil {
+		return nil, nil, err
+	}
+	return invitation, tenant, nil
+}
+
+// findAccount 查找邮箱在其他租户的已有账号，没有时返回 nil
+// 已是该租户成员（主租户账号或已加入）时返回错误；邮箱在多个租户有账号时使用最早注册的账号
+//
+//tenantscope:allow 被邀请人的已有账号可能在任意租户，按邀请邮箱查找
+func (s *Service) findAccount(ctx context.Context, tenantID, email string) (*model.User, error) {
+	ctx = xcontext.SetPlatformScope(ctx)
+	users, err := s.userRepo.ListByEmailManual(ctx, email)
+	if err != nil {
+		log.Error().Err(err).Str("email", email).Msg("查询用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+
+	var account *model.User
+	for _, user := range users {
+		if user.TenantID == tenantID {
+			return nil, xerr.ErrAlreadyTenantMember
+		}
+		if _, err := s.memberRepo.GetByTenantAndUserManual(ctx, tenantID, user.UserID); err == nil {
+			return nil, xerr.ErrAlreadyTenantMember
+		} else if err != gorm.ErrRecordNotFound {
+			log.Error().Err(err).Str("user_id", user.UserID).Msg("查询租户成员失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户成员失败", err)
+		}
+		if account == nil || user.CreatedAt < account.CreatedAt {
+			account = user
+		}
+	}
+	return account, nil
+}
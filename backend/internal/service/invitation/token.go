@@ -0,0 +1,73 @@
+package invitation
+
+import (
+	"admin/internal/dal/model"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// 邀请链接令牌格式：<邀请ID>.<签名>
+// 签名为 HMAC-SHA256(邀请ID|租户ID|邮箱|随机数|过期时间)，随机数保存在邀请记录中，
+// 重新发送时更换随机数使旧链接失效，接受后状态变更使链接不能再次使用
+const (
+	nonceBytes   = 16
+	signDomain   = "admin/invitation/v1"
+	tokenSepChar = "."
+)
+
+// signingKey 派生邀请链接签名密钥，与 JWT 签名密钥隔离
+func (s *Service) signingKey() []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.JWT.AccessSecret))
+	mac.Write([]byte(signDomain))
+	return mac.Sum(nil)
+}
+
+// sign 计算邀请签名
+func (s *Service) sign(inv *model.Invitation) []byte {
+	mac := hmac.New(sha256.New, s.signingKey())
+	mac.Write([]byte(strings.Join([]string{
+		inv.InvitationID,
+		inv.TenantID,
+		inv.Email,
+		inv.Nonce,
+		strconv.FormatInt(inv.ExpiresAt, 10),
+	}, "|")))
+	return mac.Sum(nil)
+}
+
+// formatToken 生成邀请链接令牌
+func (s *Service) formatToken(inv *model.Invitation) string {
+	return inv.InvitationID + tokenSepChar + base64.RawURLEncoding.EncodeToString(s.sign(inv))
+}
+
+// parseToken 解析邀请链接令牌，返回邀请ID和签名
+func parseToken(token string) (invitationID string, signature []byte, ok bool) {
+	invitationID, encoded, found := strings.Cut(token, tokenSepChar)
+	if !found || invitationID == "" {
+		return "", nil, false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, false
+	}
+	return invitationID, signature, true
+}
+
+// verify 校验邀请签名
+func (s *Service) verify(inv *model.Invitation, signature []byte) bool {
+	return hmac.Equal(s.sign(inv), signature)
+}
+
+// buildLink 生成邀请链接
+func (s *Service) buildLink(inv *model.Invitation) string {
+	link := s.config.Invitation.AcceptURL
+	sep := "?"
+	if strings.Contains(link, "?") {
+		sep = "&"
+	}
+	return link + sep + "token=" + url.QueryEscape(s.formatToken(inv))
+}
@@ -12,18 +12,23 @@ func modelToLoginLogInfo(log *model.LoginLog) *dto.LoginLogInfo {
 	}
 
 	return &dto.LoginLogInfo{
-		LogID:         log.LogID,
-		TenantID:      log.TenantID,
-		UserID:        log.UserID,
-		UserName:      log.UserName,
-		OperationType: log.OperationType,
-		LoginType:     log.LoginType,
-		LoginIP:       log.LoginIP,
-		LoginLocation: log.LoginLocation,
-		UserAgent:     log.UserAgent,
-		Status:        log.Status,
-		FailReason:    log.FailReason,
-		CreatedAt:     log.CreatedAt,
+		LogID:             log.LogID,
+		TenantID:          log.TenantID,
+		UserID:            log.UserID,
+		UserName:          log.UserName,
+		OperationType:     log.OperationType,
+		LoginType:         log.LoginType,
+		LoginIP:           log.LoginIP,
+		LoginLocation:     log.LoginLocation,
+		UserAgent:         log.UserAgent,
+		Status:            log.Status,
+		FailReason:        log.FailReason,
+		DeviceFingerprint: log.DeviceFingerprint,
+		CountryCode:       log.CountryCode,
+		RiskScore:         log.RiskScore,
+		RiskFlags:         log.RiskFlags,
+		RiskAction:        log.RiskAction,
+		CreatedAt:         log.CreatedAt,
 	}
 }
 
@@ -0,0 +1,63 @@
+package loginlog
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+)
+
+// CountLoginLogs 按登录日志列表的筛选条件统计日志数（导出时用于判断同步或异步）
+func (s *Service) CountLoginLogs(ctx context.Context, req *dto.ListLoginLogsRequest) (int64, error) {
+	filter, err := loginLogFilter(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := s.loginLogRepo.CountWithFilters(ctx, filter)
+	if err != nil {
+		return 0, xerr.Wrap(xerr.ErrInternal.Code, "统计登录日志数量失败", err)
+	}
+	return total, nil
+}
+
+// StreamLoginLogs 按登录日志列表的筛选条件分批读取日志（用于导出）
+func (s *Service) StreamLoginLogs(ctx context.Context, req *dto.ListLoginLogsRequest, batchSize int, fn func([]*dto.LoginLogInfo) error) error {
+	filter, err := loginLogFilter(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	err = s.loginLogRepo.StreamWithFilters(ctx, filter, batchSize, func(logs []*model.LoginLog) error {
+		return fn(modelListToLoginLogInfoList(logs))
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		return xerr.Wrap(xerr.ErrInternal.Code, "读取导出登录日志数据失败", err)
+	}
+	return nil
+}
+
+// loginLogFilter 将列表请求转换为仓储层筛选条件
+func loginLogFilter(ctx context.Context, req *dto.ListLoginLogsRequest) (repository.LoginLogFilter, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	if tenantID == "" {
+		return repository.LoginLogFilter{}, xerr.ErrUnauthorized
+	}
+
+	return repository.LoginLogFilter{
+		TenantID:      tenantID,
+		UserID:        req.UserID,
+		UserName:      req.UserName,
+		OperationType: req.OperationType,
+		LoginType:     req.LoginType,
+		IPAddress:     req.IPAddress,
+		Status:        req.Status,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+	}, nil
+}
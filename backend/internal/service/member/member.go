@@ -0,0 +1,36 @@
+package member
+
+import (
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/utils/jwt"
+
+	"gorm.io/gorm"
+)
+
+// Service 租户成员服务
+// 管理从其他租户加入当前租户的用户：成员在本租户的状态、部门和岗位独立于其主租户，角色通过用户角色接口分配
+type Service struct {
+	db           *gorm.DB
+	memberRepo   *repository.TenantMemberRepo
+	userRepo     *repository.UserRepo
+	deptRepo     *repository.DepartmentRepo
+	positionRepo *repository.PositionRepo
+	tenantRepo   *repository.TenantRepo
+	jwt          *jwt.Manager
+	recorder     *audit.Recorder
+}
+
+// NewService 创建租户成员服务
+func NewService(db *gorm.DB, jwtMgr *jwt.Manager, recorder *audit.Recorder) *Service {
+	return &Service{
+		db:           db,
+		memberRepo:   repository.NewTenantMemberRepo(db),
+		userRepo:     repository.NewUserRepo(db),
+		deptRepo:     repository.NewDepartmentRepo(db),
+		positionRepo: repository.NewPositionRepo(db),
+		tenantRepo:   repository.NewTenantRepo(db),
+		jwt:          jwtMgr,
+		recorder:     recorder,
+	}
+}
@@ -0,0 +1,80 @@
+package member
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ListMembers 分页获取从其他租户加入当前租户的成员
+func (s *Service) ListMembers(ctx context.Context, req *dto.ListMembersRequest) (*dto.ListMembersResponse, error) {
+	members, total, err := s.memberRepo.ListWithFilters(ctx, req.GetOffset(), req.GetLimit(), req.DepartmentID, req.Status)
+	if err != nil {
+		log.Error().Err(err).Msg("查询租户成员列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户成员列表失败", err)
+	}
+
+	list := make([]*dto.MemberInfo, 0, len(members))
+	if len(members) > 0 {
+		userIDs := make([]string, len(members))
+		for i, m := range members {
+			userIDs[i] = m.UserID
+		}
+		users, err := s.userRepo.ListByIDsManual(ctx, userIDs)
+		if err != nil {
+			log.Error().Err(err).Msg("查询成员账号失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询成员账号失败", err)
+		}
+		userMap := make(map[string]*model.User, len(users))
+		tenantIDs := make([]string, 0, len(users))
+		for _, u := range users {
+			userMap[u.UserID] = u
+			tenantIDs = append(tenantIDs, u.TenantID)
+		}
+		tenants, err := s.tenantRepo.GetByIDsManual(ctx, tenantIDs)
+		if err != nil {
+			log.Error().Err(err).Msg("查询成员主租户失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询成员主租户失败", err)
+		}
+		tenantMap := make(map[string]*model.Tenant, len(tenants))
+		for _, t := range tenants {
+			tenantMap[t.TenantID] = t
+		}
+
+		for _, m := range members {
+			list = append(list, modelToMemberInfo(m, userMap[m.UserID], tenantMap))
+		}
+	}
+
+	return &dto.ListMembersResponse{
+		Response: pagination.NewResponse(req.Request, total),
+		List:     list,
+	}, nil
+}
+
+// modelToMemberInfo 转换租户成员信息，账号已删除时只返回成员身份
+func modelToMemberInfo(m *model.TenantMember, user *model.User, tenants map[string]*model.Tenant) *dto.MemberInfo {
+	info := &dto.MemberInfo{
+		UserID:       m.UserID,
+		Status:       m.Status,
+		DepartmentID: m.DepartmentID,
+		PositionID:   m.PositionID,
+		InvitedBy:    m.InvitedBy,
+		JoinedAt:     m.CreatedAt,
+	}
+	if user != nil {
+		info.UserName = user.UserName
+		info.Nickname = user.Nickname
+		info.Email = user.Email
+		info.Phone = user.Phone
+		if tenant := tenants[user.TenantID]; tenant != nil {
+			info.HomeTenantCode = tenant.TenantCode
+			info.HomeTenantName = tenant.Name
+		}
+	}
+	return info
+}
@@ -0,0 +1,179 @@
+package member
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// UpdateMember 更新成员在当前租户的部门和岗位
+func (s *Service) UpdateMember(ctx context.Context, req *dto.UpdateMemberRequest) (err error) {
+	var oldMember *model.TenantMember
+	updates := map[string]interface{}{
+		"department_id": req.DepartmentID,
+		"position_id":   req.PositionID,
+	}
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithError(err),
+			)
+		} else if oldMember != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithResource(constants.ResourceTypeUser, oldMember.UserID, s.userName(ctx, oldMember.UserID)),
+				audit.WithValue(oldMember, updates),
+			)
+		}
+	}()
+
+	oldMember, err = s.getMember(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if req.DepartmentID != "" {
+		if _, err := s.deptRepo.GetByID(ctx, req.DepartmentID); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return xerr.ErrDeptNotFound
+			}
+			return xerr.Wrap(xerr.ErrInternal.Code, "查询部门失败", err)
+		}
+	}
+	if req.PositionID != "" {
+		if _, err := s.positionRepo.GetByID(ctx, req.PositionID); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return xerr.ErrPositionNotFound
+			}
+			return xerr.Wrap(xerr.ErrInternal.Code, "查询岗位失败", err)
+		}
+	}
+
+	if err := s.memberRepo.Update(ctx, req.UserID, updates); err != nil {
+		log.Error().Err(err).Str("user_id", req.UserID).Msg("更新租户成员失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "更新租户成员失败", err)
+	}
+
+	log.Info().Str("user_id", req.UserID).Str("tenant_id", xcontext.GetTenantID(ctx)).Msg("更新租户成员成功")
+	return nil
+}
+
+// UpdateMemberStatus 启用或禁用成员在当前租户的身份
+// 禁用后不能再切换到当前租户，已切换到当前租户的会话立即失效
+func (s *Service) UpdateMemberStatus(ctx context.Context, req *dto.MemberStatusRequest) (err error) {
+	var member *model.TenantMember
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithError(err),
+			)
+		} else if member != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithResource(constants.ResourceTypeUser, member.UserID, s.userName(ctx, member.UserID)),
+				audit.WithValue(map[string]any{"status": member.Status}, map[string]any{"status": req.Status}),
+			)
+		}
+	}()
+
+	member, err = s.getMember(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.memberRepo.Update(ctx, req.UserID, map[string]interface{}{"status": req.Status}); err != nil {
+		log.Error().Err(err).Str("user_id", req.UserID).Msg("更新租户成员状态失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "更新租户成员状态失败", err)
+	}
+
+	if req.Status == constants.StatusDisabled {
+		s.revokeSessions(ctx, req.UserID)
+	}
+
+	log.Info().Str("user_id", req.UserID).Int("status", req.Status).Msg("更新租户成员状态成功")
+	return nil
+}
+
+// RemoveMember 将成员移出当前租户，同时删除其在当前租户的角色
+func (s *Service) RemoveMember(ctx context.Context, userID string) (err error) {
+	var member *model.TenantMember
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleUser),
+				audit.WithError(err),
+			)
+		} else if member != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleUser),
+				audit.WithResource(constants.ResourceTypeUser, member.UserID, s.userName(ctx, member.UserID)),
+				audit.WithValue(member, nil),
+			)
+		}
+	}()
+
+	member, err = s.getMember(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	tenantID := xcontext.GetTenantID(ctx)
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewTenantMemberRepo(tx.DB).Delete(ctx, userID); err != nil {
+			return err
+		}
+		return repository.NewUserRoleRepo(tx.DB).DeleteUserRoles(ctx, userID, tenantID)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("移除租户成员失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "移除租户成员失败", err)
+	}
+
+	s.revokeSessions(ctx, userID)
+
+	log.Info().Str("user_id", userID).Str("tenant_id", tenantID).Msg("移除租户成员成功")
+	return nil
+}
+
+// getMember 获取当前租户的成员
+func (s *Service) getMember(ctx context.Context, userID string) (*model.TenantMember, error) {
+	member, err := s.memberRepo.GetByUser(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantMemberNotFound
+		}
+		log.Error().Err(err).Str("user_id", userID).Msg("查询租户成员失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户成员失败", err)
+	}
+	return member, nil
+}
+
+// userName 获取成员的用户名（用于审计日志）
+func (s *Service) userName(ctx context.Context, userID string) string {
+	user, err := s.userRepo.GetByIDManual(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return user.UserName
+}
+
+// revokeSessions 吊销成员在当前租户的会话，失败仅记录日志
+func (s *Service) revokeSessions(ctx context.Context, userID string) {
+	if err := s.jwt.RevokeAllUserTokens(ctx, xcontext.GetTenantCode(ctx), userID); err != nil {
+		log.Warn().Err(err).Str("user_id", userID).Msg("吊销成员会话失败")
+	}
+}
@@ -12,22 +12,26 @@ func modelToOperationLogInfo(log *model.OperationLog) *dto.OperationLogInfo {
 	}
 
 	resp := &dto.OperationLogInfo{
-		LogID:         log.LogID,
-		TenantID:      log.TenantID,
-		UserID:        log.UserID,
-		UserName:      log.UserName,
-		Module:        log.Module,
-		OperationType: log.OperationType,
-		ResourceType:  log.ResourceType,
-		ResourceID:    log.ResourceID,
-		ResourceName:  log.ResourceName,
-		RequestMethod: log.RequestMethod,
-		RequestPath:   log.RequestPath,
-		Status:        int(log.Status),
-		ErrorMessage:  log.ErrorMessage,
-		IPAddress:     log.IPAddress,
-		UserAgent:     log.UserAgent,
-		CreatedAt:     log.CreatedAt,
+		LogID:            log.LogID,
+		TenantID:         log.TenantID,
+		UserID:           log.UserID,
+		UserName:         log.UserName,
+		AccessKeyID:      log.AccessKeyID,
+		ImpersonatorID:   log.ImpersonatorID,
+		ImpersonatorName: log.ImpersonatorName,
+		Module:           log.Module,
+		OperationType:    log.OperationType,
+		ResourceType:     log.ResourceType,
+		ResourceID:       log.ResourceID,
+		ResourceName:     log.ResourceName,
+		RequestMethod:    log.RequestMethod,
+		RequestPath:      log.RequestPath,
+		Status:           int(log.Status),
+		ErrorMessage:     log.ErrorMessage,
+		IPAddress:        log.IPAddress,
+		Location:         log.Location,
+		UserAgent:        log.UserAgent,
+		CreatedAt:        log.CreatedAt,
 	}
 
 	resp.RequestParams = log.RequestParams
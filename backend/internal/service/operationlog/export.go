@@ -0,0 +1,62 @@
+package operationlog
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+)
+
+// CountOperationLogs 按操作日志列表的筛选条件统计日志数（导出时用于判断同步或异步）
+func (s *Service) CountOperationLogs(ctx context.Context, req *dto.ListOperationLogsRequest) (int64, error) {
+	filter, err := operationLogFilter(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := s.operationLogRepo.CountWithFilters(ctx, filter)
+	if err != nil {
+		return 0, xerr.Wrap(xerr.ErrInternal.Code, "统计操作日志数量失败", err)
+	}
+	return total, nil
+}
+
+// StreamOperationLogs 按操作日志列表的筛选条件分批读取日志（用于导出）
+func (s *Service) StreamOperationLogs(ctx context.Context, req *dto.ListOperationLogsRequest, batchSize int, fn func([]*dto.OperationLogInfo) error) error {
+	filter, err := operationLogFilter(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	err = s.operationLogRepo.StreamWithFilters(ctx, filter, batchSize, func(logs []*model.OperationLog) error {
+		return fn(modelListToOperationLogInfoList(logs))
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		return xerr.Wrap(xerr.ErrInternal.Code, "读取导出操作日志数据失败", err)
+	}
+	return nil
+}
+
+// operationLogFilter 将列表请求转换为仓储层筛选条件
+func operationLogFilter(ctx context.Context, req *dto.ListOperationLogsRequest) (repository.OperationLogFilter, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	if tenantID == "" {
+		return repository.OperationLogFilter{}, xerr.ErrUnauthorized
+	}
+
+	return repository.OperationLogFilter{
+		TenantID:      tenantID,
+		Module:        req.Module,
+		OperationType: req.OperationType,
+		ResourceType:  req.ResourceType,
+		UserName:      req.UserName,
+		Status:        req.Status,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+	}, nil
+}
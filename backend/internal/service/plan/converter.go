@@ -0,0 +1,30 @@
+package plan
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+)
+
+// modelToPlanInfo 将数据库模型转换为套餐 DTO
+func modelToPlanInfo(plan *model.Plan) *dto.PlanInfo {
+	if plan == nil {
+		return nil
+	}
+
+	return &dto.PlanInfo{
+		PlanID:      plan.PlanID,
+		PlanCode:    plan.PlanCode,
+		Name:        plan.Name,
+		Description: plan.Description,
+		Status:      int(plan.Status),
+		Sort:        int(plan.Sort),
+		PlanQuota: dto.PlanQuota{
+			MaxUsers:       int(plan.MaxUsers),
+			MaxRoles:       int(plan.MaxRoles),
+			MaxDepartments: int(plan.MaxDepartments),
+			MaxStorageMB:   plan.MaxStorageMb,
+		},
+		CreatedAt: plan.CreatedAt,
+		UpdatedAt: plan.UpdatedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package plan
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CreatePlan 创建套餐
+// 新套餐不包含任何权限，需通过设置套餐权限接口配置
+func (s *Service) CreatePlan(ctx context.Context, req *dto.CreatePlanRequest) (resp *dto.PlanInfo, err error) {
+	var plan *model.Plan
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModulePlan),
+				audit.WithError(err),
+			)
+		} else if plan != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModulePlan),
+				audit.WithResource(constants.ResourceTypePlan, plan.PlanID, plan.Name),
+				audit.WithValue(nil, plan),
+			)
+		}
+	}()
+
+	exists, err := s.planRepo.CheckExists(ctx, req.PlanCode)
+	if err != nil {
+		log.Error().Err(err).Str("plan_code", req.PlanCode).Msg("检查套餐编码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查套餐编码失败", err)
+	}
+	if exists {
+		return nil, xerr.ErrPlanCodeExists
+	}
+
+	planID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成套餐ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成套餐ID失败", err)
+	}
+
+	plan = &model.Plan{
+		PlanID:      planID,
+		PlanCode:    req.PlanCode,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      int16(req.Status),
+		Sort:        int32(req.Sort),
+
+		MaxUsers:       int32(req.MaxUsers),
+		MaxRoles:       int32(req.MaxRoles),
+		MaxDepartments: int32(req.MaxDepartments),
+		MaxStorageMb:   req.MaxStorageMB,
+	}
+	if plan.Status == int16(constants.StatusZero) {
+		plan.Status = int16(constants.StatusEnabled)
+	}
+
+	if err := s.planRepo.Create(ctx, plan); err != nil {
+		log.Error().Err(err).Str("plan_code", req.PlanCode).Msg("创建套餐失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建套餐失败", err)
+	}
+
+	return modelToPlanInfo(plan), nil
+}
@@ -0,0 +1,61 @@
+package plan
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DeletePlan 删除套餐
+// 仍有租户使用的套餐不允许删除，需先为这些租户变更套餐
+func (s *Service) DeletePlan(ctx context.Context, planID string) (err error) {
+	var plan *model.Plan
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModulePlan),
+				audit.WithError(err),
+			)
+		} else if plan != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModulePlan),
+				audit.WithResource(constants.ResourceTypePlan, plan.PlanID, plan.Name),
+				audit.WithValue(plan, nil),
+			)
+		}
+	}()
+
+	plan, err = s.getPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.tenantRepo.CountByPlanManual(ctx, planID)
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", planID).Msg("统计套餐租户数失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "统计套餐租户数失败", err)
+	}
+	if count > 0 {
+		return xerr.ErrPlanInUse
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		planRepo := repository.NewPlanRepo(tx.DB)
+		if err := planRepo.DeletePermissions(ctx, planID); err != nil {
+			return err
+		}
+		return planRepo.Delete(ctx, planID)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", planID).Msg("删除套餐失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "删除套餐失败", err)
+	}
+	return nil
+}
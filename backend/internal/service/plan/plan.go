@@ -0,0 +1,32 @@
+package plan
+
+import (
+	"admin/internal/rbac"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+
+	"gorm.io/gorm"
+)
+
+// Service 租户套餐服务
+// 套餐定义租户可使用的菜单与权限点上限，租户角色只能在套餐范围内授权
+type Service struct {
+	db             *gorm.DB
+	planRepo       *repository.PlanRepo
+	permissionRepo *repository.PermissionRepo
+	tenantRepo     *repository.TenantRepo
+	cache          *rbac.PermissionCache
+	recorder       *audit.Recorder
+}
+
+// NewService 创建租户套餐服务
+func NewService(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache) *Service {
+	return &Service{
+		db:             db,
+		planRepo:       repository.NewPlanRepo(db),
+		permissionRepo: repository.NewPermissionRepo(db),
+		tenantRepo:     repository.NewTenantRepo(db),
+		cache:          cache,
+		recorder:       recorder,
+	}
+}
@@ -0,0 +1,102 @@
+package plan
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/constants"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// GetPlan 获取套餐详情（含使用该套餐的租户数）
+func (s *Service) GetPlan(ctx context.Context, planID string) (*dto.PlanInfo, error) {
+	plan, err := s.getPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.tenantRepo.CountByPlanManual(ctx, planID)
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", planID).Msg("统计套餐租户数失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "统计套餐租户数失败", err)
+	}
+
+	info := modelToPlanInfo(plan)
+	info.TenantCount = count
+	return info, nil
+}
+
+// ListPlans 获取套餐列表
+func (s *Service) ListPlans(ctx context.Context, req *dto.ListPlansRequest) (*dto.ListPlansResponse, error) {
+	plans, total, err := s.planRepo.ListWithFilters(ctx, req.GetOffset(), req.GetLimit(), req.Name, req.PlanCode, req.Status)
+	if err != nil {
+		log.Error().Err(err).Msg("查询套餐列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询套餐列表失败", err)
+	}
+
+	list := make([]*dto.PlanInfo, len(plans))
+	for i, plan := range plans {
+		list[i] = modelToPlanInfo(plan)
+	}
+
+	return &dto.ListPlansResponse{
+		List:     list,
+		Response: pagination.NewResponse(req.Request, total),
+	}, nil
+}
+
+// GetPlanPermissions 获取套餐权限（按类型分组）
+func (s *Service) GetPlanPermissions(ctx context.Context, planID string) (*dto.PlanPermissionsResponse, error) {
+	if _, err := s.getPlan(ctx, planID); err != nil {
+		return nil, err
+	}
+
+	permIDs, err := s.planRepo.GetPermissionIDs(ctx, planID)
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", planID).Msg("查询套餐权限失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询套餐权限失败", err)
+	}
+
+	resp := &dto.PlanPermissionsResponse{
+		MenuPermIDs:   []string{},
+		ButtonPermIDs: []string{},
+		APIPermIDs:    []string{},
+	}
+	if len(permIDs) == 0 {
+		return resp, nil
+	}
+
+	perms, err := s.permissionRepo.GetByIDs(ctx, permIDs)
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", planID).Msg("查询权限详情失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询权限详情失败", err)
+	}
+	for _, perm := range perms {
+		switch perm.Type {
+		case constants.TypeMenu:
+			resp.MenuPermIDs = append(resp.MenuPermIDs, perm.PermissionID)
+		case constants.TypeButton:
+			resp.ButtonPermIDs = append(resp.ButtonPermIDs, perm.PermissionID)
+		case constants.TypeAPI:
+			resp.APIPermIDs = append(resp.APIPermIDs, perm.PermissionID)
+		}
+	}
+	return resp, nil
+}
+
+// getPlan 查询套餐，不存在时返回 ErrPlanNotFound
+func (s *Service) getPlan(ctx context.Context, planID string) (*model.Plan, error) {
+	plan, err := s.planRepo.GetByID(ctx, planID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrPlanNotFound
+		}
+		log.Error().Err(err).Str("plan_id", planID).Msg("查询套餐失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询套餐失败", err)
+	}
+	return plan, nil
+}
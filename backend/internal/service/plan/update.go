@@ -0,0 +1,203 @@
+package plan
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpdatePlan 更新套餐基本信息与默认配额
+// 配额下调不影响已创建的资源，仅限制后续新增
+func (s *Service) UpdatePlan(ctx context.Context, req *dto.UpdatePlanRequest) (resp *dto.PlanInfo, err error) {
+	var oldPlan, newPlan *model.Plan
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModulePlan),
+				audit.WithError(err),
+			)
+		} else if newPlan != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModulePlan),
+				audit.WithResource(constants.ResourceTypePlan, newPlan.PlanID, newPlan.Name),
+				audit.WithValue(oldPlan, newPlan),
+			)
+		}
+	}()
+
+	oldPlan, err = s.getPlan(ctx, req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"updated_at": time.Now().UnixMilli(),
+	}
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != "" {
+		updates["description"] = req.Description
+	}
+	if req.Status != 0 {
+		updates["status"] = req.Status
+	}
+	if req.Sort != nil {
+		updates["sort"] = *req.Sort
+	}
+	if req.MaxUsers != nil {
+		updates["max_users"] = *req.MaxUsers
+	}
+	if req.MaxRoles != nil {
+		updates["max_roles"] = *req.MaxRoles
+	}
+	if req.MaxDepartments != nil {
+		updates["max_departments"] = *req.MaxDepartments
+	}
+	if req.MaxStorageMB != nil {
+		updates["max_storage_mb"] = *req.MaxStorageMB
+	}
+
+	if err := s.planRepo.Update(ctx, req.PlanID, updates); err != nil {
+		log.Error().Err(err).Str("plan_id", req.PlanID).Msg("更新套餐失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新套餐失败", err)
+	}
+
+	newPlan, err = s.getPlan(ctx, req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	return modelToPlanInfo(newPlan), nil
+}
+
+// AssignPlanPermissions 设置套餐权限（菜单+按钮+接口）
+// 同时回收使用该套餐的租户中超出新范围的角色授权，并刷新权限缓存
+//
+//tenantscope:allow 回收所有使用该套餐的租户中的角色授权
+func (s *Service) AssignPlanPermissions(ctx context.Context, req *dto.AssignPlanPermissionsRequest) (resp *dto.AssignPlanPermissionsResponse, err error) {
+	var plan *model.Plan
+	var oldPermIDs, newPermIDs []string
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModulePlan),
+				audit.WithError(err),
+			)
+		} else if plan != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModulePlan),
+				audit.WithResource(constants.ResourceTypePlan, plan.PlanID, plan.Name),
+				audit.WithValue(
+					map[string]interface{}{"permission_ids": oldPermIDs},
+					map[string]interface{}{"permission_ids": newPermIDs, "stripped_grants": resp.StrippedGrants},
+				),
+			)
+		}
+	}()
+
+	plan, err = s.getPlan(ctx, req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	newPermIDs, err = s.validatePermissions(ctx, map[string][]string{
+		constants.TypeMenu:   req.MenuPermIDs,
+		constants.TypeButton: req.ButtonPermIDs,
+		constants.TypeAPI:    req.APIPermIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	oldPermIDs, err = s.planRepo.GetPermissionIDs(ctx, req.PlanID)
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", req.PlanID).Msg("查询套餐权限失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询套餐权限失败", err)
+	}
+
+	tenants, err := s.tenantRepo.ListByPlanManual(ctx, req.PlanID)
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", req.PlanID).Msg("查询套餐租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询套餐租户失败", err)
+	}
+	tenantIDs := make([]string, len(tenants))
+	for i, tenant := range tenants {
+		tenantIDs[i] = tenant.TenantID
+	}
+
+	var stripped int64
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		planRepo := repository.NewPlanRepo(tx.DB)
+		if err := planRepo.ReplacePermissions(ctx, req.PlanID, newPermIDs); err != nil {
+			return err
+		}
+		if err := planRepo.Update(ctx, req.PlanID, map[string]interface{}{
+			"updated_at": time.Now().UnixMilli(),
+		}); err != nil {
+			return err
+		}
+		var err error
+		// 授权分布在多个租户，回收时标记为平台级操作
+		stripped, err = repository.NewRolePermissionRepo(tx.DB).DeleteOutsidePermissionsManual(xcontext.SetPlatformScope(ctx), tenantIDs, newPermIDs)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Str("plan_id", req.PlanID).Msg("设置套餐权限失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "设置套餐权限失败", err)
+	}
+
+	s.cache.NotifyRefresh()
+
+	return &dto.AssignPlanPermissionsResponse{
+		PlanID:         req.PlanID,
+		StrippedGrants: stripped,
+	}, nil
+}
+
+// validatePermissions 校验权限ID存在且类型与分组一致，返回去重后的权限ID列表
+func (s *Service) validatePermissions(ctx context.Context, groups map[string][]string) ([]string, error) {
+	expected := make(map[string]string)
+	var permIDs []string
+	for permType, ids := range groups {
+		for _, id := range ids {
+			if _, ok := expected[id]; ok {
+				continue
+			}
+			expected[id] = permType
+			permIDs = append(permIDs, id)
+		}
+	}
+	if len(permIDs) == 0 {
+		return nil, nil
+	}
+
+	perms, err := s.permissionRepo.GetByIDs(ctx, permIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("查询权限详情失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询权限详情失败", err)
+	}
+	found := make(map[string]bool, len(perms))
+	for _, perm := range perms {
+		if perm.Type != expected[perm.PermissionID] {
+			return nil, xerr.New(xerr.ErrInvalidParams.Code, "权限类型不匹配: "+perm.PermissionID)
+		}
+		found[perm.PermissionID] = true
+	}
+	for _, id := range permIDs {
+		if !found[id] {
+			return nil, xerr.New(xerr.ErrInvalidParams.Code, "权限不存在: "+id)
+		}
+	}
+	return permIDs, nil
+}
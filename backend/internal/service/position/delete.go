@@ -43,6 +43,11 @@ func (s *Service) DeletePosition(ctx context.Context, positionID string) (err er
 		return xerr.Wrap(xerr.ErrInternal.Code, "查询岗位失败", err)
 	}
 
+	// 检查岗位下是否有用户
+	if err := s.checkNotInUse(ctx, []string{positionID}); err != nil {
+		return err
+	}
+
 	// 删除岗位
 	if err := s.positionRepo.Delete(ctx, positionID); err != nil {
 		log.Error().Err(err).Str("position_id", positionID).Str("tenant_id", position.TenantID).Msg("删除岗位失败")
@@ -100,6 +105,11 @@ func (s *Service) BatchDeletePositions(ctx context.Context, positionIDs []string
 		return xerr.New(xerr.ErrNotFound.Code, "部分岗位不存在")
 	}
 
+	// 检查岗位下是否有用户
+	if err := s.checkNotInUse(ctx, positionIDs); err != nil {
+		return err
+	}
+
 	// 批量删除岗位
 	if err := s.positionRepo.BatchDelete(ctx, positionIDs); err != nil {
 		log.Error().Err(err).Strs("position_ids", positionIDs).Msg("批量删除岗位失败")
@@ -108,3 +118,27 @@ func (s *Service) BatchDeletePositions(ctx context.Context, positionIDs []string
 
 	return nil
 }
+
+// checkNotInUse 检查岗位未被用户（含兼任）或其他租户加入的成员担任，被担任的岗位不能删除
+func (s *Service) checkNotInUse(ctx context.Context, positionIDs []string) error {
+	counts, err := s.userPosRepo.CountByPositions(ctx, positionIDs)
+	if err != nil {
+		log.Error().Err(err).Strs("position_ids", positionIDs).Msg("统计岗位用户数失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "统计岗位用户数失败", err)
+	}
+	if len(counts) > 0 {
+		log.Warn().Strs("position_ids", positionIDs).Interface("user_counts", counts).Msg("岗位下有用户，无法删除")
+		return xerr.New(xerr.ErrPositionInUse.Code, "岗位下有用户，请先调整用户岗位后再删除")
+	}
+
+	memberCount, err := s.memberRepo.CountByPositions(ctx, positionIDs)
+	if err != nil {
+		log.Error().Err(err).Strs("position_ids", positionIDs).Msg("统计岗位成员数失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "统计岗位成员数失败", err)
+	}
+	if memberCount > 0 {
+		log.Warn().Strs("position_ids", positionIDs).Int64("member_count", memberCount).Msg("岗位下有成员，无法删除")
+		return xerr.New(xerr.ErrPositionInUse.Code, "岗位下有成员，请先调整成员岗位后再删除")
+	}
+	return nil
+}
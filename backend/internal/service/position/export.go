@@ -0,0 +1,35 @@
+package position
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CountPositions 按岗位列表的筛选条件统计岗位数（导出时用于判断同步或异步）
+func (s *Service) CountPositions(ctx context.Context, req *dto.ListPositionsRequest) (int64, error) {
+	total, err := s.positionRepo.CountWithFilters(ctx, req.PositionName, req.PositionCode, req.Status)
+	if err != nil {
+		log.Error().Err(err).Str("position_name", req.PositionName).Msg("统计岗位数量失败")
+		return 0, xerr.Wrap(xerr.ErrInternal.Code, "统计岗位数量失败", err)
+	}
+	return total, nil
+}
+
+// StreamPositions 按岗位列表的筛选条件分批读取岗位（用于导出）
+func (s *Service) StreamPositions(ctx context.Context, req *dto.ListPositionsRequest, batchSize int, fn func([]*dto.PositionInfo) error) error {
+	err := s.positionRepo.StreamWithFilters(ctx, req.PositionName, req.PositionCode, req.Status, batchSize, func(positions []*model.Position) error {
+		return fn(modelListToPositionInfoList(positions))
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		log.Error().Err(err).Str("position_name", req.PositionName).Msg("读取导出岗位数据失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "读取导出岗位数据失败", err)
+	}
+	return nil
+}
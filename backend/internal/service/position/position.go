@@ -10,6 +10,8 @@ import (
 // Service 岗位服务
 type Service struct {
 	positionRepo *repository.PositionRepo
+	userPosRepo  *repository.UserPositionRepo
+	memberRepo   *repository.TenantMemberRepo
 	recorder     *audit.Recorder
 }
 
@@ -17,6 +19,8 @@ type Service struct {
 func NewService(db *gorm.DB, recorder *audit.Recorder) *Service {
 	return &Service{
 		positionRepo: repository.NewPositionRepo(db),
+		userPosRepo:  repository.NewUserPositionRepo(db),
+		memberRepo:   repository.NewTenantMemberRepo(db),
 		recorder:     recorder,
 	}
 }
@@ -0,0 +1,253 @@
+package quota
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/constants"
+	"admin/pkg/xerr"
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// bytesPerMB 存储配额单位换算
+const bytesPerMB = 1024 * 1024
+
+// resources 配额资源类型（决定用量报告中的顺序）
+var resources = []string{
+	constants.QuotaUsers,
+	constants.QuotaRoles,
+	constants.QuotaDepartments,
+	constants.QuotaStorage,
+}
+
+// resourceNames 配额资源名称（用于错误提示）
+var resourceNames = map[string]string{
+	constants.QuotaUsers:       "用户数",
+	constants.QuotaRoles:       "角色数",
+	constants.QuotaDepartments: "部门数",
+	constants.QuotaStorage:     "存储空间",
+}
+
+// quotaLimit 生效的配额上限，0 表示不限制（storage 单位为字节）
+type quotaLimit struct {
+	limit  int64
+	source string
+}
+
+// Service 租户资源配额服务
+// 配额优先取租户单独设置，其次取套餐默认值，均未设置时不限制
+type Service struct {
+	tenantRepo *repository.TenantRepo
+	planRepo   *repository.PlanRepo
+	quotaRepo  *repository.TenantQuotaRepo
+	userRepo   *repository.UserRepo
+	roleRepo   *repository.RoleRepo
+	deptRepo   *repository.DepartmentRepo
+}
+
+// NewService 创建租户资源配额服务
+func NewService(db *gorm.DB) *Service {
+	return &Service{
+		tenantRepo: repository.NewTenantRepo(db),
+		planRepo:   repository.NewPlanRepo(db),
+		quotaRepo:  repository.NewTenantQuotaRepo(db),
+		userRepo:   repository.NewUserRepo(db),
+		roleRepo:   repository.NewRoleRepo(db),
+		deptRepo:   repository.NewDepartmentRepo(db),
+	}
+}
+
+// Check 检查租户新增 delta 个资源后是否超出配额
+// 计数类资源（用户/角色/部门）在创建前调用，存储空间请使用 ReserveStorage
+func (s *Service) Check(ctx context.Context, tenantID, resource string, delta int64) error {
+	tenant, err := s.getTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	limit, err := s.resolveLimit(ctx, tenant, resource)
+	if err != nil {
+		return err
+	}
+	if limit.limit == 0 {
+		return nil
+	}
+
+	used, err := s.countUsage(ctx, tenant, resource)
+	if err != nil {
+		return err
+	}
+	if used+delta > limit.limit {
+		log.Warn().
+			Str("tenant_id", tenantID).
+			Str("resource", resource).
+			Int64("used", used).
+			Int64("limit", limit.limit).
+			Msg("租户资源配额已用尽")
+		return quotaExceeded(resource, used, limit.limit)
+	}
+	return nil
+}
+
+// ReserveStorage 占用租户存储空间（文件上传前调用），超出配额时返回 ErrQuotaExceeded
+// 检查与累加在同一条 UPDATE 中完成，避免并发上传超额
+func (s *Service) ReserveStorage(ctx context.Context, tenantID string, bytes int64) error {
+	tenant, err := s.getTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	limit, err := s.resolveLimit(ctx, tenant, constants.QuotaStorage)
+	if err != nil {
+		return err
+	}
+
+	ok, err := s.tenantRepo.AddStorageUsedManual(ctx, tenantID, bytes, limit.limit)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("更新租户存储用量失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "更新租户存储用量失败", err)
+	}
+	if !ok {
+		return quotaExceeded(constants.QuotaStorage, tenant.StorageUsed, limit.limit)
+	}
+	return nil
+}
+
+// ReleaseStorage 释放租户存储空间（文件删除或上传失败时调用）
+func (s *Service) ReleaseStorage(ctx context.Context, tenantID string, bytes int64) error {
+	if _, err := s.tenantRepo.AddStorageUsedManual(ctx, tenantID, -bytes, 0); err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("更新租户存储用量失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "更新租户存储用量失败", err)
+	}
+	return nil
+}
+
+// GetUsage 获取租户各项资源的用量与配额
+func (s *Service) GetUsage(ctx context.Context, tenantID string) (*dto.TenantUsageResponse, error) {
+	tenant, err := s.getTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := s.resolveLimits(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.TenantUsageResponse{
+		TenantID: tenant.TenantID,
+		PlanID:   tenant.PlanID,
+		Items:    make([]*dto.QuotaUsage, 0, len(resources)),
+	}
+	for _, resource := range resources {
+		used, err := s.countUsage(ctx, tenant, resource)
+		if err != nil {
+			return nil, err
+		}
+		resp.Items = append(resp.Items, &dto.QuotaUsage{
+			Resource: resource,
+			Used:     used,
+			Limit:    limits[resource].limit,
+			Source:   limits[resource].source,
+		})
+	}
+	return resp, nil
+}
+
+// resolveLimit 获取租户某项资源的生效配额
+func (s *Service) resolveLimit(ctx context.Context, tenant *model.Tenant, resource string) (quotaLimit, error) {
+	limits, err := s.resolveLimits(ctx, tenant)
+	if err != nil {
+		return quotaLimit{}, err
+	}
+	return limits[resource], nil
+}
+
+// resolveLimits 获取租户所有资源的生效配额：租户单独设置 > 套餐默认 > 不限制
+func (s *Service) resolveLimits(ctx context.Context, tenant *model.Tenant) (map[string]quotaLimit, error) {
+	limits := make(map[string]quotaLimit, len(resources))
+	for _, resource := range resources {
+		limits[resource] = quotaLimit{source: constants.QuotaSourceNone}
+	}
+
+	if tenant.PlanID != "" {
+		plan, err := s.planRepo.GetByID(ctx, tenant.PlanID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			log.Error().Err(err).Str("plan_id", tenant.PlanID).Msg("查询租户套餐失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户套餐失败", err)
+		}
+		if plan != nil {
+			for resource, limit := range map[string]int64{
+				constants.QuotaUsers:       int64(plan.MaxUsers),
+				constants.QuotaRoles:       int64(plan.MaxRoles),
+				constants.QuotaDepartments: int64(plan.MaxDepartments),
+				constants.QuotaStorage:     plan.MaxStorageMb,
+			} {
+				if limit > 0 {
+					limits[resource] = quotaLimit{limit: limit, source: constants.QuotaSourcePlan}
+				}
+			}
+		}
+	}
+
+	overrides, err := s.quotaRepo.ListByTenantManual(ctx, tenant.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenant.TenantID).Msg("查询租户配额失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户配额失败", err)
+	}
+	for _, override := range overrides {
+		limits[override.Resource] = quotaLimit{limit: override.QuotaLimit, source: constants.QuotaSourceTenant}
+	}
+
+	// 存储配额统一换算为字节
+	storage := limits[constants.QuotaStorage]
+	storage.limit *= bytesPerMB
+	limits[constants.QuotaStorage] = storage
+	return limits, nil
+}
+
+// countUsage 统计租户某项资源的当前用量
+func (s *Service) countUsage(ctx context.Context, tenant *model.Tenant, resource string) (int64, error) {
+	var (
+		used int64
+		err  error
+	)
+	switch resource {
+	case constants.QuotaUsers:
+		used, err = s.userRepo.CountByTenantID(ctx, tenant.TenantID)
+	case constants.QuotaRoles:
+		used, err = s.roleRepo.CountByTenantManual(ctx, tenant.TenantID)
+	case constants.QuotaDepartments:
+		used, err = s.deptRepo.CountByTenantManual(ctx, tenant.TenantID)
+	case constants.QuotaStorage:
+		used = tenant.StorageUsed
+	}
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenant.TenantID).Str("resource", resource).Msg("统计租户资源用量失败")
+		return 0, xerr.Wrap(xerr.ErrInternal.Code, "统计租户资源用量失败", err)
+	}
+	return used, nil
+}
+
+// getTenant 查询租户（跨租户）
+func (s *Service) getTenant(ctx context.Context, tenantID string) (*model.Tenant, error) {
+	tenant, err := s.tenantRepo.GetByIDManual(ctx, tenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+	return tenant, nil
+}
+
+// quotaExceeded 构造配额超限错误
+func quotaExceeded(resource string, used, limit int64) error {
+	return xerr.New(xerr.ErrQuotaExceeded.Code,
+		fmt.Sprintf("%s：%s上限 %d，已使用 %d", xerr.ErrQuotaExceeded.Message, resourceNames[resource], limit, used))
+}
@@ -0,0 +1,70 @@
+package role
+
+import (
+	"admin/internal/dto"
+	"admin/internal/rbac"
+	"admin/internal/service/task"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CacheRebuildTaskType 重建权限缓存的任务类型
+const CacheRebuildTaskType = "permission_cache_rebuild"
+
+// cacheRebuildPayload 重建权限缓存任务参数
+type cacheRebuildPayload struct{}
+
+// cacheRebuildResult 重建权限缓存任务结果
+type cacheRebuildResult struct {
+	Roles       int   `json:"roles"`        // 缓存中拥有权限的角色数
+	RefreshedAt int64 `json:"refreshed_at"` // 重建完成时间(毫秒)
+}
+
+// CacheService 权限缓存重建服务
+// 角色和权限变更时缓存会自动刷新；数据被直接修改（如数据修复、迁移脚本）后由超级管理员提交重建任务，
+// 执行任务的实例立即重建，其他实例在缓存有效期内定时刷新
+type CacheService struct {
+	cache *rbac.PermissionCache
+	queue *task.Queue
+}
+
+// NewCacheService 创建权限缓存重建服务，并向任务工作进程注册重建任务
+func NewCacheService(db *gorm.DB, cache *rbac.PermissionCache, worker *task.Worker) *CacheService {
+	s := &CacheService{
+		cache: cache,
+		queue: task.NewQueue(db),
+	}
+	task.Register(worker, CacheRebuildTaskType, task.Handler[cacheRebuildPayload]{
+		Run:     s.runRebuild,
+		Timeout: 5 * time.Minute,
+	})
+	return s
+}
+
+// StartRebuild 提交重建权限缓存任务（仅超级管理员）
+func (s *CacheService) StartRebuild(ctx context.Context) (*dto.TaskInfo, error) {
+	if !xcontext.HasRole(ctx, constants.SuperAdmin) {
+		return nil, xerr.ErrForbidden
+	}
+	t, err := s.queue.Enqueue(ctx, CacheRebuildTaskType, &cacheRebuildPayload{})
+	if err != nil {
+		return nil, err
+	}
+	return task.ModelToTaskInfo(t), nil
+}
+
+// runRebuild 重新加载所有角色的权限
+func (s *CacheService) runRebuild(ctx context.Context, exec *task.Execution, p *cacheRebuildPayload) (any, error) {
+	if err := s.cache.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return &cacheRebuildResult{
+		Roles:       s.cache.RoleCount(),
+		RefreshedAt: time.Now().UnixMilli(),
+	}, nil
+}
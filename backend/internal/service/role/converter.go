@@ -19,6 +19,7 @@ func ModelToRoleInfo(role *model.Role) *dto.RoleInfo {
 		Name:        role.Name,
 		Description: role.Description,
 		Status:      int(role.Status),
+		TemplateID:  role.TemplateID,
 		CreatedAt:   role.CreatedAt,
 		UpdatedAt:   role.UpdatedAt,
 	}
@@ -38,6 +38,11 @@ func (s *Service) CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (r
 		return nil, xerr.ErrUnauthorized
 	}
 
+	// 检查租户角色数配额
+	if err = s.quotaSvc.Check(ctx, tenantID, constants.QuotaRoles, 1); err != nil {
+		return nil, err
+	}
+
 	// 检查角色编码是否已存在（租户内唯一）
 	var exists bool
 	exists, err = s.roleRepo.CheckExists(ctx, tenantID, req.RoleCode)
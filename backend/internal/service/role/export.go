@@ -0,0 +1,45 @@
+package role
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CountRoles 按角色列表的筛选条件统计角色数（导出时用于判断同步或异步）
+func (s *Service) CountRoles(ctx context.Context, req *dto.ListRolesRequest) (int64, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+
+	total, err := s.roleRepo.CountByTenantWithFilters(ctx, tenantID, req.RoleName, req.RoleCode, req.Status)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("统计角色数量失败")
+		return 0, xerr.Wrap(xerr.ErrInternal.Code, "统计角色数量失败", err)
+	}
+	return total, nil
+}
+
+// StreamRoles 按角色列表的筛选条件和数据范围分批读取角色（用于导出）
+func (s *Service) StreamRoles(ctx context.Context, req *dto.ListRolesRequest, batchSize int, fn func([]*dto.RoleInfo) error) error {
+	tenantID := xcontext.GetTenantID(ctx)
+
+	err := s.roleRepo.StreamByTenantWithFilters(ctx, tenantID, req.RoleName, req.RoleCode, req.Status, batchSize, func(roles []*model.Role) error {
+		// 与 ListRoles 一致：非超管不导出 super_admin 角色
+		roles = s.filterSuperAdminRoles(ctx, roles)
+		if len(roles) == 0 {
+			return nil
+		}
+		return fn(ModelListToRoleInfoList(roles))
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("读取导出角色数据失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "读取导出角色数据失败", err)
+	}
+	return nil
+}
@@ -50,6 +50,11 @@ func (s *Service) AssignPermissions(ctx context.Context, roleID string, req *dto
 
 	tenantID := xcontext.GetTenantID(ctx)
 
+	// 校验权限是否在租户套餐范围内
+	if err := s.checkPlanCeiling(ctx, tenantID, append(append([]string{}, req.MenuPermIDs...), req.ButtonPermIDs...)); err != nil {
+		return err
+	}
+
 	// 2. 清除角色的所有现有权限（role_permissions 表）
 	if err := s.rolePermRepo.DeleteByRole(ctx, roleID, tenantID); err != nil {
 		log.Error().Err(err).Str("role_id", roleID).Msg("清除旧权限失败")
@@ -142,6 +147,34 @@ func (s *Service) GetRolePermissions(ctx context.Context, roleID string) (*dto.R
 	}, nil
 }
 
+// checkPlanCeiling 校验权限是否都在租户套餐范围内，租户未绑定套餐时不限制
+func (s *Service) checkPlanCeiling(ctx context.Context, tenantID string, permIDs []string) error {
+	if len(permIDs) == 0 {
+		return nil
+	}
+
+	allowed, limited, err := s.planRepo.GetTenantCeilingManual(ctx, tenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户套餐权限失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询租户套餐权限失败", err)
+	}
+	if !limited {
+		return nil
+	}
+
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, id := range allowed {
+		allowedSet[id] = true
+	}
+	for _, id := range permIDs {
+		if !allowedSet[id] {
+			log.Warn().Str("tenant_id", tenantID).Str("permission_id", id).Msg("权限超出租户套餐范围")
+			return xerr.New(xerr.ErrPermissionOutOfPlan.Code, xerr.ErrPermissionOutOfPlan.Message+": "+id)
+		}
+	}
+	return nil
+}
+
 // getDefaultTenantID 获取 default 租户ID
 func (s *Service) getDefaultTenantID(ctx context.Context) (string, error) {
 	tenant, err := s.tenantRepo.GetByCode(ctx, constants.DefaultTenantCode)
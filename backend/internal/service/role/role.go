@@ -3,6 +3,7 @@ package role
 import (
 	"admin/internal/rbac"
 	"admin/internal/repository"
+	"admin/internal/service/quota"
 	"admin/pkg/audit"
 
 	"gorm.io/gorm"
@@ -17,6 +18,8 @@ type Service struct {
 	userRoleRepo   *repository.UserRoleRepo
 	cache          *rbac.PermissionCache
 	tenantRepo     *repository.TenantRepo
+	planRepo       *repository.PlanRepo
+	quotaSvc       *quota.Service
 	recorder       *audit.Recorder
 }
 
@@ -30,6 +33,8 @@ func NewService(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCac
 		userRoleRepo:   repository.NewUserRoleRepo(db),
 		cache:          cache,
 		tenantRepo:     repository.NewTenantRepo(db),
+		planRepo:       repository.NewPlanRepo(db),
+		quotaSvc:       quota.NewService(db),
 		recorder:       recorder,
 	}
 }
@@ -0,0 +1,34 @@
+package roletemplate
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+)
+
+// modelToRoleTemplateInfo 将数据库模型转换为角色模板 DTO
+func modelToRoleTemplateInfo(template *model.RoleTemplate) *dto.RoleTemplateInfo {
+	if template == nil {
+		return nil
+	}
+
+	return &dto.RoleTemplateInfo{
+		TemplateID:   template.TemplateID,
+		TemplateCode: template.TemplateCode,
+		Name:         template.Name,
+		Description:  template.Description,
+		Status:       int(template.Status),
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
+
+// modelToPermissionBrief 将权限模型转换为权限摘要
+func modelToPermissionBrief(perm *model.Permission) *dto.PermissionBrief {
+	return &dto.PermissionBrief{
+		PermissionID: perm.PermissionID,
+		Name:         perm.Name,
+		Type:         perm.Type,
+		Resource:     perm.Resource,
+		Action:       perm.Action,
+	}
+}
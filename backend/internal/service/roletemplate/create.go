@@ -0,0 +1,66 @@
+package roletemplate
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CreateRoleTemplate 创建角色模板
+func (s *Service) CreateRoleTemplate(ctx context.Context, req *dto.CreateRoleTemplateRequest) (resp *dto.RoleTemplateInfo, err error) {
+	var template *model.RoleTemplate
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleRoleTemplate),
+				audit.WithError(err),
+			)
+		} else if template != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleRoleTemplate),
+				audit.WithResource(constants.ResourceTypeRoleTemplate, template.TemplateID, template.Name),
+				audit.WithValue(nil, template),
+			)
+		}
+	}()
+
+	exists, err := s.templateRepo.CheckExists(ctx, req.TemplateCode)
+	if err != nil {
+		log.Error().Err(err).Str("template_code", req.TemplateCode).Msg("检查角色模板编码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查角色模板编码失败", err)
+	}
+	if exists {
+		return nil, xerr.ErrRoleTemplateCodeExists
+	}
+
+	templateID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成角色模板ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成角色模板ID失败", err)
+	}
+
+	template = &model.RoleTemplate{
+		TemplateID:   templateID,
+		TemplateCode: req.TemplateCode,
+		Name:         req.Name,
+		Description:  req.Description,
+		Status:       int16(req.Status),
+	}
+	if template.Status == int16(constants.StatusZero) {
+		template.Status = int16(constants.StatusEnabled)
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		log.Error().Err(err).Str("template_code", req.TemplateCode).Msg("创建角色模板失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建角色模板失败", err)
+	}
+
+	return modelToRoleTemplateInfo(template), nil
+}
@@ -0,0 +1,55 @@
+package roletemplate
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DeleteRoleTemplate 删除角色模板
+// 派生角色及其权限保留，仅解除与模板的关联，之后不再参与同步
+func (s *Service) DeleteRoleTemplate(ctx context.Context, templateID string) (err error) {
+	var template *model.RoleTemplate
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleRoleTemplate),
+				audit.WithError(err),
+			)
+		} else if template != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleRoleTemplate),
+				audit.WithResource(constants.ResourceTypeRoleTemplate, template.TemplateID, template.Name),
+				audit.WithValue(template, nil),
+			)
+		}
+	}()
+
+	template, err = s.getTemplate(ctx, templateID)
+	if err != nil {
+		return err
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewRoleRepo(tx.DB).UnlinkTemplateManual(derivedScope(ctx), templateID); err != nil {
+			return err
+		}
+		templateRepo := repository.NewRoleTemplateRepo(tx.DB)
+		if err := templateRepo.DeletePermissions(ctx, templateID); err != nil {
+			return err
+		}
+		return templateRepo.Delete(ctx, templateID)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("删除角色模板失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "删除角色模板失败", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package roletemplate
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/internal/service/role"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// InstantiateRoleTemplate 由模板在租户下创建角色
+// 复制模板当前的权限并记录来源模板，之后可通过同步接口跟随模板更新
+func (s *Service) InstantiateRoleTemplate(ctx context.Context, req *dto.InstantiateRoleTemplateRequest) (resp *dto.RoleInfo, err error) {
+	var newRole *model.Role
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleRole),
+				audit.WithError(err),
+			)
+		} else if newRole != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleRole),
+				audit.WithResource(constants.ResourceTypeRole, newRole.RoleID, newRole.Name),
+				audit.WithValue(nil, newRole),
+			)
+		}
+	}()
+
+	// 仅超级管理员可为其他租户创建角色
+	tenantID := xcontext.GetTenantID(ctx)
+	if req.TenantID != "" && req.TenantID != tenantID {
+		if !xcontext.HasRole(ctx, constants.SuperAdmin) {
+			return nil, xerr.ErrForbidden
+		}
+		if _, err := s.tenantRepo.GetByIDManual(ctx, req.TenantID); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, xerr.ErrTenantNotFound
+			}
+			log.Error().Err(err).Str("tenant_id", req.TenantID).Msg("查询租户失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+		}
+		tenantID = req.TenantID
+	}
+	if tenantID == "" {
+		return nil, xerr.ErrUnauthorized
+	}
+
+	// 检查目标租户角色数配额
+	if err = s.quotaSvc.Check(ctx, tenantID, constants.QuotaRoles, 1); err != nil {
+		return nil, err
+	}
+
+	template, err := s.getTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.Status != int16(constants.StatusEnabled) {
+		return nil, xerr.ErrRoleTemplateDisabled
+	}
+
+	roleCode := req.RoleCode
+	if roleCode == "" {
+		roleCode = template.TemplateCode
+	}
+	name := req.Name
+	if name == "" {
+		name = template.Name
+	}
+	description := req.Description
+	if description == "" {
+		description = template.Description
+	}
+
+	exists, err := s.roleRepo.CheckExists(ctx, tenantID, roleCode)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("role_code", roleCode).Msg("检查角色编码是否存在失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查角色编码是否存在失败", err)
+	}
+	if exists {
+		return nil, xerr.ErrRoleCodeExists
+	}
+
+	permIDs, err := s.templateRepo.GetPermissionIDs(ctx, template.TemplateID)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", template.TemplateID).Msg("查询角色模板权限失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色模板权限失败", err)
+	}
+	// 超出租户套餐的模板权限不授予
+	permIDs, err = s.planRepo.FilterByTenantCeilingManual(ctx, tenantID, permIDs)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户套餐权限失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户套餐权限失败", err)
+	}
+
+	roleID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成角色ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成角色ID失败", err)
+	}
+	candidate := &model.Role{
+		RoleID:      roleID,
+		RoleCode:    roleCode,
+		Name:        name,
+		Description: description,
+		Status:      int16(constants.StatusEnabled),
+		TemplateID:  template.TemplateID,
+	}
+
+	// 角色及权限在目标租户下写入
+	tenantCtx := xcontext.SetTenantID(ctx, tenantID)
+	err = database.InTransactionWithCtx(tenantCtx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewRoleRepo(tx.DB).Create(ctx, candidate); err != nil {
+			return err
+		}
+		if len(permIDs) == 0 {
+			return nil
+		}
+		items := make([]*model.RolePermission, 0, len(permIDs))
+		for _, permID := range permIDs {
+			items = append(items, &model.RolePermission{
+				RoleID:       roleID,
+				PermissionID: permID,
+				TenantID:     tenantID,
+			})
+		}
+		return repository.NewRolePermissionRepo(tx.DB).AddPermissions(ctx, items)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("template_id", template.TemplateID).Msg("由模板创建角色失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "由模板创建角色失败", err)
+	}
+	newRole = candidate
+
+	s.cache.NotifyRefresh()
+
+	return role.ModelToRoleInfo(newRole), nil
+}
@@ -0,0 +1,102 @@
+package roletemplate
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/constants"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// GetRoleTemplate 获取角色模板详情（含派生角色数量）
+func (s *Service) GetRoleTemplate(ctx context.Context, templateID string) (*dto.RoleTemplateInfo, error) {
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.roleRepo.CountByTemplateManual(derivedScope(ctx), templateID)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("统计派生角色失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "统计派生角色失败", err)
+	}
+
+	info := modelToRoleTemplateInfo(template)
+	info.RoleCount = count
+	return info, nil
+}
+
+// ListRoleTemplates 获取角色模板列表
+func (s *Service) ListRoleTemplates(ctx context.Context, req *dto.ListRoleTemplatesRequest) (*dto.ListRoleTemplatesResponse, error) {
+	templates, total, err := s.templateRepo.ListWithFilters(ctx, req.GetOffset(), req.GetLimit(), req.Name, req.TemplateCode, req.Status)
+	if err != nil {
+		log.Error().Err(err).Msg("查询角色模板列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色模板列表失败", err)
+	}
+
+	list := make([]*dto.RoleTemplateInfo, len(templates))
+	for i, template := range templates {
+		list[i] = modelToRoleTemplateInfo(template)
+	}
+
+	return &dto.ListRoleTemplatesResponse{
+		List:     list,
+		Response: pagination.NewResponse(req.Request, total),
+	}, nil
+}
+
+// GetRoleTemplatePermissions 获取角色模板的权限（按类型分组）
+func (s *Service) GetRoleTemplatePermissions(ctx context.Context, templateID string) (*dto.RoleTemplatePermissionsResponse, error) {
+	if _, err := s.getTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	permIDs, err := s.templateRepo.GetPermissionIDs(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("查询角色模板权限失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色模板权限失败", err)
+	}
+
+	resp := &dto.RoleTemplatePermissionsResponse{
+		MenuPermIDs:   []string{},
+		ButtonPermIDs: []string{},
+		APIPermIDs:    []string{},
+	}
+	if len(permIDs) == 0 {
+		return resp, nil
+	}
+
+	perms, err := s.permissionRepo.GetByIDs(ctx, permIDs)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("查询权限详情失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询权限详情失败", err)
+	}
+	for _, perm := range perms {
+		switch perm.Type {
+		case constants.TypeMenu:
+			resp.MenuPermIDs = append(resp.MenuPermIDs, perm.PermissionID)
+		case constants.TypeButton:
+			resp.ButtonPermIDs = append(resp.ButtonPermIDs, perm.PermissionID)
+		case constants.TypeAPI:
+			resp.APIPermIDs = append(resp.APIPermIDs, perm.PermissionID)
+		}
+	}
+	return resp, nil
+}
+
+// getTemplate 查询角色模板，不存在时返回 ErrRoleTemplateNotFound
+func (s *Service) getTemplate(ctx context.Context, templateID string) (*model.RoleTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrRoleTemplateNotFound
+		}
+		log.Error().Err(err).Str("template_id", templateID).Msg("查询角色模板失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色模板失败", err)
+	}
+	return template, nil
+}
@@ -0,0 +1,50 @@
+package roletemplate
+
+import (
+	"admin/internal/rbac"
+	"admin/internal/repository"
+	"admin/internal/service/quota"
+	"admin/pkg/audit"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Service 角色模板服务
+// 模板由平台维护，租户通过实例化获得派生角色，模板权限变更后可同步到所有派生角色
+type Service struct {
+	db             *gorm.DB
+	templateRepo   *repository.RoleTemplateRepo
+	roleRepo       *repository.RoleRepo
+	rolePermRepo   *repository.RolePermissionRepo
+	permissionRepo *repository.PermissionRepo
+	tenantRepo     *repository.TenantRepo
+	planRepo       *repository.PlanRepo
+	quotaSvc       *quota.Service
+	cache          *rbac.PermissionCache
+	recorder       *audit.Recorder
+}
+
+// NewService 创建角色模板服务
+func NewService(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache) *Service {
+	return &Service{
+		db:             db,
+		templateRepo:   repository.NewRoleTemplateRepo(db),
+		roleRepo:       repository.NewRoleRepo(db),
+		rolePermRepo:   repository.NewRolePermissionRepo(db),
+		permissionRepo: repository.NewPermissionRepo(db),
+		tenantRepo:     repository.NewTenantRepo(db),
+		planRepo:       repository.NewPlanRepo(db),
+		quotaSvc:       quota.NewService(db),
+		cache:          cache,
+		recorder:       recorder,
+	}
+}
+
+// derivedScope 派生角色分布在所有租户，统计、同步和解除关联时标记为平台级操作
+//
+//tenantscope:allow 模板由平台维护，按模板ID访问所有租户的派生角色
+func derivedScope(ctx context.Context) context.Context {
+	return xcontext.SetPlatformScope(ctx)
+}
@@ -0,0 +1,240 @@
+package roletemplate
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// roleSyncPlan 单个派生角色的同步计划
+type roleSyncPlan struct {
+	role    *model.Role
+	target  []string
+	added   []string
+	removed []string
+}
+
+// PreviewRoleTemplateSync 预览模板同步到派生角色后的权限变化
+// 只返回存在差异的角色，不做任何修改
+func (s *Service) PreviewRoleTemplateSync(ctx context.Context, templateID string) (*dto.RoleTemplateSyncPreviewResponse, error) {
+	template, err := s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, total, err := s.buildSyncPlans(ctx, template.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RoleTemplateSyncPreviewResponse{
+		TemplateID:   template.TemplateID,
+		TotalRoles:   total,
+		ChangedRoles: len(plans),
+		Roles:        make([]*dto.RoleTemplateRoleDiff, 0, len(plans)),
+	}
+	if len(plans) == 0 {
+		return resp, nil
+	}
+
+	// 批量加载权限摘要与租户编码
+	var permIDs, tenantIDs []string
+	for _, plan := range plans {
+		permIDs = append(permIDs, plan.added...)
+		permIDs = append(permIDs, plan.removed...)
+		tenantIDs = append(tenantIDs, plan.role.TenantID)
+	}
+	perms, err := s.permissionRepo.GetByIDs(ctx, permIDs)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("查询权限详情失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询权限详情失败", err)
+	}
+	permMap := make(map[string]*model.Permission, len(perms))
+	for _, perm := range perms {
+		permMap[perm.PermissionID] = perm
+	}
+	tenants, err := s.tenantRepo.GetByIDsManual(ctx, tenantIDs)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("查询租户信息失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户信息失败", err)
+	}
+	tenantCodes := make(map[string]string, len(tenants))
+	for _, tenant := range tenants {
+		tenantCodes[tenant.TenantID] = tenant.TenantCode
+	}
+
+	toBriefs := func(ids []string) []*dto.PermissionBrief {
+		briefs := make([]*dto.PermissionBrief, 0, len(ids))
+		for _, id := range ids {
+			if perm, ok := permMap[id]; ok {
+				briefs = append(briefs, modelToPermissionBrief(perm))
+			} else {
+				// 权限已被删除，仅返回ID
+				briefs = append(briefs, &dto.PermissionBrief{PermissionID: id})
+			}
+		}
+		return briefs
+	}
+
+	for _, plan := range plans {
+		resp.Roles = append(resp.Roles, &dto.RoleTemplateRoleDiff{
+			RoleID:     plan.role.RoleID,
+			RoleCode:   plan.role.RoleCode,
+			RoleName:   plan.role.Name,
+			TenantID:   plan.role.TenantID,
+			TenantCode: tenantCodes[plan.role.TenantID],
+			Added:      toBriefs(plan.added),
+			Removed:    toBriefs(plan.removed),
+		})
+	}
+	return resp, nil
+}
+
+// SyncRoleTemplate 将模板权限同步到所有派生角色
+// 派生角色的权限被重置为与模板一致（受租户套餐限制），所有角色在同一事务中更新
+func (s *Service) SyncRoleTemplate(ctx context.Context, templateID string) (resp *dto.RoleTemplateSyncResponse, err error) {
+	var template *model.RoleTemplate
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleRoleTemplate),
+				audit.WithError(err),
+			)
+		} else if template != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleRoleTemplate),
+				audit.WithResource(constants.ResourceTypeRoleTemplate, template.TemplateID, template.Name),
+				audit.WithValue(nil, resp),
+			)
+		}
+	}()
+
+	template, err = s.getTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, total, err := s.buildSyncPlans(ctx, template.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &dto.RoleTemplateSyncResponse{
+		TemplateID:  template.TemplateID,
+		TotalRoles:  total,
+		SyncedRoles: len(plans),
+	}
+	if len(plans) == 0 {
+		return resp, nil
+	}
+
+	err = database.InTransactionWithCtx(derivedScope(ctx), s.db, func(ctx context.Context, tx *database.Tx) error {
+		rolePermRepo := repository.NewRolePermissionRepo(tx.DB)
+		for _, plan := range plans {
+			if err := rolePermRepo.DeleteByRole(ctx, plan.role.RoleID, plan.role.TenantID); err != nil {
+				return err
+			}
+			if len(plan.target) == 0 {
+				continue
+			}
+			items := make([]*model.RolePermission, 0, len(plan.target))
+			for _, permID := range plan.target {
+				items = append(items, &model.RolePermission{
+					RoleID:       plan.role.RoleID,
+					PermissionID: permID,
+					TenantID:     plan.role.TenantID,
+				})
+			}
+			if err := rolePermRepo.AddPermissions(ctx, items); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("同步角色模板失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "同步角色模板失败", err)
+	}
+
+	s.cache.NotifyRefresh()
+
+	log.Info().
+		Str("template_id", templateID).
+		Int("total_roles", total).
+		Int("synced_roles", len(plans)).
+		Msg("角色模板同步完成")
+
+	return resp, nil
+}
+
+// buildSyncPlans 比对模板与各派生角色的权限，返回存在差异的角色及派生角色总数
+// 各角色的目标权限为模板权限与所属租户套餐的交集
+func (s *Service) buildSyncPlans(ctx context.Context, templateID string) ([]*roleSyncPlan, int, error) {
+	ctx = derivedScope(ctx)
+	templatePermIDs, err := s.templateRepo.GetPermissionIDs(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("查询角色模板权限失败")
+		return nil, 0, xerr.Wrap(xerr.ErrInternal.Code, "查询角色模板权限失败", err)
+	}
+
+	roles, err := s.roleRepo.ListByTemplateManual(ctx, templateID)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", templateID).Msg("查询派生角色失败")
+		return nil, 0, xerr.Wrap(xerr.ErrInternal.Code, "查询派生角色失败", err)
+	}
+
+	var plans []*roleSyncPlan
+	targets := make(map[string][]string)
+	for _, r := range roles {
+		target, ok := targets[r.TenantID]
+		if !ok {
+			target, err = s.planRepo.FilterByTenantCeilingManual(ctx, r.TenantID, templatePermIDs)
+			if err != nil {
+				log.Error().Err(err).Str("tenant_id", r.TenantID).Msg("查询租户套餐权限失败")
+				return nil, 0, xerr.Wrap(xerr.ErrInternal.Code, "查询租户套餐权限失败", err)
+			}
+			targets[r.TenantID] = target
+		}
+
+		current, err := s.rolePermRepo.GetPermissionIDsByRole(ctx, r.RoleID, r.TenantID)
+		if err != nil {
+			log.Error().Err(err).Str("role_id", r.RoleID).Msg("查询角色权限失败")
+			return nil, 0, xerr.Wrap(xerr.ErrInternal.Code, "查询角色权限失败", err)
+		}
+		added, removed := diffPermissionIDs(current, target)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		plans = append(plans, &roleSyncPlan{role: r, target: target, added: added, removed: removed})
+	}
+	return plans, len(roles), nil
+}
+
+// diffPermissionIDs 计算从 current 变为 target 需要新增和移除的权限ID
+func diffPermissionIDs(current, target []string) (added, removed []string) {
+	currentSet := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+	targetSet := make(map[string]bool, len(target))
+	for _, id := range target {
+		targetSet[id] = true
+		if !currentSet[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range current {
+		if !targetSet[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
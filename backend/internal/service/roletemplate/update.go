@@ -0,0 +1,158 @@
+package roletemplate
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpdateRoleTemplate 更新角色模板基本信息
+func (s *Service) UpdateRoleTemplate(ctx context.Context, req *dto.UpdateRoleTemplateRequest) (resp *dto.RoleTemplateInfo, err error) {
+	var oldTemplate, newTemplate *model.RoleTemplate
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleRoleTemplate),
+				audit.WithError(err),
+			)
+		} else if newTemplate != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleRoleTemplate),
+				audit.WithResource(constants.ResourceTypeRoleTemplate, newTemplate.TemplateID, newTemplate.Name),
+				audit.WithValue(oldTemplate, newTemplate),
+			)
+		}
+	}()
+
+	oldTemplate, err = s.getTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"updated_at": time.Now().UnixMilli(),
+	}
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != "" {
+		updates["description"] = req.Description
+	}
+	if req.Status != 0 {
+		updates["status"] = req.Status
+	}
+
+	if err := s.templateRepo.Update(ctx, req.TemplateID, updates); err != nil {
+		log.Error().Err(err).Str("template_id", req.TemplateID).Msg("更新角色模板失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新角色模板失败", err)
+	}
+
+	newTemplate, err = s.getTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	return modelToRoleTemplateInfo(newTemplate), nil
+}
+
+// AssignRoleTemplatePermissions 设置角色模板权限（菜单+按钮+接口）
+// 只修改模板本身，派生角色需通过同步接口预览后再应用
+func (s *Service) AssignRoleTemplatePermissions(ctx context.Context, req *dto.AssignRoleTemplatePermissionsRequest) (err error) {
+	var template *model.RoleTemplate
+	var oldPermIDs, newPermIDs []string
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleRoleTemplate),
+				audit.WithError(err),
+			)
+		} else if template != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleRoleTemplate),
+				audit.WithResource(constants.ResourceTypeRoleTemplate, template.TemplateID, template.Name),
+				audit.WithValue(oldPermIDs, newPermIDs),
+			)
+		}
+	}()
+
+	template, err = s.getTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return err
+	}
+
+	newPermIDs, err = s.validatePermissions(ctx, map[string][]string{
+		constants.TypeMenu:   req.MenuPermIDs,
+		constants.TypeButton: req.ButtonPermIDs,
+		constants.TypeAPI:    req.APIPermIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	oldPermIDs, err = s.templateRepo.GetPermissionIDs(ctx, req.TemplateID)
+	if err != nil {
+		log.Error().Err(err).Str("template_id", req.TemplateID).Msg("查询角色模板权限失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询角色模板权限失败", err)
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		templateRepo := repository.NewRoleTemplateRepo(tx.DB)
+		if err := templateRepo.ReplacePermissions(ctx, req.TemplateID, newPermIDs); err != nil {
+			return err
+		}
+		return templateRepo.Update(ctx, req.TemplateID, map[string]interface{}{
+			"updated_at": time.Now().UnixMilli(),
+		})
+	})
+	if err != nil {
+		log.Error().Err(err).Str("template_id", req.TemplateID).Msg("设置角色模板权限失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "设置角色模板权限失败", err)
+	}
+	return nil
+}
+
+// validatePermissions 校验权限ID存在且类型与分组一致，返回去重后的权限ID列表
+func (s *Service) validatePermissions(ctx context.Context, groups map[string][]string) ([]string, error) {
+	expected := make(map[string]string)
+	var permIDs []string
+	for permType, ids := range groups {
+		for _, id := range ids {
+			if _, ok := expected[id]; ok {
+				continue
+			}
+			expected[id] = permType
+			permIDs = append(permIDs, id)
+		}
+	}
+	if len(permIDs) == 0 {
+		return nil, nil
+	}
+
+	perms, err := s.permissionRepo.GetByIDs(ctx, permIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("查询权限详情失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询权限详情失败", err)
+	}
+	found := make(map[string]bool, len(perms))
+	for _, perm := range perms {
+		if perm.Type != expected[perm.PermissionID] {
+			return nil, xerr.New(xerr.ErrInvalidParams.Code, "权限类型不匹配: "+perm.PermissionID)
+		}
+		found[perm.PermissionID] = true
+	}
+	for _, id := range permIDs {
+		if !found[id] {
+			return nil, xerr.New(xerr.ErrInvalidParams.Code, "权限不存在: "+id)
+		}
+	}
+	return permIDs, nil
+}
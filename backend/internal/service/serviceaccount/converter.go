@@ -0,0 +1,28 @@
+package serviceaccount
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+)
+
+// modelToServiceAccountInfo 将用户及凭证模型转换为服务账号信息 DTO（不含密钥哈希）
+func modelToServiceAccountInfo(user *model.User, cred *model.ServiceAccountCredential) *dto.ServiceAccountInfo {
+	if user == nil {
+		return nil
+	}
+
+	info := &dto.ServiceAccountInfo{
+		UserID:      user.UserID,
+		UserName:    user.UserName,
+		Nickname:    user.Nickname,
+		Description: user.Description,
+		Status:      int(user.Status),
+		CreatedAt:   user.CreatedAt,
+	}
+	if cred != nil {
+		info.ClientID = cred.ClientID
+		info.SecretRotatedAt = cred.SecretRotatedAt
+		info.LastUsedAt = cred.LastUsedAt
+	}
+	return info
+}
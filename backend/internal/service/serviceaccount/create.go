@@ -0,0 +1,140 @@
+package serviceaccount
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// CreateServiceAccount 创建服务账号
+// 同时创建用户记录与客户端凭证，客户端密钥明文仅在此返回一次
+func (s *Service) CreateServiceAccount(ctx context.Context, req *dto.CreateServiceAccountRequest) (resp *dto.CreateServiceAccountResponse, err error) {
+	var info *dto.ServiceAccountInfo
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleServiceAccount),
+				audit.WithError(err),
+			)
+		} else if info != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleServiceAccount),
+				audit.WithResource(constants.ResourceTypeServiceAccount, info.UserID, info.UserName),
+				audit.WithValue(nil, info),
+			)
+		}
+	}()
+
+	tenantID := xcontext.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, xerr.ErrUnauthorized
+	}
+
+	// 服务账号占用租户用户数配额
+	if err = s.quotaSvc.Check(ctx, tenantID, constants.QuotaUsers, 1); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userRepo.CheckExists(ctx, tenantID, req.UserName)
+	if err != nil {
+		log.Error().Err(err).Str("username", req.UserName).Msg("检查用户名是否存在失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查用户名是否存在失败", err)
+	}
+	if exists {
+		return nil, xerr.ErrUserExists
+	}
+
+	// 先校验角色，事务内只做写入
+	roleIDs := make([]string, 0, len(req.RoleCodes))
+	for _, roleCode := range req.RoleCodes {
+		role, err := s.roleRepo.GetByCode(ctx, roleCode)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				log.Warn().Str("role_code", roleCode).Msg("角色不存在")
+				return nil, xerr.Wrap(xerr.ErrInvalidParams.Code, "角色不存在: "+roleCode, nil)
+			}
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询角色失败", err)
+		}
+		roleIDs = append(roleIDs, role.RoleID)
+	}
+
+	userID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成用户ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成用户ID失败", err)
+	}
+	secret, err := passwordgen.GenerateSecret(secretBytes)
+	if err != nil {
+		log.Error().Err(err).Msg("生成客户端密钥失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成客户端密钥失败", err)
+	}
+
+	clientID := formatClientID(userID)
+	nickname := req.Nickname
+	if nickname == "" {
+		nickname = req.UserName
+	}
+
+	// 服务账号没有密码，password 置空后任何密码校验都会失败
+	user := &model.User{
+		UserID:             userID,
+		TenantID:           tenantID,
+		UserName:           req.UserName,
+		Nickname:           nickname,
+		Email:              formatEmail(clientID),
+		Description:        req.Description,
+		Status:             int16(constants.StatusEnabled),
+		MustChangePassword: int16(constants.False),
+		IsServiceAccount:   int16(constants.True),
+	}
+	cred := &model.ServiceAccountCredential{
+		UserID:          userID,
+		ClientID:        clientID,
+		SecretHash:      passwordgen.HashSecret(secret),
+		SecretRotatedAt: time.Now().UnixMilli(),
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewUserRepo(tx.DB).Create(ctx, user); err != nil {
+			return err
+		}
+		if err := repository.NewServiceAccountRepo(tx.DB).Create(ctx, cred); err != nil {
+			return err
+		}
+		if len(roleIDs) > 0 {
+			return repository.NewUserRoleRepo(tx.DB).AssignRoles(ctx, userID, roleIDs, tenantID)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Str("tenant_id", tenantID).Msg("创建服务账号失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建服务账号失败", err)
+	}
+
+	info = modelToServiceAccountInfo(user, cred)
+
+	log.Info().
+		Str("user_id", userID).
+		Str("client_id", clientID).
+		Strs("role_codes", req.RoleCodes).
+		Msg("创建服务账号成功")
+
+	return &dto.CreateServiceAccountResponse{
+		ServiceAccountInfo: *info,
+		ClientSecret:       secret,
+		Message:            "请妥善保存客户端密钥，关闭后将无法再次查看",
+	}, nil
+}
@@ -0,0 +1,44 @@
+package serviceaccount
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ListServiceAccounts 获取当前租户的服务账号列表
+func (s *Service) ListServiceAccounts(ctx context.Context, req *dto.ListServiceAccountsRequest) (*dto.ListServiceAccountsResponse, error) {
+	users, total, err := s.userRepo.ListServiceAccounts(ctx, req.GetOffset(), req.GetLimit(), req.Keyword)
+	if err != nil {
+		log.Error().Err(err).Msg("查询服务账号列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询服务账号列表失败", err)
+	}
+
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.UserID
+	}
+	creds, err := s.credRepo.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("查询服务账号凭证失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询服务账号凭证失败", err)
+	}
+	credMap := make(map[string]*model.ServiceAccountCredential, len(creds))
+	for _, cred := range creds {
+		credMap[cred.UserID] = cred
+	}
+
+	list := make([]*dto.ServiceAccountInfo, 0, len(users))
+	for _, user := range users {
+		list = append(list, modelToServiceAccountInfo(user, credMap[user.UserID]))
+	}
+
+	return &dto.ListServiceAccountsResponse{
+		Response: pagination.NewResponse(req.Request, total),
+		List:     list,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package serviceaccount
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// RotateSecret 轮换服务账号客户端密钥
+// 旧密钥立即失效，并吊销该账号已签发的全部访问令牌
+func (s *Service) RotateSecret(ctx context.Context, req *dto.RotateServiceAccountSecretRequest) (resp *dto.RotateServiceAccountSecretResponse, err error) {
+	var user *model.User
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleServiceAccount),
+				audit.WithOperation("轮换密钥"),
+				audit.WithError(err),
+			)
+		} else if user != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleServiceAccount),
+				audit.WithOperation("轮换密钥"),
+				audit.WithResource(constants.ResourceTypeServiceAccount, user.UserID, user.UserName),
+			)
+		}
+	}()
+
+	if xcontext.IsImpersonating(ctx) {
+		return nil, xerr.ErrImpersonationForbidden
+	}
+
+	user, err = s.getServiceAccount(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	cred, err := s.credRepo.GetByUserID(ctx, user.UserID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("user_id", user.UserID).Msg("服务账号凭证不存在")
+			return nil, xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询服务账号凭证失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询服务账号凭证失败", err)
+	}
+
+	secret, err := passwordgen.GenerateSecret(secretBytes)
+	if err != nil {
+		log.Error().Err(err).Msg("生成客户端密钥失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成客户端密钥失败", err)
+	}
+
+	if err := s.credRepo.Update(ctx, user.UserID, map[string]interface{}{
+		"secret_hash":       passwordgen.HashSecret(secret),
+		"secret_rotated_at": time.Now().UnixMilli(),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("更新客户端密钥失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新客户端密钥失败", err)
+	}
+
+	// 吊销旧密钥换取的令牌
+	if err := s.jwt.RevokeAllUserTokens(ctx, xcontext.GetTenantCode(ctx), user.UserID); err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("吊销服务账号令牌失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "吊销服务账号令牌失败", err)
+	}
+
+	log.Info().Str("user_id", user.UserID).Str("client_id", cred.ClientID).Msg("轮换服务账号密钥成功")
+
+	return &dto.RotateServiceAccountSecretResponse{
+		ClientID:     cred.ClientID,
+		ClientSecret: secret,
+		Message:      "密钥已轮换，旧密钥及已签发的令牌立即失效",
+	}, nil
+}
+
+// getServiceAccount 查询当前租户的服务账号
+func (s *Service) getServiceAccount(ctx context.Context, userID string) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("user_id", userID).Msg("服务账号不存在")
+			return nil, xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", userID).Msg("查询服务账号失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询服务账号失败", err)
+	}
+	if user.IsServiceAccount != int16(constants.True) {
+		return nil, xerr.Wrap(xerr.ErrInvalidParams.Code, "该用户不是服务账号", nil)
+	}
+	return user, nil
+}
@@ -0,0 +1,22 @@
+package serviceaccount
+
+import "fmt"
+
+const (
+	// clientIDPrefix 客户端ID前缀
+	clientIDPrefix = "sa"
+	// secretBytes 客户端密钥随机字节数
+	secretBytes = 32
+	// emailDomain 服务账号占位邮箱域名（users.email 非空且全局唯一）
+	emailDomain = "service-account.local"
+)
+
+// formatClientID 根据服务账号用户ID生成客户端ID
+func formatClientID(userID string) string {
+	return fmt.Sprintf("%s_%s", clientIDPrefix, userID)
+}
+
+// formatEmail 生成服务账号占位邮箱
+func formatEmail(clientID string) string {
+	return fmt.Sprintf("%s@%s", clientID, emailDomain)
+}
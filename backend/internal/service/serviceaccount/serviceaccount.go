@@ -0,0 +1,37 @@
+package serviceaccount
+
+import (
+	"admin/internal/repository"
+	"admin/internal/service/quota"
+	"admin/pkg/audit"
+	"admin/pkg/utils/jwt"
+
+	"gorm.io/gorm"
+)
+
+// Service 服务账号服务
+// 服务账号是 users 表中 is_service_account=1 的非人类账号：
+// 不能交互式登录、不能修改/重置密码，只能通过 client_credentials 换取访问令牌，
+// 角色分配复用用户角色接口（PUT /api/v1/users/roles）
+type Service struct {
+	db       *gorm.DB
+	userRepo *repository.UserRepo
+	credRepo *repository.ServiceAccountRepo
+	roleRepo *repository.RoleRepo
+	quotaSvc *quota.Service
+	jwt      *jwt.Manager
+	recorder *audit.Recorder
+}
+
+// NewService 创建服务账号服务
+func NewService(db *gorm.DB, jwtMgr *jwt.Manager, recorder *audit.Recorder) *Service {
+	return &Service{
+		db:       db,
+		userRepo: repository.NewUserRepo(db),
+		credRepo: repository.NewServiceAccountRepo(db),
+		roleRepo: repository.NewRoleRepo(db),
+		quotaSvc: quota.NewService(db),
+		jwt:      jwtMgr,
+		recorder: recorder,
+	}
+}
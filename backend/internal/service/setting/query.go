@@ -0,0 +1,105 @@
+package setting
+
+import (
+	"admin/internal/dto"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ListSettings 获取当前租户的全部设置项
+func (s *Service) ListSettings(ctx context.Context) (*dto.ListSettingsResponse, error) {
+	tenantID := xcontext.GetTenantID(ctx)
+
+	items, err := s.resolve(ctx, tenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户设置失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户设置失败", err)
+	}
+
+	list := make([]*dto.SettingInfo, 0, len(items))
+	for _, item := range items {
+		list = append(list, toSettingInfo(item))
+	}
+	return &dto.ListSettingsResponse{List: list}, nil
+}
+
+// GetValue 获取当前租户某个设置项的生效值
+func (s *Service) GetValue(ctx context.Context, key string) (any, error) {
+	return s.GetTenantValue(ctx, xcontext.GetTenantID(ctx), key)
+}
+
+// GetTenantValue 获取指定租户某个设置项的生效值（用于登录等 context 中尚无租户的场景）
+// 返回值类型与定义一致：string、int64、bool 或 []string
+func (s *Service) GetTenantValue(ctx context.Context, tenantID, key string) (any, error) {
+	items, err := s.resolve(ctx, tenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("key", key).Msg("查询租户设置失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户设置失败", err)
+	}
+	for _, item := range items {
+		if item.def.Key == key {
+			return item.value, nil
+		}
+	}
+	return nil, xerr.ErrSettingNotFound
+}
+
+// GetBranding 获取登录页品牌信息（无需认证）
+// 按请求识别出的租户返回公开设置项，未识别到租户时返回平台默认值
+func (s *Service) GetBranding(ctx context.Context) (*dto.BrandingResponse, error) {
+	tenantID := xcontext.GetResolvedTenantID(ctx)
+	resp := &dto.BrandingResponse{Settings: make(map[string]any)}
+
+	if tenantID != "" {
+		tenant, err := s.tenantRepo.GetByIDManual(ctx, tenantID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+		}
+		if tenant != nil {
+			resp.TenantCode = tenant.TenantCode
+			resp.TenantName = tenant.Name
+		} else {
+			tenantID = ""
+		}
+	}
+
+	items, err := s.resolve(ctx, tenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询品牌设置失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询品牌设置失败", err)
+	}
+	for _, item := range items {
+		if item.def.Public {
+			resp.Settings[item.def.Key] = item.value
+		}
+	}
+	return resp, nil
+}
+
+// toSettingInfo 转换设置项信息
+func toSettingInfo(item *resolved) *dto.SettingInfo {
+	info := &dto.SettingInfo{
+		Key:          item.def.Key,
+		Type:         string(item.def.Type),
+		Label:        item.def.Label,
+		Description:  item.def.Description,
+		Value:        item.value,
+		DefaultValue: item.defaultValue,
+		Source:       item.source,
+		Options:      item.def.Options,
+		Min:          item.def.Min,
+		Max:          item.def.Max,
+		Public:       item.def.Public,
+	}
+	if item.record != nil {
+		info.Version = item.record.Version
+		info.UpdatedBy = item.record.UpdatedBy
+		info.UpdatedAt = item.record.UpdatedAt
+	}
+	return info
+}
@@ -0,0 +1,248 @@
+package setting
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValueType 设置值类型
+type ValueType string
+
+const (
+	TypeString     ValueType = "string"      // 文本，Max 为最大长度
+	TypeInt        ValueType = "int"         // 整数，Min/Max 为取值范围
+	TypeBool       ValueType = "bool"        // 布尔
+	TypeColor      ValueType = "color"       // 颜色（#RGB 或 #RRGGBB）
+	TypeURL        ValueType = "url"         // 链接（http/https 或站内路径），可为空
+	TypeEnum       ValueType = "enum"        // 枚举，取值为 Options 之一
+	TypeStringList ValueType = "string_list" // 文本列表，取值为 Options 子集，Min 为最少项数
+)
+
+// 设置项键
+const (
+	KeyLogoURL         = "branding.logo_url"
+	KeyFaviconURL      = "branding.favicon_url"
+	KeyPrimaryColor    = "branding.primary_color"
+	KeyTheme           = "branding.theme"
+	KeyLoginTitle      = "login.title"
+	KeyLoginSubtitle   = "login.subtitle"
+	KeyLoginFooter     = "login.footer"
+	KeyDefaultLanguage = "locale.default_language"
+	KeySessionTimeout  = "security.session_timeout"
+	KeyLoginMethods    = "security.login_methods"
+)
+
+// Definition 设置项定义
+type Definition struct {
+	Key         string
+	Type        ValueType
+	Label       string   // 显示名称
+	Description string   // 说明
+	Default     any      // 内置默认值，平台未设置默认值时使用
+	Options     []string // 可选值（enum、string_list）
+	Min         int      // int 最小值；string_list 最少项数
+	Max         int      // int 最大值；string、url 最大长度（0 表示不限制）
+	Public      bool     // 是否在登录页公开（无需认证即可读取）
+}
+
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// registry 设置项注册表
+var registry = struct {
+	sync.RWMutex
+	defs map[string]*Definition
+}{defs: make(map[string]*Definition)}
+
+// Register 注册设置项，键重复或默认值不合法时 panic，应在包初始化时调用
+func Register(def Definition) {
+	if def.Key == "" {
+		panic("setting: 设置项键不能为空")
+	}
+	value, err := def.normalize(def.Default)
+	if err != nil {
+		panic(fmt.Sprintf("setting: 设置项 %s 默认值不合法: %v", def.Key, err))
+	}
+	def.Default = value
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.defs[def.Key]; ok {
+		panic(fmt.Sprintf("setting: 设置项 %s 重复注册", def.Key))
+	}
+	registry.defs[def.Key] = &def
+}
+
+// Lookup 获取设置项定义
+func Lookup(key string) (*Definition, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	def, ok := registry.defs[key]
+	return def, ok
+}
+
+// Definitions 获取全部设置项定义，按键排序
+func Definitions() []*Definition {
+	registry.RLock()
+	defs := make([]*Definition, 0, len(registry.defs))
+	for _, def := range registry.defs {
+		defs = append(defs, def)
+	}
+	registry.RUnlock()
+
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Key < defs[j].Key })
+	return defs
+}
+
+func init() {
+	for _, def := range []Definition{
+		{Key: KeyLogoURL, Type: TypeURL, Label: "Logo", Description: "页面左上角和登录页显示的 Logo 地址", Default: "", Max: 500, Public: true},
+		{Key: KeyFaviconURL, Type: TypeURL, Label: "网站图标", Description: "浏览器标签页图标地址", Default: "", Max: 500, Public: true},
+		{Key: KeyPrimaryColor, Type: TypeColor, Label: "主题色", Description: "按钮、链接等主要元素的颜色", Default: "#1677ff", Public: true},
+		{Key: KeyTheme, Type: TypeEnum, Label: "主题模式", Default: "light", Options: []string{"light", "dark", "auto"}, Public: true},
+		{Key: KeyLoginTitle, Type: TypeString, Label: "登录页标题", Default: "", Max: 100, Public: true},
+		{Key: KeyLoginSubtitle, Type: TypeString, Label: "登录页副标题", Default: "", Max: 200, Public: true},
+		{Key: KeyLoginFooter, Type: TypeString, Label: "登录页页脚", Description: "版权、备案号等信息", Default: "", Max: 500, Public: true},
+		{Key: KeyDefaultLanguage, Type: TypeEnum, Label: "默认语言", Default: "zh-CN", Options: []string{"zh-CN", "en-US"}, Public: true},
+		{Key: KeySessionTimeout, Type: TypeInt, Label: "会话超时（分钟）", Description: "无操作超过该时长后需重新登录，修改后对新登录的会话生效", Default: 120, Min: 5, Max: 43200},
+		{Key: KeyLoginMethods, Type: TypeStringList, Label: "允许的登录方式", Description: "用户所属租户未启用的方式不能登录", Default: []string{"email", "phone"}, Options: []string{"email", "phone"}, Min: 1, Public: true},
+	} {
+		Register(def)
+	}
+}
+
+// Validate 校验 JSON 编码的设置值，返回规范化后的值
+func (d *Definition) Validate(raw json.RawMessage) (any, error) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, errors.New("不是合法的 JSON 值")
+	}
+	return d.normalize(v)
+}
+
+// Decode 解码存储的设置值
+func (d *Definition) Decode(stored string) (any, error) {
+	return d.Validate(json.RawMessage(stored))
+}
+
+// normalize 按类型校验并转换为 string、int64、bool 或 []string
+func (d *Definition) normalize(v any) (any, error) {
+	switch d.Type {
+	case TypeString, TypeURL, TypeColor, TypeEnum:
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("应为字符串")
+		}
+		return s, d.checkString(s)
+	case TypeInt:
+		n, err := toInt(v)
+		if err != nil {
+			return nil, err
+		}
+		if n < int64(d.Min) || n > int64(d.Max) {
+			return nil, fmt.Errorf("取值范围为 %d ~ %d", d.Min, d.Max)
+		}
+		return n, nil
+	case TypeBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errors.New("应为布尔值")
+		}
+		return b, nil
+	case TypeStringList:
+		list, err := toStrings(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) < d.Min {
+			return nil, fmt.Errorf("至少需要 %d 项", d.Min)
+		}
+		seen := make(map[string]bool, len(list))
+		for _, s := range list {
+			if len(d.Options) > 0 && !slices.Contains(d.Options, s) {
+				return nil, fmt.Errorf("可选值为 %s", strings.Join(d.Options, ", "))
+			}
+			if seen[s] {
+				return nil, fmt.Errorf("%s 重复", s)
+			}
+			seen[s] = true
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("未知的设置类型 %s", d.Type)
+	}
+}
+
+// checkString 校验字符串类设置值
+func (d *Definition) checkString(s string) error {
+	if d.Max > 0 && utf8.RuneCountInString(s) > d.Max {
+		return fmt.Errorf("长度不能超过 %d", d.Max)
+	}
+	switch d.Type {
+	case TypeColor:
+		if !colorPattern.MatchString(s) {
+			return errors.New("应为 #RGB 或 #RRGGBB 格式的颜色")
+		}
+	case TypeEnum:
+		if !slices.Contains(d.Options, s) {
+			return fmt.Errorf("可选值为 %s", strings.Join(d.Options, ", "))
+		}
+	case TypeURL:
+		if s == "" || (strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//")) {
+			return nil
+		}
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("应为 http/https 链接或以 / 开头的站内路径")
+		}
+	}
+	return nil
+}
+
+// toInt 转换整数，接受 JSON 数字和代码中注册的整数默认值
+func toInt(v any) (int64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		if err != nil {
+			return 0, errors.New("应为整数")
+		}
+		return i, nil
+	case int:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	default:
+		return 0, errors.New("应为整数")
+	}
+}
+
+// toStrings 转换字符串列表
+func toStrings(v any) ([]string, error) {
+	switch list := v.(type) {
+	case []string:
+		return list, nil
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("应为字符串数组")
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, errors.New("应为字符串数组")
+	}
+}
@@ -0,0 +1,116 @@
+package setting
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/cache"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// 生效值来源
+const (
+	SourceBuiltin  = "builtin"  // 内置默认值
+	SourcePlatform = "platform" // 平台默认值（default 租户的设置）
+	SourceCustom   = "custom"   // 租户覆盖值
+)
+
+// Service 租户设置服务
+// 设置按 内置默认值 < 平台默认值 < 租户覆盖值 的顺序合并；default 租户修改的是平台默认值
+type Service struct {
+	db          *gorm.DB
+	settingRepo *repository.TenantSettingRepo
+	tenantRepo  *repository.TenantRepo
+	tenantCache *cache.TenantCache
+	recorder    *audit.Recorder
+}
+
+// NewService 创建租户设置服务
+func NewService(db *gorm.DB, recorder *audit.Recorder) *Service {
+	return &Service{
+		db:          db,
+		settingRepo: repository.NewTenantSettingRepo(db),
+		tenantRepo:  repository.NewTenantRepo(db),
+		tenantCache: cache.Get().Tenant,
+		recorder:    recorder,
+	}
+}
+
+// resolved 合并后的设置项
+type resolved struct {
+	def          *Definition
+	value        any
+	defaultValue any                  // 删除当前租户记录后的值
+	source       string               // 生效值来源
+	record       *model.TenantSetting // 当前租户的记录，未设置时为 nil
+}
+
+// resolve 合并租户的全部设置项，tenantID 为空时只合并平台默认值
+func (s *Service) resolve(ctx context.Context, tenantID string) ([]*resolved, error) {
+	defaultTenantID := s.tenantCache.GetDefaultTenantID()
+
+	platform, err := s.loadRecords(ctx, defaultTenantID)
+	if err != nil {
+		return nil, err
+	}
+	custom := map[string]*model.TenantSetting{}
+	if tenantID != "" && tenantID != defaultTenantID {
+		if custom, err = s.loadRecords(ctx, tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	defs := Definitions()
+	list := make([]*resolved, 0, len(defs))
+	for _, def := range defs {
+		item := &resolved{def: def, value: def.Default, defaultValue: def.Default, source: SourceBuiltin}
+		if rec := platform[def.Key]; rec != nil {
+			if value, ok := decodeRecord(def, rec); ok {
+				item.value, item.source = value, SourcePlatform
+			}
+		}
+		if tenantID == defaultTenantID {
+			// default 租户的记录即平台默认值，恢复默认后使用内置默认值
+			item.record = platform[def.Key]
+		} else {
+			item.defaultValue = item.value
+			if rec := custom[def.Key]; rec != nil {
+				item.record = rec
+				if value, ok := decodeRecord(def, rec); ok {
+					item.value, item.source = value, SourceCustom
+				}
+			}
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+// loadRecords 加载租户的设置记录（键 -> 记录）
+func (s *Service) loadRecords(ctx context.Context, tenantID string) (map[string]*model.TenantSetting, error) {
+	records, err := s.settingRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]*model.TenantSetting, len(records))
+	for _, rec := range records {
+		m[rec.SettingKey] = rec
+	}
+	return m, nil
+}
+
+// decodeRecord 解码存储的设置值，设置项规则调整后已不合法的旧值忽略并使用上一级默认值
+func decodeRecord(def *Definition, rec *model.TenantSetting) (any, bool) {
+	value, err := def.Decode(rec.SettingValue)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("tenant_id", rec.TenantID).
+			Str("key", rec.SettingKey).
+			Msg("设置值不符合当前规则，已忽略")
+		return nil, false
+	}
+	return value, true
+}
@@ -0,0 +1,163 @@
+package setting
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// UpdateSettings 批量修改当前租户的设置
+// 每项需提交读取时的版本号，任一项已被他人修改则整体不生效；default 租户修改的是平台默认值
+func (s *Service) UpdateSettings(ctx context.Context, req *dto.UpdateSettingsRequest) (err error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	oldValues := make(map[string]any)
+	newValues := make(map[string]any)
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleSystem),
+				audit.WithError(err),
+			)
+		} else {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleSystem),
+				audit.WithResource(constants.ResourceTypeSetting, tenantID, strings.Join(slices.Sorted(maps.Keys(newValues)), ",")),
+				audit.WithValue(oldValues, newValues),
+			)
+		}
+	}()
+
+	// 先校验全部设置项，避免部分写入
+	encoded := make(map[string]string, len(req.Items))
+	for _, item := range req.Items {
+		def, ok := Lookup(item.Key)
+		if !ok {
+			return xerr.New(xerr.ErrSettingNotFound.Code, fmt.Sprintf("设置项 %s 不存在", item.Key))
+		}
+		if _, dup := encoded[item.Key]; dup {
+			return xerr.New(xerr.ErrInvalidParams.Code, fmt.Sprintf("设置项 %s 重复", item.Key))
+		}
+		value, err := def.Validate(item.Value)
+		if err != nil {
+			return xerr.New(xerr.ErrInvalidParams.Code, fmt.Sprintf("设置项 %s %s", def.Label, err.Error()))
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			log.Error().Err(err).Str("key", item.Key).Msg("编码设置值失败")
+			return xerr.Wrap(xerr.ErrInternal.Code, "编码设置值失败", err)
+		}
+		encoded[item.Key] = string(data)
+		newValues[item.Key] = value
+	}
+
+	userID := xcontext.GetUserID(ctx)
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		repo := repository.NewTenantSettingRepo(tx.DB)
+		for _, item := range req.Items {
+			rec, err := repo.GetByKey(ctx, tenantID, item.Key)
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return err
+			}
+
+			if rec == nil {
+				if item.Version != 0 {
+					return xerr.ErrSettingConflict
+				}
+				settingID, err := idgen.GenerateUUID()
+				if err != nil {
+					return err
+				}
+				if err := repo.Create(ctx, &model.TenantSetting{
+					SettingID:    settingID,
+					TenantID:     tenantID,
+					SettingKey:   item.Key,
+					SettingValue: encoded[item.Key],
+					Version:      1,
+					UpdatedBy:    userID,
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			oldValues[item.Key] = json.RawMessage(rec.SettingValue)
+			affected, err := repo.UpdateValue(ctx, tenantID, rec.SettingID, item.Version, encoded[item.Key], userID)
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return xerr.ErrSettingConflict
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("修改租户设置失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "修改租户设置失败", err)
+	}
+
+	log.Info().Str("tenant_id", tenantID).Int("count", len(req.Items)).Msg("修改租户设置成功")
+	return nil
+}
+
+// ResetSetting 删除当前租户的设置记录，恢复使用平台默认值（default 租户恢复使用内置默认值）
+func (s *Service) ResetSetting(ctx context.Context, key string) (err error) {
+	tenantID := xcontext.GetTenantID(ctx)
+	var rec *model.TenantSetting
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleSystem),
+				audit.WithError(err),
+			)
+		} else if rec != nil {
+			s.recorder.Log(ctx,
+				audit.WithDelete(constants.ModuleSystem),
+				audit.WithResource(constants.ResourceTypeSetting, tenantID, key),
+				audit.WithValue(rec, nil),
+			)
+		}
+	}()
+
+	if _, ok := Lookup(key); !ok {
+		return xerr.ErrSettingNotFound
+	}
+
+	rec, err = s.settingRepo.GetByKey(ctx, tenantID, key)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			// 未设置过，已是默认值
+			return nil
+		}
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("key", key).Msg("查询租户设置失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询租户设置失败", err)
+	}
+
+	if err := s.settingRepo.DeleteByKey(ctx, tenantID, key); err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("key", key).Msg("恢复默认设置失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "恢复默认设置失败", err)
+	}
+
+	log.Info().Str("tenant_id", tenantID).Str("key", key).Msg("恢复默认设置成功")
+	return nil
+}
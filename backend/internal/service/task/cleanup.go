@@ -0,0 +1,60 @@
+package task
+
+import (
+	"admin/internal/repository"
+	"admin/pkg/config"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// cleanupBatchSize 每批删除的任务记录数
+const cleanupBatchSize = 1000
+
+// CleanupManager 已结束任务记录和任务文件清理，由定时任务执行
+type CleanupManager struct {
+	taskRepo *repository.TaskRepo
+	files    *FileStore
+	cfg      config.TaskConfig
+}
+
+// NewCleanupManager 创建任务记录清理管理器
+func NewCleanupManager(db *gorm.DB, cfg config.TaskConfig) *CleanupManager {
+	return &CleanupManager{
+		taskRepo: repository.NewTaskRepo(db),
+		files:    NewFileStore(cfg),
+		cfg:      cfg,
+	}
+}
+
+// Run 分批删除超过保留期的已结束任务，以及同样超过保留期的任务文件
+func (m *CleanupManager) Run(ctx context.Context) error {
+	cutoff := time.Now().Add(-m.cfg.GetRetention())
+	before := cutoff.UnixMilli()
+
+	var total int64
+	for {
+		n, err := m.taskRepo.DeleteFinishedManual(ctx, before, cleanupBatchSize)
+		if err != nil {
+			return err
+		}
+		total += n
+		if n < cleanupBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Info().Int64("count", total).Msg("已清理过期的任务记录")
+	}
+
+	removed, err := m.files.RemoveExpired(cutoff)
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		log.Info().Int("count", removed).Msg("已清理过期的任务文件")
+	}
+	return nil
+}
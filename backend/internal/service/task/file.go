@@ -0,0 +1,186 @@
+package task
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/config"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xcontext"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// downloadPath 任务结果文件的下载地址
+const downloadPath = "/api/v1/tasks/download?task_id="
+
+// platformDir 平台级任务（不属于任何租户）的文件目录名
+const platformDir = "platform"
+
+// FileResult 生成文件的任务结果，发起人通过下载地址获取文件
+// 处理函数返回的结果结构体嵌入该类型，下载时据此确定文件名
+type FileResult struct {
+	FileName    string `json:"file_name"`
+	FileSize    int64  `json:"file_size"`
+	DownloadURL string `json:"download_url"`
+}
+
+// FileStore 后台任务的文件存储
+// 上传的文件在入队前保存为任务输入，处理函数生成的文件保存为任务结果供发起人下载；
+// 文件按租户分目录存放，超过任务记录保留期后由清理任务删除
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore 创建任务文件存储
+func NewFileStore(cfg config.TaskConfig) *FileStore {
+	return &FileStore{dir: cfg.GetDir()}
+}
+
+// SaveInput 保存上传的文件，返回的文件名作为任务参数传给处理函数
+func (f *FileStore) SaveInput(ctx context.Context, r io.Reader, ext string) (name string, err error) {
+	id, err := idgen.GenerateUUID()
+	if err != nil {
+		return "", err
+	}
+	name = filepath.Join(tenantDir(xcontext.GetTenantID(ctx)), "input", id+ext)
+	path := f.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// OpenInput 打开任务输入文件，返回文件及其大小
+// 文件不存在时返回 Permanent 错误，任务不再重试
+func (f *FileStore) OpenInput(name string) (*os.File, int64, error) {
+	file, err := os.Open(f.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, Permanent(err)
+		}
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+// RemoveInput 删除任务输入文件，任务成功或最终失败后调用
+func (f *FileStore) RemoveInput(name string) {
+	if err := os.Remove(f.path(name)); err != nil && !os.IsNotExist(err) {
+		log.Warn().Err(err).Str("file", name).Msg("删除任务输入文件失败")
+	}
+}
+
+// WriteResult 将处理函数生成的文件保存为任务结果
+// 先写临时文件，完成后再改名，避免下载到不完整的文件
+func (f *FileStore) WriteResult(exec *Execution, fileName string, write func(w io.Writer) error) (result *FileResult, err error) {
+	path := f.resultPath(exec.task, fileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp)
+		}
+	}()
+
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(tmp)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+	return &FileResult{
+		FileName:    fileName,
+		FileSize:    info.Size(),
+		DownloadURL: downloadPath + exec.task.TaskID,
+	}, nil
+}
+
+// RemoveExpired 删除修改时间早于 before 的文件，返回删除的文件数
+func (f *FileStore) RemoveExpired(before time.Time) (int, error) {
+	var removed int
+	err := filepath.WalkDir(f.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().Before(before) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Warn().Err(err).Str("path", path).Msg("删除过期任务文件失败")
+				return nil
+			}
+			removed++
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// resultPath 任务结果文件路径：<任务目录>/<租户ID>/<任务ID>.<扩展名>
+func (f *FileStore) resultPath(task *model.Task, fileName string) string {
+	return filepath.Join(f.dir, tenantDir(task.TenantID), task.TaskID+filepath.Ext(fileName))
+}
+
+// path 任务文件的完整路径，文件名只能位于任务目录内
+func (f *FileStore) path(name string) string {
+	return filepath.Join(f.dir, filepath.Clean("/"+name))
+}
+
+// tenantDir 租户的文件目录名
+func tenantDir(tenantID string) string {
+	if tenantID == "" {
+		return platformDir
+	}
+	return tenantID
+}
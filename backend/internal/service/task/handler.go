@@ -0,0 +1,169 @@
+package task
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultTimeout   = 30 * time.Minute // 单次执行的默认最长时间
+	retryBaseDelay   = 10 * time.Second // 首次重试的等待时间，之后每次翻倍
+	retryMaxDelay    = 10 * time.Minute // 重试等待时间上限
+	progressInterval = time.Second      // 进度写库的最小间隔
+)
+
+// ErrCanceled 任务被用户取消，传给 OnFailure
+var ErrCanceled = errors.New("任务已取消")
+
+// Handler 类型化的任务处理器，P 为任务参数类型
+type Handler[P any] struct {
+	// Run 执行任务，payload 由入队时的参数反序列化得到，返回值序列化为 JSON 保存为任务结果。
+	// 返回错误时按指数退避重试，直到达到最大执行次数；用 Permanent 包装的错误不再重试。
+	// ctx 携带发起人的租户和用户信息，任务被取消、超时或服务停止时 ctx 被取消，处理函数应尽快返回
+	Run func(ctx context.Context, exec *Execution, payload *P) (any, error)
+	// OnFailure 任务最终失败或被取消后调用（可选），用于回写业务状态；被取消时 err 为 ErrCanceled
+	OnFailure func(ctx context.Context, payload *P, err error)
+	// Timeout 单次执行的最长时间，默认 30 分钟
+	Timeout time.Duration
+}
+
+// handler 注册到工作进程的处理器，参数以 JSON 传入
+type handler struct {
+	run       func(ctx context.Context, exec *Execution, payload []byte) (any, error)
+	onFailure func(ctx context.Context, payload []byte, err error)
+	timeout   time.Duration
+}
+
+// Register 注册任务类型的处理器，同一类型重复注册时后注册的生效
+func Register[P any](w *Worker, taskType string, h Handler[P]) {
+	entry := &handler{
+		run: func(ctx context.Context, exec *Execution, payload []byte) (any, error) {
+			p := new(P)
+			if err := decodePayload(payload, p); err != nil {
+				return nil, Permanent(err)
+			}
+			return h.Run(ctx, exec, p)
+		},
+		timeout: h.Timeout,
+	}
+	if entry.timeout <= 0 {
+		entry.timeout = defaultTimeout
+	}
+	if h.OnFailure != nil {
+		entry.onFailure = func(ctx context.Context, payload []byte, err error) {
+			p := new(P)
+			if decodeErr := decodePayload(payload, p); decodeErr != nil {
+				log.Error().Err(decodeErr).Str("type", taskType).Msg("解析任务参数失败")
+				return
+			}
+			h.OnFailure(ctx, p, err)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.handlers[taskType]; ok {
+		log.Warn().Str("type", taskType).Msg("任务类型重复注册，使用后注册的处理器")
+	}
+	w.handlers[taskType] = entry
+}
+
+func decodePayload(payload []byte, p any) error {
+	if len(payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(payload, p)
+}
+
+// permanentError 不再重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 包装不应重试的错误（如参数无效、数据已不存在），任务直接失败
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// isPermanent 判断错误是否不应重试
+func isPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// errorMessage 保存到任务记录的错误信息，业务错误取错误消息
+func errorMessage(err error) string {
+	var xe *xerr.AppError
+	if errors.As(err, &xe) {
+		return xe.Message
+	}
+	return err.Error()
+}
+
+// retryDelay 第 attempt 次执行失败后的重试等待时间：指数退避，上限 retryMaxDelay，
+// 在 [d/2, d) 之间随机抖动，避免大量任务同时重试
+func retryDelay(attempt int32) time.Duration {
+	d := retryMaxDelay
+	if attempt >= 1 && attempt <= 16 {
+		if backoff := retryBaseDelay << (attempt - 1); backoff < retryMaxDelay {
+			d = backoff
+		}
+	}
+	return d/2 + rand.N(d/2)
+}
+
+// Execution 正在执行的任务，供处理函数查询任务信息和报告进度
+type Execution struct {
+	w          *Worker
+	task       *model.Task
+	canceled   atomic.Bool
+	lastReport time.Time
+}
+
+// TaskID 任务ID
+func (e *Execution) TaskID() string {
+	return e.task.TaskID
+}
+
+// Attempt 当前是第几次执行，从 1 开始
+func (e *Execution) Attempt() int {
+	return int(e.task.Attempts)
+}
+
+// Progress 报告执行进度，percent 取值 0-100
+// 距上次写库不足 1 秒的报告会被跳过（100% 除外），处理函数可以按批次频繁调用
+func (e *Execution) Progress(percent int, message string) {
+	percent = min(max(percent, 0), 100)
+	now := time.Now()
+	if percent < 100 && now.Sub(e.lastReport) < progressInterval {
+		return
+	}
+	e.lastReport = now
+
+	runes := []rune(message)
+	if len(runes) > 255 {
+		message = string(runes[:255])
+	}
+	ctx, cancel := leaseContext(context.Background())
+	defer cancel()
+	if _, err := e.w.taskRepo.UpdateByWorkerManual(ctx, e.task.TaskID, e.w.id, map[string]interface{}{
+		"progress":         int16(percent),
+		"progress_message": message,
+	}); err != nil {
+		log.Warn().Err(err).Str("task_id", e.task.TaskID).Msg("更新任务进度失败")
+	}
+}
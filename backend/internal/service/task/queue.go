@@ -0,0 +1,90 @@
+package task
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"admin/pkg/constants"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// defaultMaxAttempts 默认最大执行次数
+const defaultMaxAttempts = 3
+
+// Queue 任务队列，业务服务通过它提交后台任务
+// 传入事务中的 DB 时，任务与业务数据在同一事务中提交，事务回滚则任务不会执行
+type Queue struct {
+	taskRepo *repository.TaskRepo
+}
+
+// NewQueue 创建任务队列
+func NewQueue(db *gorm.DB) *Queue {
+	return &Queue{
+		taskRepo: repository.NewTaskRepo(db),
+	}
+}
+
+// EnqueueOption 入队选项
+type EnqueueOption func(task *model.Task)
+
+// WithMaxAttempts 设置最大执行次数（含首次执行），1 表示失败不重试
+func WithMaxAttempts(n int) EnqueueOption {
+	return func(task *model.Task) {
+		if n > 0 {
+			task.MaxAttempts = int32(n)
+		}
+	}
+}
+
+// WithDelay 延迟执行
+func WithDelay(d time.Duration) EnqueueOption {
+	return func(task *model.Task) {
+		task.RunAt = time.Now().Add(d).UnixMilli()
+	}
+}
+
+// Enqueue 提交后台任务
+// payload 序列化为 JSON 保存，执行时反序列化为对应处理器注册的参数类型；
+// 当前 context 中的租户、用户和角色信息一并保存，执行时据此重建上下文，数据范围与发起人一致
+func (q *Queue) Enqueue(ctx context.Context, taskType string, payload any, opts ...EnqueueOption) (*model.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "序列化任务参数失败", err)
+	}
+	snapshot, err := json.Marshal(xcontext.TakeSnapshot(ctx))
+	if err != nil {
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "序列化任务上下文失败", err)
+	}
+	taskID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成任务ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成任务ID失败", err)
+	}
+
+	task := &model.Task{
+		TaskID:      taskID,
+		UserID:      xcontext.GetUserID(ctx),
+		Type:        taskType,
+		Payload:     string(data),
+		Context:     string(snapshot),
+		Status:      constants.TaskPending,
+		MaxAttempts: defaultMaxAttempts,
+		RunAt:       time.Now().UnixMilli(),
+	}
+	for _, opt := range opts {
+		opt(task)
+	}
+
+	if err := q.taskRepo.Create(ctx, task); err != nil {
+		log.Error().Err(err).Str("type", taskType).Msg("创建后台任务失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建后台任务失败", err)
+	}
+	return task, nil
+}
@@ -0,0 +1,176 @@
+package task
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/constants"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// Service 后台任务查询服务，用户只能查看和取消自己发起的任务
+type Service struct {
+	taskRepo *repository.TaskRepo
+	files    *FileStore
+	recorder *audit.Recorder
+}
+
+// NewService 创建后台任务查询服务
+func NewService(db *gorm.DB, recorder *audit.Recorder, cfg config.TaskConfig) *Service {
+	return &Service{
+		taskRepo: repository.NewTaskRepo(db),
+		files:    NewFileStore(cfg),
+		recorder: recorder,
+	}
+}
+
+// ListTasks 分页获取当前用户发起的任务
+func (s *Service) ListTasks(ctx context.Context, req *dto.ListTasksRequest) (*dto.ListTasksResponse, error) {
+	userID := xcontext.GetUserID(ctx)
+	tasks, total, err := s.taskRepo.List(ctx, &repository.TaskFilter{
+		UserID: userID,
+		Type:   req.Type,
+		Status: req.Status,
+	}, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("查询任务列表失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询任务列表失败", err)
+	}
+
+	list := make([]*dto.TaskInfo, len(tasks))
+	for i, task := range tasks {
+		list[i] = ModelToTaskInfo(task)
+	}
+	return &dto.ListTasksResponse{
+		Response: pagination.NewResponse(req.Request, total),
+		List:     list,
+	}, nil
+}
+
+// GetTask 获取当前用户发起的任务
+func (s *Service) GetTask(ctx context.Context, taskID string) (*dto.TaskInfo, error) {
+	task, err := s.getOwnTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return ModelToTaskInfo(task), nil
+}
+
+// GetDownloadFile 获取任务结果文件路径和下载文件名，只有任务发起人可以下载
+func (s *Service) GetDownloadFile(ctx context.Context, taskID string) (path, filename string, err error) {
+	task, err := s.getOwnTask(ctx, taskID)
+	if err != nil {
+		return "", "", err
+	}
+	var result FileResult
+	if task.Status != constants.TaskSucceeded || json.Unmarshal([]byte(task.Result), &result) != nil || result.FileName == "" {
+		return "", "", xerr.ErrTaskNotReady
+	}
+
+	path = s.files.resultPath(task, result.FileName)
+	if _, err := os.Stat(path); err != nil {
+		log.Warn().Err(err).Str("task_id", taskID).Msg("任务结果文件不存在")
+		return "", "", xerr.ErrTaskFileExpired
+	}
+	return path, result.FileName, nil
+}
+
+// CancelTask 取消当前用户发起的任务
+// 等待中的任务直接取消；执行中的任务标记为取消中，工作进程续约时发现后中断执行
+func (s *Service) CancelTask(ctx context.Context, taskID string) (resp *dto.TaskInfo, err error) {
+	var task *model.Task
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTask),
+				audit.WithError(err),
+			)
+		} else {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTask),
+				audit.WithResource(constants.ResourceTypeTask, task.TaskID, task.Type),
+				audit.WithValue(map[string]string{"status": task.Status}, map[string]string{"status": resp.Status}),
+			)
+		}
+	}()
+
+	task, err = s.getOwnTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	switch task.Status {
+	case constants.TaskPending:
+		ok, err = s.taskRepo.CancelPending(ctx, taskID)
+	case constants.TaskRunning:
+		ok, err = s.taskRepo.RequestCancel(ctx, taskID)
+	case constants.TaskCanceling:
+		// 已请求取消，重复请求直接返回
+		return ModelToTaskInfo(task), nil
+	default:
+		return nil, xerr.ErrTaskNotCancelable
+	}
+	if err != nil {
+		log.Error().Err(err).Str("task_id", taskID).Msg("取消任务失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "取消任务失败", err)
+	}
+	if !ok {
+		// 状态在查询后已发生变化（如刚被领取或已结束），由用户重试
+		return nil, xerr.ErrTaskNotCancelable
+	}
+
+	updated, err := s.getOwnTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return ModelToTaskInfo(updated), nil
+}
+
+// getOwnTask 获取当前用户发起的任务，不暴露其他用户的任务是否存在
+func (s *Service) getOwnTask(ctx context.Context, taskID string) (*model.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTaskNotFound
+		}
+		log.Error().Err(err).Str("task_id", taskID).Msg("查询任务失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询任务失败", err)
+	}
+	if task.UserID == "" || task.UserID != xcontext.GetUserID(ctx) {
+		return nil, xerr.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// ModelToTaskInfo 将任务模型转换为响应 DTO
+func ModelToTaskInfo(task *model.Task) *dto.TaskInfo {
+	info := &dto.TaskInfo{
+		TaskID:          task.TaskID,
+		Type:            task.Type,
+		Status:          task.Status,
+		Progress:        task.Progress,
+		ProgressMessage: task.ProgressMessage,
+		ErrorMessage:    task.ErrorMessage,
+		Attempts:        task.Attempts,
+		MaxAttempts:     task.MaxAttempts,
+		RunAt:           task.RunAt,
+		StartedAt:       task.StartedAt,
+		FinishedAt:      task.FinishedAt,
+		CreatedAt:       task.CreatedAt,
+	}
+	if task.Result != "" && json.Valid([]byte(task.Result)) {
+		info.Result = json.RawMessage(task.Result)
+	}
+	return info
+}
@@ -0,0 +1,358 @@
+package task
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"admin/pkg/config"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// errLeaseLost 租约已被其他实例接管，本实例放弃执行结果
+var errLeaseLost = errors.New("task lease lost")
+
+// Worker 后台任务工作进程
+// 按轮询间隔从任务表领取到期任务并发执行，执行中定期续约并检查取消请求；
+// 多个实例可同时运行，通过 FOR UPDATE SKIP LOCKED 和租约保证同一任务同一时间只由一个实例执行
+type Worker struct {
+	taskRepo *repository.TaskRepo
+	cfg      config.TaskConfig
+	id       string
+
+	mu       sync.RWMutex
+	handlers map[string]*handler
+
+	slots       chan struct{}
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	startOnce   sync.Once
+	stopOnce    sync.Once
+	lastRecover time.Time
+}
+
+// NewWorker 创建后台任务工作进程，注册处理器后调用 Start 开始执行
+func NewWorker(db *gorm.DB, cfg config.TaskConfig) *Worker {
+	hostname, _ := os.Hostname()
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Worker{
+		taskRepo: repository.NewTaskRepo(db),
+		cfg:      cfg,
+		id:       fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixMilli()),
+		handlers: make(map[string]*handler),
+		slots:    make(chan struct{}, cfg.GetConcurrency()),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// leaseContext 工作进程读写任务队列的上下文，数据库行级安全切换到旁路角色
+// 处理函数的上下文由任务快照恢复，不经过这里
+//
+//tenantscope:allow 任务队列由工作进程统一领取和更新，不属于任何租户
+func leaseContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(xcontext.SetPlatformScope(parent), 10*time.Second)
+}
+
+// Start 开始领取并执行任务
+func (w *Worker) Start() {
+	w.startOnce.Do(func() {
+		w.wg.Add(1)
+		go w.loop()
+		log.Info().Str("worker_id", w.id).Int("concurrency", cap(w.slots)).Msg("后台任务工作进程已启动")
+	})
+}
+
+// Stop 停止领取新任务，通知执行中的任务退出并等待；未完成的任务放回队列，由下次启动或其他实例继续执行
+func (w *Worker) Stop() {
+	w.stopOnce.Do(func() {
+		w.cancel()
+		w.wg.Wait()
+		log.Info().Str("worker_id", w.id).Msg("后台任务工作进程已停止")
+	})
+}
+
+// loop 轮询领取任务
+func (w *Worker) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.cfg.GetPollInterval())
+	defer ticker.Stop()
+	for {
+		w.poll()
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll 按空闲槽位领取一批任务并启动执行
+func (w *Worker) poll() {
+	// 只有 loop 占用槽位，这里算出的空闲数不会多于实际空闲数
+	free := cap(w.slots) - len(w.slots)
+	if free <= 0 {
+		return
+	}
+
+	ctx, cancel := leaseContext(w.ctx)
+	defer cancel()
+
+	now := time.Now()
+	lease := w.cfg.GetLease()
+	if now.Sub(w.lastRecover) >= lease/2 {
+		w.lastRecover = now
+		if n, err := w.taskRepo.RecoverExpiredManual(ctx, now.UnixMilli(), "任务执行中断，已达最大执行次数"); err != nil {
+			log.Error().Err(err).Msg("处理租约过期的任务失败")
+		} else if n > 0 {
+			log.Warn().Int64("count", n).Msg("已结束租约过期的任务")
+		}
+	}
+
+	tasks, err := w.taskRepo.ClaimManual(ctx, w.id, now.UnixMilli(), now.Add(lease).UnixMilli(), free)
+	if err != nil {
+		if w.ctx.Err() == nil {
+			log.Error().Err(err).Msg("领取后台任务失败")
+		}
+		return
+	}
+	for _, task := range tasks {
+		w.slots <- struct{}{}
+		w.wg.Add(1)
+		go w.execute(task)
+	}
+}
+
+// execute 执行一个已领取的任务并保存结果
+func (w *Worker) execute(task *model.Task) {
+	defer func() {
+		<-w.slots
+		w.wg.Done()
+	}()
+
+	logger := log.With().
+		Str("task_id", task.TaskID).
+		Str("type", task.Type).
+		Int32("attempt", task.Attempts).
+		Logger()
+
+	var snapshot xcontext.Snapshot
+	if task.Context != "" {
+		if err := json.Unmarshal([]byte(task.Context), &snapshot); err != nil {
+			logger.Error().Err(err).Msg("解析任务上下文失败")
+			w.fail(task, nil, nil, logger, Permanent(err))
+			return
+		}
+	}
+
+	w.mu.RLock()
+	h := w.handlers[task.Type]
+	w.mu.RUnlock()
+	if h == nil {
+		logger.Error().Msg("任务类型未注册处理器")
+		w.fail(task, nil, nil, logger, Permanent(fmt.Errorf("未注册的任务类型: %s", task.Type)))
+		return
+	}
+
+	// 服务停止时 w.ctx 取消，处理函数随之退出
+	ctx, cancel := context.WithCancelCause(snapshot.Restore(w.ctx))
+	defer cancel(nil)
+	ctx, cancelTimeout := context.WithTimeout(ctx, h.timeout)
+	defer cancelTimeout()
+
+	exec := &Execution{w: w, task: task}
+	done := make(chan struct{})
+	heartbeat := make(chan struct{})
+	go func() {
+		defer close(heartbeat)
+		w.heartbeat(task, exec, cancel, done, logger)
+	}()
+
+	logger.Info().Msg("开始执行后台任务")
+	result, err := w.run(ctx, h, exec, task, logger)
+	close(done)
+	<-heartbeat
+
+	cause := context.Cause(ctx)
+	switch {
+	case errors.Is(cause, errLeaseLost):
+		logger.Warn().Msg("任务租约已被其他实例接管，放弃本次执行结果")
+	case err == nil:
+		// 取消请求到达前已执行完成的任务按成功处理
+		w.succeed(task, result, logger)
+	case exec.canceled.Load():
+		w.finishCanceled(task, h, &snapshot, logger)
+	case w.ctx.Err() != nil:
+		w.requeue(task, h, &snapshot, logger)
+	case isPermanent(err) || task.Attempts >= task.MaxAttempts:
+		w.fail(task, h, &snapshot, logger, err)
+	default:
+		w.retry(task, logger, err)
+	}
+}
+
+// run 调用处理函数，处理函数 panic 时按执行失败处理
+func (w *Worker) run(ctx context.Context, h *handler, exec *Execution, task *model.Task, logger zerolog.Logger) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error().Interface("panic", r).Msg("后台任务发生panic")
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h.run(ctx, exec, []byte(task.Payload))
+}
+
+// heartbeat 定期续约，发现取消请求或租约丢失时取消任务 context，直到处理函数返回
+func (w *Worker) heartbeat(task *model.Task, exec *Execution, cancel context.CancelCauseFunc, done <-chan struct{}, logger zerolog.Logger) {
+	lease := w.cfg.GetLease()
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancelRenew := leaseContext(context.Background())
+		status, err := w.taskRepo.RenewLeaseManual(ctx, task.TaskID, w.id, time.Now().Add(lease).UnixMilli())
+		cancelRenew()
+		switch {
+		case err != nil:
+			logger.Warn().Err(err).Msg("任务续约失败")
+		case status == "":
+			cancel(errLeaseLost)
+		case status == constants.TaskCanceling && !exec.canceled.Load():
+			logger.Info().Msg("收到取消请求，中断任务执行")
+			exec.canceled.Store(true)
+			cancel(ErrCanceled)
+		}
+	}
+}
+
+// succeed 标记任务成功并保存结果
+func (w *Worker) succeed(task *model.Task, result any, logger zerolog.Logger) {
+	var data []byte
+	if result != nil {
+		var err error
+		if data, err = json.Marshal(result); err != nil {
+			logger.Warn().Err(err).Msg("序列化任务结果失败")
+		}
+	}
+	w.finish(task, logger, map[string]interface{}{
+		"status":        constants.TaskSucceeded,
+		"progress":      int16(100),
+		"result":        string(data),
+		"error_message": "",
+	})
+	logger.Info().Msg("后台任务执行成功")
+}
+
+// retry 按退避间隔安排重试
+func (w *Worker) retry(task *model.Task, logger zerolog.Logger, err error) {
+	delay := retryDelay(task.Attempts)
+	logger.Warn().Err(err).Dur("delay", delay).Msg("后台任务执行失败，等待重试")
+	w.update(task, logger, map[string]interface{}{
+		"status":        constants.TaskPending,
+		"error_message": errorMessage(err),
+		"run_at":        time.Now().Add(delay).UnixMilli(),
+		"locked_by":     "",
+		"locked_until":  int64(0),
+	})
+}
+
+// fail 标记任务最终失败并通知处理器，处理器未注册时 h 为空
+func (w *Worker) fail(task *model.Task, h *handler, snapshot *xcontext.Snapshot, logger zerolog.Logger, err error) {
+	logger.Error().Err(err).Msg("后台任务执行失败，不再重试")
+	if !w.finish(task, logger, map[string]interface{}{
+		"status":        constants.TaskFailed,
+		"error_message": errorMessage(err),
+	}) {
+		return
+	}
+	if h != nil {
+		w.notifyFailure(task, h, snapshot, err, logger)
+	}
+}
+
+// finishCanceled 标记任务已取消并通知处理器
+func (w *Worker) finishCanceled(task *model.Task, h *handler, snapshot *xcontext.Snapshot, logger zerolog.Logger) {
+	if !w.finish(task, logger, map[string]interface{}{
+		"status": constants.TaskCanceled,
+	}) {
+		return
+	}
+	logger.Info().Msg("后台任务已取消")
+	w.notifyFailure(task, h, snapshot, ErrCanceled, logger)
+}
+
+// requeue 服务停止时将未完成的任务放回队列，本次执行不计入执行次数；已请求取消的任务直接结束
+func (w *Worker) requeue(task *model.Task, h *handler, snapshot *xcontext.Snapshot, logger zerolog.Logger) {
+	ctx, cancel := leaseContext(context.Background())
+	status, err := w.taskRepo.RenewLeaseManual(ctx, task.TaskID, w.id, time.Now().Add(w.cfg.GetLease()).UnixMilli())
+	cancel()
+	if err == nil && status == constants.TaskCanceling {
+		w.finishCanceled(task, h, snapshot, logger)
+		return
+	}
+
+	logger.Info().Msg("服务停止，后台任务放回队列")
+	w.update(task, logger, map[string]interface{}{
+		"status":       constants.TaskPending,
+		"attempts":     gorm.Expr("attempts - 1"),
+		"run_at":       time.Now().UnixMilli(),
+		"locked_by":    "",
+		"locked_until": int64(0),
+	})
+}
+
+// finish 结束任务并释放租约，返回是否更新成功
+func (w *Worker) finish(task *model.Task, logger zerolog.Logger, updates map[string]interface{}) bool {
+	updates["finished_at"] = time.Now().UnixMilli()
+	updates["locked_by"] = ""
+	updates["locked_until"] = int64(0)
+	return w.update(task, logger, updates)
+}
+
+// update 更新本实例持有租约的任务，返回是否更新成功
+func (w *Worker) update(task *model.Task, logger zerolog.Logger, updates map[string]interface{}) bool {
+	ctx, cancel := leaseContext(context.Background())
+	defer cancel()
+	ok, err := w.taskRepo.UpdateByWorkerManual(ctx, task.TaskID, w.id, updates)
+	if err != nil {
+		logger.Error().Err(err).Msg("更新后台任务状态失败")
+		return false
+	}
+	if !ok {
+		logger.Warn().Msg("任务租约已被其他实例接管，未更新任务状态")
+	}
+	return ok
+}
+
+// notifyFailure 调用处理器的 OnFailure，context 按任务发起人重建
+func (w *Worker) notifyFailure(task *model.Task, h *handler, snapshot *xcontext.Snapshot, err error, logger zerolog.Logger) {
+	if h.onFailure == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error().Interface("panic", r).Msg("任务失败回调发生panic")
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(snapshot.Restore(context.Background()), 30*time.Second)
+	defer cancel()
+	h.onFailure(ctx, []byte(task.Payload), err)
+}
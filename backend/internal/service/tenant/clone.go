@@ -0,0 +1,327 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xerr"
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// cloneSource 克隆时读取的源租户数据
+type cloneSource struct {
+	roles           []*model.Role
+	rolePermissions []*model.RolePermission
+	departments     []*model.Department
+	positions       []*model.Position
+	dictTypes       []*model.DictType
+	dictItems       []*model.DictItem
+	users           []*model.User
+	userRoles       []*model.UserRole
+	userPositions   []*model.UserPosition
+}
+
+// CloneTenant 克隆租户
+// 以源租户为参照创建新租户：在一个事务中创建租户并复制角色及授权、部门和岗位、字典（可选复制脱敏用户），
+// 之后按创建租户流程初始化管理员；复制的角色授权按新租户套餐裁剪，未指定套餐时沿用源租户套餐
+func (s *Service) CloneTenant(ctx context.Context, req *dto.TenantCloneRequest) (resp *dto.TenantCloneResponse, err error) {
+	var tenant *model.Tenant
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if tenant != nil {
+			s.recorder.Log(ctx,
+				audit.WithCreate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, tenant.TenantID, tenant.Name),
+				audit.WithValue(nil, resp),
+			)
+		}
+	}()
+
+	source, err := s.tenantRepo.GetByIDManual(ctx, req.SourceTenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", req.SourceTenantID).Msg("查询源租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询源租户失败", err)
+	}
+
+	createReq := req.TenantCreateRequest
+	if createReq.PlanID == "" {
+		createReq.PlanID = source.PlanID
+	}
+	admin := provisionAdmin{Email: createReq.AdminEmail, Name: createReq.AdminName, Phone: createReq.AdminPhone}
+
+	// 检查租户编码是否已存在；数据复制与租户创建在同一事务中，已存在的未完成租户只需继续初始化
+	existing, err := s.tenantRepo.GetByCode(ctx, createReq.TenantCode)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		log.Error().Err(err).Str("tenant_code", createReq.TenantCode).Msg("检查租户编码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查租户编码失败", err)
+	}
+	if err == nil {
+		if provisionStatus(existing) != constants.TenantProvisionReady && existing.Name == createReq.Name {
+			log.Info().Str("tenant_id", existing.TenantID).Msg("克隆租户初始化未完成，继续初始化")
+			tenant = existing
+			created, err := s.provision(ctx, tenant, admin)
+			if err != nil {
+				return nil, err
+			}
+			return &dto.TenantCloneResponse{TenantCreateResponse: *created, SourceTenantID: source.TenantID}, nil
+		}
+		log.Warn().Str("tenant_code", createReq.TenantCode).Msg("租户编码已存在")
+		return nil, xerr.New(xerr.ErrConflict.Code, "租户编码已存在")
+	}
+
+	if err := s.checkCreatable(ctx, &createReq); err != nil {
+		return nil, err
+	}
+
+	tenantID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成租户ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成租户ID失败", err)
+	}
+	newTenant := newTenantModel(tenantID, &createReq)
+
+	var counts map[string]int64
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewTenantRepo(tx.DB).Create(ctx, newTenant); err != nil {
+			return err
+		}
+		var err error
+		counts, err = s.copyTenantData(ctx, tx, source.TenantID, newTenant, req.IncludeUsers)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Str("source_tenant_id", source.TenantID).Str("tenant_code", createReq.TenantCode).Msg("克隆租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "克隆租户失败", err)
+	}
+	tenant = newTenant
+
+	// 复制的 admin 角色与根部门会被初始化流程直接复用
+	created, err := s.provision(ctx, tenant, admin)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("source_tenant_id", source.TenantID).Str("tenant_id", tenant.TenantID).Interface("counts", counts).Msg("克隆租户成功")
+	return &dto.TenantCloneResponse{
+		TenantCreateResponse: *created,
+		SourceTenantID:       source.TenantID,
+		Counts:               counts,
+	}, nil
+}
+
+// copyTenantData 将源租户的参照数据复制到新租户，所有ID重新生成
+//
+//tenantscope:allow 按新租户裁剪复制的角色授权
+func (s *Service) copyTenantData(ctx context.Context, tx *database.Tx, sourceID string, tenant *model.Tenant, includeUsers bool) (map[string]int64, error) {
+	archiveRepo := repository.NewTenantArchiveRepo(tx.DB)
+
+	src, err := loadCloneSource(ctx, archiveRepo, sourceID, includeUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := idgen.GenerateUUIDs(len(src.roles) + len(src.departments) + len(src.positions) +
+		len(src.dictTypes) + len(src.dictItems) + len(src.users))
+	if err != nil {
+		return nil, err
+	}
+	next := func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}
+
+	// 先为可被引用的实体分配新ID，再统一改写引用
+	idMap := make(map[string]string)
+	for _, r := range src.roles {
+		idMap[r.RoleID] = next()
+	}
+	for _, d := range src.departments {
+		idMap[d.DepartmentID] = next()
+	}
+	for _, p := range src.positions {
+		idMap[p.PositionID] = next()
+	}
+	for _, dt := range src.dictTypes {
+		idMap[dt.TypeID] = next()
+	}
+	for _, u := range src.users {
+		idMap[u.UserID] = next()
+	}
+
+	tenantID := tenant.TenantID
+	for _, r := range src.roles {
+		r.RoleID = idMap[r.RoleID]
+		r.TenantID = tenantID
+		r.ParentRoleID = idMap[r.ParentRoleID]
+		r.CreatedAt, r.UpdatedAt = 0, 0
+	}
+	rolePermissions := make([]*model.RolePermission, 0, len(src.rolePermissions))
+	for _, rp := range src.rolePermissions {
+		if roleID, ok := idMap[rp.RoleID]; ok {
+			rolePermissions = append(rolePermissions, &model.RolePermission{RoleID: roleID, PermissionID: rp.PermissionID, TenantID: tenantID})
+		}
+	}
+	for _, d := range src.departments {
+		d.DepartmentID = idMap[d.DepartmentID]
+		d.TenantID = tenantID
+		d.ParentID = idMap[d.ParentID]
+		d.CreatedAt, d.UpdatedAt = 0, 0
+	}
+	for _, p := range src.positions {
+		p.PositionID = idMap[p.PositionID]
+		p.TenantID = tenantID
+		p.CreatedAt, p.UpdatedAt = 0, 0
+	}
+	for _, dt := range src.dictTypes {
+		dt.TypeID = idMap[dt.TypeID]
+		dt.TenantID = tenantID
+		dt.CreatedAt, dt.UpdatedAt = 0, 0
+	}
+	// 覆盖系统字典的字典项引用默认租户的字典类型，类型ID保持不变
+	for _, item := range src.dictItems {
+		item.ItemID = next()
+		if typeID, ok := idMap[item.TypeID]; ok {
+			item.TypeID = typeID
+		}
+		item.TenantID = tenantID
+		item.CreatedAt, item.UpdatedAt = 0, 0
+	}
+	for i, u := range src.users {
+		anonymizeUser(u, i+1, tenant.TenantCode)
+		u.UserID = idMap[u.UserID]
+		u.TenantID = tenantID
+		u.DepartmentID = idMap[u.DepartmentID]
+		u.PositionID = idMap[u.PositionID]
+	}
+	userRoles := make([]*model.UserRole, 0, len(src.userRoles))
+	for _, ur := range src.userRoles {
+		userID, roleID := idMap[ur.UserID], idMap[ur.RoleID]
+		if userID != "" && roleID != "" {
+			userRoles = append(userRoles, &model.UserRole{UserID: userID, RoleID: roleID, TenantID: tenantID})
+		}
+	}
+	userPositions := make([]*model.UserPosition, 0, len(src.userPositions))
+	for _, up := range src.userPositions {
+		userID, positionID := idMap[up.UserID], idMap[up.PositionID]
+		if userID != "" && positionID != "" {
+			userPositions = append(userPositions, &model.UserPosition{UserID: userID, PositionID: positionID, IsPrimary: up.IsPrimary})
+		}
+	}
+
+	counts := map[string]int64{
+		"roles":            int64(len(src.roles)),
+		"role_permissions": int64(len(rolePermissions)),
+		"departments":      int64(len(src.departments)),
+		"positions":        int64(len(src.positions)),
+		"dict_types":       int64(len(src.dictTypes)),
+		"dict_items":       int64(len(src.dictItems)),
+		"users":            int64(len(src.users)),
+		"user_roles":       int64(len(userRoles)),
+		"user_positions":   int64(len(userPositions)),
+	}
+	tables := []struct {
+		name string
+		rows interface{}
+	}{
+		{"roles", src.roles},
+		{"role_permissions", rolePermissions},
+		{"departments", src.departments},
+		{"positions", src.positions},
+		{"dict_types", src.dictTypes},
+		{"dict_items", src.dictItems},
+		{"users", src.users},
+		{"user_roles", userRoles},
+		{"user_positions", userPositions},
+	}
+	for _, t := range tables {
+		if counts[t.name] == 0 {
+			continue
+		}
+		if err := archiveRepo.CreateInBatches(ctx, t.rows); err != nil {
+			return nil, fmt.Errorf("%s: %w", t.name, err)
+		}
+	}
+
+	// 复制的授权不能超出新租户套餐范围
+	if tenant.PlanID != "" {
+		allowed, limited, err := repository.NewPlanRepo(tx.DB).GetTenantCeilingManual(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if limited {
+			stripped, err := repository.NewRolePermissionRepo(tx.DB).DeleteOutsidePermissionsManual(database.WithTenant(ctx, tenantID), []string{tenantID}, allowed)
+			if err != nil {
+				return nil, err
+			}
+			counts["role_permissions"] -= stripped
+		}
+	}
+	return counts, nil
+}
+
+// loadCloneSource 读取源租户需要复制的数据，服务账号不复制
+func loadCloneSource(ctx context.Context, repo *repository.TenantArchiveRepo, tenantID string, includeUsers bool) (*cloneSource, error) {
+	src := &cloneSource{}
+	for _, dest := range []interface{}{
+		&src.roles, &src.rolePermissions, &src.departments, &src.positions, &src.dictTypes, &src.dictItems,
+	} {
+		if err := repo.FindByTenantManual(ctx, dest, tenantID); err != nil {
+			return nil, err
+		}
+	}
+	if !includeUsers {
+		return src, nil
+	}
+
+	var users []*model.User
+	if err := repo.FindByTenantManual(ctx, &users, tenantID); err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.IsServiceAccount != int16(constants.True) {
+			src.users = append(src.users, u)
+		}
+	}
+	if err := repo.FindByTenantManual(ctx, &src.userRoles, tenantID); err != nil {
+		return nil, err
+	}
+	userPositions, err := repo.ListUserPositionsManual(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	src.userPositions = userPositions
+	return src, nil
+}
+
+// anonymizeUser 脱敏复制的用户：替换账号、昵称和邮箱，清空手机号、头像与密码，登录前需由管理员重置密码
+// 邮箱在租户内唯一，使用保留域名 .invalid 生成不可投递的占位邮箱
+func anonymizeUser(user *model.User, seq int, tenantCode string) {
+	user.UserName = fmt.Sprintf("demo_user_%04d", seq)
+	user.Nickname = fmt.Sprintf("演示用户%d", seq)
+	user.Email = fmt.Sprintf("demo_user_%04d@%s.invalid", seq, tenantCode)
+	user.Phone = ""
+	user.Avatar = ""
+	user.Description = ""
+	user.Remark = ""
+	user.Password = ""
+	user.MustChangePassword = constants.True
+	user.LastLoginTime = 0
+	user.CreatedAt, user.UpdatedAt = 0, 0
+}
@@ -3,6 +3,7 @@ package tenant
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dto"
+	"time"
 )
 
 // ModelToTenantInfo 将数据库模型转换为租户信息 DTO
@@ -12,14 +13,22 @@ func ModelToTenantInfo(tenant *model.Tenant) *dto.TenantInfo {
 	}
 
 	return &dto.TenantInfo{
-		TenantID:     tenant.TenantID,
-		TenantCode:   tenant.TenantCode,
-		Name:         tenant.Name,
-		Description:  tenant.Description,
-		ContactName:  tenant.ContactName,
-		ContactPhone: tenant.ContactPhone,
-		Status:       int(tenant.Status),
-		CreatedAt:    tenant.CreatedAt,
-		UpdatedAt:    tenant.UpdatedAt,
+		TenantID:         tenant.TenantID,
+		TenantCode:       tenant.TenantCode,
+		Name:             tenant.Name,
+		Description:      tenant.Description,
+		ContactName:      tenant.ContactName,
+		ContactPhone:     tenant.ContactPhone,
+		ContactEmail:     tenant.ContactEmail,
+		Status:           int(tenant.Status),
+		LifecycleStatus:  EffectiveLifecycle(tenant, time.Now().UnixMilli()),
+		ExpiresAt:        tenant.ExpiresAt,
+		LoginRiskPolicy:  tenant.LoginRiskPolicy,
+		AllowSharedEmail: int(tenant.AllowSharedEmail),
+		ProvisionStatus:  provisionStatus(tenant),
+		ProvisionError:   tenant.ProvisionError,
+		PlanID:           tenant.PlanID,
+		CreatedAt:        tenant.CreatedAt,
+		UpdatedAt:        tenant.UpdatedAt,
 	}
 }
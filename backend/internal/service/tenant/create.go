@@ -10,10 +10,13 @@ import (
 	"context"
 
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 // CreateTenant 创建租户
-func (s *Service) CreateTenant(ctx context.Context, req *dto.TenantCreateRequest) (resp *dto.TenantInfo, err error) {
+// 创建租户后执行初始化流水线（管理员角色、根部门、默认岗位、初始管理员），返回管理员一次性密码
+// 同一租户编码重复提交时，若上次初始化未完成则从失败的步骤继续
+func (s *Service) CreateTenant(ctx context.Context, req *dto.TenantCreateRequest) (resp *dto.TenantCreateResponse, err error) {
 	var tenant *model.Tenant
 
 	defer func() {
@@ -26,31 +29,32 @@ func (s *Service) CreateTenant(ctx context.Context, req *dto.TenantCreateRequest
 			s.recorder.Log(ctx,
 				audit.WithCreate(constants.ModuleTenant),
 				audit.WithResource(constants.ResourceTypeTenant, tenant.TenantID, tenant.Name),
-				audit.WithValue(nil, tenant),
+				audit.WithValue(nil, resp.Tenant),
 			)
 		}
 	}()
 
+	admin := provisionAdmin{Email: req.AdminEmail, Name: req.AdminName, Phone: req.AdminPhone}
+
 	// 检查租户编码是否已存在
-	exists, err := s.tenantRepo.CheckExists(ctx, req.TenantCode)
-	if err != nil {
+	existing, err := s.tenantRepo.GetByCode(ctx, req.TenantCode)
+	if err != nil && err != gorm.ErrRecordNotFound {
 		log.Error().Err(err).Str("tenant_code", req.TenantCode).Msg("检查租户编码失败")
 		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查租户编码失败", err)
 	}
-	if exists {
+	if err == nil {
+		// 上次初始化未完成的同名租户：继续初始化
+		if provisionStatus(existing) != constants.TenantProvisionReady && existing.Name == req.Name {
+			log.Info().Str("tenant_id", existing.TenantID).Msg("租户初始化未完成，继续初始化")
+			tenant = existing
+			return s.provision(ctx, tenant, admin)
+		}
 		log.Warn().Str("tenant_code", req.TenantCode).Msg("租户编码已存在")
 		return nil, xerr.New(xerr.ErrConflict.Code, "租户编码已存在")
 	}
 
-	// 检查租户名称是否已存在
-	nameExists, err := s.tenantRepo.CheckNameExists(ctx, req.Name)
-	if err != nil {
-		log.Error().Err(err).Str("name", req.Name).Msg("检查租户名称失败")
-		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查租户名称失败", err)
-	}
-	if nameExists {
-		log.Warn().Str("name", req.Name).Msg("租户名称已存在")
-		return nil, xerr.New(xerr.ErrConflict.Code, "租户名称已存在")
+	if err := s.checkCreatable(ctx, req); err != nil {
+		return nil, err
 	}
 
 	// 生成租户ID
@@ -59,17 +63,7 @@ func (s *Service) CreateTenant(ctx context.Context, req *dto.TenantCreateRequest
 		log.Error().Err(err).Msg("生成租户ID失败")
 		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成租户ID失败", err)
 	}
-
-	// 构建租户模型
-	tenant = &model.Tenant{
-		TenantID:     tenantID,
-		TenantCode:   req.TenantCode,
-		Name:         req.Name,
-		Description:  req.Description,
-		ContactName:  req.ContactName,
-		ContactPhone: req.ContactPhone,
-		Status:       int16(constants.StatusEnabled), // 默认启用
-	}
+	tenant = newTenantModel(tenantID, req)
 
 	// 创建租户
 	if err := s.tenantRepo.Create(ctx, tenant); err != nil {
@@ -77,5 +71,56 @@ func (s *Service) CreateTenant(ctx context.Context, req *dto.TenantCreateRequest
 		return nil, xerr.Wrap(xerr.ErrInternal.Code, "创建租户失败", err)
 	}
 
-	return ModelToTenantInfo(tenant), nil
+	return s.provision(ctx, tenant, admin)
+}
+
+// checkCreatable 检查新租户的名称、管理员邮箱和套餐是否可用（租户编码由调用方检查）
+func (s *Service) checkCreatable(ctx context.Context, req *dto.TenantCreateRequest) error {
+	// 检查租户名称是否已存在
+	nameExists, err := s.tenantRepo.CheckNameExists(ctx, req.Name)
+	if err != nil {
+		log.Error().Err(err).Str("name", req.Name).Msg("检查租户名称失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "检查租户名称失败", err)
+	}
+	if nameExists {
+		log.Warn().Str("name", req.Name).Msg("租户名称已存在")
+		return xerr.New(xerr.ErrConflict.Code, "租户名称已存在")
+	}
+
+	// 提前检查管理员邮箱，避免创建出无法完成初始化的租户
+	// 新租户尚未创建且默认不共享邮箱，邮箱被任何租户的用户使用时均不可用
+	if err := CheckEmailAvailable(ctx, s.userRepo, s.tenantRepo, "", req.AdminEmail, ""); err != nil {
+		log.Warn().Err(err).Str("email", req.AdminEmail).Msg("管理员邮箱不可用")
+		return err
+	}
+
+	if req.PlanID != "" {
+		return s.checkPlan(ctx, req.PlanID)
+	}
+	return nil
+}
+
+// newTenantModel 根据创建请求构建待初始化的租户模型
+func newTenantModel(tenantID string, req *dto.TenantCreateRequest) *model.Tenant {
+	lifecycleStatus := constants.TenantLifecycleActive
+	if req.Trial {
+		lifecycleStatus = constants.TenantLifecycleTrial
+	}
+
+	return &model.Tenant{
+		TenantID:         tenantID,
+		TenantCode:       req.TenantCode,
+		Name:             req.Name,
+		Description:      req.Description,
+		ContactName:      req.ContactName,
+		ContactPhone:     req.ContactPhone,
+		ContactEmail:     req.ContactEmail,
+		Status:           int16(constants.StatusEnabled), // 默认启用
+		LifecycleStatus:  lifecycleStatus,
+		ExpiresAt:        req.ExpiresAt,
+		LoginRiskPolicy:  constants.LoginRiskPolicyAllow,
+		AllowSharedEmail: constants.False,
+		ProvisionStatus:  constants.TenantProvisionPending,
+		PlanID:           req.PlanID,
+	}
 }
@@ -2,8 +2,10 @@ package tenant
 
 import (
 	"admin/internal/dal/model"
+	"admin/internal/repository"
 	"admin/pkg/audit"
 	"admin/pkg/constants"
+	"admin/pkg/database"
 	"admin/pkg/utils/convert"
 	"admin/pkg/xerr"
 	"context"
@@ -15,6 +17,7 @@ import (
 )
 
 // DeleteTenant 删除租户
+// 软删除租户并安排彻底清除，保留期内可恢复；租户下所有会话立即失效
 func (s *Service) DeleteTenant(ctx context.Context, tenantID string) (err error) {
 	var tenant *model.Tenant
 
@@ -51,12 +54,19 @@ func (s *Service) DeleteTenant(ctx context.Context, tenantID string) (err error)
 	//     return xerr.New(xerr.ErrBadRequest.Code, "租户下还有用户，无法删除")
 	// }
 
-	// 删除租户
-	if err := s.tenantRepo.Delete(ctx, tenantID); err != nil {
+	// 删除租户并安排彻底清除
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewTenantRepo(tx.DB).Delete(ctx, tenantID); err != nil {
+			return err
+		}
+		return s.schedulePurge(ctx, repository.NewTenantPurgeRepo(tx.DB), tenant)
+	})
+	if err != nil {
 		log.Error().Err(err).Str("tenant_id", tenantID).Msg("删除租户失败")
 		return xerr.Wrap(xerr.ErrInternal.Code, "删除租户失败", err)
 	}
 
+	s.revokeTenantSessions(ctx, tenant)
 	return nil
 }
 
@@ -126,11 +136,26 @@ func (s *Service) BatchDeleteTenants(ctx context.Context, tenantIDs []string) (e
 		return xerr.New(xerr.ErrInvalidParams.Code, fmt.Sprintf("以下租户下还有用户，无法删除：%s", strings.Join(tenantsWithUsers, "、")))
 	}
 
-	// 批量删除租户
-	if err := s.tenantRepo.BatchDelete(ctx, tenantIDs); err != nil {
+	// 批量删除租户并安排彻底清除
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewTenantRepo(tx.DB).BatchDelete(ctx, tenantIDs); err != nil {
+			return err
+		}
+		purgeRepo := repository.NewTenantPurgeRepo(tx.DB)
+		for _, tenant := range tenants {
+			if err := s.schedulePurge(ctx, purgeRepo, tenant); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		log.Error().Err(err).Strs("tenant_ids", tenantIDs).Msg("批量删除租户失败")
 		return xerr.Wrap(xerr.ErrInternal.Code, "批量删除租户失败", err)
 	}
 
+	for _, tenant := range tenants {
+		s.revokeTenantSessions(ctx, tenant)
+	}
 	return nil
 }
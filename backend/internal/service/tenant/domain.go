@@ -0,0 +1,242 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	domainTXTPrefix   = "_admin-verify." // 域名验证 TXT 记录名前缀
+	domainTXTValueKey = "admin-verify="  // 域名验证 TXT 记录值前缀
+)
+
+// AddTenantDomain 绑定自定义域名
+// 绑定后需在域名 DNS 中添加 TXT 记录并完成验证，验证通过前不参与租户识别
+//
+//tenantscope:allow 域名在所有租户间唯一，需检查其他租户的绑定
+func (s *Service) AddTenantDomain(ctx context.Context, req *dto.TenantDomainCreateRequest) (resp *dto.TenantDomainInfo, err error) {
+	var domain *model.TenantDomain
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if domain != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, domain.TenantID, domain.Domain),
+				audit.WithValue(nil, domain),
+			)
+		}
+	}()
+
+	tenantID, err := TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.tenantRepo.GetByIDManual(ctx, tenantID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+
+	// 其他租户的绑定对当前租户不可见，标记为平台级操作
+	name := strings.ToLower(strings.TrimSuffix(req.Domain, "."))
+	if _, err := s.domainRepo.GetByDomainManual(xcontext.SetPlatformScope(ctx), name); err == nil {
+		return nil, xerr.ErrTenantDomainExists
+	} else if err != gorm.ErrRecordNotFound {
+		log.Error().Err(err).Str("domain", name).Msg("检查域名失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查域名失败", err)
+	}
+
+	domainID, err := idgen.GenerateUUID()
+	if err != nil {
+		log.Error().Err(err).Msg("生成域名绑定ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成域名绑定ID失败", err)
+	}
+	token, err := passwordgen.GenerateSecret(16)
+	if err != nil {
+		log.Error().Err(err).Msg("生成域名验证令牌失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成域名验证令牌失败", err)
+	}
+
+	domain = &model.TenantDomain{
+		DomainID:    domainID,
+		TenantID:    tenantID,
+		Domain:      name,
+		VerifyToken: token,
+	}
+	if err := s.domainRepo.Create(ctx, domain); err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Str("domain", name).Msg("绑定域名失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "绑定域名失败", err)
+	}
+
+	return modelToDomainInfo(domain), nil
+}
+
+// ListTenantDomains 获取租户绑定的自定义域名
+func (s *Service) ListTenantDomains(ctx context.Context, req *dto.TenantDomainListRequest) ([]*dto.TenantDomainInfo, error) {
+	tenantID, err := TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := s.domainRepo.ListByTenantManual(ctx, tenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户域名失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户域名失败", err)
+	}
+
+	list := make([]*dto.TenantDomainInfo, len(domains))
+	for i, domain := range domains {
+		list[i] = modelToDomainInfo(domain)
+	}
+	return list, nil
+}
+
+// VerifyTenantDomain 通过 DNS TXT 记录验证域名所有权
+func (s *Service) VerifyTenantDomain(ctx context.Context, req *dto.TenantDomainRequest) (resp *dto.TenantDomainInfo, err error) {
+	var domain *model.TenantDomain
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if domain != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, domain.TenantID, domain.Domain),
+				audit.WithValue(nil, map[string]interface{}{"verified_at": domain.VerifiedAt}),
+			)
+		}
+	}()
+
+	domain, err = s.getDomain(ctx, req.DomainID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.VerifiedAt > 0 {
+		return modelToDomainInfo(domain), nil
+	}
+
+	records, err := net.DefaultResolver.LookupTXT(ctx, domainTXTPrefix+domain.Domain)
+	if err != nil {
+		log.Warn().Err(err).Str("domain", domain.Domain).Msg("查询域名 TXT 记录失败")
+		return nil, xerr.ErrTenantDomainUnverified
+	}
+	expected := domainTXTValueKey + domain.VerifyToken
+	matched := false
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return nil, xerr.ErrTenantDomainUnverified
+	}
+
+	domain.VerifiedAt = time.Now().UnixMilli()
+	if err := s.domainRepo.MarkVerifiedManual(ctx, domain.DomainID, domain.VerifiedAt); err != nil {
+		log.Error().Err(err).Str("domain", domain.Domain).Msg("更新域名验证状态失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新域名验证状态失败", err)
+	}
+
+	return modelToDomainInfo(domain), nil
+}
+
+// DeleteTenantDomain 解绑自定义域名
+func (s *Service) DeleteTenantDomain(ctx context.Context, req *dto.TenantDomainRequest) (err error) {
+	var domain *model.TenantDomain
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if domain != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, domain.TenantID, domain.Domain),
+				audit.WithValue(domain, nil),
+			)
+		}
+	}()
+
+	domain, err = s.getDomain(ctx, req.DomainID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.domainRepo.DeleteManual(ctx, domain.DomainID); err != nil {
+		log.Error().Err(err).Str("domain", domain.Domain).Msg("解绑域名失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "解绑域名失败", err)
+	}
+	return nil
+}
+
+// getDomain 获取域名绑定，非超级管理员只能操作当前租户的域名
+func (s *Service) getDomain(ctx context.Context, domainID string) (*model.TenantDomain, error) {
+	domain, err := s.domainRepo.GetByIDManual(ctx, domainID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantDomainNotFound
+		}
+		log.Error().Err(err).Str("domain_id", domainID).Msg("查询域名失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询域名失败", err)
+	}
+	if domain.TenantID != xcontext.GetTenantID(ctx) && !xcontext.HasRole(ctx, constants.SuperAdmin) {
+		return nil, xerr.ErrTenantDomainNotFound
+	}
+	return domain, nil
+}
+
+// TargetTenantID 获取操作的目标租户
+// 超级管理员可指定任意租户，其他用户只能操作当前租户
+func TargetTenantID(ctx context.Context, tenantID string) (string, error) {
+	current := xcontext.GetTenantID(ctx)
+	if tenantID != "" && tenantID != current {
+		if !xcontext.HasRole(ctx, constants.SuperAdmin) {
+			return "", xerr.ErrForbidden
+		}
+		return tenantID, nil
+	}
+	if current == "" {
+		return "", xerr.ErrUnauthorized
+	}
+	return current, nil
+}
+
+// modelToDomainInfo 将域名绑定模型转换为 DTO
+func modelToDomainInfo(domain *model.TenantDomain) *dto.TenantDomainInfo {
+	return &dto.TenantDomainInfo{
+		DomainID:   domain.DomainID,
+		TenantID:   domain.TenantID,
+		Domain:     domain.Domain,
+		Verified:   domain.VerifiedAt > 0,
+		VerifiedAt: domain.VerifiedAt,
+		TXTName:    domainTXTPrefix + domain.Domain,
+		TXTValue:   domainTXTValueKey + domain.VerifyToken,
+		CreatedAt:  domain.CreatedAt,
+	}
+}
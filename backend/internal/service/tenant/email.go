@@ -0,0 +1,64 @@
+package tenant
+
+import (
+	"admin/internal/repository"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CheckEmailAvailable 检查邮箱在目标租户是否可用
+// 同一租户内邮箱唯一；跨租户重复仅允许在所有相关租户都开启共享邮箱时出现，
+// 读取不到相关租户的设置时按不允许共享处理（包括尚未创建的新租户）
+//
+//tenantscope:allow 邮箱唯一性需比对所有租户的用户
+func CheckEmailAvailable(ctx context.Context, userRepo *repository.UserRepo, tenantRepo *repository.TenantRepo, tenantID, email, excludeUserID string) error {
+	if email == "" {
+		return nil
+	}
+	// 其他租户的用户对当前租户不可见，标记为平台级操作
+	ctx = xcontext.SetPlatformScope(ctx)
+
+	users, err := userRepo.ListByEmailManual(ctx, email)
+	if err != nil {
+		log.Error().Err(err).Str("email", email).Msg("检查邮箱失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "检查邮箱失败", err)
+	}
+
+	tenantIDs := []string{tenantID}
+	seen := map[string]bool{tenantID: true}
+	for _, user := range users {
+		if user.UserID == excludeUserID {
+			continue
+		}
+		if user.TenantID == tenantID {
+			return xerr.ErrEmailOrPhoneExists
+		}
+		if !seen[user.TenantID] {
+			seen[user.TenantID] = true
+			tenantIDs = append(tenantIDs, user.TenantID)
+		}
+	}
+	if len(tenantIDs) == 1 {
+		return nil
+	}
+
+	tenants, err := tenantRepo.GetByIDsManual(ctx, tenantIDs)
+	if err != nil {
+		log.Error().Err(err).Str("email", email).Msg("查询租户共享邮箱设置失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询租户共享邮箱设置失败", err)
+	}
+	if len(tenants) != len(tenantIDs) {
+		log.Warn().Str("email", email).Strs("tenant_ids", tenantIDs).Msg("部分租户的共享邮箱设置不可见，按不允许共享处理")
+		return xerr.ErrEmailOrPhoneExists
+	}
+	for _, tenant := range tenants {
+		if tenant.AllowSharedEmail != constants.True {
+			return xerr.ErrEmailOrPhoneExists
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// EffectiveLifecycle 获取租户当前生效的生命周期状态
+// 状态由定时任务每日流转，到期当天任务执行前按宽限期处理，避免到期后仍可写入
+func EffectiveLifecycle(tenant *model.Tenant, now int64) string {
+	status := tenant.LifecycleStatus
+	if status == "" {
+		status = constants.TenantLifecycleActive
+	}
+	if (status == constants.TenantLifecycleTrial || status == constants.TenantLifecycleActive) &&
+		tenant.ExpiresAt > 0 && tenant.ExpiresAt <= now {
+		return constants.TenantLifecycleGrace
+	}
+	return status
+}
+
+// CheckLifecycle 检查租户生命周期是否允许登录与访问
+// 已过期、已归档的租户拒绝访问，宽限期仍允许登录（只读限制由中间件处理）
+func CheckLifecycle(tenant *model.Tenant) error {
+	switch EffectiveLifecycle(tenant, time.Now().UnixMilli()) {
+	case constants.TenantLifecycleExpired:
+		return xerr.ErrTenantExpired
+	case constants.TenantLifecycleArchived:
+		return xerr.ErrTenantArchived
+	}
+	return nil
+}
+
+// IsReadOnlyLifecycle 判断生命周期状态是否只允许只读访问
+func IsReadOnlyLifecycle(status string) bool {
+	return status == constants.TenantLifecycleGrace
+}
+
+// UpdateTenantSubscription 变更租户订阅（续期、试用转正、归档）
+// 未指定状态时按新的到期时间自动判断：宽限期或已过期的租户续期到未来时间后恢复正常
+func (s *Service) UpdateTenantSubscription(ctx context.Context, req *dto.TenantSubscriptionRequest) (resp *dto.TenantInfo, err error) {
+	var oldTenant, newTenant *model.Tenant
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if newTenant != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, newTenant.TenantID, newTenant.Name),
+				audit.WithValue(
+					map[string]interface{}{"lifecycle_status": oldTenant.LifecycleStatus, "expires_at": oldTenant.ExpiresAt},
+					map[string]interface{}{"lifecycle_status": newTenant.LifecycleStatus, "expires_at": newTenant.ExpiresAt},
+				),
+			)
+		}
+	}()
+
+	oldTenant, err = s.tenantRepo.GetByID(ctx, req.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", req.TenantID).Msg("查询租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+
+	now := time.Now().UnixMilli()
+	expiresAt := oldTenant.ExpiresAt
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	status := req.LifecycleStatus
+	if status == "" {
+		status = EffectiveLifecycle(oldTenant, now)
+		renewed := expiresAt == 0 || expiresAt > now
+		if renewed && (status == constants.TenantLifecycleGrace || status == constants.TenantLifecycleExpired) {
+			status = constants.TenantLifecycleActive
+		}
+	}
+
+	updates := map[string]interface{}{
+		"lifecycle_status": status,
+		"expires_at":       expiresAt,
+		"updated_at":       now,
+	}
+	// 到期时间变化后重新计算提醒
+	if expiresAt != oldTenant.ExpiresAt {
+		updates["expiry_reminded_at"] = int64(0)
+	}
+
+	if err := s.tenantRepo.Update(ctx, oldTenant.TenantID, updates); err != nil {
+		log.Error().Err(err).Str("tenant_id", oldTenant.TenantID).Interface("updates", updates).Msg("更新租户订阅失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新租户订阅失败", err)
+	}
+
+	newTenant, err = s.tenantRepo.GetByID(ctx, oldTenant.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", oldTenant.TenantID).Msg("获取更新后租户信息失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "获取更新后租户信息失败", err)
+	}
+
+	return ModelToTenantInfo(newTenant), nil
+}
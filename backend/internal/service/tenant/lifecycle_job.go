@@ -0,0 +1,174 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"admin/pkg/config"
+	"admin/pkg/constants"
+	"admin/pkg/utils/notify"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// dayMillis 一天的毫秒数
+const dayMillis = int64(24 * time.Hour / time.Millisecond)
+
+// LifecycleManager 租户订阅生命周期流转
+// 由定时任务每日执行：到期进入宽限期，宽限期结束后过期，过期超过保留期后归档，并在到期前向租户联系人发送提醒
+type LifecycleManager struct {
+	tenantRepo *repository.TenantRepo
+	notifier   notify.Sender
+	cfg        config.TenantConfig
+}
+
+// NewLifecycleManager 创建租户生命周期流转管理器
+func NewLifecycleManager(db *gorm.DB, notifier notify.Sender, cfg config.TenantConfig) *LifecycleManager {
+	return &LifecycleManager{
+		tenantRepo: repository.NewTenantRepo(db),
+		notifier:   notifier,
+		cfg:        cfg,
+	}
+}
+
+// lifecycleTransition 生命周期流转规则
+type lifecycleTransition struct {
+	from   []string // 原状态
+	to     string   // 目标状态
+	before int64    // 到期时间不晚于该时间的租户参与流转
+}
+
+// Run 执行一次生命周期流转与到期提醒
+// 状态按条件更新，多实例并发执行时同一租户只会流转一次
+func (m *LifecycleManager) Run(ctx context.Context) error {
+	now := time.Now().UnixMilli()
+	grace := m.cfg.GetGracePeriod().Milliseconds()
+
+	transitions := []lifecycleTransition{
+		{from: []string{constants.TenantLifecycleTrial, constants.TenantLifecycleActive}, to: constants.TenantLifecycleGrace, before: now},
+		{from: []string{constants.TenantLifecycleGrace}, to: constants.TenantLifecycleExpired, before: now - grace},
+	}
+	if m.cfg.ArchiveDays > 0 {
+		transitions = append(transitions, lifecycleTransition{
+			from:   []string{constants.TenantLifecycleExpired},
+			to:     constants.TenantLifecycleArchived,
+			before: now - grace - m.cfg.GetArchivePeriod().Milliseconds(),
+		})
+	}
+
+	for _, t := range transitions {
+		tenants, err := m.tenantRepo.ListExpiringManual(ctx, t.from, t.before)
+		if err != nil {
+			return fmt.Errorf("查询待流转租户失败: %w", err)
+		}
+		for _, tenant := range tenants {
+			ok, err := m.tenantRepo.UpdateLifecycleManual(ctx, tenant.TenantID, tenant.LifecycleStatus, t.to)
+			if err != nil {
+				log.Error().Err(err).Str("tenant_id", tenant.TenantID).Str("to", t.to).Msg("更新租户生命周期状态失败")
+				continue
+			}
+			if !ok {
+				continue
+			}
+			log.Info().Str("tenant_id", tenant.TenantID).Str("from", tenant.LifecycleStatus).Str("to", t.to).Msg("租户生命周期状态已流转")
+			m.notifyTransition(ctx, tenant, t.to)
+		}
+	}
+
+	return m.remind(ctx, now)
+}
+
+// remind 向即将到期的租户联系人发送提醒
+// 每个提醒节点（如到期前7天、3天、1天）只发送一次，续期后重新计算
+func (m *LifecycleManager) remind(ctx context.Context, now int64) error {
+	maxDays := 0
+	for _, days := range m.cfg.ReminderDays {
+		if days > maxDays {
+			maxDays = days
+		}
+	}
+	if maxDays == 0 {
+		return nil
+	}
+
+	statuses := []string{constants.TenantLifecycleTrial, constants.TenantLifecycleActive}
+	tenants, err := m.tenantRepo.ListExpiringManual(ctx, statuses, now+int64(maxDays)*dayMillis)
+	if err != nil {
+		return fmt.Errorf("查询即将到期租户失败: %w", err)
+	}
+
+	for _, tenant := range tenants {
+		daysLeft := daysUntil(tenant.ExpiresAt, now)
+		if !m.isReminderDay(daysLeft) {
+			continue
+		}
+		if tenant.ExpiryRemindedAt > 0 && daysUntil(tenant.ExpiresAt, tenant.ExpiryRemindedAt) == daysLeft {
+			continue
+		}
+
+		expireDate := time.UnixMilli(tenant.ExpiresAt).Format(time.DateOnly)
+		m.notifyContacts(ctx, tenant, "租户订阅即将到期",
+			fmt.Sprintf("您的租户「%s」将于 %s 到期（剩余 %d 天），到期后进入 %d 天只读宽限期，请及时续费。",
+				tenant.Name, expireDate, daysLeft, m.cfg.GraceDays))
+
+		if err := m.tenantRepo.MarkExpiryRemindedManual(ctx, tenant.TenantID, now); err != nil {
+			log.Error().Err(err).Str("tenant_id", tenant.TenantID).Msg("记录租户到期提醒时间失败")
+		}
+	}
+	return nil
+}
+
+// notifyTransition 租户进入宽限期或过期时通知联系人
+func (m *LifecycleManager) notifyTransition(ctx context.Context, tenant *model.Tenant, to string) {
+	switch to {
+	case constants.TenantLifecycleGrace:
+		m.notifyContacts(ctx, tenant, "租户订阅已到期",
+			fmt.Sprintf("您的租户「%s」订阅已到期，现处于 %d 天只读宽限期，宽限期结束后将无法登录，请及时续费。",
+				tenant.Name, m.cfg.GraceDays))
+	case constants.TenantLifecycleExpired:
+		m.notifyContacts(ctx, tenant, "租户订阅已过期",
+			fmt.Sprintf("您的租户「%s」宽限期已结束，成员已无法登录，续费后即可恢复使用。", tenant.Name))
+	}
+}
+
+// notifyContacts 通过邮件和短信通知租户联系人，发送失败仅记录日志
+func (m *LifecycleManager) notifyContacts(ctx context.Context, tenant *model.Tenant, subject, content string) {
+	if m.notifier == nil {
+		return
+	}
+
+	var msgs []*notify.Message
+	if tenant.ContactEmail != "" {
+		msgs = append(msgs, &notify.Message{Channel: notify.ChannelEmail, To: tenant.ContactEmail, Subject: subject, Content: content})
+	}
+	if tenant.ContactPhone != "" {
+		msgs = append(msgs, &notify.Message{Channel: notify.ChannelSMS, To: tenant.ContactPhone, Subject: subject, Content: content})
+	}
+	for _, msg := range msgs {
+		if err := m.notifier.Send(ctx, msg); err != nil {
+			log.Warn().Err(err).Str("tenant_id", tenant.TenantID).Str("channel", msg.Channel).Msg("发送租户到期通知失败")
+		}
+	}
+}
+
+// isReminderDay 判断剩余天数是否为提醒节点
+func (m *LifecycleManager) isReminderDay(daysLeft int) bool {
+	for _, days := range m.cfg.ReminderDays {
+		if days == daysLeft {
+			return true
+		}
+	}
+	return false
+}
+
+// daysUntil 计算从 from 到 expiresAt 剩余的天数（向上取整）
+func daysUntil(expiresAt, from int64) int {
+	diff := expiresAt - from
+	if diff <= 0 {
+		return 0
+	}
+	return int((diff + dayMillis - 1) / dayMillis)
+}
@@ -0,0 +1,118 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ChangeTenantPlan 变更租户套餐
+// 回收租户内超出新套餐范围的角色授权并刷新权限缓存，PlanID 为空表示取消套餐限制
+//
+//tenantscope:allow 按被变更套餐的租户回收角色授权
+func (s *Service) ChangeTenantPlan(ctx context.Context, req *dto.TenantPlanRequest) (resp *dto.TenantPlanResponse, err error) {
+	var tenant *model.Tenant
+	var oldPlanID string
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if tenant != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, tenant.TenantID, tenant.Name),
+				audit.WithValue(
+					map[string]interface{}{"plan_id": oldPlanID},
+					map[string]interface{}{"plan_id": req.PlanID, "stripped_grants": resp.StrippedGrants},
+				),
+			)
+		}
+	}()
+
+	tenant, err = s.tenantRepo.GetByID(ctx, req.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", req.TenantID).Msg("查询租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+	oldPlanID = tenant.PlanID
+
+	var allowed []string
+	if req.PlanID != "" {
+		if err := s.checkPlan(ctx, req.PlanID); err != nil {
+			return nil, err
+		}
+		allowed, err = s.planRepo.GetPermissionIDs(ctx, req.PlanID)
+		if err != nil {
+			log.Error().Err(err).Str("plan_id", req.PlanID).Msg("查询套餐权限失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询套餐权限失败", err)
+		}
+	}
+
+	var stripped int64
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewTenantRepo(tx.DB).Update(ctx, tenant.TenantID, map[string]interface{}{
+			"plan_id":    req.PlanID,
+			"updated_at": time.Now().UnixMilli(),
+		}); err != nil {
+			return err
+		}
+		if req.PlanID == "" {
+			return nil
+		}
+		var err error
+		stripped, err = repository.NewRolePermissionRepo(tx.DB).DeleteOutsidePermissionsManual(database.WithTenant(ctx, tenant.TenantID), []string{tenant.TenantID}, allowed)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenant.TenantID).Str("plan_id", req.PlanID).Msg("变更租户套餐失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "变更租户套餐失败", err)
+	}
+
+	if s.cache != nil {
+		s.cache.NotifyRefresh()
+	}
+
+	tenant.PlanID = req.PlanID
+	log.Info().
+		Str("tenant_id", tenant.TenantID).
+		Str("old_plan_id", oldPlanID).
+		Str("plan_id", req.PlanID).
+		Int64("stripped_grants", stripped).
+		Msg("租户套餐已变更")
+
+	return &dto.TenantPlanResponse{
+		Tenant:         ModelToTenantInfo(tenant),
+		StrippedGrants: stripped,
+	}, nil
+}
+
+// checkPlan 校验套餐存在且已启用
+func (s *Service) checkPlan(ctx context.Context, planID string) error {
+	plan, err := s.planRepo.GetByID(ctx, planID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return xerr.ErrPlanNotFound
+		}
+		log.Error().Err(err).Str("plan_id", planID).Msg("查询套餐失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询套餐失败", err)
+	}
+	if plan.Status != int16(constants.StatusEnabled) {
+		return xerr.ErrPlanDisabled
+	}
+	return nil
+}
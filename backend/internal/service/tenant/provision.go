@@ -0,0 +1,371 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/utils/rsapwd"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// defaultTenantPositions 新租户的默认岗位
+var defaultTenantPositions = []struct {
+	Code  string
+	Name  string
+	Level int32
+	Sort  int32
+}{
+	{"DEPT_MANAGER", "部门经理", 60, 1},
+	{"DEPT_LEADER", "部门主管", 50, 2},
+	{"EMPLOYEE", "员工", 10, 3},
+}
+
+// provisionAdmin 初始管理员信息
+type provisionAdmin struct {
+	Email string
+	Name  string
+	Phone string
+}
+
+// provisionState 租户初始化过程中各步骤共享的状态
+type provisionState struct {
+	tenant       *model.Tenant
+	admin        provisionAdmin
+	roleID       string
+	departmentID string
+	adminUserID  string
+	password     string
+}
+
+// provisionStep 租户初始化步骤
+// 每个步骤在独立事务中执行，且必须可重复执行（已完成的数据直接复用），失败后可从该步骤续跑
+type provisionStep struct {
+	name string
+	run  func(ctx context.Context, tx *database.Tx, state *provisionState) error
+}
+
+// ProvisionTenant 重试租户初始化
+// 用于初始化中途失败的租户，已完成的步骤会被跳过
+func (s *Service) ProvisionTenant(ctx context.Context, req *dto.TenantProvisionRequest) (resp *dto.TenantCreateResponse, err error) {
+	var tenant *model.Tenant
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if tenant != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, tenant.TenantID, tenant.Name),
+				audit.WithValue(nil, resp.Tenant),
+			)
+		}
+	}()
+
+	tenant, err = s.tenantRepo.GetByID(ctx, req.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", req.TenantID).Msg("查询租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+	if provisionStatus(tenant) == constants.TenantProvisionReady {
+		return nil, xerr.ErrTenantProvisioned
+	}
+
+	return s.provision(ctx, tenant, provisionAdmin{Email: req.AdminEmail, Name: req.AdminName, Phone: req.AdminPhone})
+}
+
+// provision 执行租户初始化流水线：管理员角色 -> 根部门 -> 默认岗位 -> 初始管理员
+// 初始管理员与"已完成"状态在同一事务中写入，保证一次性密码只会在初始化成功时生成并返回
+func (s *Service) provision(ctx context.Context, tenant *model.Tenant, admin provisionAdmin) (*dto.TenantCreateResponse, error) {
+	// 后续步骤均在新租户下执行
+	ctx = xcontext.SetTenantID(ctx, tenant.TenantID)
+
+	state := &provisionState{tenant: tenant, admin: admin}
+	steps := []provisionStep{
+		{"创建租户管理员角色", s.provisionAdminRole},
+		{"创建根部门", s.provisionRootDepartment},
+		{"创建默认岗位", s.provisionPositions},
+		{"创建初始管理员", s.provisionAdminUser},
+	}
+
+	for _, step := range steps {
+		err := database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+			return step.run(ctx, tx, state)
+		})
+		if err != nil {
+			log.Error().Err(err).Str("tenant_id", tenant.TenantID).Str("step", step.name).Msg("租户初始化失败")
+
+			// 记录失败原因，便于排查后重试
+			if updateErr := s.tenantRepo.Update(ctx, tenant.TenantID, map[string]interface{}{
+				"provision_error": fmt.Sprintf("%s: %v", step.name, err),
+			}); updateErr != nil {
+				log.Error().Err(updateErr).Str("tenant_id", tenant.TenantID).Msg("记录租户初始化失败原因失败")
+			}
+
+			if xe, ok := err.(*xerr.AppError); ok {
+				return nil, xe
+			}
+			return nil, xerr.Wrap(xerr.ErrTenantProvisioning.Code, "租户初始化失败（"+step.name+"），请重试初始化", err)
+		}
+	}
+
+	// 新角色权限需要刷新权限缓存
+	if s.cache != nil {
+		s.cache.NotifyRefresh()
+	}
+
+	tenant.ProvisionStatus = constants.TenantProvisionReady
+	tenant.ProvisionError = ""
+
+	log.Info().
+		Str("tenant_id", tenant.TenantID).
+		Str("tenant_code", tenant.TenantCode).
+		Str("admin_user_id", state.adminUserID).
+		Msg("租户初始化完成")
+
+	return &dto.TenantCreateResponse{
+		Tenant:        ModelToTenantInfo(tenant),
+		AdminUserID:   state.adminUserID,
+		AdminEmail:    admin.Email,
+		AdminPassword: state.password,
+		Message:       "租户创建成功，请妥善保存管理员初始密码，首次登录后需修改密码",
+	}, nil
+}
+
+// provisionAdminRole 从模板创建租户管理员角色
+// 优先使用已启用的全局 admin 角色模板（派生角色可随模板同步），否则复制 default 租户下 admin 角色的权限
+func (s *Service) provisionAdminRole(ctx context.Context, tx *database.Tx, state *provisionState) error {
+	roleRepo := repository.NewRoleRepo(tx.DB)
+
+	role, err := roleRepo.GetByCodeWithTenant(ctx, state.tenant.TenantID, constants.Admin)
+	if err == nil {
+		state.roleID = role.RoleID
+		return nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	templateID, permIDs, err := s.adminTemplatePermissions(ctx, tx)
+	if err != nil {
+		return err
+	}
+	permIDs, err = repository.NewPlanRepo(tx.DB).FilterByTenantCeilingManual(ctx, state.tenant.TenantID, permIDs)
+	if err != nil {
+		return err
+	}
+
+	roleID, err := idgen.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	role = &model.Role{
+		RoleID:      roleID,
+		RoleCode:    constants.Admin,
+		Name:        "租户管理员",
+		Description: "租户初始化时创建的管理员角色",
+		Status:      int16(constants.StatusEnabled),
+		TemplateID:  templateID,
+	}
+	if err := roleRepo.Create(ctx, role); err != nil {
+		return err
+	}
+	state.roleID = roleID
+
+	if len(permIDs) == 0 {
+		log.Warn().Str("tenant_id", state.tenant.TenantID).Msg("租户管理员角色模板没有权限，请手动分配")
+		return nil
+	}
+
+	items := make([]*model.RolePermission, 0, len(permIDs))
+	for _, permID := range permIDs {
+		items = append(items, &model.RolePermission{
+			RoleID:       roleID,
+			PermissionID: permID,
+			TenantID:     state.tenant.TenantID,
+		})
+	}
+	return repository.NewRolePermissionRepo(tx.DB).AddPermissions(ctx, items)
+}
+
+// adminTemplatePermissions 获取租户管理员角色的模板ID与权限ID列表
+// 使用全局角色模板时返回模板ID，回退到 default 租户 admin 角色时模板ID为空
+func (s *Service) adminTemplatePermissions(ctx context.Context, tx *database.Tx) (string, []string, error) {
+	templateRepo := repository.NewRoleTemplateRepo(tx.DB)
+	template, err := templateRepo.GetByCode(ctx, constants.Admin)
+	if err == nil && template.Status == int16(constants.StatusEnabled) {
+		permIDs, err := templateRepo.GetPermissionIDs(ctx, template.TemplateID)
+		if err != nil {
+			return "", nil, err
+		}
+		return template.TemplateID, permIDs, nil
+	}
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return "", nil, err
+	}
+
+	defaultTenant, err := repository.NewTenantRepo(tx.DB).GetByCodeManual(ctx, constants.DefaultTenantCode)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	adminRole, err := repository.NewRoleRepo(tx.DB).GetByCodeWithTenant(ctx, defaultTenant.TenantID, constants.Admin)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	permIDs, err := repository.NewRolePermissionRepo(tx.DB).GetPermissionIDsByRole(ctx, adminRole.RoleID, defaultTenant.TenantID)
+	return "", permIDs, err
+}
+
+// provisionRootDepartment 创建根部门（以租户名称命名）
+func (s *Service) provisionRootDepartment(ctx context.Context, tx *database.Tx, state *provisionState) error {
+	deptRepo := repository.NewDepartmentRepo(tx.DB)
+
+	roots, err := deptRepo.GetChildren(ctx, "")
+	if err != nil {
+		return err
+	}
+	if len(roots) > 0 {
+		state.departmentID = roots[0].DepartmentID
+		return nil
+	}
+
+	departmentID, err := idgen.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	if err := deptRepo.Create(ctx, &model.Department{
+		DepartmentID:   departmentID,
+		DepartmentName: state.tenant.Name,
+		Description:    "租户根部门",
+		Status:         int16(constants.StatusEnabled),
+	}); err != nil {
+		return err
+	}
+	state.departmentID = departmentID
+	return nil
+}
+
+// provisionPositions 创建默认岗位，已存在的岗位编码跳过
+func (s *Service) provisionPositions(ctx context.Context, tx *database.Tx, state *provisionState) error {
+	positionRepo := repository.NewPositionRepo(tx.DB)
+
+	for _, def := range defaultTenantPositions {
+		exists, err := positionRepo.CheckExists(ctx, state.tenant.TenantID, def.Code)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		positionID, err := idgen.GenerateUUID()
+		if err != nil {
+			return err
+		}
+		if err := positionRepo.Create(ctx, &model.Position{
+			PositionID:   positionID,
+			PositionCode: def.Code,
+			PositionName: def.Name,
+			Level:        def.Level,
+			Sort:         def.Sort,
+			Status:       int16(constants.StatusEnabled),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// provisionAdminUser 创建初始管理员并标记租户初始化完成
+func (s *Service) provisionAdminUser(ctx context.Context, tx *database.Tx, state *provisionState) error {
+	userRepo := repository.NewUserRepo(tx.DB)
+
+	// 与创建用户一致：租户内唯一，跨租户重复需相关租户均开启共享邮箱
+	if err := CheckEmailAvailable(ctx, userRepo, repository.NewTenantRepo(tx.DB), state.tenant.TenantID, state.admin.Email, ""); err != nil {
+		return err
+	}
+
+	userID, err := idgen.GenerateUUID()
+	if err != nil {
+		return err
+	}
+
+	// 与 CreateUser 一致：随机密码 -> SHA256（与前端登录流程一致）-> Argon2
+	plainPassword := passwordgen.GenerateRandomPassword(8)
+	salt, err := passwordgen.GenerateSalt()
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := passwordgen.Argon2Hash(rsapwd.HashPassword(plainPassword), salt)
+	if err != nil {
+		return err
+	}
+
+	userName := state.admin.Name
+	if userName == "" {
+		userName = state.admin.Email
+	}
+
+	user := &model.User{
+		UserID:             userID,
+		UserName:           userName,
+		Password:           hashedPassword,
+		Nickname:           userName,
+		Phone:              state.admin.Phone,
+		Email:              state.admin.Email,
+		DepartmentID:       state.departmentID,
+		Description:        "租户初始管理员",
+		Status:             int16(constants.StatusEnabled),
+		MustChangePassword: constants.True,
+	}
+	if err := userRepo.Create(ctx, user); err != nil {
+		return err
+	}
+	if err := repository.NewUserRoleRepo(tx.DB).AssignRoles(ctx, userID, []string{state.roleID}, state.tenant.TenantID); err != nil {
+		return err
+	}
+
+	if err := repository.NewTenantRepo(tx.DB).Update(ctx, state.tenant.TenantID, map[string]interface{}{
+		"provision_status": constants.TenantProvisionReady,
+		"provision_error":  "",
+	}); err != nil {
+		return err
+	}
+
+	state.adminUserID = userID
+	state.password = plainPassword
+	return nil
+}
+
+// provisionStatus 获取租户初始化状态
+// 空值视为已完成（初始化流水线之前创建的租户）
+func provisionStatus(tenant *model.Tenant) string {
+	if tenant.ProvisionStatus == "" {
+		return constants.TenantProvisionReady
+	}
+	return tenant.ProvisionStatus
+}
@@ -0,0 +1,187 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/convert"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// RestoreTenant 恢复已删除的租户
+// 仅在彻底清除开始前可恢复，恢复后取消清除计划
+func (s *Service) RestoreTenant(ctx context.Context, req *dto.TenantRestoreRequest) (resp *dto.TenantInfo, err error) {
+	var tenant *model.Tenant
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if tenant != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, tenant.TenantID, tenant.Name),
+				audit.WithValue(nil, resp),
+			)
+			log.Info().Str("tenant_id", tenant.TenantID).Msg("恢复租户成功")
+		}
+	}()
+
+	deleted, err := s.tenantRepo.GetDeletedByIDManual(ctx, req.TenantID)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			log.Error().Err(err).Str("tenant_id", req.TenantID).Msg("查询租户失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+		}
+		if _, err := s.tenantRepo.GetByIDManual(ctx, req.TenantID); err == nil {
+			return nil, xerr.ErrTenantNotDeleted
+		}
+		return nil, xerr.ErrTenantNotFound
+	}
+
+	// 删除期间编码或名称可能已被新租户使用
+	exists, err := s.tenantRepo.CheckExists(ctx, deleted.TenantCode)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_code", deleted.TenantCode).Msg("检查租户编码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查租户编码失败", err)
+	}
+	if exists {
+		return nil, xerr.New(xerr.ErrConflict.Code, "租户编码已被其他租户使用，无法恢复")
+	}
+	exists, err = s.tenantRepo.CheckNameExists(ctx, deleted.Name)
+	if err != nil {
+		log.Error().Err(err).Str("name", deleted.Name).Msg("检查租户名称失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查租户名称失败", err)
+	}
+	if exists {
+		return nil, xerr.New(xerr.ErrConflict.Code, "租户名称已被其他租户使用，无法恢复")
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		purgeRepo := repository.NewTenantPurgeRepo(tx.DB)
+		purge, err := purgeRepo.GetActiveByTenantManual(ctx, deleted.TenantID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		if purge != nil {
+			// 与清除任务并发时，以状态条件更新判定谁先生效
+			ok, err := purgeRepo.TransitionManual(ctx, purge.PurgeID, []string{constants.TenantPurgeScheduled}, map[string]interface{}{
+				"status":      constants.TenantPurgeCanceled,
+				"finished_at": time.Now().UnixMilli(),
+			})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return xerr.ErrTenantPurgeStarted
+			}
+		}
+		return repository.NewTenantRepo(tx.DB).RestoreManual(ctx, deleted.TenantID)
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return nil, xe
+		}
+		log.Error().Err(err).Str("tenant_id", deleted.TenantID).Msg("恢复租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "恢复租户失败", err)
+	}
+
+	tenant, err = s.tenantRepo.GetByIDManual(ctx, deleted.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", deleted.TenantID).Msg("获取恢复后租户信息失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "获取恢复后租户信息失败", err)
+	}
+	return ModelToTenantInfo(tenant), nil
+}
+
+// ListTenantPurges 分页获取租户彻底清除记录
+func (s *Service) ListTenantPurges(ctx context.Context, req *dto.TenantPurgeListRequest) (*dto.TenantPurgeListResponse, error) {
+	purges, total, err := s.purgeRepo.ListWithFilters(ctx, req.GetOffset(), req.GetLimit(), req.TenantCode, req.Status)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_code", req.TenantCode).Str("status", req.Status).Msg("查询租户清除记录失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户清除记录失败", err)
+	}
+
+	list := make([]*dto.TenantPurgeInfo, len(purges))
+	for i, purge := range purges {
+		list[i] = modelToPurgeInfo(purge)
+	}
+	return &dto.TenantPurgeListResponse{
+		List:     list,
+		Response: pagination.NewResponse(req.Request, total),
+	}, nil
+}
+
+// schedulePurge 为已软删除的租户创建清除计划，保留期结束后由定时任务彻底清除
+func (s *Service) schedulePurge(ctx context.Context, purgeRepo *repository.TenantPurgeRepo, tenant *model.Tenant) error {
+	purgeID, err := idgen.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	return purgeRepo.Create(ctx, &model.TenantPurge{
+		PurgeID:    purgeID,
+		TenantID:   tenant.TenantID,
+		TenantCode: tenant.TenantCode,
+		TenantName: tenant.Name,
+		Status:     constants.TenantPurgeScheduled,
+		PurgeAfter: time.Now().Add(s.cfg.GetPurgeRetention()).UnixMilli(),
+	})
+}
+
+// revokeTenantSessions 吊销租户下所有用户及加入该租户的成员在该租户的会话
+// 失败时仅记录日志：租户已删除，租户生命周期中间件会拒绝残留令牌的请求
+func (s *Service) revokeTenantSessions(ctx context.Context, tenant *model.Tenant) {
+	userIDs, err := s.userRepo.ListIDsByTenantManual(ctx, tenant.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenant.TenantID).Msg("查询租户用户失败，未能吊销会话")
+		return
+	}
+	memberIDs, err := s.memberRepo.ListUserIDsByTenantManual(ctx, tenant.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenant.TenantID).Msg("查询租户成员失败，未能吊销成员会话")
+	}
+	userIDs = convert.SliceToUnique(append(userIDs, memberIDs...))
+
+	for _, userID := range userIDs {
+		if err := s.jwt.RevokeAllUserTokens(ctx, tenant.TenantCode, userID); err != nil {
+			log.Error().Err(err).Str("tenant_id", tenant.TenantID).Str("user_id", userID).Msg("吊销用户会话失败")
+		}
+	}
+	log.Info().Str("tenant_id", tenant.TenantID).Int("users", len(userIDs)).Msg("已吊销租户会话")
+}
+
+// modelToPurgeInfo 将清除记录模型转换为 DTO
+func modelToPurgeInfo(purge *model.TenantPurge) *dto.TenantPurgeInfo {
+	report := make(map[string]int64)
+	if purge.Report != "" {
+		_ = json.Unmarshal([]byte(purge.Report), &report)
+	}
+	return &dto.TenantPurgeInfo{
+		PurgeID:      purge.PurgeID,
+		TenantID:     purge.TenantID,
+		TenantCode:   purge.TenantCode,
+		TenantName:   purge.TenantName,
+		Status:       purge.Status,
+		PurgeAfter:   purge.PurgeAfter,
+		CurrentTable: purge.CurrentTable,
+		DeletedRows:  purge.DeletedRows,
+		Report:       report,
+		ErrorMessage: purge.ErrorMessage,
+		StartedAt:    purge.StartedAt,
+		FinishedAt:   purge.FinishedAt,
+		CreatedAt:    purge.CreatedAt,
+	}
+}
@@ -0,0 +1,214 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"admin/internal/service/task"
+	"admin/pkg/config"
+	"admin/pkg/constants"
+	"admin/pkg/utils/idgen"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// PurgeTaskType 彻底清除租户数据的任务类型
+const PurgeTaskType = "tenant_purge"
+
+const (
+	purgeBatchSize   = 1000             // 彻底清除每批删除的行数
+	purgeStaleAfter  = 30 * time.Minute // 执行中的记录超过该时长无进度视为中断
+	purgeTaskTimeout = time.Hour        // 单个租户单次清除的最长执行时间
+)
+
+// purgeTaskPayload 彻底清除任务参数
+// StartedAt 为抢占记录时写入的开始时间，记录被中断重置并重新抢占后旧任务不再执行
+type purgeTaskPayload struct {
+	PurgeID   string `json:"purge_id"`
+	StartedAt int64  `json:"started_at"`
+}
+
+// PurgeManager 已删除租户的彻底清除
+// 由定时任务每日执行：保留期结束的租户逐个提交后台清除任务，任务按表分批物理删除全部数据并记录进度，
+// 失败的清除在下次执行时继续
+type PurgeManager struct {
+	purgeRepo *repository.TenantPurgeRepo
+	queue     *task.Queue
+	cfg       config.TenantConfig
+}
+
+// NewPurgeManager 创建租户彻底清除管理器，并向任务工作进程注册清除任务
+func NewPurgeManager(db *gorm.DB, worker *task.Worker, cfg config.TenantConfig) *PurgeManager {
+	m := &PurgeManager{
+		purgeRepo: repository.NewTenantPurgeRepo(db),
+		queue:     task.NewQueue(db),
+		cfg:       cfg,
+	}
+	task.Register(worker, PurgeTaskType, task.Handler[purgeTaskPayload]{
+		Run:       m.runPurge,
+		OnFailure: m.failPurge,
+		Timeout:   purgeTaskTimeout,
+	})
+	return m
+}
+
+// Run 为保留期结束的租户提交清除任务
+// 提交前按状态条件抢占记录，多实例并发执行或与恢复操作并发时同一租户只会被处理一次
+func (m *PurgeManager) Run(ctx context.Context) error {
+	now := time.Now()
+
+	if err := m.scheduleMissing(ctx); err != nil {
+		return err
+	}
+	if n, err := m.purgeRepo.ResetStaleManual(ctx, now.Add(-purgeStaleAfter).UnixMilli()); err != nil {
+		return err
+	} else if n > 0 {
+		log.Warn().Int64("count", n).Msg("租户清除任务中断，已置为失败待重试")
+	}
+
+	purges, err := m.purgeRepo.ListDueManual(ctx, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+	for _, purge := range purges {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		startedAt := time.Now().UnixMilli()
+		ok, err := m.purgeRepo.TransitionManual(ctx, purge.PurgeID,
+			[]string{constants.TenantPurgeScheduled, constants.TenantPurgeFailed},
+			map[string]interface{}{
+				"status":        constants.TenantPurgeRunning,
+				"started_at":    startedAt,
+				"error_message": "",
+			})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// 已被恢复或被其他实例抢占
+			continue
+		}
+
+		if _, err := m.queue.Enqueue(ctx, PurgeTaskType, &purgeTaskPayload{
+			PurgeID:   purge.PurgeID,
+			StartedAt: startedAt,
+		}); err != nil {
+			log.Error().Err(err).Str("tenant_id", purge.TenantID).Msg("提交租户清除任务失败")
+			m.markFailed(ctx, purge.PurgeID, err)
+			continue
+		}
+		log.Info().Str("tenant_id", purge.TenantID).Str("tenant_code", purge.TenantCode).Msg("已提交租户清除任务")
+	}
+	return nil
+}
+
+// runPurge 执行清除任务，记录已被恢复、完成或重新抢占时跳过
+func (m *PurgeManager) runPurge(ctx context.Context, exec *task.Execution, p *purgeTaskPayload) (any, error) {
+	purge, err := m.purgeRepo.GetByIDManual(ctx, p.PurgeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, task.Permanent(err)
+		}
+		return nil, err
+	}
+	if purge.Status != constants.TenantPurgeRunning || purge.StartedAt != p.StartedAt {
+		log.Info().Str("purge_id", purge.PurgeID).Str("status", purge.Status).Msg("租户清除记录状态已变化，跳过")
+		return nil, nil
+	}
+
+	if err := m.purge(ctx, exec, purge); err != nil {
+		log.Error().Err(err).Str("tenant_id", purge.TenantID).Str("table", purge.CurrentTable).Msg("彻底清除租户数据失败")
+		return nil, err
+	}
+	log.Info().Str("tenant_id", purge.TenantID).Str("tenant_code", purge.TenantCode).Int64("rows", purge.DeletedRows).Msg("彻底清除租户数据完成")
+	return map[string]int64{"deleted_rows": purge.DeletedRows}, nil
+}
+
+// failPurge 清除任务最终失败或被取消时将记录置为失败，下次定时执行时继续
+func (m *PurgeManager) failPurge(ctx context.Context, p *purgeTaskPayload, err error) {
+	m.markFailed(ctx, p.PurgeID, err)
+}
+
+// markFailed 将执行中的清除记录置为失败
+func (m *PurgeManager) markFailed(ctx context.Context, purgeID string, cause error) {
+	// 调用方上下文可能已超时，使用不随之取消的上下文记录失败状态
+	if err := m.purgeRepo.UpdateProgressManual(context.WithoutCancel(ctx), purgeID, map[string]interface{}{
+		"status":        constants.TenantPurgeFailed,
+		"error_message": cause.Error(),
+	}); err != nil {
+		log.Error().Err(err).Str("purge_id", purgeID).Msg("更新租户清除状态失败")
+	}
+}
+
+// purge 按表分批删除租户数据，每批更新进度；重试时在上次的报告上累加
+func (m *PurgeManager) purge(ctx context.Context, exec *task.Execution, purge *model.TenantPurge) error {
+	report := make(map[string]int64)
+	if purge.Report != "" {
+		_ = json.Unmarshal([]byte(purge.Report), &report)
+	}
+
+	tables := m.purgeRepo.PurgeTables()
+	for i, table := range tables {
+		purge.CurrentTable = table
+		exec.Progress(i*100/len(tables), fmt.Sprintf("正在清除 %s", table))
+		for {
+			n, err := m.purgeRepo.PurgeBatchManual(ctx, table, purge.TenantID, purgeBatchSize)
+			if err != nil {
+				return err
+			}
+			report[table] += n
+			purge.DeletedRows += n
+
+			data, _ := json.Marshal(report)
+			if err := m.purgeRepo.UpdateProgressManual(ctx, purge.PurgeID, map[string]interface{}{
+				"current_table": table,
+				"deleted_rows":  purge.DeletedRows,
+				"report":        string(data),
+			}); err != nil {
+				return err
+			}
+			if n < purgeBatchSize {
+				break
+			}
+		}
+	}
+
+	return m.purgeRepo.UpdateProgressManual(ctx, purge.PurgeID, map[string]interface{}{
+		"status":        constants.TenantPurgeCompleted,
+		"current_table": "",
+		"finished_at":   time.Now().UnixMilli(),
+	})
+}
+
+// scheduleMissing 为没有清除计划的已删除租户补建计划，保留期从租户删除时间起算
+func (m *PurgeManager) scheduleMissing(ctx context.Context) error {
+	tenants, err := m.purgeRepo.ListUnscheduledDeletedTenantsManual(ctx)
+	if err != nil {
+		return err
+	}
+	retention := m.cfg.GetPurgeRetention().Milliseconds()
+	for _, tenant := range tenants {
+		purgeID, err := idgen.GenerateUUID()
+		if err != nil {
+			return err
+		}
+		if err := m.purgeRepo.Create(ctx, &model.TenantPurge{
+			PurgeID:    purgeID,
+			TenantID:   tenant.TenantID,
+			TenantCode: tenant.TenantCode,
+			TenantName: tenant.Name,
+			Status:     constants.TenantPurgeScheduled,
+			PurgeAfter: int64(tenant.DeletedAt) + retention,
+		}); err != nil {
+			return err
+		}
+		log.Info().Str("tenant_id", tenant.TenantID).Msg("已为删除的租户补建清除计划")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package tenant
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// GetTenantUsage 获取租户资源用量与配额
+// 超级管理员可查询任意租户，其他用户只能查询当前租户
+func (s *Service) GetTenantUsage(ctx context.Context, req *dto.TenantUsageRequest) (*dto.TenantUsageResponse, error) {
+	tenantID, err := TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.quotaSvc.GetUsage(ctx, tenantID)
+}
+
+// SetTenantQuotas 设置租户单独配额
+// Limit 为空时删除单独设置，恢复使用套餐默认配额
+func (s *Service) SetTenantQuotas(ctx context.Context, req *dto.TenantQuotaRequest) (resp *dto.TenantUsageResponse, err error) {
+	var tenant *model.Tenant
+	oldQuotas := make(map[string]int64)
+	newQuotas := make(map[string]interface{})
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if tenant != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, tenant.TenantID, tenant.Name),
+				audit.WithValue(oldQuotas, newQuotas),
+			)
+		}
+	}()
+
+	tenant, err = s.tenantRepo.GetByID(ctx, req.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", req.TenantID).Msg("查询租户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+
+	existing, err := s.quotaRepo.ListByTenantManual(ctx, tenant.TenantID)
+	if err != nil {
+		log.Error().Err(err).Str("tenant_id", tenant.TenantID).Msg("查询租户配额失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询租户配额失败", err)
+	}
+	for _, quota := range existing {
+		oldQuotas[quota.Resource] = quota.QuotaLimit
+	}
+
+	now := time.Now().UnixMilli()
+	for _, item := range req.Quotas {
+		if item.Limit == nil {
+			if err := s.quotaRepo.Delete(ctx, tenant.TenantID, item.Resource); err != nil {
+				log.Error().Err(err).Str("tenant_id", tenant.TenantID).Str("resource", item.Resource).Msg("删除租户配额失败")
+				return nil, xerr.Wrap(xerr.ErrInternal.Code, "删除租户配额失败", err)
+			}
+			newQuotas[item.Resource] = nil
+			continue
+		}
+
+		if err := s.quotaRepo.Upsert(ctx, &model.TenantQuota{
+			TenantID:   tenant.TenantID,
+			Resource:   item.Resource,
+			QuotaLimit: *item.Limit,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}); err != nil {
+			log.Error().Err(err).Str("tenant_id", tenant.TenantID).Str("resource", item.Resource).Msg("设置租户配额失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "设置租户配额失败", err)
+		}
+		newQuotas[item.Resource] = *item.Limit
+	}
+
+	return s.quotaSvc.GetUsage(ctx, tenant.TenantID)
+}
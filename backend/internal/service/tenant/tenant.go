@@ -1,24 +1,48 @@
 package tenant
 
 import (
+	"admin/internal/rbac"
 	"admin/internal/repository"
+	"admin/internal/service/quota"
 	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/utils/jwt"
 
 	"gorm.io/gorm"
 )
 
 // Service 租户服务
 type Service struct {
+	db         *gorm.DB
 	tenantRepo *repository.TenantRepo
 	userRepo   *repository.UserRepo
+	memberRepo *repository.TenantMemberRepo
+	planRepo   *repository.PlanRepo
+	quotaRepo  *repository.TenantQuotaRepo
+	domainRepo *repository.TenantDomainRepo
+	purgeRepo  *repository.TenantPurgeRepo
+	quotaSvc   *quota.Service
+	jwt        *jwt.Manager
 	recorder   *audit.Recorder
+	cache      *rbac.PermissionCache
+	cfg        config.TenantConfig
 }
 
 // NewService 创建租户服务
-func NewService(db *gorm.DB, recorder *audit.Recorder) *Service {
+func NewService(db *gorm.DB, jwtMgr *jwt.Manager, recorder *audit.Recorder, cache *rbac.PermissionCache, cfg config.TenantConfig) *Service {
 	return &Service{
+		db:         db,
 		tenantRepo: repository.NewTenantRepo(db),
 		userRepo:   repository.NewUserRepo(db),
+		memberRepo: repository.NewTenantMemberRepo(db),
+		planRepo:   repository.NewPlanRepo(db),
+		quotaRepo:  repository.NewTenantQuotaRepo(db),
+		domainRepo: repository.NewTenantDomainRepo(db),
+		purgeRepo:  repository.NewTenantPurgeRepo(db),
+		quotaSvc:   quota.NewService(db),
+		jwt:        jwtMgr,
 		recorder:   recorder,
+		cache:      cache,
+		cfg:        cfg,
 	}
 }
@@ -68,9 +68,29 @@ func (s *Service) UpdateTenant(ctx context.Context, tenantID string, req *dto.Te
 	if req.ContactPhone != "" {
 		updates["contact_phone"] = req.ContactPhone
 	}
+	if req.ContactEmail != "" {
+		updates["contact_email"] = req.ContactEmail
+	}
 	if req.Status != constants.StatusZero {
 		updates["status"] = int16(req.Status)
 	}
+	if req.LoginRiskPolicy != "" {
+		updates["login_risk_policy"] = req.LoginRiskPolicy
+	}
+	if req.AllowSharedEmail != constants.StatusZero {
+		// 关闭共享邮箱前需确保租户内没有与其他租户重复的邮箱，否则这些用户无法唯一定位
+		if req.AllowSharedEmail != constants.True && oldTenant.AllowSharedEmail == constants.True {
+			shared, err := s.userRepo.CountSharedEmailsManual(ctx, tenantID)
+			if err != nil {
+				log.Error().Err(err).Str("tenant_id", tenantID).Msg("检查共享邮箱失败")
+				return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查共享邮箱失败", err)
+			}
+			if shared > 0 {
+				return nil, xerr.New(xerr.ErrConflict.Code, "租户内存在与其他租户重复的邮箱，无法关闭共享邮箱")
+			}
+		}
+		updates["allow_shared_email"] = int16(req.AllowSharedEmail)
+	}
 	updates["updated_at"] = time.Now().UnixMilli()
 
 	// 更新租户
@@ -0,0 +1,183 @@
+package tenantarchive
+
+import (
+	"admin/internal/dal/model"
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// 数据包格式
+// 数据包为 zip 文件：manifest.json 描述格式版本和各表行数，tenant.json 为租户本身，
+// refs.json 记录引用的全局数据（权限、角色模板、系统字典、套餐）的业务键，其余每张表一个 JSON Lines 文件
+const (
+	archiveFormat  = "admin-tenant-archive"
+	archiveVersion = 1
+)
+
+// 数据包内文件名
+const (
+	fileManifest        = "manifest.json"
+	fileTenant          = "tenant.json"
+	fileRefs            = "refs.json"
+	fileDepartments     = "departments.jsonl"
+	filePositions       = "positions.jsonl"
+	fileRoles           = "roles.jsonl"
+	fileUsers           = "users.jsonl"
+	fileUserRoles       = "user_roles.jsonl"
+	fileRolePermissions = "role_permissions.jsonl"
+	fileUserPositions   = "user_positions.jsonl"
+	fileDictTypes       = "dict_types.jsonl"
+	fileDictItems       = "dict_items.jsonl"
+	fileTenantQuotas    = "tenant_quotas.jsonl"
+	fileLoginLogs       = "login_logs.jsonl"
+	fileOperationLogs   = "operation_logs.jsonl"
+)
+
+// archiveManifest 数据包清单
+type archiveManifest struct {
+	Format             string           `json:"format"`
+	Version            int              `json:"version"`
+	ExportedAt         int64            `json:"exported_at"`
+	TenantID           string           `json:"tenant_id"`
+	TenantCode         string           `json:"tenant_code"`
+	IncludeLogs        bool             `json:"include_logs"`
+	IncludeCredentials bool             `json:"include_credentials"`
+	Counts             map[string]int64 `json:"counts"`
+}
+
+// archiveRefs 数据包引用的全局数据
+// 全局数据的ID在不同环境中不同，导入时按业务键重新匹配
+type archiveRefs struct {
+	Permissions   []*archivePermission `json:"permissions"`    // 角色授权引用的权限
+	RoleTemplates map[string]string    `json:"role_templates"` // 角色模板ID -> 模板编码
+	DictTypes     map[string]string    `json:"dict_types"`     // 系统字典类型ID -> 类型编码（租户覆盖系统字典项时引用）
+	PlanCode      string               `json:"plan_code"`      // 租户套餐编码
+}
+
+// archivePermission 权限引用，Type+Resource+Action 唯一确定一个权限
+type archivePermission struct {
+	PermissionID string `json:"permission_id"`
+	Type         string `json:"type"`
+	Resource     string `json:"resource"`
+	Action       string `json:"action"`
+}
+
+// permissionKey 权限业务键
+func permissionKey(perm *model.Permission) string {
+	return fmt.Sprintf("%s|%s|%s", perm.Type, perm.Resource, perm.Action)
+}
+
+// key 权限引用的业务键
+func (p *archivePermission) key() string {
+	return fmt.Sprintf("%s|%s|%s", p.Type, p.Resource, p.Action)
+}
+
+// writeJSON 向数据包写入 JSON 文件
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+// writeJSONL 向数据包写入 JSON Lines 文件
+func writeJSONL[T any](zw *zip.Writer, name string, rows []*T) (int64, error) {
+	w, err := zw.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	enc := json.NewEncoder(w)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(rows)), nil
+}
+
+// archiveReader 数据包读取器
+type archiveReader struct {
+	files map[string]*zip.File
+}
+
+// newArchiveReader 打开数据包
+func newArchiveReader(r io.ReaderAt, size int64) (*archiveReader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	return &archiveReader{files: files}, nil
+}
+
+// has 判断数据包是否包含指定文件
+func (a *archiveReader) has(name string) bool {
+	_, ok := a.files[name]
+	return ok
+}
+
+// readJSON 读取 JSON 文件，文件必须存在
+func (a *archiveReader) readJSON(name string, v interface{}) error {
+	f, ok := a.files[name]
+	if !ok {
+		return fmt.Errorf("missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return json.NewDecoder(rc).Decode(v)
+}
+
+// eachJSONL 分批读取 JSON Lines 文件，文件不存在时视为空表
+func eachJSONL[T any](a *archiveReader, name string, batchSize int, fn func([]*T) error) error {
+	f, ok := a.files[name]
+	if !ok {
+		return nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(rc))
+	batch := make([]*T, 0, batchSize)
+	for {
+		row := new(T)
+		if err := dec.Decode(row); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		batch = append(batch, row)
+		if len(batch) == batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]*T, 0, batchSize)
+		}
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
+// readJSONL 读取整个 JSON Lines 文件
+func readJSONL[T any](a *archiveReader, name string) ([]*T, error) {
+	var rows []*T
+	err := eachJSONL(a, name, importBatchSize, func(batch []*T) error {
+		rows = append(rows, batch...)
+		return nil
+	})
+	return rows, err
+}
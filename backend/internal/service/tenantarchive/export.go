@@ -0,0 +1,271 @@
+package tenantarchive
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	tenantsvc "admin/internal/service/tenant"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ExportTenant 导出租户数据包
+// 校验通过后调用 open 获取输出流并以流式写入 zip，open 的参数为建议的文件名；
+// 默认不导出密码哈希，导入后用户需由管理员重置密码；服务账号凭证和自定义域名不导出
+func (s *Service) ExportTenant(ctx context.Context, req *dto.TenantExportRequest, open func(filename string) io.Writer) (err error) {
+	var tenant *model.Tenant
+	var manifest *archiveManifest
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithExport(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if tenant != nil {
+			s.recorder.Log(ctx,
+				audit.WithExport(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, tenant.TenantID, tenant.Name),
+				audit.WithValue(nil, manifest),
+			)
+		}
+	}()
+
+	tenantID, err := tenantsvc.TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		return err
+	}
+	if req.IncludeCredentials && !xcontext.HasRole(ctx, constants.SuperAdmin) {
+		return xerr.ErrForbidden
+	}
+	tenant, err = s.tenantRepo.GetByIDManual(ctx, tenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return xerr.ErrTenantNotFound
+		}
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("查询租户失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询租户失败", err)
+	}
+
+	manifest = &archiveManifest{
+		Format:             archiveFormat,
+		Version:            archiveVersion,
+		ExportedAt:         time.Now().UnixMilli(),
+		TenantID:           tenant.TenantID,
+		TenantCode:         tenant.TenantCode,
+		IncludeLogs:        req.IncludeLogs,
+		IncludeCredentials: req.IncludeCredentials,
+		Counts:             make(map[string]int64),
+	}
+
+	zw := zip.NewWriter(open(archiveFileName(tenant.TenantCode)))
+	if err := s.writeArchive(ctx, zw, tenant, manifest); err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("导出租户数据失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "导出租户数据失败", err)
+	}
+	if err := zw.Close(); err != nil {
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("写入租户数据包失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "写入租户数据包失败", err)
+	}
+
+	log.Info().Str("tenant_id", tenantID).Interface("counts", manifest.Counts).Msg("导出租户数据成功")
+	return nil
+}
+
+// archiveFileName 租户数据包的下载文件名
+func archiveFileName(tenantCode string) string {
+	return fmt.Sprintf("tenant-%s-%s.zip", tenantCode, time.Now().Format("20060102150405"))
+}
+
+// writeArchive 按表写入租户数据，同时收集引用的全局数据
+func (s *Service) writeArchive(ctx context.Context, zw *zip.Writer, tenant *model.Tenant, manifest *archiveManifest) error {
+	tenantID := tenant.TenantID
+	counts := manifest.Counts
+	var err error
+
+	if err := writeJSON(zw, fileTenant, tenant); err != nil {
+		return err
+	}
+
+	if counts[fileDepartments], err = exportTable[model.Department](ctx, zw, s.archiveRepo, fileDepartments, tenantID, nil); err != nil {
+		return err
+	}
+	if counts[filePositions], err = exportTable[model.Position](ctx, zw, s.archiveRepo, filePositions, tenantID, nil); err != nil {
+		return err
+	}
+
+	templateIDs := make(map[string]bool)
+	if counts[fileRoles], err = exportTable(ctx, zw, s.archiveRepo, fileRoles, tenantID, func(role *model.Role) {
+		if role.TemplateID != "" {
+			templateIDs[role.TemplateID] = true
+		}
+	}); err != nil {
+		return err
+	}
+
+	if counts[fileUsers], err = exportTable(ctx, zw, s.archiveRepo, fileUsers, tenantID, func(user *model.User) {
+		if !manifest.IncludeCredentials {
+			user.Password = ""
+		}
+	}); err != nil {
+		return err
+	}
+	if counts[fileUserRoles], err = exportTable[model.UserRole](ctx, zw, s.archiveRepo, fileUserRoles, tenantID, nil); err != nil {
+		return err
+	}
+
+	permIDs := make(map[string]bool)
+	if counts[fileRolePermissions], err = exportTable(ctx, zw, s.archiveRepo, fileRolePermissions, tenantID, func(rp *model.RolePermission) {
+		permIDs[rp.PermissionID] = true
+	}); err != nil {
+		return err
+	}
+
+	userPositions, err := s.archiveRepo.ListUserPositionsManual(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if counts[fileUserPositions], err = writeJSONL(zw, fileUserPositions, userPositions); err != nil {
+		return err
+	}
+
+	ownTypeIDs := make(map[string]bool)
+	if counts[fileDictTypes], err = exportTable(ctx, zw, s.archiveRepo, fileDictTypes, tenantID, func(dt *model.DictType) {
+		ownTypeIDs[dt.TypeID] = true
+	}); err != nil {
+		return err
+	}
+	// 租户覆盖系统字典时，字典项引用的是默认租户的字典类型
+	systemTypeIDs := make(map[string]bool)
+	if counts[fileDictItems], err = exportTable(ctx, zw, s.archiveRepo, fileDictItems, tenantID, func(item *model.DictItem) {
+		if !ownTypeIDs[item.TypeID] {
+			systemTypeIDs[item.TypeID] = true
+		}
+	}); err != nil {
+		return err
+	}
+
+	quotas, err := s.quotaRepo.ListByTenantManual(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if counts[fileTenantQuotas], err = writeJSONL(zw, fileTenantQuotas, quotas); err != nil {
+		return err
+	}
+
+	if manifest.IncludeLogs {
+		if counts[fileLoginLogs], err = exportTable[model.LoginLog](ctx, zw, s.archiveRepo, fileLoginLogs, tenantID, nil); err != nil {
+			return err
+		}
+		if counts[fileOperationLogs], err = exportTable[model.OperationLog](ctx, zw, s.archiveRepo, fileOperationLogs, tenantID, nil); err != nil {
+			return err
+		}
+	}
+
+	refs, err := s.collectRefs(ctx, tenant, templateIDs, permIDs, systemTypeIDs)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(zw, fileRefs, refs); err != nil {
+		return err
+	}
+	return writeJSON(zw, fileManifest, manifest)
+}
+
+// collectRefs 收集租户数据引用的全局数据业务键
+func (s *Service) collectRefs(ctx context.Context, tenant *model.Tenant, templateIDs, permIDs, systemTypeIDs map[string]bool) (*archiveRefs, error) {
+	refs := &archiveRefs{
+		RoleTemplates: make(map[string]string, len(templateIDs)),
+		DictTypes:     make(map[string]string, len(systemTypeIDs)),
+	}
+
+	perms, err := s.permissionRepo.GetByIDs(ctx, mapKeys(permIDs))
+	if err != nil {
+		return nil, err
+	}
+	for _, perm := range perms {
+		refs.Permissions = append(refs.Permissions, &archivePermission{
+			PermissionID: perm.PermissionID,
+			Type:         perm.Type,
+			Resource:     perm.Resource,
+			Action:       perm.Action,
+		})
+	}
+
+	for templateID := range templateIDs {
+		template, err := s.templateRepo.GetByID(ctx, templateID)
+		if err == gorm.ErrRecordNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		refs.RoleTemplates[templateID] = template.TemplateCode
+	}
+
+	if len(systemTypeIDs) > 0 {
+		types, err := s.dictTypeRepo.GetByIDsManual(ctx, mapKeys(systemTypeIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, dt := range types {
+			refs.DictTypes[dt.TypeID] = dt.TypeCode
+		}
+	}
+
+	if tenant.PlanID != "" {
+		plan, err := s.planRepo.GetByID(ctx, tenant.PlanID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		if plan != nil {
+			refs.PlanCode = plan.PlanCode
+		}
+	}
+	return refs, nil
+}
+
+// exportTable 分批读取租户的一张表并写入 JSON Lines 文件，each 可在写入前修改或收集每一行
+func exportTable[T any](ctx context.Context, zw *zip.Writer, repo *repository.TenantArchiveRepo, name, tenantID string, each func(*T)) (int64, error) {
+	w, err := zw.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	enc := json.NewEncoder(w)
+
+	var count int64
+	var batch []*T
+	err = repo.EachByTenantManual(ctx, &batch, tenantID, func() error {
+		for _, row := range batch {
+			if each != nil {
+				each(row)
+			}
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// mapKeys 返回集合中的全部键
+func mapKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	return keys
+}
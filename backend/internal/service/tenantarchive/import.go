@@ -0,0 +1,594 @@
+package tenantarchive
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/cache"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// importBatchSize 导入时每批读取和写入的行数
+const importBatchSize = 500
+
+// errDryRun 预检完成后用于回滚事务
+var errDryRun = errors.New("tenant import dry run")
+
+// importState 一次导入过程中的上下文
+type importState struct {
+	archive  *archiveReader
+	manifest archiveManifest
+	source   model.Tenant
+	refs     archiveRefs
+	report   *dto.TenantImportReport
+
+	tenantID  string
+	ids       map[string]string // 数据包内ID -> 新ID（部门、岗位、角色、用户、字典类型）
+	perms     map[string]string // 数据包权限ID -> 目标环境权限ID
+	templates map[string]string // 数据包角色模板ID -> 目标环境模板ID
+	dictTypes map[string]string // 数据包系统字典类型ID -> 目标环境系统字典类型ID
+	planID    string
+}
+
+// ImportTenant 从数据包导入为新租户（仅超级管理员）
+// 所有ID重新生成，全局数据按业务键匹配；存在冲突时拒绝导入；整个导入在一个事务中完成，
+// 预检模式完整执行后回滚，只返回报告
+func (s *Service) ImportTenant(ctx context.Context, req *dto.TenantImportRequest, r io.ReaderAt, size int64) (resp *dto.TenantImportReport, err error) {
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithImport(constants.ModuleTenant),
+				audit.WithError(err),
+			)
+		} else if resp != nil && resp.Applied {
+			s.recorder.Log(ctx,
+				audit.WithImport(constants.ModuleTenant),
+				audit.WithResource(constants.ResourceTypeTenant, resp.TenantID, resp.Name),
+				audit.WithValue(nil, resp),
+			)
+		}
+	}()
+
+	if !xcontext.HasRole(ctx, constants.SuperAdmin) {
+		return nil, xerr.ErrForbidden
+	}
+
+	st, err := openArchive(r, size)
+	if err != nil {
+		log.Warn().Err(err).Msg("租户数据包格式无效")
+		return nil, xerr.ErrTenantArchiveInvalid
+	}
+
+	resp = &dto.TenantImportReport{
+		DryRun:           req.DryRun,
+		TenantCode:       st.source.TenantCode,
+		Name:             st.source.Name,
+		SourceTenantCode: st.source.TenantCode,
+		ArchiveVersion:   st.manifest.Version,
+		Counts:           make(map[string]int64),
+		Conflicts:        []string{},
+		Warnings:         []string{},
+	}
+	if req.TenantCode != "" {
+		resp.TenantCode = req.TenantCode
+	}
+	if req.Name != "" {
+		resp.Name = req.Name
+	}
+	st.report = resp
+
+	if err := s.resolveRefs(ctx, st); err != nil {
+		log.Error().Err(err).Msg("匹配租户数据引用失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "匹配租户数据引用失败", err)
+	}
+
+	users, err := readJSONL[model.User](st.archive, fileUsers)
+	if err != nil {
+		log.Warn().Err(err).Msg("租户数据包格式无效")
+		return nil, xerr.ErrTenantArchiveInvalid
+	}
+	if err := s.checkConflicts(ctx, st, users); err != nil {
+		log.Error().Err(err).Msg("检查租户数据冲突失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查租户数据冲突失败", err)
+	}
+	if len(resp.Conflicts) > 0 {
+		if req.DryRun {
+			return resp, nil
+		}
+		return nil, xerr.New(xerr.ErrTenantImportConflict.Code,
+			fmt.Sprintf("租户数据导入存在 %d 处冲突，请先预检查看详情", len(resp.Conflicts)))
+	}
+	if !st.manifest.IncludeCredentials {
+		resp.Warnings = append(resp.Warnings, "数据包不含用户密码，导入后需由管理员重置用户密码")
+	}
+
+	if st.tenantID, err = idgen.GenerateUUID(); err != nil {
+		log.Error().Err(err).Msg("生成租户ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成租户ID失败", err)
+	}
+	resp.TenantID = st.tenantID
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := s.importData(ctx, tx, st, users); err != nil {
+			return err
+		}
+		if req.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		log.Error().Err(err).Str("tenant_code", resp.TenantCode).Msg("导入租户数据失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "导入租户数据失败", err)
+	}
+
+	if !req.DryRun {
+		resp.Applied = true
+		s.cache.NotifyRefresh()
+		log.Info().Str("tenant_id", st.tenantID).Str("tenant_code", resp.TenantCode).Interface("counts", resp.Counts).Msg("导入租户数据成功")
+	}
+	return resp, nil
+}
+
+// openArchive 打开数据包并读取清单、租户和引用
+func openArchive(r io.ReaderAt, size int64) (*importState, error) {
+	archive, err := newArchiveReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	st := &importState{
+		archive:   archive,
+		ids:       make(map[string]string),
+		perms:     make(map[string]string),
+		templates: make(map[string]string),
+		dictTypes: make(map[string]string),
+	}
+	if err := archive.readJSON(fileManifest, &st.manifest); err != nil {
+		return nil, err
+	}
+	if st.manifest.Format != archiveFormat {
+		return nil, fmt.Errorf("unknown archive format %q", st.manifest.Format)
+	}
+	if st.manifest.Version < 1 || st.manifest.Version > archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", st.manifest.Version)
+	}
+	if err := archive.readJSON(fileTenant, &st.source); err != nil {
+		return nil, err
+	}
+	if err := archive.readJSON(fileRefs, &st.refs); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// resolveRefs 按业务键将数据包引用的全局数据匹配到目标环境，缺失的记录为警告
+func (s *Service) resolveRefs(ctx context.Context, st *importState) error {
+	report := st.report
+
+	if len(st.refs.Permissions) > 0 {
+		perms, err := s.permissionRepo.List(ctx)
+		if err != nil {
+			return err
+		}
+		byKey := make(map[string]string, len(perms))
+		for _, perm := range perms {
+			byKey[permissionKey(perm)] = perm.PermissionID
+		}
+		for _, ref := range st.refs.Permissions {
+			if id, ok := byKey[ref.key()]; ok {
+				st.perms[ref.PermissionID] = id
+				continue
+			}
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("权限 %s %s 在目标环境不存在，相关授权已跳过", ref.Resource, ref.Action))
+		}
+	}
+
+	for oldID, code := range st.refs.RoleTemplates {
+		template, err := s.templateRepo.GetByCode(ctx, code)
+		if err == gorm.ErrRecordNotFound {
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("角色模板 %s 在目标环境不存在，相关角色已解除模板关联", code))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		st.templates[oldID] = template.TemplateID
+	}
+
+	if len(st.refs.DictTypes) > 0 {
+		defaultTenantID := cache.Get().Tenant.GetDefaultTenantID()
+		for oldID, code := range st.refs.DictTypes {
+			dt, err := s.dictTypeRepo.GetByCodeAndTenant(ctx, code, defaultTenantID)
+			if err == gorm.ErrRecordNotFound {
+				report.Warnings = append(report.Warnings,
+					fmt.Sprintf("系统字典 %s 在目标环境不存在，相关字典项已跳过", code))
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			st.dictTypes[oldID] = dt.TypeID
+		}
+	}
+
+	if st.refs.PlanCode != "" {
+		plan, err := s.planRepo.GetByCode(ctx, st.refs.PlanCode)
+		if err == gorm.ErrRecordNotFound {
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("套餐 %s 在目标环境不存在，租户导入后未绑定套餐", st.refs.PlanCode))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		st.planID = plan.PlanID
+	}
+	return nil
+}
+
+// checkConflicts 检查租户编码、名称以及用户邮箱、手机号是否与目标环境冲突
+func (s *Service) checkConflicts(ctx context.Context, st *importState, users []*model.User) error {
+	report := st.report
+
+	exists, err := s.tenantRepo.CheckExists(ctx, report.TenantCode)
+	if err != nil {
+		return err
+	}
+	if exists {
+		report.Conflicts = append(report.Conflicts, fmt.Sprintf("租户编码 %s 已存在", report.TenantCode))
+	}
+	exists, err = s.tenantRepo.CheckNameExists(ctx, report.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		report.Conflicts = append(report.Conflicts, fmt.Sprintf("租户名称 %s 已存在", report.Name))
+	}
+
+	var emails, phones []string
+	for _, user := range users {
+		if user.Email != "" {
+			emails = append(emails, user.Email)
+		}
+		if user.Phone != "" {
+			phones = append(phones, user.Phone)
+		}
+	}
+
+	// 跨租户重复邮箱仅在双方租户都开启共享邮箱时允许
+	var emailUsers []*model.User
+	for _, chunk := range chunkStrings(emails, importBatchSize) {
+		list, err := s.userRepo.ListByEmailsManual(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		emailUsers = append(emailUsers, list...)
+	}
+	if len(emailUsers) > 0 {
+		shared := make(map[string]bool)
+		if st.source.AllowSharedEmail == constants.True {
+			tenantIDs := make(map[string]bool)
+			for _, user := range emailUsers {
+				tenantIDs[user.TenantID] = true
+			}
+			tenants, err := s.tenantRepo.GetByIDsManual(ctx, mapKeys(tenantIDs))
+			if err != nil {
+				return err
+			}
+			for _, tenant := range tenants {
+				shared[tenant.TenantID] = tenant.AllowSharedEmail == constants.True
+			}
+		}
+		reported := make(map[string]bool)
+		for _, user := range emailUsers {
+			if !shared[user.TenantID] && !reported[user.Email] {
+				reported[user.Email] = true
+				report.Conflicts = append(report.Conflicts, fmt.Sprintf("邮箱 %s 已被其他租户用户使用", user.Email))
+			}
+		}
+	}
+
+	for _, chunk := range chunkStrings(phones, importBatchSize) {
+		list, err := s.userRepo.ListByPhonesManual(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for _, user := range list {
+			report.Conflicts = append(report.Conflicts, fmt.Sprintf("手机号 %s 已被使用", user.Phone))
+		}
+	}
+	return nil
+}
+
+// importData 在事务中按依赖顺序写入租户数据
+func (s *Service) importData(ctx context.Context, tx *database.Tx, st *importState, users []*model.User) error {
+	archiveRepo := repository.NewTenantArchiveRepo(tx.DB)
+	report := st.report
+	now := time.Now().UnixMilli()
+
+	tenant := st.source
+	tenant.TenantID = st.tenantID
+	tenant.TenantCode = report.TenantCode
+	tenant.Name = report.Name
+	tenant.PlanID = st.planID
+	tenant.ProvisionStatus = constants.TenantProvisionReady
+	tenant.ProvisionError = ""
+	tenant.StorageUsed = 0
+	tenant.ExpiryRemindedAt = 0
+	tenant.DeletedAt = 0
+	tenant.CreatedAt = now
+	tenant.UpdatedAt = now
+	if err := repository.NewTenantRepo(tx.DB).Create(ctx, &tenant); err != nil {
+		return err
+	}
+
+	// 先为可被引用的实体统一分配新ID，再写入，保证父子引用可以正确转换
+	departments, err := readJSONL[model.Department](st.archive, fileDepartments)
+	if err != nil {
+		return err
+	}
+	positions, err := readJSONL[model.Position](st.archive, filePositions)
+	if err != nil {
+		return err
+	}
+	roles, err := readJSONL[model.Role](st.archive, fileRoles)
+	if err != nil {
+		return err
+	}
+	dictTypes, err := readJSONL[model.DictType](st.archive, fileDictTypes)
+	if err != nil {
+		return err
+	}
+	for _, d := range departments {
+		if err := st.assign(d.DepartmentID); err != nil {
+			return err
+		}
+	}
+	for _, p := range positions {
+		if err := st.assign(p.PositionID); err != nil {
+			return err
+		}
+	}
+	for _, r := range roles {
+		if err := st.assign(r.RoleID); err != nil {
+			return err
+		}
+	}
+	for _, u := range users {
+		if err := st.assign(u.UserID); err != nil {
+			return err
+		}
+	}
+	for _, dt := range dictTypes {
+		if err := st.assign(dt.TypeID); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range departments {
+		d.DepartmentID = st.ids[d.DepartmentID]
+		d.TenantID = st.tenantID
+		d.ParentID = st.ids[d.ParentID]
+	}
+	if err := insertAll(ctx, st, archiveRepo, fileDepartments, departments); err != nil {
+		return err
+	}
+
+	for _, p := range positions {
+		p.PositionID = st.ids[p.PositionID]
+		p.TenantID = st.tenantID
+	}
+	if err := insertAll(ctx, st, archiveRepo, filePositions, positions); err != nil {
+		return err
+	}
+
+	for _, r := range roles {
+		r.RoleID = st.ids[r.RoleID]
+		r.TenantID = st.tenantID
+		r.ParentRoleID = st.ids[r.ParentRoleID]
+		r.TemplateID = st.templates[r.TemplateID]
+	}
+	if err := insertAll(ctx, st, archiveRepo, fileRoles, roles); err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		u.UserID = st.ids[u.UserID]
+		u.TenantID = st.tenantID
+		u.DepartmentID = st.ids[u.DepartmentID]
+		u.PositionID = st.ids[u.PositionID]
+		if u.Password == "" {
+			u.MustChangePassword = constants.True
+		}
+	}
+	if err := insertAll(ctx, st, archiveRepo, fileUsers, users); err != nil {
+		return err
+	}
+
+	if err := importStream(ctx, st, archiveRepo, fileUserRoles, func(ur *model.UserRole) (bool, error) {
+		ur.ID = 0
+		ur.TenantID = st.tenantID
+		ur.UserID = st.ids[ur.UserID]
+		ur.RoleID = st.ids[ur.RoleID]
+		return ur.UserID != "" && ur.RoleID != "", nil
+	}); err != nil {
+		return err
+	}
+
+	if err := importStream(ctx, st, archiveRepo, fileRolePermissions, func(rp *model.RolePermission) (bool, error) {
+		rp.ID = 0
+		rp.TenantID = st.tenantID
+		rp.RoleID = st.ids[rp.RoleID]
+		rp.PermissionID = st.perms[rp.PermissionID]
+		return rp.RoleID != "" && rp.PermissionID != "", nil
+	}); err != nil {
+		return err
+	}
+
+	if err := importStream(ctx, st, archiveRepo, fileUserPositions, func(up *model.UserPosition) (bool, error) {
+		up.UserID = st.ids[up.UserID]
+		up.PositionID = st.ids[up.PositionID]
+		return up.UserID != "" && up.PositionID != "", nil
+	}); err != nil {
+		return err
+	}
+
+	for _, dt := range dictTypes {
+		dt.TypeID = st.ids[dt.TypeID]
+		dt.TenantID = st.tenantID
+	}
+	if err := insertAll(ctx, st, archiveRepo, fileDictTypes, dictTypes); err != nil {
+		return err
+	}
+
+	if err := importStream(ctx, st, archiveRepo, fileDictItems, func(item *model.DictItem) (bool, error) {
+		typeID, ok := st.ids[item.TypeID]
+		if !ok {
+			typeID = st.dictTypes[item.TypeID]
+		}
+		item.TypeID = typeID
+		item.TenantID = st.tenantID
+		var err error
+		item.ItemID, err = idgen.GenerateUUID()
+		return typeID != "", err
+	}); err != nil {
+		return err
+	}
+
+	if err := importStream(ctx, st, archiveRepo, fileTenantQuotas, func(q *model.TenantQuota) (bool, error) {
+		q.TenantID = st.tenantID
+		return true, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := importStream(ctx, st, archiveRepo, fileLoginLogs, func(l *model.LoginLog) (bool, error) {
+		var err error
+		l.LogID, err = idgen.GenerateUUID()
+		l.TenantID = st.tenantID
+		l.UserID = st.ids[l.UserID]
+		return true, err
+	}); err != nil {
+		return err
+	}
+	if err := importStream(ctx, st, archiveRepo, fileOperationLogs, func(l *model.OperationLog) (bool, error) {
+		var err error
+		l.LogID, err = idgen.GenerateUUID()
+		l.TenantID = st.tenantID
+		l.UserID = st.ids[l.UserID]
+		return true, err
+	}); err != nil {
+		return err
+	}
+	// 回收超出目标套餐范围的授权
+	if st.planID != "" {
+		allowed, limited, err := repository.NewPlanRepo(tx.DB).GetTenantCeilingManual(ctx, st.tenantID)
+		if err != nil {
+			return err
+		}
+		if limited {
+			report.StrippedGrants, err = repository.NewRolePermissionRepo(tx.DB).DeleteOutsidePermissionsManual(ctx, []string{st.tenantID}, allowed)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// assign 为数据包内的ID分配新ID
+func (st *importState) assign(oldID string) error {
+	if oldID == "" {
+		return nil
+	}
+	if _, ok := st.ids[oldID]; ok {
+		return nil
+	}
+	newID, err := idgen.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	st.ids[oldID] = newID
+	return nil
+}
+
+// insertAll 写入已转换的整表数据并记录行数
+func insertAll[T any](ctx context.Context, st *importState, repo *repository.TenantArchiveRepo, name string, rows []*T) error {
+	if len(rows) > 0 {
+		if err := repo.CreateInBatches(ctx, rows); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	st.report.Counts[tableName(name)] = int64(len(rows))
+	return nil
+}
+
+// importStream 分批读取、转换并写入一张表，convert 返回 false 的行因引用缺失被跳过
+func importStream[T any](ctx context.Context, st *importState, repo *repository.TenantArchiveRepo, name string, convert func(*T) (bool, error)) error {
+	var count, skipped int64
+	err := eachJSONL(st.archive, name, importBatchSize, func(batch []*T) error {
+		rows := make([]*T, 0, len(batch))
+		for _, row := range batch {
+			ok, err := convert(row)
+			if err != nil {
+				return err
+			}
+			if ok {
+				rows = append(rows, row)
+			} else {
+				skipped++
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := repo.CreateInBatches(ctx, rows); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		count += int64(len(rows))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	st.report.Counts[tableName(name)] = count
+	if skipped > 0 {
+		st.report.Warnings = append(st.report.Warnings,
+			fmt.Sprintf("%s 有 %d 行引用的数据不存在，已跳过", tableName(name), skipped))
+	}
+	return nil
+}
+
+// tableName 由数据包文件名得到表名
+func tableName(file string) string {
+	return strings.TrimSuffix(file, ".jsonl")
+}
+
+// chunkStrings 将字符串列表按大小分组
+func chunkStrings(list []string, size int) [][]string {
+	var chunks [][]string
+	for len(list) > size {
+		chunks = append(chunks, list[:size])
+		list = list[size:]
+	}
+	if len(list) > 0 {
+		chunks = append(chunks, list)
+	}
+	return chunks
+}
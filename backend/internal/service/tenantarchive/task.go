@@ -0,0 +1,141 @@
+package tenantarchive
+
+import (
+	"admin/internal/dto"
+	"admin/internal/service/task"
+	tenantsvc "admin/internal/service/tenant"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// 租户数据导入导出的任务类型
+const (
+	ExportTaskType = "tenant_export"
+	ImportTaskType = "tenant_import"
+)
+
+const (
+	archiveTaskTimeout    = time.Hour // 单次导出或导入的最长执行时间
+	exportTaskMaxAttempts = 2         // 导出失败后重试一次
+	archiveFileExt        = ".zip"    // 数据包文件扩展名
+)
+
+// exportTaskPayload 导出租户数据任务参数，目标租户在入队前已校验
+type exportTaskPayload struct {
+	TenantID           string `json:"tenant_id"`
+	IncludeLogs        bool   `json:"include_logs"`
+	IncludeCredentials bool   `json:"include_credentials"`
+}
+
+// importTaskPayload 导入租户数据任务参数
+type importTaskPayload struct {
+	File       string `json:"file"` // 上传的数据包
+	TenantCode string `json:"tenant_code"`
+	Name       string `json:"name"`
+	DryRun     bool   `json:"dry_run"`
+}
+
+// StartExport 校验导出权限后提交后台导出任务，数据包通过任务结果中的下载地址获取
+func (s *Service) StartExport(ctx context.Context, req *dto.TenantExportRequest) (*dto.TaskInfo, error) {
+	tenantID, err := tenantsvc.TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if req.IncludeCredentials && !xcontext.HasRole(ctx, constants.SuperAdmin) {
+		return nil, xerr.ErrForbidden
+	}
+
+	t, err := s.queue.Enqueue(ctx, ExportTaskType, &exportTaskPayload{
+		TenantID:           tenantID,
+		IncludeLogs:        req.IncludeLogs,
+		IncludeCredentials: req.IncludeCredentials,
+	}, task.WithMaxAttempts(exportTaskMaxAttempts))
+	if err != nil {
+		return nil, err
+	}
+	return task.ModelToTaskInfo(t), nil
+}
+
+// StartImport 保存数据包并提交后台导入任务（仅超级管理员），导入报告为任务结果
+// 导入会创建新租户，失败后不自动重试
+func (s *Service) StartImport(ctx context.Context, req *dto.TenantImportRequest, r io.Reader) (*dto.TaskInfo, error) {
+	if !xcontext.HasRole(ctx, constants.SuperAdmin) {
+		return nil, xerr.ErrForbidden
+	}
+
+	file, err := s.files.SaveInput(ctx, r, archiveFileExt)
+	if err != nil {
+		log.Error().Err(err).Msg("保存租户数据包失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "保存租户数据包失败", err)
+	}
+
+	t, err := s.queue.Enqueue(ctx, ImportTaskType, &importTaskPayload{
+		File:       file,
+		TenantCode: req.TenantCode,
+		Name:       req.Name,
+		DryRun:     req.DryRun,
+	}, task.WithMaxAttempts(1))
+	if err != nil {
+		s.files.RemoveInput(file)
+		return nil, err
+	}
+	return task.ModelToTaskInfo(t), nil
+}
+
+// runExport 执行租户数据导出，context 已按发起人重建
+func (s *Service) runExport(ctx context.Context, exec *task.Execution, p *exportTaskPayload) (any, error) {
+	tenant, err := s.tenantRepo.GetByIDManual(ctx, p.TenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, task.Permanent(xerr.ErrTenantNotFound)
+		}
+		return nil, err
+	}
+
+	exec.Progress(0, "正在导出租户数据")
+	req := &dto.TenantExportRequest{
+		TenantID:           p.TenantID,
+		IncludeLogs:        p.IncludeLogs,
+		IncludeCredentials: p.IncludeCredentials,
+	}
+	result, err := s.files.WriteResult(exec, archiveFileName(tenant.TenantCode), func(w io.Writer) error {
+		return s.ExportTenant(ctx, req, func(string) io.Writer { return w })
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// runImport 执行租户数据导入，返回导入报告
+func (s *Service) runImport(ctx context.Context, exec *task.Execution, p *importTaskPayload) (any, error) {
+	file, size, err := s.files.OpenInput(p.File)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	exec.Progress(0, "正在导入租户数据")
+	report, err := s.ImportTenant(ctx, &dto.TenantImportRequest{
+		TenantCode: p.TenantCode,
+		Name:       p.Name,
+		DryRun:     p.DryRun,
+	}, file, size)
+	if err != nil {
+		return nil, task.Permanent(err)
+	}
+	s.files.RemoveInput(p.File)
+	return report, nil
+}
+
+// cleanupImport 导入任务最终失败或被取消时删除上传的数据包
+func (s *Service) cleanupImport(ctx context.Context, p *importTaskPayload, err error) {
+	s.files.RemoveInput(p.File)
+}
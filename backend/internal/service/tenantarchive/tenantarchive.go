@@ -0,0 +1,59 @@
+package tenantarchive
+
+import (
+	"admin/internal/rbac"
+	"admin/internal/repository"
+	"admin/internal/service/task"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// Service 租户数据导入导出服务
+// 将租户的全部业务数据打包为版本化的数据包，并可在同一或其他环境中导入为新租户；
+// 接口提交后台任务执行，数据包和导入报告通过任务查询和下载
+type Service struct {
+	db             *gorm.DB
+	tenantRepo     *repository.TenantRepo
+	userRepo       *repository.UserRepo
+	permissionRepo *repository.PermissionRepo
+	templateRepo   *repository.RoleTemplateRepo
+	planRepo       *repository.PlanRepo
+	dictTypeRepo   *repository.DictTypeRepo
+	quotaRepo      *repository.TenantQuotaRepo
+	archiveRepo    *repository.TenantArchiveRepo
+	queue          *task.Queue
+	files          *task.FileStore
+	recorder       *audit.Recorder
+	cache          *rbac.PermissionCache
+}
+
+// NewService 创建租户数据导入导出服务，并向任务工作进程注册导出和导入任务
+func NewService(db *gorm.DB, recorder *audit.Recorder, cache *rbac.PermissionCache, worker *task.Worker, cfg config.TaskConfig) *Service {
+	s := &Service{
+		db:             db,
+		tenantRepo:     repository.NewTenantRepo(db),
+		userRepo:       repository.NewUserRepo(db),
+		permissionRepo: repository.NewPermissionRepo(db),
+		templateRepo:   repository.NewRoleTemplateRepo(db),
+		planRepo:       repository.NewPlanRepo(db),
+		dictTypeRepo:   repository.NewDictTypeRepo(db),
+		quotaRepo:      repository.NewTenantQuotaRepo(db),
+		archiveRepo:    repository.NewTenantArchiveRepo(db),
+		queue:          task.NewQueue(db),
+		files:          task.NewFileStore(cfg),
+		recorder:       recorder,
+		cache:          cache,
+	}
+	task.Register(worker, ExportTaskType, task.Handler[exportTaskPayload]{
+		Run:     s.runExport,
+		Timeout: archiveTaskTimeout,
+	})
+	task.Register(worker, ImportTaskType, task.Handler[importTaskPayload]{
+		Run:       s.runImport,
+		OnFailure: s.cleanupImport,
+		Timeout:   archiveTaskTimeout,
+	})
+	return s
+}
@@ -23,6 +23,7 @@ func modelToUserInfo(user *model.User) *dto.UserInfo {
 		TenantID:           user.TenantID,
 		LastLoginTime:      user.LastLoginTime,
 		MustChangePassword: user.MustChangePassword,
+		IsServiceAccount:   user.IsServiceAccount,
 		CreatedAt:          user.CreatedAt,
 		UpdatedAt:          user.UpdatedAt,
 		Remark:             user.Remark,
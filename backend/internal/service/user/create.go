@@ -3,6 +3,7 @@ package user
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dto"
+	tenantsvc "admin/internal/service/tenant"
 	"admin/pkg/audit"
 	"admin/pkg/constants"
 	"admin/pkg/utils/idgen"
@@ -35,10 +36,21 @@ func (s *Service) CreateUser(ctx context.Context, req *dto.CreateUserRequest) (r
 		}
 	}()
 
-	// 租户ID 要传入，创建不同租户用户
-	tenantID := req.TenantID
-	if tenantID == "" {
-		return nil, xerr.ErrInvalidParams
+	// 未指定租户时创建到当前租户，只有超级管理员可以创建其他租户的用户
+	tenantID, err := tenantsvc.TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		log.Warn().Str("tenant_id", req.TenantID).Msg("无权在其他租户创建用户")
+		return nil, err
+	}
+
+	// 检查租户用户数配额
+	if err := s.quotaSvc.Check(ctx, tenantID, constants.QuotaUsers, 1); err != nil {
+		return nil, err
+	}
+
+	// 检查邮箱是否可用（租户内唯一，跨租户需双方开启共享邮箱）
+	if err := s.checkEmailAvailable(ctx, tenantID, req.Email, ""); err != nil {
+		return nil, err
 	}
 
 	// 生成用户ID
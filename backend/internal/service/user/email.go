@@ -0,0 +1,11 @@
+package user
+
+import (
+	tenantsvc "admin/internal/service/tenant"
+	"context"
+)
+
+// checkEmailAvailable 检查邮箱在目标租户是否可用，规则见 tenantsvc.CheckEmailAvailable
+func (s *Service) checkEmailAvailable(ctx context.Context, tenantID, email, excludeUserID string) error {
+	return tenantsvc.CheckEmailAvailable(ctx, s.userRepo, s.tenantRepo, tenantID, email, excludeUserID)
+}
@@ -0,0 +1,108 @@
+package user
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	tenantsvc "admin/internal/service/tenant"
+	"admin/pkg/xerr"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CountUsers 按用户列表的筛选条件统计用户数（导出时用于判断同步或异步）
+func (s *Service) CountUsers(ctx context.Context, req *dto.ListUsersRequest) (int64, error) {
+	tenantID, err := tenantsvc.TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		log.Warn().Str("tenant_id", req.TenantID).Msg("无权导出其他租户的用户")
+		return 0, err
+	}
+
+	total, err := s.userRepo.CountWithFiltersAndTenant(ctx, req.Nickname, req.Status, tenantID)
+	if err != nil {
+		log.Error().Err(err).Str("nickname", req.Nickname).Int("status", req.Status).Str("tenant_id", req.TenantID).Msg("统计用户数量失败")
+		return 0, xerr.Wrap(xerr.ErrInternal.Code, "统计用户数量失败", err)
+	}
+	return total, nil
+}
+
+// StreamUsers 按用户列表的筛选条件和数据范围分批读取用户（用于导出）
+// 说明：
+//   - 与 ListUsers 使用相同的筛选条件和超管过滤规则
+//   - 每批用户的角色一次性批量查询，避免逐个用户查询
+func (s *Service) StreamUsers(ctx context.Context, req *dto.ListUsersRequest, batchSize int, fn func([]*dto.UserInfo) error) error {
+	// 异步导出在任务中执行，context 由提交时的快照恢复，同样按提交人校验
+	tenantID, err := tenantsvc.TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		log.Warn().Str("tenant_id", req.TenantID).Msg("无权导出其他租户的用户")
+		return err
+	}
+
+	err = s.userRepo.StreamWithFiltersAndTenant(ctx, req.Nickname, req.Status, tenantID, batchSize, func(users []*model.User) error {
+		users = s.filterSuperAdminUsers(ctx, users)
+		if len(users) == 0 {
+			return nil
+		}
+
+		// 迭代期间事务连接被占用，角色查询使用事务外的连接
+		rolesByUser, err := s.batchUserRoles(ctx, users, tenantID)
+		if err != nil {
+			return err
+		}
+
+		infos := make([]*dto.UserInfo, len(users))
+		for i, user := range users {
+			infos[i] = modelToUserInfoWithRoles(user, rolesByUser[user.UserID])
+		}
+		return fn(infos)
+	})
+	if err != nil {
+		if xe, ok := err.(*xerr.AppError); ok {
+			return xe
+		}
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("读取导出用户数据失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "读取导出用户数据失败", err)
+	}
+	return nil
+}
+
+// batchUserRoles 批量查询一批用户在租户中的角色，返回 用户ID -> 角色列表
+func (s *Service) batchUserRoles(ctx context.Context, users []*model.User, tenantID string) (map[string][]*model.Role, error) {
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.UserID
+	}
+
+	userRoles, err := s.userRoleRepo.ListByUserIDs(ctx, userIDs, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(userRoles) == 0 {
+		return map[string][]*model.Role{}, nil
+	}
+
+	roleIDs := make([]string, 0, len(userRoles))
+	seen := make(map[string]bool, len(userRoles))
+	for _, ur := range userRoles {
+		if !seen[ur.RoleID] {
+			seen[ur.RoleID] = true
+			roleIDs = append(roleIDs, ur.RoleID)
+		}
+	}
+	roles, err := s.roleRepo.GetByIDs(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	roleMap := make(map[string]*model.Role, len(roles))
+	for _, role := range roles {
+		roleMap[role.RoleID] = role
+	}
+
+	result := make(map[string][]*model.Role, len(users))
+	for _, ur := range userRoles {
+		if role, ok := roleMap[ur.RoleID]; ok {
+			result[ur.UserID] = append(result[ur.UserID], role)
+		}
+	}
+	return result, nil
+}
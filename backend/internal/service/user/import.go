@@ -0,0 +1,645 @@
+package user
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/csv"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/utils/rsapwd"
+	"admin/pkg/utils/xlsx"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+)
+
+// 导入文件格式
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatXLSX = "xlsx"
+)
+
+const (
+	// importMaxRows 单次导入的最大数据行数
+	importMaxRows = 1000
+	// importBatchSize 查询和写入时每批处理的行数
+	importBatchSize = 200
+	// importHashWorkers 并发计算密码哈希的协程数（Argon2 每次约占用 64MB 内存）
+	importHashWorkers = 4
+)
+
+// 导入文件的列，表头同时支持中文和英文
+const (
+	importColUserName    = "user_name"
+	importColNickname    = "nickname"
+	importColEmail       = "email"
+	importColPhone       = "phone"
+	importColDepartment  = "department"
+	importColPosition    = "position"
+	importColRoleCodes   = "role_codes"
+	importColDescription = "description"
+	importColRemark      = "remark"
+)
+
+var importHeaderAliases = map[string]string{
+	"用户名": importColUserName, "user_name": importColUserName, "username": importColUserName,
+	"昵称": importColNickname, "nickname": importColNickname,
+	"邮箱": importColEmail, "email": importColEmail,
+	"手机号": importColPhone, "phone": importColPhone,
+	"部门": importColDepartment, "department": importColDepartment,
+	"岗位": importColPosition, "position": importColPosition,
+	"角色编码": importColRoleCodes, "role_codes": importColRoleCodes,
+	"描述": importColDescription, "description": importColDescription,
+	"备注": importColRemark, "remark": importColRemark,
+}
+
+// ImportUsers 从 CSV/XLSX 批量导入当前租户的用户
+// 逐行校验后，校验通过的行在一个事务中分批创建，校验失败的行跳过；
+// 每个新用户生成随机初始密码并要求首次登录修改，预检模式只校验不写入
+func (s *Service) ImportUsers(ctx context.Context, req *dto.ImportUsersRequest, format string, r io.ReaderAt, size int64) (resp *dto.ImportUsersReport, err error) {
+	var created []*model.User
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithImport(constants.ModuleUser),
+				audit.WithError(err),
+			)
+		} else if len(created) > 0 {
+			ids := make([]string, len(created))
+			names := make([]string, len(created))
+			for i, user := range created {
+				ids[i] = user.UserID
+				names[i] = user.UserName
+			}
+			s.recorder.Log(ctx,
+				audit.WithImport(constants.ModuleUser),
+				audit.WithBatchResource(constants.ResourceTypeUser, ids, names),
+				audit.WithValue(nil, map[string]interface{}{
+					"total":     resp.Total,
+					"succeeded": resp.Succeeded,
+					"failed":    resp.Failed,
+				}),
+			)
+		}
+	}()
+
+	records, err := readImportFile(format, r, size)
+	if err != nil {
+		log.Warn().Err(err).Str("format", format).Msg("导入文件格式无效")
+		return nil, xerr.ErrUserImportInvalid
+	}
+	rows, err := parseImportRows(records)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := xcontext.GetTenantID(ctx)
+	if err := s.validateImportRows(ctx, tenantID, rows); err != nil {
+		log.Error().Err(err).Msg("校验导入用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "校验导入用户失败", err)
+	}
+
+	resp = &dto.ImportUsersReport{
+		DryRun: req.DryRun,
+		Total:  len(rows),
+		Rows:   rows,
+	}
+	var valid []*dto.ImportUserRow
+	for _, row := range rows {
+		if len(row.Errors) == 0 {
+			valid = append(valid, row)
+		}
+	}
+	resp.Succeeded = len(valid)
+	resp.Failed = len(rows) - len(valid)
+	if req.DryRun || len(valid) == 0 {
+		return resp, nil
+	}
+
+	// 检查租户用户数配额
+	if err := s.quotaSvc.Check(ctx, tenantID, constants.QuotaUsers, int64(len(valid))); err != nil {
+		return nil, err
+	}
+
+	created, err = s.createImportedUsers(ctx, valid)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("tenant_id", tenantID).
+		Int("total", resp.Total).
+		Int("succeeded", resp.Succeeded).
+		Int("failed", resp.Failed).
+		Msg("批量导入用户成功")
+
+	return resp, nil
+}
+
+// readImportFile 读取导入文件的所有行（含表头）
+func readImportFile(format string, r io.ReaderAt, size int64) ([][]string, error) {
+	switch format {
+	case ImportFormatXLSX:
+		return xlsx.ReadFirstSheet(r, size)
+	case ImportFormatCSV:
+		parser := csv.NewParser(io.NewSectionReader(r, 0, size), true)
+		headers := parser.GetHeaders()
+		if headers == nil {
+			return nil, fmt.Errorf("缺少表头")
+		}
+		records, err := parser.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		// Excel 另存的 CSV 带有 UTF-8 BOM
+		headers[0] = strings.TrimPrefix(headers[0], "\ufeff")
+		return append([][]string{headers}, records...), nil
+	default:
+		return nil, fmt.Errorf("不支持的文件格式: %s", format)
+	}
+}
+
+// parseImportRows 按表头解析数据行，跳过空行
+func parseImportRows(records [][]string) ([]*dto.ImportUserRow, error) {
+	if len(records) == 0 {
+		return nil, xerr.New(xerr.ErrUserImportInvalid.Code, "导入文件缺少表头")
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		if col, ok := importHeaderAliases[strings.ToLower(strings.TrimSpace(header))]; ok {
+			columns[col] = i
+		}
+	}
+	if _, ok := columns[importColEmail]; !ok {
+		return nil, xerr.New(xerr.ErrUserImportInvalid.Code, "导入文件缺少邮箱列")
+	}
+
+	rows := make([]*dto.ImportUserRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		get := func(col string) string {
+			if idx, ok := columns[col]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+
+		row := &dto.ImportUserRow{
+			Line:        i + 2,
+			UserName:    get(importColUserName),
+			Nickname:    get(importColNickname),
+			Email:       get(importColEmail),
+			Phone:       get(importColPhone),
+			Department:  get(importColDepartment),
+			Position:    get(importColPosition),
+			RoleCodes:   splitRoleCodes(get(importColRoleCodes)),
+			Description: get(importColDescription),
+			Remark:      get(importColRemark),
+		}
+		if row.UserName == "" && row.Nickname == "" && row.Email == "" && row.Phone == "" &&
+			row.Department == "" && row.Position == "" && len(row.RoleCodes) == 0 &&
+			row.Description == "" && row.Remark == "" {
+			continue
+		}
+		if row.UserName == "" {
+			row.UserName = row.Email
+		}
+		if row.Nickname == "" {
+			row.Nickname = row.Email
+		}
+		rows = append(rows, row)
+
+		if len(rows) > importMaxRows {
+			return nil, xerr.New(xerr.ErrUserImportTooLarge.Code, fmt.Sprintf("单次最多导入 %d 个用户", importMaxRows))
+		}
+	}
+	if len(rows) == 0 {
+		return nil, xerr.New(xerr.ErrUserImportInvalid.Code, "导入文件没有数据行")
+	}
+	return rows, nil
+}
+
+// splitRoleCodes 拆分角色编码，支持逗号、分号、竖线和空白分隔
+func splitRoleCodes(value string) []string {
+	codes := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '，' || r == ';' || r == '；' || r == '|' || unicode.IsSpace(r)
+	})
+	seen := make(map[string]bool, len(codes))
+	result := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if !seen[code] {
+			seen[code] = true
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+// validateImportRows 校验各行数据，错误记录到行结果中，返回值仅表示查询失败
+func (s *Service) validateImportRows(ctx context.Context, tenantID string, rows []*dto.ImportUserRow) error {
+	addError := func(row *dto.ImportUserRow, format string, args ...any) {
+		row.Errors = append(row.Errors, fmt.Sprintf(format, args...))
+	}
+
+	// 格式校验和文件内重复校验
+	emailLines := make(map[string]int)
+	phoneLines := make(map[string]int)
+	userNameLines := make(map[string]int)
+	for _, row := range rows {
+		if row.Email == "" {
+			addError(row, "邮箱不能为空")
+		} else if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email || len(row.Email) > 255 {
+			addError(row, "邮箱格式不正确")
+		} else if line, ok := emailLines[strings.ToLower(row.Email)]; ok {
+			addError(row, "邮箱与第 %d 行重复", line)
+		} else {
+			emailLines[strings.ToLower(row.Email)] = row.Line
+		}
+
+		if row.Phone != "" {
+			if !isValidPhone(row.Phone) {
+				addError(row, "手机号格式不正确")
+			} else if line, ok := phoneLines[row.Phone]; ok {
+				addError(row, "手机号与第 %d 行重复", line)
+			} else {
+				phoneLines[row.Phone] = row.Line
+			}
+		}
+
+		if len(row.UserName) > 255 {
+			addError(row, "用户名过长")
+		} else if line, ok := userNameLines[row.UserName]; ok && row.UserName != "" {
+			addError(row, "用户名与第 %d 行重复", line)
+		} else if row.UserName != "" {
+			userNameLines[row.UserName] = row.Line
+		}
+
+		if len(row.Remark) > 500 {
+			addError(row, "备注过长")
+		}
+	}
+
+	if err := s.checkImportConflicts(ctx, tenantID, rows, emailLines, phoneLines, userNameLines); err != nil {
+		return err
+	}
+	return s.resolveImportRefs(ctx, tenantID, rows)
+}
+
+// checkImportConflicts 校验邮箱、手机号和用户名与已有用户是否冲突
+// 邮箱在租户内唯一，跨租户重复仅在双方都开启共享邮箱时允许；手机号全局唯一（用于手机号登录）
+//
+//tenantscope:allow 邮箱和手机号唯一性需比对所有租户的用户
+func (s *Service) checkImportConflicts(ctx context.Context, tenantID string, rows []*dto.ImportUserRow, emailLines, phoneLines, userNameLines map[string]int) error {
+	// 其他租户的用户对当前租户不可见，标记为平台级操作；用户名仍按导入租户校验
+	ctx = xcontext.SetPlatformScope(ctx)
+	byLine := make(map[int]*dto.ImportUserRow, len(rows))
+	for _, row := range rows {
+		byLine[row.Line] = row
+	}
+	conflict := func(lines map[string]int, key, msg string) {
+		if line, ok := lines[key]; ok {
+			row := byLine[line]
+			row.Errors = append(row.Errors, msg)
+			// 同一行只报告一次
+			delete(lines, key)
+		}
+	}
+
+	emails := make([]string, 0, len(emailLines))
+	for _, line := range emailLines {
+		emails = append(emails, byLine[line].Email)
+	}
+	var emailUsers []*model.User
+	for _, chunk := range chunkStrings(emails, importBatchSize) {
+		list, err := s.userRepo.ListByEmailsManual(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		emailUsers = append(emailUsers, list...)
+	}
+	if len(emailUsers) > 0 {
+		tenantIDs := []string{tenantID}
+		for _, user := range emailUsers {
+			tenantIDs = append(tenantIDs, user.TenantID)
+		}
+		tenants, err := s.tenantRepo.GetByIDsManual(ctx, tenantIDs)
+		if err != nil {
+			return err
+		}
+		shared := make(map[string]bool, len(tenants))
+		for _, tenant := range tenants {
+			shared[tenant.TenantID] = tenant.AllowSharedEmail == constants.True
+		}
+		for _, user := range emailUsers {
+			key := strings.ToLower(user.Email)
+			if user.TenantID == tenantID {
+				conflict(emailLines, key, "邮箱已存在")
+			} else if !shared[tenantID] || !shared[user.TenantID] {
+				conflict(emailLines, key, "邮箱已被其他租户用户使用")
+			}
+		}
+	}
+
+	phones := make([]string, 0, len(phoneLines))
+	for phone := range phoneLines {
+		phones = append(phones, phone)
+	}
+	for _, chunk := range chunkStrings(phones, importBatchSize) {
+		list, err := s.userRepo.ListByPhonesManual(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for _, user := range list {
+			conflict(phoneLines, user.Phone, "手机号已被使用")
+		}
+	}
+
+	userNames := make([]string, 0, len(userNameLines))
+	for userName := range userNameLines {
+		userNames = append(userNames, userName)
+	}
+	for _, chunk := range chunkStrings(userNames, importBatchSize) {
+		list, err := s.userRepo.ListByUserNames(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for _, user := range list {
+			conflict(userNameLines, user.UserName, "用户名已存在")
+		}
+	}
+	return nil
+}
+
+// resolveImportRefs 将部门名称、岗位名称（或编码）和角色编码解析为ID
+func (s *Service) resolveImportRefs(ctx context.Context, tenantID string, rows []*dto.ImportUserRow) error {
+	departments, err := s.deptRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	deptIDs := make(map[string][]string)
+	for _, dept := range departments {
+		deptIDs[dept.DepartmentName] = append(deptIDs[dept.DepartmentName], dept.DepartmentID)
+	}
+
+	positions, err := s.positionRepo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	positionIDs := make(map[string][]string)
+	positionCodes := make(map[string]string)
+	for _, position := range positions {
+		positionIDs[position.PositionName] = append(positionIDs[position.PositionName], position.PositionID)
+		positionCodes[position.PositionCode] = position.PositionID
+	}
+
+	codeSet := make(map[string]bool)
+	for _, row := range rows {
+		for _, code := range row.RoleCodes {
+			codeSet[code] = true
+		}
+	}
+	roleIDs := make(map[string]string)
+	if len(codeSet) > 0 {
+		codes := make([]string, 0, len(codeSet))
+		for code := range codeSet {
+			codes = append(codes, code)
+		}
+		roles, err := s.roleRepo.ListByCodesWithTenant(ctx, tenantID, codes)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			roleIDs[role.RoleCode] = role.RoleID
+		}
+	}
+
+	for _, row := range rows {
+		if row.Department != "" {
+			switch ids := deptIDs[row.Department]; len(ids) {
+			case 0:
+				row.Errors = append(row.Errors, "部门不存在: "+row.Department)
+			case 1:
+				row.DepartmentID = ids[0]
+			default:
+				row.Errors = append(row.Errors, "部门名称不唯一: "+row.Department)
+			}
+		}
+
+		if row.Position != "" {
+			switch ids := positionIDs[row.Position]; {
+			case len(ids) == 1:
+				row.PositionID = ids[0]
+			case len(ids) > 1:
+				row.Errors = append(row.Errors, "岗位名称不唯一，请使用岗位编码: "+row.Position)
+			case positionCodes[row.Position] != "":
+				row.PositionID = positionCodes[row.Position]
+			default:
+				row.Errors = append(row.Errors, "岗位不存在: "+row.Position)
+			}
+		}
+
+		for _, code := range row.RoleCodes {
+			if id, ok := roleIDs[code]; ok {
+				row.RoleIDs = append(row.RoleIDs, id)
+			} else {
+				row.Errors = append(row.Errors, "角色不存在: "+code)
+			}
+		}
+	}
+	return nil
+}
+
+// createImportedUsers 生成初始密码后在一个事务中分批创建用户并分配角色
+func (s *Service) createImportedUsers(ctx context.Context, rows []*dto.ImportUserRow) ([]*model.User, error) {
+	userIDs, err := idgen.GenerateUUIDs(len(rows))
+	if err != nil {
+		log.Error().Err(err).Msg("生成用户ID失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成用户ID失败", err)
+	}
+
+	passwords := make([]string, len(rows))
+	for i := range rows {
+		passwords[i] = passwordgen.GenerateRandomPassword(8)
+	}
+	hashes, err := hashImportPasswords(passwords)
+	if err != nil {
+		log.Error().Err(err).Msg("密码加密失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "密码加密失败", err)
+	}
+
+	users := make([]*model.User, len(rows))
+	var userRoles []*model.UserRole
+	var userPositions []*model.UserPosition
+	tenantID := xcontext.GetTenantID(ctx)
+	for i, row := range rows {
+		users[i] = &model.User{
+			UserID:             userIDs[i],
+			UserName:           row.UserName,
+			Password:           hashes[i],
+			Nickname:           row.Nickname,
+			Phone:              row.Phone,
+			Email:              row.Email,
+			DepartmentID:       row.DepartmentID,
+			PositionID:         row.PositionID,
+			Description:        row.Description,
+			Remark:             row.Remark,
+			Status:             int16(constants.StatusEnabled),
+			MustChangePassword: constants.True, // 新用户必须修改密码
+		}
+		if row.PositionID != "" {
+			userPositions = append(userPositions, &model.UserPosition{
+				UserID:     userIDs[i],
+				PositionID: row.PositionID,
+				IsPrimary:  int16(constants.True),
+			})
+		}
+		for _, roleID := range row.RoleIDs {
+			userRoles = append(userRoles, &model.UserRole{
+				UserID:   userIDs[i],
+				RoleID:   roleID,
+				TenantID: tenantID,
+			})
+		}
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewUserRepo(tx.DB).CreateInBatches(ctx, users, importBatchSize); err != nil {
+			return err
+		}
+		if len(userPositions) > 0 {
+			if err := repository.NewUserPositionRepo(tx.DB).CreateInBatches(ctx, userPositions, importBatchSize); err != nil {
+				return err
+			}
+		}
+		if len(userRoles) == 0 {
+			return nil
+		}
+		return repository.NewUserRoleRepo(tx.DB).CreateInBatches(ctx, userRoles, importBatchSize)
+	})
+	if err != nil {
+		// 校验后到写入前被其他请求抢先使用了邮箱或手机号
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "duplicate key") ||
+			strings.Contains(errMsg, "uk_users_tenant_email") ||
+			strings.Contains(errMsg, "uk_users_phone") {
+			log.Warn().Err(err).Msg("邮箱或手机号已存在")
+			return nil, xerr.ErrEmailOrPhoneExists
+		}
+		log.Error().Err(err).Int("count", len(users)).Msg("批量创建用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "批量创建用户失败", err)
+	}
+
+	for i, row := range rows {
+		row.UserID = userIDs[i]
+		row.Password = passwords[i]
+	}
+	return users, nil
+}
+
+// hashImportPasswords 并发计算密码哈希（与前端登录流程一致，先计算 SHA256 再 Argon2）
+func hashImportPasswords(passwords []string) ([]string, error) {
+	hashes := make([]string, len(passwords))
+	errs := make([]error, len(passwords))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, importHashWorkers)
+	for i, password := range passwords {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, password string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			salt, err := passwordgen.GenerateSalt()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			hashes[i], errs[i] = passwordgen.Argon2Hash(rsapwd.HashPassword(password), salt)
+		}(i, password)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hashes, nil
+}
+
+// isValidPhone 校验手机号：数字，允许以 + 开头和 - 分隔
+func isValidPhone(phone string) bool {
+	if len(phone) > 20 {
+		return false
+	}
+	digits := 0
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0, r == '-':
+		default:
+			return false
+		}
+	}
+	return digits >= 5
+}
+
+// chunkStrings 将字符串列表按大小分组
+func chunkStrings(list []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(list); start += size {
+		end := start + size
+		if end > len(list) {
+			end = len(list)
+		}
+		chunks = append(chunks, list[start:end])
+	}
+	return chunks
+}
+
+// WriteImportResult 将导入结果写为与导入文件相同格式的结果文件
+func WriteImportResult(w io.Writer, format string, report *dto.ImportUsersReport) error {
+	headers := []string{"行号", "用户名", "昵称", "邮箱", "手机号", "部门", "岗位", "角色编码", "描述", "备注", "结果", "错误信息", "用户ID", "初始密码"}
+	rows := make([][]string, len(report.Rows))
+	for i, row := range report.Rows {
+		result := "失败"
+		if len(row.Errors) == 0 {
+			result = "成功"
+			if report.DryRun {
+				result = "校验通过"
+			}
+		}
+		rows[i] = []string{
+			fmt.Sprint(row.Line), row.UserName, row.Nickname, row.Email, row.Phone,
+			row.Department, row.Position, strings.Join(row.RoleCodes, ","), row.Description, row.Remark,
+			result, strings.Join(row.Errors, "；"), row.UserID, row.Password,
+		}
+	}
+
+	if format == ImportFormatXLSX {
+		return xlsx.Write(w, "导入结果", headers, rows)
+	}
+
+	// 写入 UTF-8 BOM，避免 Excel 打开中文乱码
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	exporter := csv.New(headers)
+	exporter.AddRows(rows)
+	return exporter.WriteToWriter(w)
+}
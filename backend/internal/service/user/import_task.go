@@ -0,0 +1,116 @@
+package user
+
+import (
+	"admin/internal/dto"
+	"admin/internal/service/task"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/utils/rsapwd"
+	"admin/pkg/xerr"
+	"context"
+	"io"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ImportTaskType 批量导入用户的任务类型
+const ImportTaskType = "user_import"
+
+// importTaskPayload 批量导入用户任务参数
+type importTaskPayload struct {
+	File   string `json:"file"` // 上传的导入文件
+	Format string `json:"format"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// importTaskResult 批量导入用户任务结果，结果文件与上传格式相同，包含新用户的初始密码
+type importTaskResult struct {
+	task.FileResult
+	DryRun    bool `json:"dry_run"`
+	Total     int  `json:"total"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+}
+
+// ImportService 批量导入用户服务
+// 上传的文件保存后提交到后台任务队列，由任务工作进程校验并创建用户，结果文件通过任务下载地址获取
+type ImportService struct {
+	users *Service
+	queue *task.Queue
+	files *task.FileStore
+}
+
+// NewImportService 创建批量导入用户服务，并向任务工作进程注册导入任务
+func NewImportService(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, worker *task.Worker, cfg config.TaskConfig) *ImportService {
+	s := &ImportService{
+		users: NewService(db, recorder, rsaCipher),
+		queue: task.NewQueue(db),
+		files: task.NewFileStore(cfg),
+	}
+	task.Register(worker, ImportTaskType, task.Handler[importTaskPayload]{
+		Run:       s.runImport,
+		OnFailure: s.cleanupImport,
+	})
+	return s
+}
+
+// StartImport 保存导入文件并提交后台导入任务，返回的任务通过 /api/v1/tasks 查询进度和结果
+// 导入会创建用户并生成初始密码，失败后不自动重试，避免重复创建
+func (s *ImportService) StartImport(ctx context.Context, req *dto.ImportUsersRequest, format string, r io.Reader) (*dto.TaskInfo, error) {
+	file, err := s.files.SaveInput(ctx, r, "."+format)
+	if err != nil {
+		log.Error().Err(err).Msg("保存导入文件失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "保存导入文件失败", err)
+	}
+
+	t, err := s.queue.Enqueue(ctx, ImportTaskType, &importTaskPayload{
+		File:   file,
+		Format: format,
+		DryRun: req.DryRun,
+	}, task.WithMaxAttempts(1))
+	if err != nil {
+		s.files.RemoveInput(file)
+		return nil, err
+	}
+	return task.ModelToTaskInfo(t), nil
+}
+
+// runImport 执行批量导入，context 已按发起人重建，用户创建在发起人的租户中
+func (s *ImportService) runImport(ctx context.Context, exec *task.Execution, p *importTaskPayload) (any, error) {
+	file, size, err := s.files.OpenInput(p.File)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	exec.Progress(0, "正在校验导入数据")
+	report, err := s.users.ImportUsers(ctx, &dto.ImportUsersRequest{DryRun: p.DryRun}, p.Format, file, size)
+	if err != nil {
+		return nil, task.Permanent(err)
+	}
+
+	exec.Progress(90, "正在生成导入结果文件")
+	result, err := s.files.WriteResult(exec, "user_import_result."+p.Format, func(w io.Writer) error {
+		return WriteImportResult(w, p.Format, report)
+	})
+	if err != nil {
+		// 用户已创建，结果文件写出失败时只能记录日志
+		log.Error().Err(err).Int("succeeded", report.Succeeded).Msg("写出导入结果文件失败")
+		return nil, task.Permanent(err)
+	}
+	s.files.RemoveInput(p.File)
+
+	return &importTaskResult{
+		FileResult: *result,
+		DryRun:     report.DryRun,
+		Total:      report.Total,
+		Succeeded:  report.Succeeded,
+		Failed:     report.Failed,
+	}, nil
+}
+
+// cleanupImport 导入任务最终失败或被取消时删除上传的文件
+func (s *ImportService) cleanupImport(ctx context.Context, p *importTaskPayload, err error) {
+	s.files.RemoveInput(p.File)
+}
@@ -0,0 +1,32 @@
+package user
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/repository"
+	"context"
+)
+
+// getTenantUser 获取当前租户可见的用户
+// 当前租户的用户直接返回；从其他租户加入的成员返回其主租户账号，并以成员身份中的部门、岗位和状态覆盖
+// 用户既不属于当前租户也不是成员时返回 gorm.ErrRecordNotFound
+func getTenantUser(ctx context.Context, userRepo *repository.UserRepo, memberRepo *repository.TenantMemberRepo, userID string) (*model.User, error) {
+	user, err := userRepo.GetByID(ctx, userID)
+	if err == nil {
+		return user, nil
+	}
+
+	member, memberErr := memberRepo.GetByUser(ctx, userID)
+	if memberErr != nil {
+		// 不是成员时保留原始错误
+		return nil, err
+	}
+
+	user, err = userRepo.GetByIDManual(ctx, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+	user.DepartmentID = member.DepartmentID
+	user.PositionID = member.PositionID
+	user.Status = member.Status
+	return user, nil
+}
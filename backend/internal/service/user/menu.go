@@ -44,7 +44,7 @@ func (s *MenuService) GetUserMenu(ctx context.Context, userName, tenantCode stri
 		return &dto.UserMenuResponse{List: []*dto.MenuTreeNode{}}, nil
 	}
 
-	// 从 PermissionCache 获取菜单ID列表
+	// 从 PermissionCache 获取菜单ID列表（已按租户套餐裁剪，套餐外的菜单不会返回）
 	menuIDs := s.cache.GetMenuIDs(roleIDs)
 	if len(menuIDs) == 0 {
 		return &dto.UserMenuResponse{List: []*dto.MenuTreeNode{}}, nil
@@ -37,6 +37,11 @@ func (s *Service) ChangePassword(ctx context.Context, req *dto.ChangePasswordReq
 		}
 	}()
 
+	// 模拟登录状态下不允许修改被模拟用户的密码
+	if xcontext.IsImpersonating(ctx) {
+		return xerr.ErrImpersonationForbidden
+	}
+
 	// 获取当前用户ID
 	userID := xcontext.GetUserID(ctx)
 
@@ -50,6 +55,9 @@ func (s *Service) ChangePassword(ctx context.Context, req *dto.ChangePasswordReq
 		log.Error().Err(err).Str("user_id", userID).Msg("查询用户失败")
 		return xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
 	}
+	if user.IsServiceAccount == int16(constants.True) {
+		return xerr.ErrServiceAccountPassword
+	}
 
 	// 解密前端传来的旧密码
 	// 前端使用 JSEncrypt 库（PKCS#1 v1.5 填充）加密密码
@@ -128,6 +136,11 @@ func (s *Service) ResetPassword(ctx context.Context, targetUserID string, req *d
 		}
 	}()
 
+	// 模拟登录状态下不允许重置密码
+	if xcontext.IsImpersonating(ctx) {
+		return nil, xerr.ErrImpersonationForbidden
+	}
+
 	// 查询目标用户
 	user, err = s.userRepo.GetByID(ctx, targetUserID)
 	if err != nil {
@@ -138,6 +151,9 @@ func (s *Service) ResetPassword(ctx context.Context, targetUserID string, req *d
 		log.Error().Err(err).Str("target_user_id", targetUserID).Msg("查询用户失败")
 		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
 	}
+	if user.IsServiceAccount == int16(constants.True) {
+		return nil, xerr.ErrServiceAccountPassword
+	}
 
 	// 自动生成随机密码（8位字母+数字）
 	newPassword := passwordgen.GenerateRandomPassword(8)
@@ -0,0 +1,239 @@
+package user
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/internal/repository"
+	"admin/pkg/audit"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/pagination"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// GetUserPositions 获取用户在当前租户担任的岗位
+// 从其他租户加入的成员只有一个岗位，取成员身份上的岗位
+func (s *Service) GetUserPositions(ctx context.Context, userID string) (*dto.UserPositionsResponse, error) {
+	user, err := getTenantUser(ctx, s.userRepo, s.memberRepo, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("user_id", userID).Msg("用户不存在")
+			return nil, xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+
+	positions, err := s.getUserPositions(ctx, user)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户岗位失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户岗位失败", err)
+	}
+
+	return &dto.UserPositionsResponse{
+		UserID:    user.UserID,
+		UserName:  user.UserName,
+		Positions: positions,
+	}, nil
+}
+
+// getUserPositions 查询用户的岗位详情，主岗位在前
+func (s *Service) getUserPositions(ctx context.Context, user *model.User) ([]*dto.UserPositionInfo, error) {
+	var links []*model.UserPosition
+	if user.TenantID == xcontext.GetTenantID(ctx) {
+		var err error
+		links, err = s.userPosRepo.ListByUser(ctx, user.UserID)
+		if err != nil {
+			return nil, err
+		}
+	} else if user.PositionID != "" {
+		links = []*model.UserPosition{{UserID: user.UserID, PositionID: user.PositionID, IsPrimary: int16(constants.True)}}
+	}
+	if len(links) == 0 {
+		return []*dto.UserPositionInfo{}, nil
+	}
+
+	positionIDs := make([]string, len(links))
+	for i, link := range links {
+		positionIDs[i] = link.PositionID
+	}
+	positions, err := s.positionRepo.ListByIDs(ctx, positionIDs)
+	if err != nil {
+		return nil, err
+	}
+	positionMap := make(map[string]*model.Position, len(positions))
+	for _, position := range positions {
+		positionMap[position.PositionID] = position
+	}
+
+	list := make([]*dto.UserPositionInfo, 0, len(links))
+	for _, link := range links {
+		position, ok := positionMap[link.PositionID]
+		if !ok {
+			// 岗位已删除
+			continue
+		}
+		list = append(list, &dto.UserPositionInfo{
+			PositionID:   position.PositionID,
+			PositionCode: position.PositionCode,
+			PositionName: position.PositionName,
+			Level:        int(position.Level),
+			Status:       int(position.Status),
+			IsPrimary:    link.IsPrimary,
+		})
+	}
+	return list, nil
+}
+
+// AssignPositions 为用户设置岗位（覆盖式），主岗位同步到 users.position_id
+func (s *Service) AssignPositions(ctx context.Context, req *dto.AssignPositionsRequest) (err error) {
+	var user *model.User
+	var oldPositionIDs []string
+	var primaryPositionID string
+
+	defer func() {
+		if err != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithOperation("设置岗位"),
+				audit.WithError(err),
+			)
+		} else if user != nil {
+			s.recorder.Log(ctx,
+				audit.WithUpdate(constants.ModuleUser),
+				audit.WithOperation("设置岗位"),
+				audit.WithResource(constants.ResourceTypeUser, user.UserID, user.UserName),
+				audit.WithValue(map[string]interface{}{
+					"position_ids":        oldPositionIDs,
+					"primary_position_id": user.PositionID,
+				}, map[string]interface{}{
+					"position_ids":        req.PositionIDs,
+					"primary_position_id": primaryPositionID,
+				}),
+			)
+		}
+	}()
+
+	user, err = getTenantUser(ctx, s.userRepo, s.memberRepo, req.UserID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn().Str("user_id", req.UserID).Msg("用户不存在")
+			return xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", req.UserID).Msg("查询用户失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+	if user.TenantID != xcontext.GetTenantID(ctx) {
+		return xerr.New(xerr.ErrInvalidParams.Code, "从其他租户加入的成员只能有一个岗位，请在成员管理中设置")
+	}
+
+	// 去重并校验岗位
+	positionIDs := make([]string, 0, len(req.PositionIDs))
+	seen := make(map[string]bool, len(req.PositionIDs))
+	for _, id := range req.PositionIDs {
+		if !seen[id] {
+			seen[id] = true
+			positionIDs = append(positionIDs, id)
+		}
+	}
+	req.PositionIDs = positionIDs
+
+	primaryPositionID = req.PrimaryPositionID
+	if len(positionIDs) > 0 {
+		if primaryPositionID == "" {
+			primaryPositionID = positionIDs[0]
+		} else if !seen[primaryPositionID] {
+			return xerr.New(xerr.ErrInvalidParams.Code, "主岗位必须包含在岗位列表中")
+		}
+
+		positions, err := s.positionRepo.ListByIDs(ctx, positionIDs)
+		if err != nil {
+			log.Error().Err(err).Strs("position_ids", positionIDs).Msg("查询岗位失败")
+			return xerr.Wrap(xerr.ErrInternal.Code, "查询岗位失败", err)
+		}
+		if len(positions) != len(positionIDs) {
+			log.Warn().Strs("position_ids", positionIDs).Msg("部分岗位不存在")
+			return xerr.New(xerr.ErrPositionNotFound.Code, "部分岗位不存在")
+		}
+	} else if primaryPositionID != "" {
+		return xerr.New(xerr.ErrInvalidParams.Code, "主岗位必须包含在岗位列表中")
+	}
+
+	links, err := s.userPosRepo.ListByUser(ctx, user.UserID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询用户岗位失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "查询用户岗位失败", err)
+	}
+	oldPositionIDs = make([]string, len(links))
+	for i, link := range links {
+		oldPositionIDs[i] = link.PositionID
+	}
+
+	err = database.InTransactionWithCtx(ctx, s.db, func(ctx context.Context, tx *database.Tx) error {
+		if err := repository.NewUserPositionRepo(tx.DB).ReplaceByUser(ctx, user.UserID, positionIDs, primaryPositionID); err != nil {
+			return err
+		}
+		return repository.NewUserRepo(tx.DB).Update(ctx, user.UserID, map[string]interface{}{
+			"position_id": primaryPositionID,
+			"updated_at":  time.Now().UnixMilli(),
+		})
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Strs("position_ids", positionIDs).Msg("设置用户岗位失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "设置用户岗位失败", err)
+	}
+
+	log.Info().
+		Str("user_id", user.UserID).
+		Strs("position_ids", positionIDs).
+		Str("primary_position_id", primaryPositionID).
+		Msg("设置用户岗位成功")
+	return nil
+}
+
+// ListPositionUsers 分页获取担任指定岗位的用户（含兼任），主岗在前
+func (s *Service) ListPositionUsers(ctx context.Context, req *dto.ListPositionUsersRequest) (*dto.ListUsersResponse, error) {
+	if _, err := s.positionRepo.GetByID(ctx, req.PositionID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, xerr.ErrPositionNotFound
+		}
+		log.Error().Err(err).Str("position_id", req.PositionID).Msg("查询岗位失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询岗位失败", err)
+	}
+
+	userIDs, total, err := s.userPosRepo.ListUserIDsByPosition(ctx, req.PositionID, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		log.Error().Err(err).Str("position_id", req.PositionID).Msg("查询岗位用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询岗位用户失败", err)
+	}
+
+	list := make([]*dto.UserInfo, 0, len(userIDs))
+	if len(userIDs) > 0 {
+		users, err := s.userRepo.GetByIDs(ctx, userIDs)
+		if err != nil {
+			log.Error().Err(err).Str("position_id", req.PositionID).Msg("查询岗位用户失败")
+			return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询岗位用户失败", err)
+		}
+		userMap := make(map[string]*model.User, len(users))
+		for _, user := range users {
+			userMap[user.UserID] = user
+		}
+		// 保持按岗位关联的排序（主岗在前）
+		for _, id := range userIDs {
+			if user, ok := userMap[id]; ok {
+				list = append(list, modelToUserInfo(user))
+			}
+		}
+	}
+
+	return &dto.ListUsersResponse{
+		Response: pagination.NewResponse(req.Request, total),
+		List:     list,
+	}, nil
+}
@@ -0,0 +1,478 @@
+package user
+
+import (
+	"admin/internal/dal/model"
+	"admin/internal/dto"
+	"admin/pkg/audit"
+	"admin/pkg/config"
+	"admin/pkg/constants"
+	"admin/pkg/database"
+	"admin/pkg/utils/idgen"
+	"admin/pkg/utils/notify"
+	"admin/pkg/utils/passwordgen"
+	"admin/pkg/utils/rsapwd"
+	"admin/pkg/utils/thumbnail"
+	"admin/pkg/xcontext"
+	"admin/pkg/xerr"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	contactCodeKeyPrefix     = "profile_contact:"          // 邮箱/手机号变更验证码 Redis key 前缀
+	contactAttemptsKeyPrefix = "profile_contact_attempts:" // 验证失败次数 Redis key 前缀
+	contactCooldownKeyPrefix = "profile_contact_cooldown:" // 发送冷却 Redis key 前缀
+	contactCodeDigits        = 6                           // 验证码位数
+	contactMaxAttempts       = 5                           // 单个验证码允许的最大失败次数
+	contactCooldown          = time.Minute                 // 同一类型验证码的最短发送间隔
+
+	contactTypeEmail = "email"
+	contactTypePhone = "phone"
+)
+
+// contactChallenge 待验证的邮箱/手机号变更，仅保存验证码哈希
+type contactChallenge struct {
+	Value    string `json:"value"`
+	CodeHash string `json:"code_hash"`
+}
+
+// ProfileService 个人资料自助修改服务
+// 用户只能修改自己的资料：昵称、简介、头像直接生效，邮箱和手机号需向新地址发送验证码验证后才生效。
+// 切换到其他租户的成员修改的是主租户账号，资料在所有租户中共享
+type ProfileService struct {
+	users    *Service
+	rdb      redis.UniversalClient
+	notifier notify.Sender
+	cfg      config.ProfileConfig
+}
+
+// NewProfileService 创建个人资料服务
+func NewProfileService(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher, rdb redis.UniversalClient, notifier notify.Sender, cfg config.ProfileConfig) *ProfileService {
+	return &ProfileService{
+		users:    NewService(db, recorder, rsaCipher),
+		rdb:      rdb,
+		notifier: notifier,
+		cfg:      cfg,
+	}
+}
+
+// getSelf 查询当前登录用户的账号
+func (s *ProfileService) getSelf(ctx context.Context) (*model.User, error) {
+	userID := xcontext.GetUserID(ctx)
+	user, err := s.users.userRepo.GetByIDManual(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn().Str("user_id", userID).Msg("用户不存在")
+			return nil, xerr.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("user_id", userID).Msg("查询用户失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户失败", err)
+	}
+	return user, nil
+}
+
+// homeContext 修改自己的账号时按账号所属的主租户访问，切换到其他租户后修改的仍是主租户账号
+//
+//tenantscope:allow 当前用户修改自己的主租户账号
+func homeContext(ctx context.Context, user *model.User) context.Context {
+	return database.WithTenant(ctx, user.TenantID)
+}
+
+// UpdateProfile 修改自己的昵称和简介
+func (s *ProfileService) UpdateProfile(ctx context.Context, req *dto.UpdateProfileRequest) (resp *dto.UserInfo, err error) {
+	var oldUser, newUser *model.User
+
+	defer func() {
+		if err != nil {
+			s.users.recorder.Log(ctx, audit.WithUpdate(constants.ModuleUser), audit.WithOperation("修改个人资料"), audit.WithError(err))
+		} else if newUser != nil {
+			s.users.recorder.RecordUpdate(ctx, constants.ModuleUser, constants.ResourceTypeUser, newUser.UserID, newUser.UserName, oldUser, newUser)
+		}
+	}()
+
+	oldUser, err = s.getSelf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Nickname != nil {
+		nickname := strings.TrimSpace(*req.Nickname)
+		if nickname == "" {
+			return nil, xerr.New(xerr.ErrInvalidParams.Code, "昵称不能为空")
+		}
+		updates["nickname"] = nickname
+	}
+	if req.Description != nil {
+		updates["description"] = strings.TrimSpace(*req.Description)
+	}
+	if len(updates) == 0 {
+		return modelToUserInfo(oldUser), nil
+	}
+	updates["updated_at"] = time.Now().UnixMilli()
+
+	if err := s.users.userRepo.UpdateManual(homeContext(ctx, oldUser), oldUser.UserID, updates); err != nil {
+		log.Error().Err(err).Str("user_id", oldUser.UserID).Msg("更新个人资料失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新个人资料失败", err)
+	}
+
+	newUser, err = s.getSelf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("user_id", newUser.UserID).Msg("用户修改个人资料成功")
+	return modelToUserInfo(newUser), nil
+}
+
+// UploadAvatar 上传自己的头像
+// 按文件内容校验格式，居中裁剪并缩放为正方形后保存到本地，占用主租户的存储配额；旧头像文件随之删除
+func (s *ProfileService) UploadAvatar(ctx context.Context, data []byte) (resp *dto.UploadAvatarResponse, err error) {
+	var oldUser, newUser *model.User
+
+	defer func() {
+		if err != nil {
+			s.users.recorder.Log(ctx, audit.WithUpdate(constants.ModuleUser), audit.WithOperation("修改头像"), audit.WithError(err))
+		} else if newUser != nil {
+			s.users.recorder.RecordUpdate(ctx, constants.ModuleUser, constants.ResourceTypeUser, newUser.UserID, newUser.UserName,
+				map[string]string{"avatar": oldUser.Avatar}, map[string]string{"avatar": newUser.Avatar})
+		}
+	}()
+
+	if int64(len(data)) > s.cfg.GetAvatarMaxSize() {
+		return nil, xerr.ErrAvatarTooLarge
+	}
+
+	oldUser, err = s.getSelf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	thumb, err := thumbnail.Square(data, s.cfg.GetAvatarSize())
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", oldUser.UserID).Msg("头像图片无效")
+		return nil, xerr.ErrAvatarInvalid
+	}
+
+	size := int64(len(thumb.Data))
+	if err := s.users.quotaSvc.ReserveStorage(ctx, oldUser.TenantID, size); err != nil {
+		return nil, err
+	}
+
+	fileID, err := idgen.GenerateUUID()
+	if err != nil {
+		s.releaseStorage(ctx, oldUser.TenantID, size)
+		log.Error().Err(err).Msg("生成头像文件名失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成头像文件名失败", err)
+	}
+	relPath := path.Join(oldUser.TenantID, oldUser.UserID+"-"+fileID+"."+thumb.Ext)
+	filePath, err := s.writeAvatar(relPath, thumb.Data)
+	if err != nil {
+		s.releaseStorage(ctx, oldUser.TenantID, size)
+		log.Error().Err(err).Str("user_id", oldUser.UserID).Msg("保存头像文件失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "保存头像文件失败", err)
+	}
+
+	avatar := s.cfg.GetAvatarURLPrefix() + "/" + relPath
+	if err := s.users.userRepo.UpdateManual(homeContext(ctx, oldUser), oldUser.UserID, map[string]interface{}{
+		"avatar":     avatar,
+		"updated_at": time.Now().UnixMilli(),
+	}); err != nil {
+		os.Remove(filePath)
+		s.releaseStorage(ctx, oldUser.TenantID, size)
+		log.Error().Err(err).Str("user_id", oldUser.UserID).Msg("更新头像失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新头像失败", err)
+	}
+
+	s.removeAvatar(ctx, oldUser)
+
+	newUser = &model.User{}
+	*newUser = *oldUser
+	newUser.Avatar = avatar
+	log.Info().Str("user_id", newUser.UserID).Str("avatar", avatar).Msg("用户修改头像成功")
+	return &dto.UploadAvatarResponse{Avatar: avatar}, nil
+}
+
+// writeAvatar 写入头像文件，先写临时文件再重命名，返回文件路径
+func (s *ProfileService) writeAvatar(relPath string, data []byte) (string, error) {
+	filePath := filepath.Join(s.cfg.GetAvatarDir(), filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return "", err
+	}
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	return filePath, nil
+}
+
+// removeAvatar 删除用户原来上传的头像文件并释放存储配额
+// 仅处理本服务保存的头像，外部链接或默认头像不处理；删除失败只记录日志
+func (s *ProfileService) removeAvatar(ctx context.Context, user *model.User) {
+	prefix := s.cfg.GetAvatarURLPrefix() + "/"
+	if !strings.HasPrefix(user.Avatar, prefix) {
+		return
+	}
+	relPath := path.Clean(strings.TrimPrefix(user.Avatar, prefix))
+	if relPath == "." || strings.HasPrefix(relPath, "..") || path.IsAbs(relPath) {
+		return
+	}
+
+	filePath := filepath.Join(s.cfg.GetAvatarDir(), filepath.FromSlash(relPath))
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn().Err(err).Str("path", filePath).Msg("读取旧头像文件失败")
+		}
+		return
+	}
+	if err := os.Remove(filePath); err != nil {
+		log.Warn().Err(err).Str("path", filePath).Msg("删除旧头像文件失败")
+		return
+	}
+	s.releaseStorage(ctx, user.TenantID, info.Size())
+}
+
+// releaseStorage 释放存储配额，失败只记录日志
+func (s *ProfileService) releaseStorage(ctx context.Context, tenantID string, size int64) {
+	if err := s.users.quotaSvc.ReleaseStorage(ctx, tenantID, size); err != nil {
+		log.Warn().Err(err).Str("tenant_id", tenantID).Int64("size", size).Msg("释放头像存储配额失败")
+	}
+}
+
+// SendContactCode 向新的邮箱或手机号发送变更验证码
+// 发送前校验格式和唯一性，同一类型的验证码 1 分钟内只能发送一次，新验证码使之前的验证码失效
+func (s *ProfileService) SendContactCode(ctx context.Context, req *dto.SendContactCodeRequest) (*dto.SendContactCodeResponse, error) {
+	// 模拟登录状态下不允许修改被模拟用户的联系方式
+	if xcontext.IsImpersonating(ctx) {
+		return nil, xerr.ErrImpersonationForbidden
+	}
+
+	value := strings.TrimSpace(req.Value)
+	if err := validateContact(req.Type, value); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getSelf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if value == contactValue(user, req.Type) {
+		return nil, xerr.ErrContactUnchanged
+	}
+	if err := s.checkContactAvailable(ctx, user, req.Type, value); err != nil {
+		return nil, err
+	}
+	if s.notifier == nil {
+		return nil, xerr.New(xerr.ErrInternal.Code, "未配置验证码发送渠道")
+	}
+
+	cooldownKey := contactCooldownKeyPrefix + user.UserID + ":" + req.Type
+	ok, err := s.rdb.SetNX(ctx, cooldownKey, 1, contactCooldown).Result()
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("检查验证码发送频率失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "检查验证码发送频率失败", err)
+	}
+	if !ok {
+		return nil, xerr.ErrVerifyCodeTooFrequent
+	}
+
+	code, err := passwordgen.GenerateNumericCode(contactCodeDigits)
+	if err != nil {
+		s.rdb.Del(ctx, cooldownKey)
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("生成验证码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "生成验证码失败", err)
+	}
+	data, err := json.Marshal(&contactChallenge{Value: value, CodeHash: passwordgen.HashSecret(code)})
+	if err != nil {
+		s.rdb.Del(ctx, cooldownKey)
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "序列化验证码失败", err)
+	}
+
+	ttl := s.cfg.GetVerifyCodeTTL()
+	key := contactCodeKeyPrefix + user.UserID + ":" + req.Type
+	attemptsKey := contactAttemptsKeyPrefix + user.UserID + ":" + req.Type
+	if err := s.rdb.Set(ctx, key, data, ttl).Err(); err != nil {
+		s.rdb.Del(ctx, cooldownKey)
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("保存验证码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "保存验证码失败", err)
+	}
+	s.rdb.Del(ctx, attemptsKey)
+
+	msg := &notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      value,
+		Subject: "邮箱变更验证码",
+		Content: fmt.Sprintf("您正在将账号 %s 的邮箱修改为 %s，验证码：%s，%d 分钟内有效。如非本人操作，请忽略。", user.UserName, value, code, int(ttl.Minutes())),
+	}
+	if req.Type == contactTypePhone {
+		msg.Channel = notify.ChannelSMS
+		msg.Subject = "手机号变更验证码"
+		msg.Content = fmt.Sprintf("您正在修改账号绑定的手机号，验证码：%s，%d 分钟内有效。如非本人操作，请忽略。", code, int(ttl.Minutes()))
+	}
+	if err := s.notifier.Send(ctx, msg); err != nil {
+		s.rdb.Del(ctx, key, cooldownKey)
+		log.Error().Err(err).Str("user_id", user.UserID).Str("type", req.Type).Msg("发送验证码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "发送验证码失败", err)
+	}
+
+	log.Info().Str("user_id", user.UserID).Str("type", req.Type).Msg("联系方式变更验证码已发送")
+	return &dto.SendContactCodeResponse{
+		Target:    maskContact(req.Type, value),
+		ExpiresIn: int(ttl.Seconds()),
+	}, nil
+}
+
+// VerifyContact 校验验证码并修改邮箱或手机号
+// 连续 5 次验证失败后验证码作废，需重新发送
+func (s *ProfileService) VerifyContact(ctx context.Context, req *dto.VerifyContactRequest) (resp *dto.UserInfo, err error) {
+	var oldUser, newUser *model.User
+
+	defer func() {
+		if err != nil {
+			s.users.recorder.Log(ctx, audit.WithUpdate(constants.ModuleUser), audit.WithOperation("修改联系方式"), audit.WithError(err))
+		} else if newUser != nil {
+			s.users.recorder.RecordUpdate(ctx, constants.ModuleUser, constants.ResourceTypeUser, newUser.UserID, newUser.UserName,
+				map[string]string{req.Type: contactValue(oldUser, req.Type)}, map[string]string{req.Type: contactValue(newUser, req.Type)})
+		}
+	}()
+
+	if xcontext.IsImpersonating(ctx) {
+		return nil, xerr.ErrImpersonationForbidden
+	}
+
+	userID := xcontext.GetUserID(ctx)
+	key := contactCodeKeyPrefix + userID + ":" + req.Type
+	attemptsKey := contactAttemptsKeyPrefix + userID + ":" + req.Type
+
+	data, err := s.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, xerr.ErrContactCodeInvalid
+		}
+		log.Error().Err(err).Str("user_id", userID).Msg("读取验证码失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "读取验证码失败", err)
+	}
+	var challenge contactChallenge
+	if err := json.Unmarshal(data, &challenge); err != nil {
+		s.rdb.Del(ctx, key)
+		return nil, xerr.ErrContactCodeInvalid
+	}
+
+	if !passwordgen.VerifySecret(req.Code, challenge.CodeHash) {
+		attempts, incrErr := s.rdb.Incr(ctx, attemptsKey).Result()
+		if incrErr == nil && attempts == 1 {
+			s.rdb.Expire(ctx, attemptsKey, s.cfg.GetVerifyCodeTTL())
+		}
+		if attempts >= contactMaxAttempts {
+			s.rdb.Del(ctx, key, attemptsKey)
+		}
+		log.Warn().Str("user_id", userID).Str("type", req.Type).Int64("attempts", attempts).Msg("联系方式变更验证码错误")
+		return nil, xerr.ErrContactCodeInvalid
+	}
+
+	oldUser, err = s.getSelf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 发送验证码后该地址可能已被他人占用，提交前重新检查
+	if err := s.checkContactAvailable(ctx, oldUser, req.Type, challenge.Value); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.userRepo.UpdateManual(homeContext(ctx, oldUser), oldUser.UserID, map[string]interface{}{
+		req.Type:     challenge.Value,
+		"updated_at": time.Now().UnixMilli(),
+	}); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, xerr.ErrEmailOrPhoneExists
+		}
+		log.Error().Err(err).Str("user_id", oldUser.UserID).Msg("更新联系方式失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "更新联系方式失败", err)
+	}
+	s.rdb.Del(ctx, key, attemptsKey)
+
+	newUser, err = s.getSelf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("user_id", newUser.UserID).Str("type", req.Type).Msg("用户修改联系方式成功")
+	return modelToUserInfo(newUser), nil
+}
+
+// checkContactAvailable 检查新的邮箱或手机号未被其他用户使用
+// 邮箱按主租户的共享邮箱规则检查，手机号全局唯一
+//
+//tenantscope:allow 手机号唯一性需比对所有租户的用户
+func (s *ProfileService) checkContactAvailable(ctx context.Context, user *model.User, contactType, value string) error {
+	if contactType == contactTypeEmail {
+		return s.users.checkEmailAvailable(ctx, user.TenantID, value, user.UserID)
+	}
+
+	users, err := s.users.userRepo.ListByPhonesManual(xcontext.SetPlatformScope(ctx), []string{value})
+	if err != nil {
+		log.Error().Err(err).Msg("检查手机号失败")
+		return xerr.Wrap(xerr.ErrInternal.Code, "检查手机号失败", err)
+	}
+	for _, u := range users {
+		if u.UserID != user.UserID {
+			return xerr.ErrEmailOrPhoneExists
+		}
+	}
+	return nil
+}
+
+// validateContact 校验邮箱或手机号格式
+func validateContact(contactType, value string) error {
+	switch contactType {
+	case contactTypeEmail:
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value || len(value) > 100 {
+			return xerr.New(xerr.ErrInvalidParams.Code, "邮箱格式不正确")
+		}
+	case contactTypePhone:
+		if !isValidPhone(value) {
+			return xerr.New(xerr.ErrInvalidParams.Code, "手机号格式不正确")
+		}
+	default:
+		return xerr.ErrInvalidParams
+	}
+	return nil
+}
+
+// contactValue 用户当前的邮箱或手机号
+func contactValue(user *model.User, contactType string) string {
+	if contactType == contactTypePhone {
+		return user.Phone
+	}
+	return user.Email
+}
+
+// maskContact 脱敏，如 alice@example.com -> a***@example.com，13800138000 -> 138****8000
+func maskContact(contactType, value string) string {
+	if contactType == contactTypeEmail {
+		if at := strings.Index(value, "@"); at > 0 {
+			return value[:1] + "***" + value[at:]
+		}
+		return value
+	}
+	if len(value) < 7 {
+		return value
+	}
+	return value[:3] + "****" + value[len(value)-4:]
+}
@@ -36,7 +36,14 @@ func (s *Service) GetUserByID(ctx context.Context, userID string) (*dto.UserInfo
 		roles = nil
 	}
 
-	return modelToUserInfoWithRoles(user, roles), nil
+	userInfo := modelToUserInfoWithRoles(user, roles)
+	userInfo.Positions, err = s.getUserPositions(ctx, user)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.UserID).Msg("查询用户岗位失败")
+		return nil, xerr.Wrap(xerr.ErrInternal.Code, "查询用户岗位失败", err)
+	}
+
+	return userInfo, nil
 }
 
 // GetProfile 获取当前用户档案（含角色和租户信息）
@@ -48,8 +55,8 @@ func (s *Service) GetProfile(ctx context.Context) (*dto.ProfileResponse, error)
 
 	log.Debug().Str("user_id", userID).Strs("role_codes", roleCodes).Str("tenant_id", tenantID).Msg("[GetProfile] 开始获取用户档案")
 
-	// 获取用户信息
-	user, err := s.userRepo.GetByID(ctx, userID)
+	// 获取用户信息（切换到其他租户的成员使用主租户账号及其在当前租户的部门、岗位）
+	user, err := getTenantUser(ctx, s.userRepo, s.memberRepo, userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			log.Warn().Str("user_id", userID).Msg("用户不存在")
@@ -93,17 +100,35 @@ func (s *Service) GetProfile(ctx context.Context) (*dto.ProfileResponse, error)
 
 	log.Debug().Int("roles_count", len(roles)).Msg("[GetProfile] 角色详情查询成功")
 
-	return &dto.ProfileResponse{
+	resp := &dto.ProfileResponse{
 		User:   modelToUserInfoWithRoles(user, roles),
 		Tenant: tenantconv.ModelToTenantInfo(tenant),
 		Roles:  roleconv.ModelListToRoleInfoList(roles),
-	}, nil
+	}
+
+	// 模拟登录时返回实际操作者，前端据此显示模拟提示横幅
+	if xcontext.IsImpersonating(ctx) {
+		resp.Impersonation = &dto.ImpersonationInfo{
+			Active:           true,
+			ImpersonatorID:   xcontext.GetImpersonatorID(ctx),
+			ImpersonatorName: xcontext.GetImpersonatorName(ctx),
+		}
+	}
+
+	return resp, nil
 }
 
 // ListUsers 获取用户列表
 func (s *Service) ListUsers(ctx context.Context, req *dto.ListUsersRequest) (*dto.ListUsersResponse, error) {
+	// 只有超级管理员可以按租户筛选其他租户的用户
+	tenantID, err := tenantconv.TargetTenantID(ctx, req.TenantID)
+	if err != nil {
+		log.Warn().Str("tenant_id", req.TenantID).Msg("无权查询其他租户的用户")
+		return nil, err
+	}
+
 	// 获取用户列表和总数，支持筛选条件和租户过滤
-	users, total, err := s.userRepo.ListWithFiltersAndTenant(ctx, req.GetOffset(), req.GetLimit(), req.Nickname, req.Status, req.TenantID)
+	users, total, err := s.userRepo.ListWithFiltersAndTenant(ctx, req.GetOffset(), req.GetLimit(), req.Nickname, req.Status, tenantID)
 	if err != nil {
 		log.Error().Err(err).
 			Str("nickname", req.Nickname).
@@ -20,6 +20,7 @@ import (
 type RoleService struct {
 	userRepo     *repository.UserRepo
 	userRoleRepo *repository.UserRoleRepo
+	memberRepo   *repository.TenantMemberRepo
 	roleRepo     *repository.RoleRepo
 	tenantRepo   *repository.TenantRepo
 	recorder     *audit.Recorder
@@ -30,6 +31,7 @@ func NewRoleService(db *gorm.DB, recorder *audit.Recorder) *RoleService {
 	return &RoleService{
 		userRepo:     repository.NewUserRepo(db),
 		userRoleRepo: repository.NewUserRoleRepo(db),
+		memberRepo:   repository.NewTenantMemberRepo(db),
 		roleRepo:     repository.NewRoleRepo(db),
 		tenantRepo:   repository.NewTenantRepo(db),
 		recorder:     recorder,
@@ -57,7 +59,8 @@ func (s *RoleService) AssignRoles(ctx context.Context, userID string, req *dto.A
 		}
 	}()
 
-	user, err = s.userRepo.GetByID(ctx, userID)
+	// 从其他租户加入的成员同样可以在当前租户分配角色
+	user, err = getTenantUser(ctx, s.userRepo, s.memberRepo, userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			log.Warn().Str("user_id", userID).Msg("用户不存在")
@@ -102,7 +105,7 @@ func (s *RoleService) AssignRoles(ctx context.Context, userID string, req *dto.A
 
 // GetUserRoles 获取用户的角色列表
 func (s *RoleService) GetUserRoles(ctx context.Context, userID string) (*dto.UserRolesResponse, error) {
-	user, err := s.userRepo.GetByID(ctx, userID)
+	user, err := getTenantUser(ctx, s.userRepo, s.memberRepo, userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			log.Warn().Str("user_id", userID).Msg("用户不存在")
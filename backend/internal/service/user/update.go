@@ -3,6 +3,7 @@ package user
 import (
 	"admin/internal/dal/model"
 	"admin/internal/dto"
+	tenantsvc "admin/internal/service/tenant"
 	"admin/pkg/audit"
 	"admin/pkg/constants"
 	"admin/pkg/xerr"
@@ -74,6 +75,12 @@ func (s *Service) UpdateUser(ctx context.Context, userID string, req *dto.Update
 	// 处理租户更新
 	var newTenantID string
 	if req.TenantID != "" && req.TenantID != oldUser.TenantID {
+		// 只有超级管理员可以把用户迁移到其他租户
+		if _, err := tenantsvc.TargetTenantID(ctx, req.TenantID); err != nil {
+			log.Warn().Str("user_id", userID).Str("target_tenant_id", req.TenantID).Msg("无权迁移用户到其他租户")
+			return nil, err
+		}
+
 		// 验证目标租户是否存在
 		targetTenant, err := s.tenantRepo.GetByIDManual(ctx, req.TenantID)
 		if err != nil {
@@ -98,6 +105,17 @@ func (s *Service) UpdateUser(ctx context.Context, userID string, req *dto.Update
 		newTenantID = oldUser.TenantID
 	}
 
+	// 邮箱或租户变化时检查邮箱是否可用
+	email := oldUser.Email
+	if req.Email != "" {
+		email = req.Email
+	}
+	if email != oldUser.Email || newTenantID != oldUser.TenantID {
+		if err := s.checkEmailAvailable(ctx, newTenantID, email, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	updates["updated_at"] = time.Now().UnixMilli()
 
 	// 更新用户
@@ -2,6 +2,7 @@ package user
 
 import (
 	"admin/internal/repository"
+	"admin/internal/service/quota"
 	"admin/pkg/audit"
 	"admin/pkg/utils/rsapwd"
 
@@ -10,11 +11,17 @@ import (
 
 // Service 用户服务
 type Service struct {
+	db              *gorm.DB
 	userRepo        *repository.UserRepo
 	userRoleRepo    *repository.UserRoleRepo
+	memberRepo      *repository.TenantMemberRepo
 	userRoleService *RoleService
 	roleRepo        *repository.RoleRepo
+	deptRepo        *repository.DepartmentRepo
+	positionRepo    *repository.PositionRepo
+	userPosRepo     *repository.UserPositionRepo
 	tenantRepo      *repository.TenantRepo
+	quotaSvc        *quota.Service
 	recorder        *audit.Recorder
 	rsaCipher       *rsapwd.RSACipher
 }
@@ -23,11 +30,17 @@ type Service struct {
 func NewService(db *gorm.DB, recorder *audit.Recorder, rsaCipher *rsapwd.RSACipher) *Service {
 	roleSvc := NewRoleService(db, recorder)
 	return &Service{
+		db:              db,
 		userRepo:        repository.NewUserRepo(db),
 		userRoleRepo:    repository.NewUserRoleRepo(db),
+		memberRepo:      repository.NewTenantMemberRepo(db),
 		userRoleService: roleSvc,
 		roleRepo:        repository.NewRoleRepo(db),
+		deptRepo:        repository.NewDepartmentRepo(db),
+		positionRepo:    repository.NewPositionRepo(db),
+		userPosRepo:     repository.NewUserPositionRepo(db),
 		tenantRepo:      repository.NewTenantRepo(db),
+		quotaSvc:        quota.NewService(db),
 		recorder:        recorder,
 		rsaCipher:       rsaCipher,
 	}
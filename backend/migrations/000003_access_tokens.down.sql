@@ -0,0 +1,4 @@
+-- 回滚访问令牌
+
+ALTER TABLE operation_logs DROP COLUMN IF EXISTS access_key_id;
+DROP TABLE IF EXISTS access_tokens;
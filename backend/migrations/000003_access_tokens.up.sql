@@ -0,0 +1,26 @@
+-- =====================================================
+-- 访问令牌：个人访问令牌（PAT）与租户 API Key
+-- 供 CI 脚本、第三方集成等机器调用 /api/v1/* 使用
+-- =====================================================
+
+-- 1. 访问令牌表
+CREATE TABLE IF NOT EXISTS access_tokens (
+    token_id     VARCHAR(20)  PRIMARY KEY,
+    tenant_id    VARCHAR(20)  NOT NULL,
+    user_id      VARCHAR(20)  NOT NULL,                -- 所有者（PAT 为本人，API Key 为创建人）
+    token_type   VARCHAR(20)  NOT NULL,                -- PAT:个人访问令牌, API_KEY:租户 API Key
+    name         VARCHAR(100) NOT NULL,                -- 令牌名称
+    secret_hash  VARCHAR(64)  NOT NULL,                -- 密钥 SHA256 哈希（明文仅创建时返回一次）
+    scopes       TEXT         NOT NULL DEFAULT '',     -- 授权范围(JSON 数组，所有者 API 权限的子集)
+    expires_at   BIGINT       NOT NULL DEFAULT 0,      -- 过期时间戳(毫秒，0 表示永不过期)
+    last_used_at BIGINT       NOT NULL DEFAULT 0,      -- 最后使用时间戳(毫秒)
+    last_used_ip VARCHAR(50)  NOT NULL DEFAULT '',     -- 最后使用 IP
+    created_at   BIGINT       NOT NULL DEFAULT 0,
+    updated_at   BIGINT       NOT NULL DEFAULT 0,
+    deleted_at   BIGINT       DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_access_tokens_tenant_type ON access_tokens(tenant_id, token_type, deleted_at);
+CREATE INDEX IF NOT EXISTS idx_access_tokens_user ON access_tokens(user_id, deleted_at);
+
+-- 2. 操作日志记录调用方使用的访问令牌ID
+ALTER TABLE operation_logs ADD COLUMN IF NOT EXISTS access_key_id VARCHAR(20) NOT NULL DEFAULT '';
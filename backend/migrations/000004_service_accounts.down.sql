@@ -0,0 +1,4 @@
+-- 回滚服务账号
+
+DROP TABLE IF EXISTS service_account_credentials;
+ALTER TABLE users DROP COLUMN IF EXISTS is_service_account;
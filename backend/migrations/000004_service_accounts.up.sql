@@ -0,0 +1,21 @@
+-- =====================================================
+-- 服务账号：非人类账号，通过 OAuth2 client_credentials 换取访问令牌
+-- =====================================================
+
+-- 1. users 表添加服务账号标记（1:是, 2:否）
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_service_account SMALLINT NOT NULL DEFAULT 2;
+
+-- 2. 服务账号客户端凭证表（与 users 一对一）
+CREATE TABLE IF NOT EXISTS service_account_credentials (
+    user_id           VARCHAR(20) PRIMARY KEY,
+    tenant_id         VARCHAR(20) NOT NULL,
+    client_id         VARCHAR(64) NOT NULL,               -- 客户端ID（全局唯一）
+    secret_hash       VARCHAR(64) NOT NULL,               -- 客户端密钥 SHA256 哈希（明文仅创建/轮换时返回一次）
+    secret_rotated_at BIGINT      NOT NULL DEFAULT 0,     -- 最近一次轮换时间戳(毫秒)
+    last_used_at      BIGINT      NOT NULL DEFAULT 0,     -- 最后换取令牌时间戳(毫秒)
+    created_at        BIGINT      NOT NULL DEFAULT 0,
+    updated_at        BIGINT      NOT NULL DEFAULT 0,
+    deleted_at        BIGINT      DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_service_account_credentials_client ON service_account_credentials(client_id) WHERE deleted_at = 0;
+CREATE INDEX IF NOT EXISTS idx_service_account_credentials_tenant ON service_account_credentials(tenant_id, deleted_at);
@@ -0,0 +1,5 @@
+-- 回滚模拟登录
+
+DROP INDEX IF EXISTS idx_operation_logs_impersonator;
+ALTER TABLE operation_logs DROP COLUMN IF EXISTS impersonator_name;
+ALTER TABLE operation_logs DROP COLUMN IF EXISTS impersonator_id;
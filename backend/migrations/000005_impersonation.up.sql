@@ -0,0 +1,8 @@
+-- =====================================================
+-- 模拟登录：操作日志同时记录目标用户与实际操作者
+-- user_id/user_name 为被模拟的用户，impersonator_* 为发起模拟的管理员
+-- =====================================================
+
+ALTER TABLE operation_logs ADD COLUMN IF NOT EXISTS impersonator_id VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE operation_logs ADD COLUMN IF NOT EXISTS impersonator_name VARCHAR(100) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_operation_logs_impersonator ON operation_logs(impersonator_id) WHERE impersonator_id <> '';
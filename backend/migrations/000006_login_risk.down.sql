@@ -0,0 +1,12 @@
+-- 回滚登录风险识别
+
+ALTER TABLE tenants DROP COLUMN IF EXISTS login_risk_policy;
+
+DROP INDEX IF EXISTS idx_login_logs_user_status_time;
+ALTER TABLE login_logs DROP COLUMN IF EXISTS risk_action;
+ALTER TABLE login_logs DROP COLUMN IF EXISTS risk_flags;
+ALTER TABLE login_logs DROP COLUMN IF EXISTS risk_score;
+ALTER TABLE login_logs DROP COLUMN IF EXISTS longitude;
+ALTER TABLE login_logs DROP COLUMN IF EXISTS latitude;
+ALTER TABLE login_logs DROP COLUMN IF EXISTS country_code;
+ALTER TABLE login_logs DROP COLUMN IF EXISTS device_fingerprint;
@@ -0,0 +1,17 @@
+-- =====================================================
+-- 登录风险识别：新设备 / 新国家 / 不可能的旅行
+-- login_logs 记录设备指纹、地理坐标与风险评分，tenants 记录风险处置策略
+-- =====================================================
+
+-- 1. 登录日志风险字段
+ALTER TABLE login_logs ADD COLUMN IF NOT EXISTS device_fingerprint VARCHAR(64) NOT NULL DEFAULT '';   -- 设备指纹（设备类型+平台+系统+浏览器主版本）
+ALTER TABLE login_logs ADD COLUMN IF NOT EXISTS country_code VARCHAR(8) NOT NULL DEFAULT '';          -- 登录国家代码（GeoIP 解析）
+ALTER TABLE login_logs ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION NOT NULL DEFAULT 0;         -- 纬度
+ALTER TABLE login_logs ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION NOT NULL DEFAULT 0;        -- 经度
+ALTER TABLE login_logs ADD COLUMN IF NOT EXISTS risk_score SMALLINT NOT NULL DEFAULT 0;               -- 风险评分(0-100)
+ALTER TABLE login_logs ADD COLUMN IF NOT EXISTS risk_flags VARCHAR(255) NOT NULL DEFAULT '';          -- 风险标记，逗号分隔(NEW_DEVICE,NEW_COUNTRY,IMPOSSIBLE_TRAVEL)
+ALTER TABLE login_logs ADD COLUMN IF NOT EXISTS risk_action VARCHAR(20) NOT NULL DEFAULT '';          -- 处置动作(ALLOW/MFA/BLOCK)
+CREATE INDEX IF NOT EXISTS idx_login_logs_user_status_time ON login_logs(user_id, status, created_at);
+
+-- 2. 租户登录风险策略（ALLOW:放行并通知, MFA:二次验证, BLOCK:拒绝登录）
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS login_risk_policy VARCHAR(20) NOT NULL DEFAULT 'ALLOW';
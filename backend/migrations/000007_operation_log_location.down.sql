@@ -0,0 +1,3 @@
+-- 回滚操作日志地理位置
+
+ALTER TABLE operation_logs DROP COLUMN IF EXISTS location;
@@ -0,0 +1,6 @@
+-- =====================================================
+-- IP 地理位置：操作日志增加 IP 解析出的地理位置
+-- 登录日志沿用已有的 login_location 字段
+-- =====================================================
+
+ALTER TABLE operation_logs ADD COLUMN IF NOT EXISTS location VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- 回滚租户开通流水线
+
+ALTER TABLE tenants DROP COLUMN IF EXISTS provision_error;
+ALTER TABLE tenants DROP COLUMN IF EXISTS provision_status;
@@ -0,0 +1,7 @@
+-- =====================================================
+-- 租户开通流水线：记录租户初始化进度，失败后可重试续跑
+-- 已有租户视为已完成初始化
+-- =====================================================
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS provision_status VARCHAR(20) NOT NULL DEFAULT 'READY';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS provision_error TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- 回滚角色模板
+
+DROP INDEX IF EXISTS idx_roles_template;
+ALTER TABLE roles DROP COLUMN IF EXISTS template_id;
+DROP TABLE IF EXISTS role_template_permissions;
+DROP TABLE IF EXISTS role_templates;
@@ -0,0 +1,36 @@
+-- =====================================================
+-- 角色模板：平台维护的全局权限集合（菜单/按钮/接口）
+-- 租户可由模板实例化角色，模板更新后可同步到所有派生角色
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS role_templates (
+    template_id VARCHAR(20) PRIMARY KEY,
+    template_code VARCHAR(50) NOT NULL,            -- 模板编码（全局唯一，实例化时默认作为角色编码）
+    name VARCHAR(100) NOT NULL,                    -- 模板名称
+    description TEXT NOT NULL DEFAULT '',          -- 模板描述
+    status SMALLINT NOT NULL DEFAULT 1,            -- 状态(1:启用, 2:禁用)
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0,
+    deleted_at BIGINT DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_role_templates_code ON role_templates(template_code) WHERE deleted_at = 0;
+
+COMMENT ON TABLE role_templates IS '角色模板表(全局，平台维护)';
+COMMENT ON COLUMN role_templates.template_code IS '模板编码(全局唯一)';
+COMMENT ON COLUMN role_templates.status IS '状态(1:启用, 2:禁用)';
+
+CREATE TABLE IF NOT EXISTS role_template_permissions (
+    template_id VARCHAR(20) NOT NULL,
+    permission_id VARCHAR(20) NOT NULL,
+    created_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (template_id, permission_id)
+);
+
+COMMENT ON TABLE role_template_permissions IS '角色模板权限关联表';
+
+-- 角色记录派生自哪个模板，用于模板变更同步
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS template_id VARCHAR(20) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_roles_template ON roles(template_id) WHERE template_id <> '';
+
+COMMENT ON COLUMN roles.template_id IS '来源角色模板ID(空表示非模板派生)';
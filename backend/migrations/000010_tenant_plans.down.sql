@@ -0,0 +1,6 @@
+-- 回滚租户套餐
+
+DROP INDEX IF EXISTS idx_tenants_plan;
+ALTER TABLE tenants DROP COLUMN IF EXISTS plan_id;
+DROP TABLE IF EXISTS plan_permissions;
+DROP TABLE IF EXISTS plans;
@@ -0,0 +1,36 @@
+-- =====================================================
+-- 租户套餐：定义租户可使用的菜单与权限点上限
+-- 租户未绑定套餐时不做限制（兼容已有租户）
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS plans (
+    plan_id VARCHAR(20) PRIMARY KEY,
+    plan_code VARCHAR(50) NOT NULL,                -- 套餐编码（全局唯一）
+    name VARCHAR(100) NOT NULL,                    -- 套餐名称
+    description TEXT NOT NULL DEFAULT '',          -- 套餐描述
+    status SMALLINT NOT NULL DEFAULT 1,            -- 状态(1:启用, 2:禁用)
+    sort INT NOT NULL DEFAULT 0,                   -- 排序
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0,
+    deleted_at BIGINT DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_plans_code ON plans(plan_code) WHERE deleted_at = 0;
+
+COMMENT ON TABLE plans IS '租户套餐表(全局，平台维护)';
+COMMENT ON COLUMN plans.plan_code IS '套餐编码(全局唯一)';
+COMMENT ON COLUMN plans.status IS '状态(1:启用, 2:禁用)';
+
+CREATE TABLE IF NOT EXISTS plan_permissions (
+    plan_id VARCHAR(20) NOT NULL,
+    permission_id VARCHAR(20) NOT NULL,
+    created_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (plan_id, permission_id)
+);
+
+COMMENT ON TABLE plan_permissions IS '套餐权限关联表(套餐内可授予的菜单/按钮/接口权限)';
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS plan_id VARCHAR(20) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_tenants_plan ON tenants(plan_id) WHERE plan_id <> '';
+
+COMMENT ON COLUMN tenants.plan_id IS '套餐ID(空表示不限制)';
@@ -0,0 +1,8 @@
+-- 回滚租户资源配额
+
+ALTER TABLE tenants DROP COLUMN IF EXISTS storage_used;
+DROP TABLE IF EXISTS tenant_quotas;
+ALTER TABLE plans DROP COLUMN IF EXISTS max_storage_mb;
+ALTER TABLE plans DROP COLUMN IF EXISTS max_departments;
+ALTER TABLE plans DROP COLUMN IF EXISTS max_roles;
+ALTER TABLE plans DROP COLUMN IF EXISTS max_users;
@@ -0,0 +1,33 @@
+-- =====================================================
+-- 租户资源配额：用户数、角色数、部门数、存储空间
+-- 配额默认取自套餐，可按租户单独覆盖；0 表示不限制
+-- =====================================================
+
+ALTER TABLE plans ADD COLUMN IF NOT EXISTS max_users INT NOT NULL DEFAULT 0;
+ALTER TABLE plans ADD COLUMN IF NOT EXISTS max_roles INT NOT NULL DEFAULT 0;
+ALTER TABLE plans ADD COLUMN IF NOT EXISTS max_departments INT NOT NULL DEFAULT 0;
+ALTER TABLE plans ADD COLUMN IF NOT EXISTS max_storage_mb BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN plans.max_users IS '最大用户数(0:不限制)';
+COMMENT ON COLUMN plans.max_roles IS '最大角色数(0:不限制)';
+COMMENT ON COLUMN plans.max_departments IS '最大部门数(0:不限制)';
+COMMENT ON COLUMN plans.max_storage_mb IS '最大存储空间MB(0:不限制)';
+
+-- 租户单独覆盖的配额，存在记录时优先于套餐配额
+CREATE TABLE IF NOT EXISTS tenant_quotas (
+    tenant_id VARCHAR(20) NOT NULL,
+    resource VARCHAR(30) NOT NULL,                 -- 资源类型(users/roles/departments/storage)
+    quota_limit BIGINT NOT NULL DEFAULT 0,         -- 配额上限(0:不限制，storage 单位为MB)
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, resource)
+);
+
+COMMENT ON TABLE tenant_quotas IS '租户配额覆盖表';
+COMMENT ON COLUMN tenant_quotas.resource IS '资源类型(users/roles/departments/storage)';
+COMMENT ON COLUMN tenant_quotas.quota_limit IS '配额上限(0:不限制，storage 单位为MB)';
+
+-- 已用存储空间由文件上传时累加
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS storage_used BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN tenants.storage_used IS '已用存储空间(字节)';
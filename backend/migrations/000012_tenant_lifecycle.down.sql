@@ -0,0 +1,7 @@
+-- 回滚租户订阅生命周期
+
+DROP INDEX IF EXISTS idx_tenants_lifecycle;
+ALTER TABLE tenants DROP COLUMN IF EXISTS contact_email;
+ALTER TABLE tenants DROP COLUMN IF EXISTS expiry_reminded_at;
+ALTER TABLE tenants DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE tenants DROP COLUMN IF EXISTS lifecycle_status;
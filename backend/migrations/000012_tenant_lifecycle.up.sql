@@ -0,0 +1,17 @@
+-- =====================================================
+-- 租户订阅生命周期：试用、正常、宽限期、已过期、已归档
+-- 与 status(启用/冻结) 相互独立，status 为人工开关，lifecycle_status 随到期时间流转
+-- =====================================================
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS lifecycle_status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS expires_at BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS expiry_reminded_at BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS contact_email VARCHAR(100) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN tenants.lifecycle_status IS '生命周期状态(TRIAL:试用 ACTIVE:正常 GRACE:宽限期 EXPIRED:已过期 ARCHIVED:已归档)';
+COMMENT ON COLUMN tenants.expires_at IS '到期时间(毫秒时间戳，0:永不过期)';
+COMMENT ON COLUMN tenants.expiry_reminded_at IS '最近一次发送到期提醒的时间(毫秒时间戳)';
+COMMENT ON COLUMN tenants.contact_email IS '联系人邮箱';
+
+-- 定时任务按生命周期状态和到期时间扫描
+CREATE INDEX IF NOT EXISTS idx_tenants_lifecycle ON tenants(lifecycle_status, expires_at) WHERE deleted_at = 0;
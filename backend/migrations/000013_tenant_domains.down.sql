@@ -0,0 +1,7 @@
+-- 回滚租户识别
+-- 注意：已存在跨租户重复邮箱时无法恢复全局唯一约束，需先清理重复数据
+
+DROP INDEX IF EXISTS uk_users_tenant_email;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_users_email ON users(email) WHERE deleted_at = 0;
+ALTER TABLE tenants DROP COLUMN IF EXISTS allow_shared_email;
+DROP TABLE IF EXISTS tenant_domains;
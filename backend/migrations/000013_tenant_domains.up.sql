@@ -0,0 +1,32 @@
+-- =====================================================
+-- 租户识别：子域名、自定义域名、X-Tenant-Code 请求头
+-- 登录按识别出的租户查找用户，开启共享邮箱的租户间允许使用相同邮箱
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS tenant_domains (
+    domain_id VARCHAR(20) PRIMARY KEY,
+    tenant_id VARCHAR(20) NOT NULL,
+    domain VARCHAR(255) NOT NULL,                  -- 自定义域名（小写，不含端口）
+    verify_token VARCHAR(64) NOT NULL,             -- DNS TXT 验证令牌
+    verified_at BIGINT NOT NULL DEFAULT 0,         -- 验证通过时间(0:未验证)
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0,
+    deleted_at BIGINT DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_tenant_domains_domain ON tenant_domains(domain) WHERE deleted_at = 0;
+CREATE INDEX IF NOT EXISTS idx_tenant_domains_tenant ON tenant_domains(tenant_id);
+
+COMMENT ON TABLE tenant_domains IS '租户自定义域名表';
+COMMENT ON COLUMN tenant_domains.domain IS '自定义域名(全局唯一)';
+COMMENT ON COLUMN tenant_domains.verify_token IS 'DNS TXT 验证令牌';
+COMMENT ON COLUMN tenant_domains.verified_at IS '验证通过时间(0:未验证)';
+
+-- 共享邮箱：开启后该租户的用户邮箱可与其他同样开启的租户重复
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS allow_shared_email SMALLINT NOT NULL DEFAULT 2;
+
+COMMENT ON COLUMN tenants.allow_shared_email IS '是否允许与其他租户共享邮箱(1:是, 2:否)';
+
+-- 邮箱唯一约束由全局唯一改为租户内唯一，跨租户唯一性由应用层按租户设置校验
+DROP INDEX IF EXISTS uk_users_email;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_users_tenant_email ON users(tenant_id, email) WHERE deleted_at = 0;
@@ -0,0 +1,3 @@
+-- 回滚租户彻底清除
+
+DROP TABLE IF EXISTS tenant_purges;
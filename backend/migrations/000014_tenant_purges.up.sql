@@ -0,0 +1,32 @@
+-- =====================================================
+-- 租户彻底清除：软删除的租户在保留期结束后由定时任务分批物理删除全部数据
+-- 清除开始前可恢复租户，清除过程记录进度和各表删除行数
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS tenant_purges (
+    purge_id VARCHAR(20) PRIMARY KEY,
+    tenant_id VARCHAR(20) NOT NULL,
+    tenant_code VARCHAR(50) NOT NULL DEFAULT '',
+    tenant_name VARCHAR(200) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED',  -- SCHEDULED/RUNNING/FAILED/COMPLETED/CANCELED
+    purge_after BIGINT NOT NULL DEFAULT 0,             -- 保留期结束时间，之后才会清除
+    current_table VARCHAR(50) NOT NULL DEFAULT '',     -- 正在清除的表
+    deleted_rows BIGINT NOT NULL DEFAULT 0,            -- 已删除总行数
+    report TEXT NOT NULL DEFAULT '',                   -- 各表删除行数(JSON)
+    error_message TEXT NOT NULL DEFAULT '',
+    started_at BIGINT NOT NULL DEFAULT 0,
+    finished_at BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0
+);
+
+-- 同一租户同时只有一条未结束的清除记录
+CREATE UNIQUE INDEX IF NOT EXISTS uk_tenant_purges_tenant_active ON tenant_purges(tenant_id) WHERE status IN ('SCHEDULED', 'RUNNING', 'FAILED');
+CREATE INDEX IF NOT EXISTS idx_tenant_purges_status ON tenant_purges(status, purge_after);
+
+COMMENT ON TABLE tenant_purges IS '租户彻底清除记录表';
+COMMENT ON COLUMN tenant_purges.status IS '状态(SCHEDULED:等待保留期结束 RUNNING:清除中 FAILED:失败待重试 COMPLETED:已完成 CANCELED:租户已恢复)';
+COMMENT ON COLUMN tenant_purges.purge_after IS '保留期结束时间(毫秒时间戳)';
+COMMENT ON COLUMN tenant_purges.current_table IS '正在清除的表';
+COMMENT ON COLUMN tenant_purges.deleted_rows IS '已删除总行数';
+COMMENT ON COLUMN tenant_purges.report IS '各表删除行数(JSON)';
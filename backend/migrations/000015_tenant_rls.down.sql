@@ -0,0 +1,36 @@
+-- 回滚行级安全
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'roles', 'user_roles', 'role_permissions', 'departments', 'positions',
+        'dict_types', 'dict_items', 'login_logs', 'operation_logs', 'access_tokens',
+        'service_account_credentials', 'tenant_quotas', 'tenant_domains', 'user_positions'
+    ] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_shared_read ON %I', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+    END LOOP;
+END
+$$;
+
+DROP FUNCTION IF EXISTS app_default_tenant();
+DROP FUNCTION IF EXISTS app_tenant_visible(VARCHAR);
+DROP FUNCTION IF EXISTS app_current_tenant();
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'admin_platform') THEN
+        ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE ALL ON TABLES FROM admin_platform;
+        ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE ALL ON SEQUENCES FROM admin_platform;
+        REVOKE ALL ON ALL TABLES IN SCHEMA public FROM admin_platform;
+        REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM admin_platform;
+        DROP ROLE admin_platform;
+    END IF;
+EXCEPTION WHEN insufficient_privilege OR dependent_objects_still_exist THEN
+    RAISE NOTICE '无法删除 admin_platform 角色，需由超级用户处理';
+END
+$$;
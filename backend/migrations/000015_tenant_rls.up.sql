@@ -0,0 +1,74 @@
+-- =====================================================
+-- 行级安全（RLS）：租户隔离的数据库兜底
+-- 应用在每个事务内设置 app.tenant_id，策略只放行该租户的数据；未设置时（迁移、定时任务等平台级连接）不做限制
+-- 平台级操作切换到 admin_platform 角色（BYPASSRLS）绕过策略
+-- 注意：超级用户始终绕过 RLS，开启 database.rls 时应用需使用非超级用户连接数据库
+-- =====================================================
+
+-- 当前事务的租户ID，未设置时返回 NULL
+CREATE OR REPLACE FUNCTION app_current_tenant() RETURNS VARCHAR
+    LANGUAGE sql STABLE
+AS $$ SELECT NULLIF(current_setting('app.tenant_id', true), '') $$;
+
+-- 行是否对当前事务的租户可见
+CREATE OR REPLACE FUNCTION app_tenant_visible(row_tenant_id VARCHAR) RETURNS BOOLEAN
+    LANGUAGE sql STABLE
+AS $$ SELECT app_current_tenant() IS NULL OR row_tenant_id = app_current_tenant() $$;
+
+-- default 租户ID：系统角色和系统字典存放在 default 租户，所有租户可读
+CREATE OR REPLACE FUNCTION app_default_tenant() RETURNS VARCHAR
+    LANGUAGE sql STABLE
+AS $$ SELECT tenant_id FROM tenants WHERE tenant_code = 'default' AND deleted_at = 0 LIMIT 1 $$;
+
+COMMENT ON FUNCTION app_current_tenant() IS 'RLS: 当前事务的租户ID(app.tenant_id)';
+COMMENT ON FUNCTION app_tenant_visible(VARCHAR) IS 'RLS: 行是否对当前租户可见';
+COMMENT ON FUNCTION app_default_tenant() IS 'RLS: default 租户ID';
+
+-- 带 tenant_id 的租户数据表：租户只能读写本租户的数据
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'roles', 'user_roles', 'role_permissions', 'departments', 'positions',
+        'dict_types', 'dict_items', 'login_logs', 'operation_logs', 'access_tokens',
+        'service_account_credentials', 'tenant_quotas', 'tenant_domains'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        -- 应用通常以表所有者连接，需强制所有者也受策略约束
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id))', t);
+    END LOOP;
+
+    -- default 租户的系统角色及其授权、系统字典对所有租户只读
+    FOREACH t IN ARRAY ARRAY['roles', 'role_permissions', 'dict_types', 'dict_items'] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_shared_read ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_shared_read ON %I FOR SELECT USING (tenant_id = app_default_tenant())', t);
+    END LOOP;
+END
+$$;
+
+-- user_positions 没有 tenant_id，通过 users 的策略间接隔离
+ALTER TABLE user_positions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_positions FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON user_positions;
+CREATE POLICY tenant_isolation ON user_positions
+    USING (app_current_tenant() IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.user_id = user_positions.user_id))
+    WITH CHECK (app_current_tenant() IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.user_id = user_positions.user_id));
+
+-- 平台级旁路角色：应用连接用户可通过 SET ROLE 切换，创建需要超级用户权限
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'admin_platform') THEN
+        CREATE ROLE admin_platform NOLOGIN BYPASSRLS;
+    END IF;
+    EXECUTE format('GRANT admin_platform TO %I', current_user);
+    GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO admin_platform;
+    GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO admin_platform;
+    ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO admin_platform;
+    ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO admin_platform;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE '当前用户无权创建 admin_platform 角色，开启 database.rls 前需由超级用户执行本段';
+END
+$$;
@@ -0,0 +1,3 @@
+-- 回滚租户设置
+
+DROP TABLE IF EXISTS tenant_settings;
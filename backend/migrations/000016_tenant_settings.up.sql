@@ -0,0 +1,32 @@
+-- =====================================================
+-- 租户设置：品牌、登录页、语言、会话等租户级配置
+-- 设置项及校验规则由应用内的设置注册表定义；default 租户的记录为平台默认值，其他租户的记录为覆盖值
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS tenant_settings (
+    setting_id VARCHAR(20) PRIMARY KEY,
+    tenant_id VARCHAR(20) NOT NULL,
+    setting_key VARCHAR(100) NOT NULL,             -- 设置项键（如 branding.logo_url）
+    setting_value TEXT NOT NULL,                   -- JSON 编码的设置值
+    version INTEGER NOT NULL DEFAULT 1,            -- 版本号，每次修改递增，用于并发控制
+    updated_by VARCHAR(20) NOT NULL DEFAULT '',    -- 最后修改人
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0,
+    deleted_at BIGINT DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_tenant_settings_key ON tenant_settings(tenant_id, setting_key) WHERE deleted_at = 0;
+
+COMMENT ON TABLE tenant_settings IS '租户设置表';
+COMMENT ON COLUMN tenant_settings.setting_key IS '设置项键';
+COMMENT ON COLUMN tenant_settings.setting_value IS 'JSON 编码的设置值';
+COMMENT ON COLUMN tenant_settings.version IS '版本号(每次修改递增)';
+COMMENT ON COLUMN tenant_settings.updated_by IS '最后修改人';
+
+-- 行级安全：与其他租户数据表一致，default 租户的平台默认值对所有租户只读
+ALTER TABLE tenant_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tenant_settings FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_settings;
+CREATE POLICY tenant_isolation ON tenant_settings USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id));
+DROP POLICY IF EXISTS tenant_shared_read ON tenant_settings;
+CREATE POLICY tenant_shared_read ON tenant_settings FOR SELECT USING (tenant_id = app_default_tenant());
@@ -0,0 +1,3 @@
+-- 回滚租户邀请
+
+DROP TABLE IF EXISTS invitations;
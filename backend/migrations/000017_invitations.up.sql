@@ -0,0 +1,38 @@
+-- =====================================================
+-- 租户邀请：管理员按邮箱邀请成员，被邀请人通过邮件中的签名链接自助加入
+-- 新用户设置密码后创建账号；已在其他租户有账号的用户直接获得本租户的角色授权，通过切换租户访问
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS invitations (
+    invitation_id VARCHAR(20) PRIMARY KEY,
+    tenant_id VARCHAR(20) NOT NULL,
+    email VARCHAR(100) NOT NULL,                   -- 被邀请邮箱（小写）
+    role_ids TEXT NOT NULL DEFAULT '',             -- 预分配角色ID(JSON 数组)
+    department_id VARCHAR(20) NOT NULL DEFAULT '', -- 预分配部门（仅新用户生效）
+    position_id VARCHAR(20) NOT NULL DEFAULT '',   -- 预分配岗位（仅新用户生效）
+    nonce VARCHAR(64) NOT NULL,                    -- 链接签名随机数，重新发送时更换使旧链接失效
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING:待接受, ACCEPTED:已接受, REVOKED:已撤销
+    invited_by VARCHAR(20) NOT NULL DEFAULT '',    -- 邀请人
+    accepted_user_id VARCHAR(20) NOT NULL DEFAULT '', -- 接受邀请的用户
+    expires_at BIGINT NOT NULL DEFAULT 0,          -- 过期时间(毫秒)
+    accepted_at BIGINT NOT NULL DEFAULT 0,         -- 接受时间(毫秒)
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0,
+    deleted_at BIGINT DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitations_tenant_status ON invitations(tenant_id, status, deleted_at);
+CREATE INDEX IF NOT EXISTS idx_invitations_email ON invitations(email);
+
+COMMENT ON TABLE invitations IS '租户邀请表';
+COMMENT ON COLUMN invitations.email IS '被邀请邮箱';
+COMMENT ON COLUMN invitations.role_ids IS '预分配角色ID(JSON 数组)';
+COMMENT ON COLUMN invitations.nonce IS '链接签名随机数';
+COMMENT ON COLUMN invitations.status IS '状态(PENDING:待接受, ACCEPTED:已接受, REVOKED:已撤销)';
+COMMENT ON COLUMN invitations.expires_at IS '过期时间(毫秒)';
+
+-- 行级安全：与其他租户数据表一致
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON invitations;
+CREATE POLICY tenant_isolation ON invitations USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id));
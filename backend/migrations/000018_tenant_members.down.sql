@@ -0,0 +1,3 @@
+-- 回滚租户成员
+
+DROP TABLE IF EXISTS tenant_members;
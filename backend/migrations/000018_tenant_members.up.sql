@@ -0,0 +1,41 @@
+-- =====================================================
+-- 租户成员：同一用户身份加入多个租户
+-- 用户账号（users）属于注册所在的主租户，部门、岗位、状态记录在 users 上；
+-- 在其他租户的成员身份记录在 tenant_members，每个租户单独维护状态、部门和岗位，角色仍记录在 user_roles
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS tenant_members (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(20) NOT NULL,                 -- 加入的租户
+    user_id VARCHAR(20) NOT NULL,                   -- 用户（主租户中的账号）
+    status SMALLINT NOT NULL DEFAULT 1,             -- 1:正常, 2:禁用（禁用后不能切换到该租户）
+    department_id VARCHAR(20) NOT NULL DEFAULT '',  -- 在该租户的部门
+    position_id VARCHAR(20) NOT NULL DEFAULT '',    -- 在该租户的岗位
+    invited_by VARCHAR(20) NOT NULL DEFAULT '',     -- 邀请人
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0,
+    UNIQUE(tenant_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_members_user ON tenant_members(user_id);
+
+COMMENT ON TABLE tenant_members IS '租户成员表（用户在主租户以外加入的租户）';
+COMMENT ON COLUMN tenant_members.user_id IS '用户ID（主租户中的账号）';
+COMMENT ON COLUMN tenant_members.status IS '状态(1:正常, 2:禁用)';
+COMMENT ON COLUMN tenant_members.department_id IS '在该租户的部门';
+COMMENT ON COLUMN tenant_members.position_id IS '在该租户的岗位';
+
+-- 已有的跨租户角色授权转为成员身份
+INSERT INTO tenant_members (tenant_id, user_id, status, created_at, updated_at)
+SELECT DISTINCT ur.tenant_id, ur.user_id, 1,
+       (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT, (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+FROM user_roles ur
+JOIN users u ON u.user_id = ur.user_id AND u.deleted_at = 0
+WHERE ur.tenant_id <> u.tenant_id
+ON CONFLICT (tenant_id, user_id) DO NOTHING;
+
+-- 行级安全：与其他租户数据表一致
+ALTER TABLE tenant_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tenant_members FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_members;
+CREATE POLICY tenant_isolation ON tenant_members USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id));
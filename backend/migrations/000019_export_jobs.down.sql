@@ -0,0 +1,3 @@
+-- 回滚导出任务
+
+DROP TABLE IF EXISTS export_jobs;
@@ -0,0 +1,39 @@
+-- =====================================================
+-- 导出任务：列表导出超过行数阈值时转为后台任务，完成后通过下载链接获取文件
+-- 文件保留一段时间后由定时任务连同任务记录一起清理
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS export_jobs (
+    export_id VARCHAR(20) PRIMARY KEY,
+    tenant_id VARCHAR(20) NOT NULL,
+    user_id VARCHAR(20) NOT NULL,                  -- 发起导出的用户，仅本人可下载
+    resource VARCHAR(50) NOT NULL,                 -- 导出资源(user/role/department/position/login_log/operation_log)
+    format VARCHAR(10) NOT NULL,                   -- 文件格式(csv/xlsx)
+    lang VARCHAR(10) NOT NULL DEFAULT '',          -- 表头语言
+    filters TEXT NOT NULL DEFAULT '',              -- 筛选条件(JSON)
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING:等待, RUNNING:执行中, SUCCEEDED:成功, FAILED:失败
+    row_count BIGINT NOT NULL DEFAULT 0,           -- 导出行数
+    file_size BIGINT NOT NULL DEFAULT 0,           -- 文件大小(字节)
+    error_message TEXT NOT NULL DEFAULT '',        -- 失败原因
+    expires_at BIGINT NOT NULL DEFAULT 0,          -- 文件过期时间(毫秒)
+    finished_at BIGINT NOT NULL DEFAULT 0,         -- 完成时间(毫秒)
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_jobs_tenant_user ON export_jobs(tenant_id, user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_export_jobs_expires_at ON export_jobs(expires_at);
+
+COMMENT ON TABLE export_jobs IS '导出任务表';
+COMMENT ON COLUMN export_jobs.user_id IS '发起导出的用户';
+COMMENT ON COLUMN export_jobs.resource IS '导出资源';
+COMMENT ON COLUMN export_jobs.format IS '文件格式(csv/xlsx)';
+COMMENT ON COLUMN export_jobs.filters IS '筛选条件(JSON)';
+COMMENT ON COLUMN export_jobs.status IS '状态(PENDING:等待, RUNNING:执行中, SUCCEEDED:成功, FAILED:失败)';
+COMMENT ON COLUMN export_jobs.expires_at IS '文件过期时间(毫秒)';
+
+-- 行级安全：与其他租户数据表一致
+ALTER TABLE export_jobs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE export_jobs FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON export_jobs;
+CREATE POLICY tenant_isolation ON export_jobs USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id));
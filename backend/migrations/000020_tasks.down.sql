@@ -0,0 +1,4 @@
+-- 回滚后台任务队列
+
+ALTER TABLE export_jobs DROP COLUMN IF EXISTS task_id;
+DROP TABLE IF EXISTS tasks;
@@ -0,0 +1,55 @@
+-- =====================================================
+-- 后台任务队列：导入、导出、租户清理、缓存重建等耗时操作入队后由工作进程异步执行
+-- 工作进程以 FOR UPDATE SKIP LOCKED 领取任务并持有租约，执行中定期续约；
+-- 租约过期未续约视为实例中断，任务由其他实例重新领取
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS tasks (
+    task_id VARCHAR(20) PRIMARY KEY,
+    tenant_id VARCHAR(20) NOT NULL DEFAULT '',      -- 发起任务的租户，平台级任务为空
+    user_id VARCHAR(20) NOT NULL DEFAULT '',        -- 发起任务的用户，系统任务为空
+    type VARCHAR(50) NOT NULL,                      -- 任务类型，对应工作进程中注册的处理函数
+    payload TEXT NOT NULL DEFAULT '',               -- 任务参数(JSON)
+    context TEXT NOT NULL DEFAULT '',               -- 发起人认证上下文快照(JSON)，执行时据此重建租户和用户上下文
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',  -- PENDING:等待, RUNNING:执行中, CANCELING:取消中, SUCCEEDED:成功, FAILED:失败, CANCELED:已取消
+    progress SMALLINT NOT NULL DEFAULT 0,           -- 进度(0-100)
+    progress_message VARCHAR(255) NOT NULL DEFAULT '', -- 进度说明
+    result TEXT NOT NULL DEFAULT '',                -- 执行结果(JSON)
+    error_message TEXT NOT NULL DEFAULT '',         -- 最近一次失败原因
+    attempts INT NOT NULL DEFAULT 0,                -- 已执行次数
+    max_attempts INT NOT NULL DEFAULT 1,            -- 最大执行次数，失败后按退避间隔重试
+    run_at BIGINT NOT NULL DEFAULT 0,               -- 最早执行时间(毫秒)
+    locked_by VARCHAR(100) NOT NULL DEFAULT '',     -- 持有租约的工作进程
+    locked_until BIGINT NOT NULL DEFAULT 0,         -- 租约到期时间(毫秒)
+    started_at BIGINT NOT NULL DEFAULT 0,           -- 最近一次开始执行时间(毫秒)
+    finished_at BIGINT NOT NULL DEFAULT 0,          -- 结束时间(毫秒)
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status_run_at ON tasks(status, run_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_tenant_user ON tasks(tenant_id, user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_finished_at ON tasks(finished_at);
+
+COMMENT ON TABLE tasks IS '后台任务表';
+COMMENT ON COLUMN tasks.user_id IS '发起任务的用户';
+COMMENT ON COLUMN tasks.type IS '任务类型';
+COMMENT ON COLUMN tasks.payload IS '任务参数(JSON)';
+COMMENT ON COLUMN tasks.context IS '发起人认证上下文快照(JSON)';
+COMMENT ON COLUMN tasks.status IS '状态(PENDING:等待, RUNNING:执行中, CANCELING:取消中, SUCCEEDED:成功, FAILED:失败, CANCELED:已取消)';
+COMMENT ON COLUMN tasks.progress IS '进度(0-100)';
+COMMENT ON COLUMN tasks.attempts IS '已执行次数';
+COMMENT ON COLUMN tasks.max_attempts IS '最大执行次数';
+COMMENT ON COLUMN tasks.run_at IS '最早执行时间(毫秒)';
+COMMENT ON COLUMN tasks.locked_by IS '持有租约的工作进程';
+COMMENT ON COLUMN tasks.locked_until IS '租约到期时间(毫秒)';
+
+-- 行级安全：与其他租户数据表一致；工作进程领取任务时不带租户，可见全部任务
+ALTER TABLE tasks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tasks FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tasks;
+CREATE POLICY tenant_isolation ON tasks USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id));
+
+-- 后台导出改由任务队列执行，记录对应的任务ID便于查询进度
+ALTER TABLE export_jobs ADD COLUMN IF NOT EXISTS task_id VARCHAR(20) NOT NULL DEFAULT '';
+COMMENT ON COLUMN export_jobs.task_id IS '执行导出的后台任务ID';
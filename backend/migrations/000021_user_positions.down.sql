@@ -0,0 +1,3 @@
+-- 回滚用户多岗索引（补齐的主岗位关联与 users.position_id 一致，保留）
+
+DROP INDEX IF EXISTS idx_user_positions_position;
@@ -0,0 +1,12 @@
+-- =====================================================
+-- 用户多岗：user_positions 记录用户兼任的全部岗位，主岗位(is_primary=1)与 users.position_id 保持一致
+-- 为已有用户的 users.position_id 补齐主岗位关联，并为按岗位查询用户增加索引
+-- =====================================================
+
+CREATE INDEX IF NOT EXISTS idx_user_positions_position ON user_positions(position_id);
+
+INSERT INTO user_positions (user_id, position_id, is_primary)
+SELECT user_id, position_id, 1
+FROM users
+WHERE position_id IS NOT NULL AND position_id <> '' AND deleted_at = 0
+ON CONFLICT (user_id, position_id) DO UPDATE SET is_primary = 1;
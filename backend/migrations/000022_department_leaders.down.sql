@@ -0,0 +1,3 @@
+-- 回滚部门负责人
+
+DROP TABLE IF EXISTS department_leaders;
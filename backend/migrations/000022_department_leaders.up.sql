@@ -0,0 +1,26 @@
+-- =====================================================
+-- 部门负责人：一个部门可以有多名负责人，用于组织架构图和解析用户的直属上级
+-- 负责人可以不在本部门（如上级部门负责人兼管），sort 越小越靠前，第一位为主要负责人
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS department_leaders (
+    tenant_id VARCHAR(20) NOT NULL,
+    department_id VARCHAR(20) NOT NULL,
+    user_id VARCHAR(20) NOT NULL,
+    sort INT NOT NULL DEFAULT 0,          -- 排序(越小越靠前)
+    created_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (department_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_department_leaders_tenant_user ON department_leaders(tenant_id, user_id);
+
+COMMENT ON TABLE department_leaders IS '部门负责人表';
+COMMENT ON COLUMN department_leaders.department_id IS '部门ID';
+COMMENT ON COLUMN department_leaders.user_id IS '负责人用户ID';
+COMMENT ON COLUMN department_leaders.sort IS '排序(越小越靠前，第一位为主要负责人)';
+
+-- 行级安全：与其他租户数据表一致
+ALTER TABLE department_leaders ENABLE ROW LEVEL SECURITY;
+ALTER TABLE department_leaders FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON department_leaders;
+CREATE POLICY tenant_isolation ON department_leaders USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id));
@@ -0,0 +1,14 @@
+-- 回滚行级安全失败即拒绝
+
+DROP POLICY IF EXISTS tenant_member_read ON users;
+
+DROP POLICY IF EXISTS tenant_isolation ON user_positions;
+CREATE POLICY tenant_isolation ON user_positions
+    USING (app_current_tenant() IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.user_id = user_positions.user_id))
+    WITH CHECK (app_current_tenant() IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.user_id = user_positions.user_id));
+
+CREATE OR REPLACE FUNCTION app_tenant_visible(row_tenant_id VARCHAR) RETURNS BOOLEAN
+    LANGUAGE sql STABLE
+AS $$ SELECT app_current_tenant() IS NULL OR row_tenant_id = app_current_tenant() $$;
+
+COMMENT ON FUNCTION app_tenant_visible(VARCHAR) IS 'RLS: 行是否对当前租户可见';
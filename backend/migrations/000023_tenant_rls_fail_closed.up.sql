@@ -0,0 +1,23 @@
+-- =====================================================
+-- 行级安全改为失败即拒绝：未设置 app.tenant_id 时不再放行任何租户数据
+-- 平台级操作（定时任务、登录等认证前流程、超级管理员）须切换到 admin_platform 角色（BYPASSRLS）
+-- 注意：此后的迁移如需读写租户数据表，应由超级用户执行或在事务内 SET LOCAL ROLE admin_platform
+-- =====================================================
+
+-- 行是否对当前事务的租户可见，未设置租户时不可见
+CREATE OR REPLACE FUNCTION app_tenant_visible(row_tenant_id VARCHAR) RETURNS BOOLEAN
+    LANGUAGE sql STABLE
+AS $$ SELECT app_current_tenant() IS NOT NULL AND row_tenant_id = app_current_tenant() $$;
+
+COMMENT ON FUNCTION app_tenant_visible(VARCHAR) IS 'RLS: 行是否对当前租户可见(未设置租户时不可见)';
+
+-- user_positions 没有 tenant_id，只放行当前租户主账号的岗位关联
+DROP POLICY IF EXISTS tenant_isolation ON user_positions;
+CREATE POLICY tenant_isolation ON user_positions
+    USING (EXISTS (SELECT 1 FROM users u WHERE u.user_id = user_positions.user_id AND u.tenant_id = app_current_tenant()))
+    WITH CHECK (EXISTS (SELECT 1 FROM users u WHERE u.user_id = user_positions.user_id AND u.tenant_id = app_current_tenant()));
+
+-- 其他租户的用户加入当前租户后，其主账号对当前租户只读可见（成员列表、部门负责人等）
+DROP POLICY IF EXISTS tenant_member_read ON users;
+CREATE POLICY tenant_member_read ON users FOR SELECT
+    USING (EXISTS (SELECT 1 FROM tenant_members m WHERE m.user_id = users.user_id AND m.tenant_id = app_current_tenant()));
@@ -0,0 +1,158 @@
+// Package tenantscope 检查跨租户数据访问是否有注解说明
+//
+// 租户自动过滤由 database.TenantScopePlugin 完成，以下写法会绕过过滤或切换过滤的租户，必须添加注解：
+//   - 名称以 Manual 结尾的方法（约定为跨租户方法）
+//   - 调用 database.SkipTenant 的函数
+//   - 调用 database.WithTenant 的函数（租户ID可能来自请求参数，需说明来源已校验）
+//   - 调用 xcontext.SetPlatformScope 的函数（数据库行级安全切换到旁路角色）
+//
+// 注解写在函数或接收者类型的文档注释中，格式为 //tenantscope:allow <原因>，原因不能为空。
+// 接收者类型上的注解对该类型的所有方法生效，用于租户、套餐等平台级数据的 Repository。
+package tenantscope
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	directive    = "//tenantscope:allow"
+	databasePath = "admin/pkg/database"
+	xcontextPath = "admin/pkg/xcontext"
+	skipFunc     = "SkipTenant"
+	withFunc     = "WithTenant"
+	platformFunc = "SetPlatformScope"
+	manualSuffix = "Manual"
+)
+
+// Analyzer 跨租户访问注解检查器
+var Analyzer = &analysis.Analyzer{
+	Name: "tenantscope",
+	Doc:  "检查 Manual 方法和 database.SkipTenant、database.WithTenant、xcontext.SetPlatformScope 调用是否有 //tenantscope:allow 注解",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		if strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			continue
+		}
+		allowedTypes := typeDirectives(pass, file)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			allowed := hasDirective(pass, fn.Doc, fn.Name) || allowedTypes[receiverName(fn)]
+			if allowed {
+				continue
+			}
+			if strings.HasSuffix(fn.Name.Name, manualSuffix) {
+				pass.Reportf(fn.Name.Pos(), "跨租户方法 %s 缺少 %s 注解", fn.Name.Name, directive)
+			}
+			if fn.Body == nil {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if name := scopeFunc(pass, call); name != "" {
+						pass.Reportf(call.Pos(), "%s 调用 %s 缺少 %s 注解", fn.Name.Name, name, directive)
+					}
+				}
+				return true
+			})
+		}
+	}
+	return nil, nil
+}
+
+// typeDirectives 收集文件中带注解的类型名
+func typeDirectives(pass *analysis.Pass, file *ast.File) map[string]bool {
+	allowed := make(map[string]bool)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			// 单个类型声明的文档注释挂在 GenDecl 上
+			if hasDirective(pass, ts.Doc, ts.Name) || (len(gen.Specs) == 1 && hasDirective(pass, gen.Doc, ts.Name)) {
+				allowed[ts.Name.Name] = true
+			}
+		}
+	}
+	return allowed
+}
+
+// hasDirective 判断注释中是否有注解，原因为空的注解在声明名称处单独报告
+func hasDirective(pass *analysis.Pass, doc *ast.CommentGroup, name *ast.Ident) bool {
+	if doc == nil {
+		return false
+	}
+	found := false
+	for _, c := range doc.List {
+		if c.Text != directive && !strings.HasPrefix(c.Text, directive+" ") {
+			continue
+		}
+		if strings.TrimSpace(strings.TrimPrefix(c.Text, directive)) == "" {
+			pass.Reportf(name.Pos(), "%s 的 %s 注解需说明跨租户访问的原因", name.Name, directive)
+			continue
+		}
+		found = true
+	}
+	return found
+}
+
+// receiverName 方法接收者的类型名，普通函数返回空字符串
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		if id, ok := t.X.(*ast.Ident); ok {
+			return id.Name
+		}
+	case *ast.IndexListExpr:
+		if id, ok := t.X.(*ast.Ident); ok {
+			return id.Name
+		}
+	}
+	return ""
+}
+
+// scopeFunc 调用需注解的租户范围函数时返回带包名的函数名，否则返回空字符串
+func scopeFunc(pass *analysis.Pass, call *ast.CallExpr) string {
+	var id *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	case *ast.Ident:
+		id = fun
+	default:
+		return ""
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	switch {
+	case fn.Pkg().Path() == databasePath && (fn.Name() == skipFunc || fn.Name() == withFunc),
+		fn.Pkg().Path() == xcontextPath && fn.Name() == platformFunc:
+		return fn.Pkg().Name() + "." + fn.Name()
+	}
+	return ""
+}
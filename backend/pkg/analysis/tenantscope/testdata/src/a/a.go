@@ -0,0 +1,66 @@
+package a
+
+import (
+	"admin/pkg/database"
+	"admin/pkg/xcontext"
+	"context"
+)
+
+type UserRepo struct{}
+
+func (r *UserRepo) GetByID(ctx context.Context) {
+	_ = database.WithTenant(ctx, "t1") // want `GetByID 调用 database.WithTenant 缺少 //tenantscope:allow 注解`
+}
+
+// ListByTenant 按指定租户查询
+//
+//tenantscope:allow 租户ID由调用方校验
+func (r *UserRepo) ListByTenant(ctx context.Context, tenantID string) {
+	_ = database.WithTenant(ctx, tenantID)
+}
+
+func (r *UserRepo) GetByIDManual(ctx context.Context) {} // want `跨租户方法 GetByIDManual 缺少 //tenantscope:allow 注解`
+
+// ListByEmailManual 跨租户查询
+//
+//tenantscope:allow 邮箱登录需查找所有租户
+func (r *UserRepo) ListByEmailManual(ctx context.Context) {
+	_ = database.SkipTenant(ctx)
+}
+
+func (r *UserRepo) CountAll(ctx context.Context) {
+	_ = database.SkipTenant(ctx) // want `CountAll 调用 database.SkipTenant 缺少 //tenantscope:allow 注解`
+}
+
+// UpdateManual 更新
+//
+//tenantscope:allow
+func (r *UserRepo) UpdateManual(ctx context.Context) {} // want `UpdateManual 的 //tenantscope:allow 注解需说明跨租户访问的原因` `跨租户方法 UpdateManual 缺少 //tenantscope:allow 注解`
+
+// TenantRepo 租户数据访问
+//
+//tenantscope:allow 租户为平台级数据
+type TenantRepo struct{}
+
+func (r *TenantRepo) GetByIDManual(ctx context.Context) {
+	_ = database.SkipTenant(ctx)
+}
+
+func (r *TenantRepo) Get(ctx context.Context, tenantID string) {
+	_ = database.WithTenant(ctx, tenantID)
+}
+
+func helper(ctx context.Context) {
+	_ = database.SkipTenant(ctx) // want `helper 调用 database.SkipTenant 缺少 //tenantscope:allow 注解`
+}
+
+// purgeJob 定时清理
+//
+//tenantscope:allow 定时任务处理所有租户的数据
+func purgeJob(ctx context.Context) {
+	_ = xcontext.SetPlatformScope(ctx)
+}
+
+func cleanupJob(ctx context.Context) {
+	_ = xcontext.SetPlatformScope(ctx) // want `cleanupJob 调用 xcontext.SetPlatformScope 缺少 //tenantscope:allow 注解`
+}
@@ -0,0 +1,7 @@
+package database
+
+import "context"
+
+func SkipTenant(ctx context.Context) context.Context { return ctx }
+
+func WithTenant(ctx context.Context, tenantID string) context.Context { return ctx }
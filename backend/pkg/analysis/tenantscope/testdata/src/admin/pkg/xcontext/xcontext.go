@@ -0,0 +1,5 @@
+package xcontext
+
+import "context"
+
+func SetPlatformScope(ctx context.Context) context.Context { return ctx }
@@ -131,6 +131,22 @@ func WithExport(module string) LogOption {
 	}
 }
 
+// WithImport 导入操作选项
+func WithImport(module string) LogOption {
+	return func(e *LogEntry) {
+		e.Module = module
+		e.OperationType = constants.OperationImport
+	}
+}
+
+// WithImpersonate 模拟登录操作选项
+func WithImpersonate(module string) LogOption {
+	return func(e *LogEntry) {
+		e.Module = module
+		e.OperationType = constants.OperationImpersonate
+	}
+}
+
 // WithLogin 登录操作选项
 func WithLogin() LogOption {
 	return func(e *LogEntry) {
@@ -155,6 +171,21 @@ func WithLoginPhone() LogOption {
 	}
 }
 
+// WithLoginClientCredentials 服务账号 client_credentials 授权操作选项
+func WithLoginClientCredentials() LogOption {
+	return func(e *LogEntry) {
+		e.Module = constants.LoginTypeClientCredentials
+		e.OperationType = constants.OperationLogin
+	}
+}
+
+// WithLoginRisk 设置登录风险评估结果
+func WithLoginRisk(risk *LoginRisk) LogOption {
+	return func(e *LogEntry) {
+		e.LoginRisk = risk
+	}
+}
+
 // WithLogout 登出操作选项
 func WithLogout() LogOption {
 	return func(e *LogEntry) {
@@ -40,6 +40,13 @@ func (r *Recorder) Log(ctx context.Context, opts ...LogOption) {
 	if entry.UserName == "" {
 		entry.UserName = xcontext.GetUserName(ctx)
 	}
+	if entry.AccessKeyID == "" {
+		entry.AccessKeyID = xcontext.GetAccessKeyID(ctx)
+	}
+	if entry.ImpersonatorID == "" {
+		entry.ImpersonatorID = xcontext.GetImpersonatorID(ctx)
+		entry.ImpersonatorName = xcontext.GetImpersonatorName(ctx)
+	}
 
 	// 从 context 获取客户端和请求信息
 	if clientInfo := GetClientInfo(ctx); clientInfo != nil {
@@ -81,7 +88,8 @@ func (r *Recorder) Login(ctx context.Context, tenantID, userID, userName string,
 }
 
 // LoginEmail 记录邮箱登录日志
-func (r *Recorder) LoginEmail(ctx context.Context, tenantID, userID, userName string, err error) {
+// extra 用于附加登录风险等信息
+func (r *Recorder) LoginEmail(ctx context.Context, tenantID, userID, userName string, err error, extra ...LogOption) {
 	opts := []LogOption{
 		WithLoginEmail(),
 		WithUser(tenantID, userID, userName),
@@ -89,11 +97,13 @@ func (r *Recorder) LoginEmail(ctx context.Context, tenantID, userID, userName st
 	if err != nil {
 		opts = append(opts, WithError(err))
 	}
+	opts = append(opts, extra...)
 	r.Log(ctx, opts...)
 }
 
 // LoginPhone 记录手机号登录日志
-func (r *Recorder) LoginPhone(ctx context.Context, tenantID, userID, userName string, err error) {
+// extra 用于附加登录风险等信息
+func (r *Recorder) LoginPhone(ctx context.Context, tenantID, userID, userName string, err error, extra ...LogOption) {
 	opts := []LogOption{
 		WithLoginPhone(),
 		WithUser(tenantID, userID, userName),
@@ -101,6 +111,19 @@ func (r *Recorder) LoginPhone(ctx context.Context, tenantID, userID, userName st
 	if err != nil {
 		opts = append(opts, WithError(err))
 	}
+	opts = append(opts, extra...)
+	r.Log(ctx, opts...)
+}
+
+// LoginClientCredentials 记录服务账号 client_credentials 授权日志
+func (r *Recorder) LoginClientCredentials(ctx context.Context, tenantID, userID, userName string, err error) {
+	opts := []LogOption{
+		WithLoginClientCredentials(),
+		WithUser(tenantID, userID, userName),
+	}
+	if err != nil {
+		opts = append(opts, WithError(err))
+	}
 	r.Log(ctx, opts...)
 }
 
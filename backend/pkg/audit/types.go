@@ -2,22 +2,37 @@ package audit
 
 // LogEntry 日志条目（内部使用）
 type LogEntry struct {
-	TenantID      string
-	UserID        string
-	UserName      string
-	Module        string
-	OperationType string
-	ResourceType  string
-	ResourceID    string // 单个资源ID或批量操作的JSON数组
-	ResourceName  string // 单个资源名称或批量操作的汇总描述
-	OldValue      any    // 任意类型，写入时转为 JSON 字符串
-	NewValue      any    // 任意类型，写入时转为 JSON 字符串
-	RequestMethod string
-	RequestPath   string
-	RequestParams string
-	IPAddress     string
-	UserAgent     string
-	Status        int16
-	ErrorMessage  string
-	CreatedAt     int64
+	TenantID         string
+	UserID           string
+	UserName         string
+	AccessKeyID      string // 访问令牌ID（PAT / API Key 调用时记录）
+	ImpersonatorID   string // 模拟登录时的实际操作者ID
+	ImpersonatorName string // 模拟登录时的实际操作者用户名
+	Module           string
+	OperationType    string
+	ResourceType     string
+	ResourceID       string // 单个资源ID或批量操作的JSON数组
+	ResourceName     string // 单个资源名称或批量操作的汇总描述
+	OldValue         any    // 任意类型，写入时转为 JSON 字符串
+	NewValue         any    // 任意类型，写入时转为 JSON 字符串
+	RequestMethod    string
+	RequestPath      string
+	RequestParams    string
+	IPAddress        string
+	UserAgent        string
+	Status           int16
+	ErrorMessage     string
+	CreatedAt        int64
+	LoginRisk        *LoginRisk // 登录风险评估结果（仅登录日志）
+}
+
+// LoginRisk 登录风险评估结果
+type LoginRisk struct {
+	DeviceFingerprint string   // 设备指纹
+	CountryCode       string   // 国家代码
+	Latitude          float64  // 纬度
+	Longitude         float64  // 经度
+	Score             int      // 风险评分(0-100)
+	Flags             []string // 风险标记
+	Action            string   // 处置动作(ALLOW/MFA/BLOCK)
 }
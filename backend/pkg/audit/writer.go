@@ -3,10 +3,12 @@ package audit
 import (
 	"admin/internal/dal/model"
 	"admin/pkg/constants"
+	"admin/pkg/utils/geoip"
 	"admin/pkg/utils/idgen"
 	"admin/pkg/xcontext"
 	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -14,15 +16,17 @@ import (
 
 // DB 数据库写入接口（简化命名）
 type DB struct {
-	db *gorm.DB
+	db  *gorm.DB
+	geo geoip.Resolver // IP 地理位置解析，为 nil 时仅标记内网 IP
 }
 
 // NewDB 创建写入器
-func NewDB(db *gorm.DB) *DB {
-	return &DB{db: db}
+func NewDB(db *gorm.DB, geo geoip.Resolver) *DB {
+	return &DB{db: db, geo: geo}
 }
 
 // Write 写入日志（统一入口）
+// 由 Recorder 异步调用，IP 地理位置在此解析，不阻塞请求
 func (w *DB) Write(ctx context.Context, entry *LogEntry) error {
 	if entry == nil {
 		return nil
@@ -59,13 +63,23 @@ func (w *DB) writeLoginLog(ctx context.Context, entry *LogEntry) error {
 		OperationType: entry.OperationType,
 		LoginType:     entry.Module,
 		LoginIP:       entry.IPAddress,
-		LoginLocation: "", // 暂不实现 IP 地址解析
+		LoginLocation: geoip.Describe(w.geo, entry.IPAddress),
 		UserAgent:     entry.UserAgent,
 		Status:        entry.Status,
 		FailReason:    entry.ErrorMessage,
 		CreatedAt:     entry.CreatedAt,
 	}
 
+	if risk := entry.LoginRisk; risk != nil {
+		loginLog.DeviceFingerprint = risk.DeviceFingerprint
+		loginLog.CountryCode = risk.CountryCode
+		loginLog.Latitude = risk.Latitude
+		loginLog.Longitude = risk.Longitude
+		loginLog.RiskScore = int16(risk.Score)
+		loginLog.RiskFlags = strings.Join(risk.Flags, ",")
+		loginLog.RiskAction = risk.Action
+	}
+
 	// 使用手动模式：跳过租户检查，直接使用 entry.TenantID
 	ctx = xcontext.SetTenantID(ctx, entry.TenantID)
 
@@ -92,25 +106,29 @@ func (w *DB) writeOperationLog(ctx context.Context, entry *LogEntry) error {
 	}
 
 	operationLog := &model.OperationLog{
-		LogID:         logID,
-		TenantID:      entry.TenantID,
-		UserID:        entry.UserID,
-		UserName:      entry.UserName,
-		Module:        entry.Module,
-		OperationType: entry.OperationType,
-		ResourceType:  entry.ResourceType,
-		ResourceID:    entry.ResourceID,
-		ResourceName:  entry.ResourceName,
-		RequestMethod: entry.RequestMethod,
-		RequestPath:   entry.RequestPath,
-		RequestParams: entry.RequestParams,
-		OldValue:      toJSON(entry.OldValue),
-		NewValue:      toJSON(entry.NewValue),
-		Status:        entry.Status,
-		ErrorMessage:  entry.ErrorMessage,
-		IPAddress:     entry.IPAddress,
-		UserAgent:     entry.UserAgent,
-		CreatedAt:     entry.CreatedAt,
+		LogID:            logID,
+		TenantID:         entry.TenantID,
+		UserID:           entry.UserID,
+		UserName:         entry.UserName,
+		AccessKeyID:      entry.AccessKeyID,
+		ImpersonatorID:   entry.ImpersonatorID,
+		ImpersonatorName: entry.ImpersonatorName,
+		Module:           entry.Module,
+		OperationType:    entry.OperationType,
+		ResourceType:     entry.ResourceType,
+		ResourceID:       entry.ResourceID,
+		ResourceName:     entry.ResourceName,
+		RequestMethod:    entry.RequestMethod,
+		RequestPath:      entry.RequestPath,
+		RequestParams:    entry.RequestParams,
+		OldValue:         toJSON(entry.OldValue),
+		NewValue:         toJSON(entry.NewValue),
+		Status:           entry.Status,
+		ErrorMessage:     entry.ErrorMessage,
+		IPAddress:        entry.IPAddress,
+		Location:         geoip.Describe(w.geo, entry.IPAddress),
+		UserAgent:        entry.UserAgent,
+		CreatedAt:        entry.CreatedAt,
 	}
 
 	// 使用手动模式：跳过租户检查，直接使用 entry.TenantID
@@ -2,18 +2,26 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
 // Config 全局配置结构
 type Config struct {
-	App       AppConfig       `mapstructure:"app"`
-	Server    ServerConfig    `mapstructure:"server"`
-	Database  DatabaseConfig  `mapstructure:"database"`
-	Redis     RedisConfig     `mapstructure:"redis"`
-	Log       LogConfig       `mapstructure:"log"`
-	JWT       JWTConfig       `mapstructure:"jwt"`
-	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	App        AppConfig        `mapstructure:"app"`
+	Server     ServerConfig     `mapstructure:"server"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	Redis      RedisConfig      `mapstructure:"redis"`
+	Log        LogConfig        `mapstructure:"log"`
+	JWT        JWTConfig        `mapstructure:"jwt"`
+	RateLimit  RateLimitConfig  `mapstructure:"rate_limit"`
+	GeoIP      GeoIPConfig      `mapstructure:"geoip"`
+	LoginRisk  LoginRiskConfig  `mapstructure:"login_risk"`
+	Tenant     TenantConfig     `mapstructure:"tenant"`
+	Invitation InvitationConfig `mapstructure:"invitation"`
+	Export     ExportConfig     `mapstructure:"export"`
+	Task       TaskConfig       `mapstructure:"task"`
+	Profile    ProfileConfig    `mapstructure:"profile"`
 }
 
 type AppConfig struct {
@@ -46,6 +54,66 @@ type RateLimitConfig struct {
 	Burst             int  `mapstructure:"burst"`
 }
 
+// GeoIPConfig 离线 IP 地理位置库配置
+type GeoIPConfig struct {
+	DBPath         string `mapstructure:"db_path"`         // 数据库文件路径，为空或文件不存在时不做地理位置解析
+	Format         string `mapstructure:"format"`          // 数据库格式：mmdb(MaxMind City) / xdb(ip2region)，为空时按扩展名识别
+	ReloadInterval int    `mapstructure:"reload_interval"` // 热更新检查间隔(秒)，0 表示不热更新
+}
+
+// LoginRiskConfig 登录风险识别配置
+type LoginRiskConfig struct {
+	Enabled        bool    `mapstructure:"enabled"`          // 是否启用
+	Threshold      int     `mapstructure:"threshold"`        // 风险评分阈值，达到后按租户策略处置
+	MaxTravelSpeed float64 `mapstructure:"max_travel_speed"` // 最大合理移动速度(km/h)，超过视为不可能的旅行
+	HistorySize    int     `mapstructure:"history_size"`     // 参与比对的历史成功登录条数
+	MFACodeTTL     int     `mapstructure:"mfa_code_ttl"`     // 二次验证码有效期(秒)
+}
+
+// TenantConfig 租户配置（订阅生命周期、租户识别）
+type TenantConfig struct {
+	GraceDays     int    `mapstructure:"grace_days"`     // 到期后的只读宽限天数
+	ArchiveDays   int    `mapstructure:"archive_days"`   // 过期后自动归档的天数，0 表示不自动归档
+	ReminderDays  []int  `mapstructure:"reminder_days"`  // 到期前第几天发送提醒，如 [7, 3, 1]
+	LifecycleCron string `mapstructure:"lifecycle_cron"` // 生命周期流转任务执行时间（cron 表达式，含秒）
+	BaseDomain    string `mapstructure:"base_domain"`    // 租户子域名的主域名，如 example.com（tenant_code.example.com），为空时不按子域名识别
+	PurgeDays     int    `mapstructure:"purge_days"`     // 删除租户后的数据保留天数，保留期内可恢复，之后彻底清除
+	PurgeCron     string `mapstructure:"purge_cron"`     // 彻底清除任务执行时间（cron 表达式，含秒）
+}
+
+// InvitationConfig 成员邀请配置
+type InvitationConfig struct {
+	AcceptURL   string `mapstructure:"accept_url"`   // 前端接受邀请页面地址，邀请链接为 accept_url?token=xxx
+	ExpireHours int    `mapstructure:"expire_hours"` // 邀请链接有效期(小时)
+}
+
+// ExportConfig 列表导出配置
+type ExportConfig struct {
+	Dir            string `mapstructure:"dir"`             // 异步导出文件存放目录
+	AsyncThreshold int    `mapstructure:"async_threshold"` // 导出行数超过该值时转为后台任务，完成后通过下载链接获取
+	RetainHours    int    `mapstructure:"retain_hours"`    // 异步导出文件保留时长(小时)
+	CleanupCron    string `mapstructure:"cleanup_cron"`    // 过期导出文件清理任务执行时间（cron 表达式，含秒）
+}
+
+// ProfileConfig 个人资料自助修改配置
+type ProfileConfig struct {
+	AvatarDir       string `mapstructure:"avatar_dir"`         // 头像存放目录，需位于静态文件目录 uploads 下
+	AvatarURLPrefix string `mapstructure:"avatar_url_prefix"`  // 头像访问地址前缀，与 avatar_dir 对应
+	AvatarMaxSizeKB int    `mapstructure:"avatar_max_size_kb"` // 头像上传文件大小上限(KB)
+	AvatarSize      int    `mapstructure:"avatar_size"`        // 头像裁剪缩放后的边长(像素)
+	VerifyCodeTTL   int    `mapstructure:"verify_code_ttl"`    // 邮箱/手机号变更验证码有效期(秒)
+}
+
+// TaskConfig 后台任务队列配置
+type TaskConfig struct {
+	Concurrency  int    `mapstructure:"concurrency"`   // 每个实例同时执行的任务数
+	PollInterval int    `mapstructure:"poll_interval"` // 领取任务的轮询间隔(毫秒)
+	LeaseSeconds int    `mapstructure:"lease_seconds"` // 任务租约时长(秒)，执行中定期续约，实例中断后租约过期由其他实例接管
+	RetainDays   int    `mapstructure:"retain_days"`   // 已结束任务记录保留天数
+	CleanupCron  string `mapstructure:"cleanup_cron"`  // 已结束任务清理执行时间（cron 表达式，含秒）
+	Dir          string `mapstructure:"dir"`           // 任务上传文件和结果文件存放目录
+}
+
 type DatabaseConfig struct {
 	Host            string `mapstructure:"host"`
 	Port            int    `mapstructure:"port"`
@@ -56,6 +124,8 @@ type DatabaseConfig struct {
 	MaxIdleConns    int    `mapstructure:"max_idle_conns"`
 	MaxOpenConns    int    `mapstructure:"max_open_conns"`
 	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime"`
+	RLS             bool   `mapstructure:"rls"`             // 是否启用行级安全租户隔离
+	RLSBypassRole   string `mapstructure:"rls_bypass_role"` // 平台级操作切换的旁路角色
 }
 
 type RedisConfig struct {
@@ -96,6 +166,14 @@ func (c *DatabaseConfig) GetConnMaxLifetime() time.Duration {
 	return time.Duration(c.ConnMaxLifetime) * time.Second
 }
 
+// GetRLSBypassRole 获取行级安全旁路角色，未配置时默认 admin_platform
+func (c *DatabaseConfig) GetRLSBypassRole() string {
+	if c.RLSBypassRole == "" {
+		return "admin_platform"
+	}
+	return c.RLSBypassRole
+}
+
 // GetDialTimeout 获取Redis连接超时时间
 func (c *RedisConfig) GetDialTimeout() time.Duration {
 	return time.Duration(c.DialTimeout) * time.Second
@@ -116,6 +194,146 @@ func (c *RedisConfig) GetAddr() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+// GetReloadInterval 获取 IP 地理位置库热更新检查间隔
+func (c *GeoIPConfig) GetReloadInterval() time.Duration {
+	return time.Duration(c.ReloadInterval) * time.Second
+}
+
+// GetMFACodeTTL 获取二次验证码有效期
+func (c *LoginRiskConfig) GetMFACodeTTL() time.Duration {
+	return time.Duration(c.MFACodeTTL) * time.Second
+}
+
+// GetGracePeriod 获取到期后的宽限时长
+func (c *TenantConfig) GetGracePeriod() time.Duration {
+	return time.Duration(c.GraceDays) * 24 * time.Hour
+}
+
+// GetArchivePeriod 获取过期后自动归档的时长
+func (c *TenantConfig) GetArchivePeriod() time.Duration {
+	return time.Duration(c.ArchiveDays) * 24 * time.Hour
+}
+
+// GetPurgeRetention 获取删除租户后的数据保留时长，未配置时默认 30 天
+func (c *TenantConfig) GetPurgeRetention() time.Duration {
+	if c.PurgeDays <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.PurgeDays) * 24 * time.Hour
+}
+
+// GetExpire 获取邀请链接有效期，未配置时默认 72 小时
+func (c *InvitationConfig) GetExpire() time.Duration {
+	if c.ExpireHours <= 0 {
+		return 72 * time.Hour
+	}
+	return time.Duration(c.ExpireHours) * time.Hour
+}
+
+// GetDir 获取异步导出文件存放目录，未配置时默认 data/exports
+func (c *ExportConfig) GetDir() string {
+	if c.Dir == "" {
+		return "data/exports"
+	}
+	return c.Dir
+}
+
+// GetAsyncThreshold 获取转为后台导出的行数阈值，未配置时默认 5000 行
+func (c *ExportConfig) GetAsyncThreshold() int64 {
+	if c.AsyncThreshold <= 0 {
+		return 5000
+	}
+	return int64(c.AsyncThreshold)
+}
+
+// GetRetention 获取异步导出文件保留时长，未配置时默认 24 小时
+func (c *ExportConfig) GetRetention() time.Duration {
+	if c.RetainHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.RetainHours) * time.Hour
+}
+
+// GetAvatarDir 获取头像存放目录，未配置时默认 uploads/avatars
+func (c *ProfileConfig) GetAvatarDir() string {
+	if c.AvatarDir == "" {
+		return "uploads/avatars"
+	}
+	return c.AvatarDir
+}
+
+// GetAvatarURLPrefix 获取头像访问地址前缀，未配置时默认 /uploads/avatars
+func (c *ProfileConfig) GetAvatarURLPrefix() string {
+	if c.AvatarURLPrefix == "" {
+		return "/uploads/avatars"
+	}
+	return strings.TrimRight(c.AvatarURLPrefix, "/")
+}
+
+// GetAvatarMaxSize 获取头像上传文件大小上限(字节)，未配置时默认 2MB
+func (c *ProfileConfig) GetAvatarMaxSize() int64 {
+	if c.AvatarMaxSizeKB <= 0 {
+		return 2 << 20
+	}
+	return int64(c.AvatarMaxSizeKB) << 10
+}
+
+// GetAvatarSize 获取头像边长，未配置时默认 256 像素
+func (c *ProfileConfig) GetAvatarSize() int {
+	if c.AvatarSize <= 0 {
+		return 256
+	}
+	return c.AvatarSize
+}
+
+// GetVerifyCodeTTL 获取邮箱/手机号变更验证码有效期，未配置时默认 10 分钟
+func (c *ProfileConfig) GetVerifyCodeTTL() time.Duration {
+	if c.VerifyCodeTTL <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.VerifyCodeTTL) * time.Second
+}
+
+// GetDir 获取任务文件存放目录，未配置时默认 data/tasks
+func (c *TaskConfig) GetDir() string {
+	if c.Dir == "" {
+		return "data/tasks"
+	}
+	return c.Dir
+}
+
+// GetConcurrency 获取每个实例同时执行的任务数，未配置时默认 4
+func (c *TaskConfig) GetConcurrency() int {
+	if c.Concurrency <= 0 {
+		return 4
+	}
+	return c.Concurrency
+}
+
+// GetPollInterval 获取领取任务的轮询间隔，未配置时默认 1 秒
+func (c *TaskConfig) GetPollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return time.Second
+	}
+	return time.Duration(c.PollInterval) * time.Millisecond
+}
+
+// GetLease 获取任务租约时长，未配置时默认 60 秒
+func (c *TaskConfig) GetLease() time.Duration {
+	if c.LeaseSeconds <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(c.LeaseSeconds) * time.Second
+}
+
+// GetRetention 获取已结束任务记录保留时长，未配置时默认 7 天
+func (c *TaskConfig) GetRetention() time.Duration {
+	if c.RetainDays <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(c.RetainDays) * 24 * time.Hour
+}
+
 // GetAccessExpire 获取访问令牌过期时间
 func (c *JWTConfig) GetAccessExpire() time.Duration {
 	return time.Duration(c.AccessExpire) * time.Second
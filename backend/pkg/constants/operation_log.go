@@ -2,33 +2,47 @@ package constants
 
 // 模块名称常量
 const (
-	ModuleUser       = "user"       // 用户管理
-	ModuleRole       = "role"       // 角色管理
-	ModulePermission = "permission" // 权限管理
-	ModuleTenant     = "tenant"     // 租户管理
-	ModuleSystem     = "system"     // 系统设置
-	ModuleMenu       = "menu"       // 菜单管理
-	ModuleDict       = "dict"       // 字典管理
-	ModuleLog        = "log"        // 日志管理
-	ModuleFile       = "file"       // 文件管理
-	ModuleAuth       = "auth"       // 认证相关 (登录、登出等)
-	ModuleDept       = "dept"       // 部门管理
-	ModulePosition   = "position"   // 岗位管理
-	ModuleDepartment = "department" // 部门管理
+	ModuleUser           = "user"            // 用户管理
+	ModuleRole           = "role"            // 角色管理
+	ModulePermission     = "permission"      // 权限管理
+	ModuleTenant         = "tenant"          // 租户管理
+	ModuleSystem         = "system"          // 系统设置
+	ModuleMenu           = "menu"            // 菜单管理
+	ModuleDict           = "dict"            // 字典管理
+	ModuleLog            = "log"             // 日志管理
+	ModuleFile           = "file"            // 文件管理
+	ModuleAuth           = "auth"            // 认证相关 (登录、登出等)
+	ModuleDept           = "dept"            // 部门管理
+	ModulePosition       = "position"        // 岗位管理
+	ModuleDepartment     = "department"      // 部门管理
+	ModuleAccessToken    = "access_token"    // 访问令牌管理
+	ModuleServiceAccount = "service_account" // 服务账号管理
+	ModuleRoleTemplate   = "role_template"   // 角色模板管理
+	ModulePlan           = "plan"            // 套餐管理
+	ModuleTask           = "task"            // 后台任务
 )
 
 // 资源类型常量（用于操作日志记录）
 const (
-	ResourceTypeUser       = "user"       // 用户资源
-	ResourceTypeRole       = "role"       // 角色资源
-	ResourceTypePermission = "permission" // 权限资源
-	ResourceTypeTenant     = "tenant"     // 租户资源
-	ResourceTypeMenu       = "menu"       // 菜单资源
-	ResourceTypeDict       = "dict"       // 字典资源
-	ResourceTypeDictItem   = "dict_item"  // 字典项资源
-	ResourceTypeDept       = "dept"       // 部门资源
-	ResourceTypeDepartment = "department" // 部门资源 (别名)
-	ResourceTypePosition   = "position"   // 岗位资源
+	ResourceTypeUser           = "user"            // 用户资源
+	ResourceTypeRole           = "role"            // 角色资源
+	ResourceTypePermission     = "permission"      // 权限资源
+	ResourceTypeTenant         = "tenant"          // 租户资源
+	ResourceTypeMenu           = "menu"            // 菜单资源
+	ResourceTypeDict           = "dict"            // 字典资源
+	ResourceTypeDictItem       = "dict_item"       // 字典项资源
+	ResourceTypeDept           = "dept"            // 部门资源
+	ResourceTypeDepartment     = "department"      // 部门资源 (别名)
+	ResourceTypePosition       = "position"        // 岗位资源
+	ResourceTypeAccessToken    = "access_token"    // 访问令牌资源
+	ResourceTypeServiceAccount = "service_account" // 服务账号资源
+	ResourceTypeRoleTemplate   = "role_template"   // 角色模板资源
+	ResourceTypePlan           = "plan"            // 套餐资源
+	ResourceTypeSetting        = "setting"         // 租户设置资源
+	ResourceTypeInvitation     = "invitation"      // 成员邀请资源
+	ResourceTypeLoginLog       = "login_log"       // 登录日志资源
+	ResourceTypeOperationLog   = "operation_log"   // 操作日志资源
+	ResourceTypeTask           = "task"            // 后台任务资源
 )
 
 // 操作类型常量
@@ -42,6 +56,7 @@ const (
 	OperationImport      = "IMPORT"       // 导入
 	OperationLogin       = "LOGIN"        // 登录
 	OperationLogout      = "LOGOUT"       // 登出
+	OperationImpersonate = "IMPERSONATE"  // 模拟登录
 )
 
 // 操作状态常量
@@ -57,6 +72,8 @@ const (
 	LoginTypePhone    = "PHONE"    // 手机号登录
 	LoginTypeSSO      = "SSO"      // 单点登录
 	LoginTypeOAuth    = "OAUTH"    // 第三方登录
+
+	LoginTypeClientCredentials = "CLIENT_CREDENTIALS" // 服务账号 client_credentials 授权
 )
 
 // OperationTypeText 操作类型中文描述映射
@@ -70,20 +87,25 @@ var OperationTypeText = map[string]string{
 	OperationImport:      "导入",
 	OperationLogin:       "登录",
 	OperationLogout:      "登出",
+	OperationImpersonate: "模拟登录",
 }
 
 // ModuleText 模块名称中文描述映射
 var ModuleText = map[string]string{
-	ModuleUser:       "用户管理",
-	ModuleRole:       "角色管理",
-	ModulePermission: "权限管理",
-	ModuleTenant:     "租户管理",
-	ModuleSystem:     "系统设置",
-	ModuleMenu:       "菜单管理",
-	ModuleDict:       "字典管理",
-	ModuleLog:        "日志管理",
-	ModuleFile:       "文件管理",
-	ModuleAuth:       "认证管理",
-	ModuleDept:       "部门管理",
-	ModulePosition:   "岗位管理",
+	ModuleUser:           "用户管理",
+	ModuleRole:           "角色管理",
+	ModulePermission:     "权限管理",
+	ModuleTenant:         "租户管理",
+	ModuleSystem:         "系统设置",
+	ModuleMenu:           "菜单管理",
+	ModuleDict:           "字典管理",
+	ModuleLog:            "日志管理",
+	ModuleFile:           "文件管理",
+	ModuleAuth:           "认证管理",
+	ModuleDept:           "部门管理",
+	ModulePosition:       "岗位管理",
+	ModuleAccessToken:    "访问令牌管理",
+	ModuleServiceAccount: "服务账号管理",
+	ModuleRoleTemplate:   "角色模板管理",
+	ModulePlan:           "套餐管理",
 }
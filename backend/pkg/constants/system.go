@@ -26,6 +26,98 @@ const (
 	TypeData   = "DATA"   // 数据权限
 )
 
+// 访问令牌类型常量
+const (
+	TokenTypePAT    = "PAT"     // 个人访问令牌（用户级）
+	TokenTypeAPIKey = "API_KEY" // API Key（租户级）
+)
+
+// 登录风险处置策略常量（租户级）
+const (
+	LoginRiskPolicyAllow = "ALLOW" // 放行，仅通知用户
+	LoginRiskPolicyMFA   = "MFA"   // 要求二次验证
+	LoginRiskPolicyBlock = "BLOCK" // 拒绝登录
+)
+
+// 租户初始化状态常量
+const (
+	TenantProvisionPending = "PROVISIONING" // 初始化中（未完成或失败待重试）
+	TenantProvisionReady   = "READY"        // 已完成初始化
+)
+
+// 租户订阅生命周期状态常量
+// 流转：TRIAL/ACTIVE --到期--> GRACE --宽限期结束--> EXPIRED --保留期结束--> ARCHIVED
+const (
+	TenantLifecycleTrial    = "TRIAL"    // 试用
+	TenantLifecycleActive   = "ACTIVE"   // 正常
+	TenantLifecycleGrace    = "GRACE"    // 宽限期（只读）
+	TenantLifecycleExpired  = "EXPIRED"  // 已过期（禁止登录）
+	TenantLifecycleArchived = "ARCHIVED" // 已归档（禁止登录）
+)
+
+// 租户彻底清除状态常量
+// 流转：SCHEDULED --保留期结束--> RUNNING --> COMPLETED，失败时为 FAILED 并在下次执行时重试；
+// 仅 SCHEDULED 状态可恢复租户，恢复后为 CANCELED
+const (
+	TenantPurgeScheduled = "SCHEDULED" // 等待保留期结束
+	TenantPurgeRunning   = "RUNNING"   // 清除中
+	TenantPurgeFailed    = "FAILED"    // 清除失败，待重试
+	TenantPurgeCompleted = "COMPLETED" // 已完成
+	TenantPurgeCanceled  = "CANCELED"  // 租户已恢复
+)
+
+// 成员邀请状态常量
+// 流转：PENDING --接受--> ACCEPTED，PENDING --撤销--> REVOKED；过期按 expires_at 判断，不单独记录状态
+const (
+	InvitationPending  = "PENDING"  // 待接受
+	InvitationAccepted = "ACCEPTED" // 已接受
+	InvitationRevoked  = "REVOKED"  // 已撤销
+	InvitationExpired  = "EXPIRED"  // 已过期（仅用于展示）
+)
+
+// 导出任务状态常量
+// 流转：PENDING --> RUNNING --> SUCCEEDED / FAILED；文件过期后任务记录连同文件一起清理
+const (
+	ExportPending   = "PENDING"   // 等待执行
+	ExportRunning   = "RUNNING"   // 执行中
+	ExportSucceeded = "SUCCEEDED" // 已完成，可下载
+	ExportFailed    = "FAILED"    // 失败
+)
+
+// 后台任务状态常量
+// 流转：PENDING --领取--> RUNNING --> SUCCEEDED / FAILED，失败且未达最大次数时回到 PENDING 等待重试；
+// PENDING --取消--> CANCELED，RUNNING --取消--> CANCELING --处理函数退出--> CANCELED
+const (
+	TaskPending   = "PENDING"   // 等待执行
+	TaskRunning   = "RUNNING"   // 执行中
+	TaskCanceling = "CANCELING" // 已请求取消，等待处理函数退出
+	TaskSucceeded = "SUCCEEDED" // 成功
+	TaskFailed    = "FAILED"    // 失败，不再重试
+	TaskCanceled  = "CANCELED"  // 已取消
+)
+
+// 租户配额资源类型常量
+const (
+	QuotaUsers       = "users"       // 用户数
+	QuotaRoles       = "roles"       // 角色数
+	QuotaDepartments = "departments" // 部门数
+	QuotaStorage     = "storage"     // 存储空间（配额单位MB，用量单位字节）
+)
+
+// 租户配额来源常量
+const (
+	QuotaSourceTenant = "TENANT" // 租户单独设置
+	QuotaSourcePlan   = "PLAN"   // 套餐默认
+	QuotaSourceNone   = "NONE"   // 不限制
+)
+
+// 登录风险标记常量
+const (
+	LoginRiskNewDevice        = "NEW_DEVICE"        // 新设备
+	LoginRiskNewCountry       = "NEW_COUNTRY"       // 新国家
+	LoginRiskImpossibleTravel = "IMPOSSIBLE_TRAVEL" // 不可能的旅行
+)
+
 // 菜单状态常量
 const (
 	MenuStatusShow   = 1 // 显示
@@ -17,6 +17,8 @@ type Config struct {
 	MaxOpenConns    int
 	ConnMaxLifetime time.Duration
 	LogLevel        string
+	RLS             bool   // 启用行级安全租户隔离
+	RLSBypassRole   string // 平台级操作切换的旁路角色
 }
 
 var globalDB *gorm.DB
@@ -48,7 +50,18 @@ func Connect(cfg Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// 不再使用 RegisterCallbacks，多租户过滤改为显式 TenantScope helper
+	// 不再使用 RegisterCallbacks，多租户过滤改为显式 TenantScope helper，
+	// 并由 TenantScopePlugin 为实现 TenantScoped 的模型自动追加租户条件，防止遗漏
+	if err := db.Use(&TenantScopePlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tenant scope: %w", err)
+	}
+
+	// 行级安全只做兜底：按 context 设置事务级租户，由数据库策略拦截遗漏租户条件的查询
+	if cfg.RLS {
+		if err := db.Use(&TenantIsolation{BypassRole: cfg.RLSBypassRole}); err != nil {
+			return nil, fmt.Errorf("failed to register tenant isolation: %w", err)
+		}
+	}
 
 	globalDB = db
 	return db, nil
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// rlsStartedKey 标记由插件为查询开启的事务，语句结束后由插件提交
+	rlsStartedKey = "tenant_isolation:started_transaction"
+
+	// rlsSetSQL 设置事务级租户ID和角色，事务结束后自动失效，不会污染连接池中的连接
+	rlsSetSQL = "SELECT set_config('app.tenant_id', $1, true), set_config('role', $2, true)"
+)
+
+// TenantIsolation 行级安全租户隔离插件
+// 在每个事务内按 context 设置 app.tenant_id，由数据库 RLS 策略过滤其他租户的数据（见 000015、000023 迁移），
+// 作为 Repository 租户条件之外的兜底。WithTenant 指定的租户优先于当前租户，没有租户时策略不放行任何租户数据；
+// 只有平台级操作（超级管理员、xcontext.SetPlatformScope 标记）切换到旁路角色，SkipTenant 只跳过应用层过滤。
+//
+// 事务级设置只在事务中生效：写操作使用 GORM 默认事务，查询和原生 SQL 在未处于事务时由插件开启事务；
+// Rows/Scan 等行迭代无法包裹事务，仅在调用方显式事务中受约束
+type TenantIsolation struct {
+	BypassRole string // 具有 BYPASSRLS 属性的角色，连接用户需是其成员
+}
+
+// Name 插件名称
+func (p *TenantIsolation) Name() string {
+	return "tenant_isolation"
+}
+
+// Initialize 注册回调
+func (p *TenantIsolation) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:begin_transaction").Register("tenant_isolation:apply", p.apply); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:begin_transaction").Register("tenant_isolation:apply", p.apply); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:begin_transaction").Register("tenant_isolation:apply", p.apply); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant_isolation:begin", p.begin); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:after_query").Register("tenant_isolation:end", p.end); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("gorm:raw").Register("tenant_isolation:begin", p.begin); err != nil {
+		return err
+	}
+	if err := cb.Raw().After("gorm:raw").Register("tenant_isolation:end", p.end); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("tenant_isolation:apply", p.apply)
+}
+
+// begin 未处于事务时开启事务，再设置租户
+func (p *TenantIsolation) begin(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	if !inTransaction(db) {
+		tx := db.Begin()
+		if tx.Error != nil {
+			db.AddError(tx.Error)
+			return
+		}
+		db.Statement.ConnPool = tx.Statement.ConnPool
+		db.InstanceSet(rlsStartedKey, true)
+	}
+	p.apply(db)
+}
+
+// end 提交或回滚 begin 开启的事务
+func (p *TenantIsolation) end(db *gorm.DB) {
+	if _, ok := db.InstanceGet(rlsStartedKey); !ok {
+		return
+	}
+	if db.Error != nil {
+		db.Rollback()
+	} else {
+		db.Commit()
+	}
+	db.Statement.ConnPool = db.ConnPool
+}
+
+// apply 在当前事务中设置租户ID和角色
+func (p *TenantIsolation) apply(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || !inTransaction(db) {
+		return
+	}
+	ctx := db.Statement.Context
+	tenantID, role := rlsTenantID(ctx), "none"
+	if isPlatform(ctx) {
+		tenantID, role = "", p.BypassRole
+	}
+	if _, err := db.Statement.ConnPool.ExecContext(ctx, rlsSetSQL, tenantID, role); err != nil {
+		db.AddError(err)
+	}
+}
+
+// rlsTenantID 语句可见的租户ID，与应用层过滤一致：WithTenant 指定的租户优先，其次为当前租户；
+// 未认证的登录等接口使用按域名或请求头识别出的租户
+func rlsTenantID(ctx context.Context) string {
+	if tenantID := scopeTenantID(ctx); tenantID != "" {
+		return tenantID
+	}
+	return xcontext.GetResolvedTenantID(ctx)
+}
+
+// isPlatform 超级管理员和标记为平台级的操作不受租户隔离约束
+// SetPlatformScope 的调用处均需 //tenantscope:allow 注解说明原因，由 tenantscope 检查器校验
+func isPlatform(ctx context.Context) bool {
+	return xcontext.IsPlatformScope(ctx) || xcontext.HasRole(ctx, constants.SuperAdmin)
+}
+
+// inTransaction 判断语句是否在事务连接上执行
+func inTransaction(db *gorm.DB) bool {
+	_, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
+}
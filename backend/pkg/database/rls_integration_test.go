@@ -0,0 +1,407 @@
+//go:build integration
+
+package database
+
+import (
+	"admin/internal/dal/model"
+	"admin/pkg/constants"
+	"admin/pkg/xcontext"
+	"context"
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// 运行方式（数据库需已执行全部迁移，且连接用户不能是超级用户或具有 BYPASSRLS 属性）：
+//
+//	RLS_TEST_DSN="host=127.0.0.1 user=app password=app dbname=admin_db sslmode=disable" \
+//	    go test -tags integration ./pkg/database/ -run TestTenantIsolation -v
+
+const (
+	rlsTenantA   = "rls_test_tenant_a"
+	rlsTenantB   = "rls_test_tenant_b"
+	rlsPositionA = "rls_test_pos_a"
+	rlsPositionB = "rls_test_pos_b"
+	rlsUserA     = "rls_test_user_a"
+	rlsUserB     = "rls_test_user_b"
+	rlsRoleB     = "rls_test_role_b"
+	rlsEmail     = "rls_test@example.com"
+	rlsTenantC   = "rls_test_tenant_c"
+)
+
+func setupRLS(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("RLS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("未设置 RLS_TEST_DSN，跳过行级安全集成测试")
+	}
+	db, err := Connect(Config{
+		DSN:           dsn,
+		MaxIdleConns:  2,
+		MaxOpenConns:  5,
+		LogLevel:      "silent",
+		RLS:           true,
+		RLSBypassRole: "admin_platform",
+	})
+	if err != nil {
+		t.Fatalf("连接数据库失败: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	var bypass bool
+	if err := db.Raw("SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user").Scan(&bypass).Error; err != nil {
+		t.Fatalf("查询连接用户属性失败: %v", err)
+	}
+	if bypass {
+		t.Skip("连接用户为超级用户或具有 BYPASSRLS 属性，行级安全策略不生效")
+	}
+	var policies int64
+	if err := db.Raw("SELECT count(*) FROM pg_policies WHERE tablename = 'positions' AND policyname = 'tenant_isolation'").Scan(&policies).Error; err != nil {
+		t.Fatalf("查询行级安全策略失败: %v", err)
+	}
+	if policies == 0 {
+		t.Skip("未找到行级安全策略，需先执行 000015 迁移")
+	}
+	var visible bool
+	if err := db.Raw("SELECT COALESCE(app_tenant_visible('rls_test'), false)").Scan(&visible).Error; err != nil {
+		t.Fatalf("查询行级安全函数失败: %v", err)
+	}
+	if visible {
+		t.Skip("未设置租户时策略仍放行，需先执行 000023 迁移")
+	}
+
+	platform := xcontext.SetPlatformScope(context.Background())
+	cleanup := func() {
+		db.WithContext(platform).Unscoped().
+			Where("position_id IN ?", []string{rlsPositionA, rlsPositionB, "rls_test_pos_c"}).
+			Delete(&model.Position{})
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	for _, p := range []*model.Position{
+		{PositionID: rlsPositionA, TenantID: rlsTenantA, PositionCode: "rls_test_a", PositionName: "A", Status: 1},
+		{PositionID: rlsPositionB, TenantID: rlsTenantB, PositionCode: "rls_test_b", PositionName: "B", Status: 1},
+	} {
+		if err := db.WithContext(platform).Create(p).Error; err != nil {
+			t.Fatalf("准备测试数据失败: %v", err)
+		}
+	}
+	return db
+}
+
+// setupRLSUsers 准备跨租户场景的数据：租户A、B各有一个使用相同邮箱的用户，
+// 租户A的用户加入租户B（分配了角色）和租户C（成员身份已禁用）
+func setupRLSUsers(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	platform := xcontext.SetPlatformScope(context.Background())
+	userIDs := []string{rlsUserA, rlsUserB}
+	cleanup := func() {
+		db.WithContext(platform).Where("user_id IN ?", userIDs).Delete(&model.UserRole{})
+		db.WithContext(platform).Where("user_id IN ?", userIDs).Delete(&model.TenantMember{})
+		db.WithContext(platform).Unscoped().Where("user_id IN ?", userIDs).Delete(&model.User{})
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	for _, record := range []interface{}{
+		&model.User{UserID: rlsUserA, TenantID: rlsTenantA, UserName: "rls_test_user_a", Email: rlsEmail, Status: 1},
+		&model.User{UserID: rlsUserB, TenantID: rlsTenantB, UserName: "rls_test_user_b", Email: rlsEmail, Status: 1},
+		&model.UserRole{UserID: rlsUserA, RoleID: rlsRoleB, TenantID: rlsTenantB},
+		&model.TenantMember{TenantID: rlsTenantB, UserID: rlsUserA, Status: 1},
+		&model.TenantMember{TenantID: rlsTenantC, UserID: rlsUserA, Status: 2},
+	} {
+		if err := db.WithContext(platform).Create(record).Error; err != nil {
+			t.Fatalf("准备测试数据失败: %v", err)
+		}
+	}
+}
+
+func tenantCtx(tenantID string) context.Context {
+	return xcontext.SetTenantID(context.Background(), tenantID)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	db := setupRLS(t)
+	ids := []string{rlsPositionA, rlsPositionB}
+
+	t.Run("查询只返回本租户数据", func(t *testing.T) {
+		var list []*model.Position
+		if err := db.WithContext(tenantCtx(rlsTenantA)).Where("position_id IN ?", ids).Find(&list).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if len(list) != 1 || list[0].PositionID != rlsPositionA {
+			t.Fatalf("期望只返回 %s，实际 %d 条", rlsPositionA, len(list))
+		}
+	})
+
+	t.Run("显式按其他租户查询返回空", func(t *testing.T) {
+		var count int64
+		if err := db.WithContext(tenantCtx(rlsTenantA)).Model(&model.Position{}).Where("tenant_id = ?", rlsTenantB).Count(&count).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if count != 0 {
+			t.Fatalf("跨租户查询返回了 %d 条数据", count)
+		}
+	})
+
+	t.Run("按主键读取其他租户数据不存在", func(t *testing.T) {
+		var p model.Position
+		err := db.WithContext(tenantCtx(rlsTenantA)).Where("position_id = ?", rlsPositionB).First(&p).Error
+		if err != gorm.ErrRecordNotFound {
+			t.Fatalf("期望 ErrRecordNotFound，实际 %v", err)
+		}
+	})
+
+	t.Run("更新其他租户数据不生效", func(t *testing.T) {
+		result := db.WithContext(tenantCtx(rlsTenantA)).Model(&model.Position{}).
+			Where("position_id = ?", rlsPositionB).Update("position_name", "hacked")
+		if result.Error != nil {
+			t.Fatalf("更新失败: %v", result.Error)
+		}
+		if result.RowsAffected != 0 {
+			t.Fatalf("跨租户更新影响了 %d 行", result.RowsAffected)
+		}
+	})
+
+	t.Run("删除其他租户数据不生效", func(t *testing.T) {
+		result := db.WithContext(tenantCtx(rlsTenantA)).Unscoped().Where("position_id = ?", rlsPositionB).Delete(&model.Position{})
+		if result.Error != nil {
+			t.Fatalf("删除失败: %v", result.Error)
+		}
+		if result.RowsAffected != 0 {
+			t.Fatalf("跨租户删除影响了 %d 行", result.RowsAffected)
+		}
+	})
+
+	t.Run("原生SQL同样受约束", func(t *testing.T) {
+		result := db.WithContext(tenantCtx(rlsTenantA)).Exec("UPDATE positions SET sort = sort WHERE position_id IN ?", ids)
+		if result.Error != nil {
+			t.Fatalf("执行失败: %v", result.Error)
+		}
+		if result.RowsAffected != 1 {
+			t.Fatalf("期望只影响本租户 1 行，实际 %d 行", result.RowsAffected)
+		}
+	})
+
+	t.Run("不能写入其他租户数据", func(t *testing.T) {
+		p := &model.Position{PositionID: "rls_test_pos_c", TenantID: rlsTenantB, PositionCode: "rls_test_c", PositionName: "C", Status: 1}
+		if err := db.WithContext(tenantCtx(rlsTenantA)).Create(p).Error; err == nil {
+			t.Fatal("期望写入其他租户数据被策略拒绝")
+		}
+	})
+
+	t.Run("显式事务中同样受约束", func(t *testing.T) {
+		err := InTransactionWithCtx(tenantCtx(rlsTenantA), db, func(ctx context.Context, tx *Tx) error {
+			var count int64
+			if err := tx.DB.WithContext(ctx).Model(&model.Position{}).Where("position_id IN ?", ids).Count(&count).Error; err != nil {
+				return err
+			}
+			if count != 1 {
+				t.Errorf("事务中期望可见 1 条，实际 %d 条", count)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("事务执行失败: %v", err)
+		}
+	})
+
+	t.Run("同一事务切换租户后按新租户约束", func(t *testing.T) {
+		err := InTransactionWithCtx(context.Background(), db, func(ctx context.Context, tx *Tx) error {
+			var a, b []*model.Position
+			if err := tx.DB.WithContext(tenantCtx(rlsTenantA)).Where("position_id IN ?", ids).Find(&a).Error; err != nil {
+				return err
+			}
+			if err := tx.DB.WithContext(tenantCtx(rlsTenantB)).Where("position_id IN ?", ids).Find(&b).Error; err != nil {
+				return err
+			}
+			if len(a) != 1 || a[0].TenantID != rlsTenantA || len(b) != 1 || b[0].TenantID != rlsTenantB {
+				t.Errorf("期望各自只可见本租户数据，实际 A=%d B=%d", len(a), len(b))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("事务执行失败: %v", err)
+		}
+	})
+
+	t.Run("未设置租户时不可见任何租户数据", func(t *testing.T) {
+		var count int64
+		if err := db.WithContext(context.Background()).Model(&model.Position{}).Where("position_id IN ?", ids).Count(&count).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if count != 0 {
+			t.Fatalf("未设置租户时可见 %d 条数据", count)
+		}
+	})
+
+	t.Run("未设置租户时不能写入", func(t *testing.T) {
+		p := &model.Position{PositionID: "rls_test_pos_c", TenantID: rlsTenantA, PositionCode: "rls_test_c", PositionName: "C", Status: 1}
+		if err := db.WithContext(context.Background()).Create(p).Error; err == nil {
+			t.Fatal("期望未设置租户时写入被策略拒绝")
+		}
+	})
+
+	t.Run("SkipTenant不绕过行级安全", func(t *testing.T) {
+		var count int64
+		ctx := SkipTenant(tenantCtx(rlsTenantA))
+		if err := db.WithContext(ctx).Model(&model.Position{}).Where("position_id IN ?", ids).Count(&count).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if count != 1 {
+			t.Fatalf("SkipTenant 期望只可见本租户 1 条，实际 %d 条", count)
+		}
+	})
+
+	t.Run("平台级操作可跨租户", func(t *testing.T) {
+		var count int64
+		ctx := xcontext.SetPlatformScope(tenantCtx(rlsTenantA))
+		if err := db.WithContext(ctx).Model(&model.Position{}).Where("position_id IN ?", ids).Count(&count).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("平台级操作期望可见 2 条，实际 %d 条", count)
+		}
+	})
+
+	t.Run("超级管理员可跨租户", func(t *testing.T) {
+		var count int64
+		ctx := xcontext.SetRoles(tenantCtx(rlsTenantA), []string{constants.SuperAdmin})
+		if err := db.WithContext(ctx).Model(&model.Position{}).Where("position_id IN ?", ids).Count(&count).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("超级管理员期望可见 2 条，实际 %d 条", count)
+		}
+	})
+
+	t.Run("平台级操作后连接不残留旁路角色", func(t *testing.T) {
+		var list []*model.Position
+		if err := db.WithContext(tenantCtx(rlsTenantB)).Where("position_id IN ?", ids).Find(&list).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if len(list) != 1 || list[0].PositionID != rlsPositionB {
+			t.Fatalf("期望只返回 %s，实际 %d 条", rlsPositionB, len(list))
+		}
+	})
+}
+
+func TestTenantIsolationCrossTenant(t *testing.T) {
+	db := setupRLS(t)
+	setupRLSUsers(t, db)
+
+	t.Run("WithTenant按指定租户约束", func(t *testing.T) {
+		// 切换租户：当前处于租户A，读取用户在目标租户B的角色
+		var roleIDs []string
+		ctx := WithTenant(tenantCtx(rlsTenantA), rlsTenantB)
+		if err := db.WithContext(ctx).Model(&model.UserRole{}).Where("user_id = ?", rlsUserA).Pluck("role_id", &roleIDs).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if len(roleIDs) != 1 || roleIDs[0] != rlsRoleB {
+			t.Fatalf("期望读取到目标租户的角色 %s，实际 %v", rlsRoleB, roleIDs)
+		}
+
+		var count int64
+		if err := db.WithContext(ctx).Model(&model.User{}).Where("user_id = ?", rlsUserA).Count(&count).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if count != 0 {
+			t.Fatalf("指定租户B后仍可见当前租户A的用户")
+		}
+	})
+
+	t.Run("成员的主租户账号对加入的租户可见", func(t *testing.T) {
+		// 切换到租户B后按ID读取用户在主租户A的账号
+		var user model.User
+		ctx := SkipTenant(tenantCtx(rlsTenantB))
+		if err := db.WithContext(ctx).Where("user_id = ?", rlsUserA).First(&user).Error; err != nil {
+			t.Fatalf("期望读取到成员的主租户账号，实际 %v", err)
+		}
+
+		// 租户B的用户不是租户A的成员
+		err := db.WithContext(SkipTenant(tenantCtx(rlsTenantA))).Where("user_id = ?", rlsUserB).First(&user).Error
+		if err != gorm.ErrRecordNotFound {
+			t.Fatalf("期望非成员的用户不可见，实际 %v", err)
+		}
+	})
+
+	t.Run("成员不能修改主租户账号", func(t *testing.T) {
+		result := db.WithContext(SkipTenant(tenantCtx(rlsTenantB))).Model(&model.User{}).
+			Where("user_id = ?", rlsUserA).Update("nickname", "hacked")
+		if result.Error != nil {
+			t.Fatalf("更新失败: %v", result.Error)
+		}
+		if result.RowsAffected != 0 {
+			t.Fatalf("加入的租户修改了成员的主租户账号")
+		}
+	})
+
+	t.Run("跨租户邮箱校验需标记平台级操作", func(t *testing.T) {
+		var users []*model.User
+		if err := db.WithContext(SkipTenant(tenantCtx(rlsTenantA))).Where("email = ?", rlsEmail).Find(&users).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if len(users) != 1 {
+			t.Fatalf("SkipTenant 期望只可见本租户 1 个用户，实际 %d 个", len(users))
+		}
+
+		ctx := xcontext.SetPlatformScope(SkipTenant(tenantCtx(rlsTenantA)))
+		if err := db.WithContext(ctx).Where("email = ?", rlsEmail).Find(&users).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if len(users) != 2 {
+			t.Fatalf("期望可见两个租户中使用该邮箱的 2 个用户，实际 %d 个", len(users))
+		}
+	})
+
+	t.Run("切换租户前校验目标租户的成员身份", func(t *testing.T) {
+		// 当前处于主租户A，按目标租户读取成员身份
+		for _, target := range []string{rlsTenantB, rlsTenantC} {
+			var member model.TenantMember
+			ctx := WithTenant(tenantCtx(rlsTenantA), target)
+			err := db.WithContext(ctx).Where("tenant_id = ? AND user_id = ?", target, rlsUserA).First(&member).Error
+			if err != nil {
+				t.Fatalf("期望读取到在租户 %s 的成员身份，实际 %v", target, err)
+			}
+		}
+	})
+
+	t.Run("列出用户加入的所有租户", func(t *testing.T) {
+		var members []*model.TenantMember
+		ctx := xcontext.SetPlatformScope(SkipTenant(tenantCtx(rlsTenantA)))
+		if err := db.WithContext(ctx).Where("user_id = ? AND status = ?", rlsUserA, 1).Find(&members).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if len(members) != 1 || members[0].TenantID != rlsTenantB {
+			t.Fatalf("期望只返回在租户 %s 的正常成员身份，实际 %d 条", rlsTenantB, len(members))
+		}
+	})
+
+	t.Run("成员身份对其他租户不可见", func(t *testing.T) {
+		var count int64
+		if err := db.WithContext(tenantCtx(rlsTenantA)).Model(&model.TenantMember{}).Where("user_id = ?", rlsUserA).Count(&count).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if count != 0 {
+			t.Fatalf("租户A可见其他租户的 %d 条成员身份", count)
+		}
+	})
+
+	t.Run("跨租户读取后连接恢复租户约束", func(t *testing.T) {
+		var users []*model.User
+		if err := db.WithContext(tenantCtx(rlsTenantA)).Where("email = ?", rlsEmail).Find(&users).Error; err != nil {
+			t.Fatalf("查询失败: %v", err)
+		}
+		if len(users) != 1 || users[0].UserID != rlsUserA {
+			t.Fatalf("期望只返回 %s，实际 %d 条", rlsUserA, len(users))
+		}
+	})
+}
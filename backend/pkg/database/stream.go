@@ -0,0 +1,40 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// ScanInBatches 以游标方式逐行读取查询结果，每凑满 batchSize 行回调一次，最后不足一批的行也会回调
+//
+// 结果集不会一次性加载到内存，适用于导出等大数据量场景。行迭代不经过查询回调，
+// 行级安全的租户设置只在显式事务中生效，调用方应在 InTransactionWithCtx 中使用事务的 DB 构造查询；
+// 迭代期间事务连接被占用，fn 中的查询需使用事务外的 DB
+func ScanInBatches[T any](db *gorm.DB, batchSize int, fn func([]*T) error) error {
+	rows, err := db.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := make([]*T, 0, batchSize)
+	for rows.Next() {
+		item := new(T)
+		if err := db.ScanRows(rows, item); err != nil {
+			return err
+		}
+		batch = append(batch, item)
+		if len(batch) >= batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]*T, 0, batchSize)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
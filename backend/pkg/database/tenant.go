@@ -2,10 +2,22 @@ package database
 
 import (
 	"context"
+	"reflect"
+	"sync"
 
 	"admin/pkg/xcontext"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// tenantColumn 租户字段列名
+const tenantColumn = "tenant_id"
+
+type (
+	skipTenantKey  struct{}
+	scopeTenantKey struct{}
 )
 
 // TenantScope 返回 GORM Scope 函数，为查询添加租户过滤条件
@@ -32,3 +44,125 @@ func SetTenantIDFromCtx(ctx context.Context, model TenantScoped) {
 		model.SetTenantID(tenantID)
 	}
 }
+
+// SkipTenant 标记跳过租户自动过滤，用于登录、跨租户统计等需要访问多个租户数据的场景
+// 调用处所在函数需添加 //tenantscope:allow 注解说明原因，CI 中由 tenantscope 检查器校验；
+// 只跳过应用层过滤，数据库行级安全（如已启用）仍按当前租户生效，需要看到其他租户的数据时
+// 由调用方通过 xcontext.SetPlatformScope 显式标记为平台级操作
+func SkipTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipTenantKey{}, true)
+}
+
+// IsSkipTenant 判断是否跳过租户自动过滤
+func IsSkipTenant(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipTenantKey{}).(bool)
+	return skip
+}
+
+// WithTenant 指定自动过滤使用的租户，用于按参数访问指定租户数据的方法（如超级管理员管理其他租户）
+// 租户ID来自请求参数时，调用方须先确认操作人有权访问该租户（非超级管理员只能是当前租户）；
+// 调用处所在函数需添加 //tenantscope:allow 注解说明租户ID的来源。
+// 应用层过滤和数据库行级安全（如已启用）均按指定租户生效，不改变 context 中的当前租户
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, scopeTenantKey{}, tenantID)
+}
+
+// scopeTenantID 自动过滤使用的租户：WithTenant 指定的租户优先，其次为当前租户
+func scopeTenantID(ctx context.Context) string {
+	if tenantID, ok := ctx.Value(scopeTenantKey{}).(string); ok && tenantID != "" {
+		return tenantID
+	}
+	return xcontext.GetTenantID(ctx)
+}
+
+// TenantScopePlugin 租户自动过滤插件
+// 对实现 TenantScoped 的模型：查询、更新、删除自动追加租户条件，创建时为未设置租户的记录填充租户ID。
+// 与 Repository 中显式的租户条件叠加生效，用于兜住遗漏；context 中没有租户时（未认证接口、定时任务）不做处理
+type TenantScopePlugin struct {
+	scoped sync.Map // reflect.Type -> bool
+}
+
+// Name 插件名称
+func (p *TenantScopePlugin) Name() string {
+	return "tenant_scope"
+}
+
+// Initialize 注册回调
+func (p *TenantScopePlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant_scope:create", p.fill); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant_scope:query", p.where); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant_scope:update", p.where); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant_scope:delete", p.where); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("tenant_scope:row", p.where)
+}
+
+// where 追加租户条件
+func (p *TenantScopePlugin) where(db *gorm.DB) {
+	tenantID := p.tenantID(db)
+	if tenantID == "" {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn}, Value: tenantID},
+	}})
+}
+
+// fill 为未设置租户的记录填充租户ID，已设置的保持不变
+func (p *TenantScopePlugin) fill(db *gorm.DB) {
+	tenantID := p.tenantID(db)
+	if tenantID == "" {
+		return
+	}
+	ctx := db.Statement.Context
+	field := db.Statement.Schema.LookUpField(tenantColumn)
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			setTenantIfEmpty(ctx, field, reflect.Indirect(rv.Index(i)), tenantID)
+		}
+	case reflect.Struct:
+		setTenantIfEmpty(ctx, field, rv, tenantID)
+	}
+}
+
+// tenantID 返回语句需要限定的租户，不需要处理时返回空字符串
+func (p *TenantScopePlugin) tenantID(db *gorm.DB) string {
+	if db.Error != nil || db.Statement.Schema == nil || !p.isScoped(db.Statement.Schema) {
+		return ""
+	}
+	ctx := db.Statement.Context
+	if IsSkipTenant(ctx) {
+		return ""
+	}
+	return scopeTenantID(ctx)
+}
+
+// isScoped 判断模型是否实现 TenantScoped 且包含租户字段
+func (p *TenantScopePlugin) isScoped(s *schema.Schema) bool {
+	if v, ok := p.scoped.Load(s.ModelType); ok {
+		return v.(bool)
+	}
+	_, ok := reflect.New(s.ModelType).Interface().(TenantScoped)
+	scoped := ok && s.LookUpField(tenantColumn) != nil
+	p.scoped.Store(s.ModelType, scoped)
+	return scoped
+}
+
+func setTenantIfEmpty(ctx context.Context, field *schema.Field, rv reflect.Value, tenantID string) {
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	if _, zero := field.ValueOf(ctx, rv); zero {
+		_ = field.Set(ctx, rv, tenantID)
+	}
+}
@@ -0,0 +1,40 @@
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+)
+
+// utf8BOM 让 Excel 按 UTF-8 识别中文
+const utf8BOM = "\ufeff"
+
+// StreamWriter 逐行写出 CSV，内存占用与行数无关
+// 输出以 UTF-8 BOM 开头，便于 Excel 直接打开
+type StreamWriter struct {
+	buf    *bufio.Writer
+	writer *csv.Writer
+}
+
+// NewStreamWriter 创建流式写出器，写完所有行后必须调用 Close
+func NewStreamWriter(w io.Writer) (*StreamWriter, error) {
+	buf := bufio.NewWriter(w)
+	if _, err := buf.WriteString(utf8BOM); err != nil {
+		return nil, err
+	}
+	return &StreamWriter{buf: buf, writer: csv.NewWriter(buf)}, nil
+}
+
+// WriteRow 写出一行
+func (s *StreamWriter) WriteRow(values []string) error {
+	return s.writer.Write(values)
+}
+
+// Close 写出缓冲区中剩余的数据，不关闭底层 io.Writer
+func (s *StreamWriter) Close() error {
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil {
+		return err
+	}
+	return s.buf.Flush()
+}
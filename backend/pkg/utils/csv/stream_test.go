@@ -0,0 +1,43 @@
+package csv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStreamWriter(t *testing.T) {
+	var buf bytes.Buffer
+	sw, err := NewStreamWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewStreamWriter() error = %v", err)
+	}
+
+	rows := [][]string{
+		{"用户名", "备注"},
+		{"alice", "含,逗号"},
+		{"bob", "含\"引号\""},
+	}
+	for _, row := range rows {
+		if err := sw.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow() error = %v", err)
+		}
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := utf8BOM + "用户名,备注\nalice,\"含,逗号\"\nbob,\"含\"\"引号\"\"\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	// 去掉 BOM 后应能被解析器原样读回
+	parser := NewParser(bytes.NewReader(buf.Bytes()[len(utf8BOM):]), true)
+	records, err := parser.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records) != 2 || records[1][1] != "含\"引号\"" {
+		t.Errorf("records = %v", records)
+	}
+}
@@ -0,0 +1,113 @@
+package geoip
+
+import (
+	"admin/pkg/utils/useragent"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+)
+
+// 数据库格式
+const (
+	FormatMaxMind   = "mmdb" // MaxMind GeoLite2/GeoIP2 City
+	FormatIp2Region = "xdb"  // ip2region xdb
+)
+
+// InternalLocation 内网 IP 的地理位置标识
+const InternalLocation = "内网IP"
+
+// earthRadiusKm 地球平均半径（公里）
+const earthRadiusKm = 6371.0
+
+var (
+	// ErrInvalidIP 无法解析的 IP 地址
+	ErrInvalidIP = errors.New("无效的IP地址")
+	// ErrNotFound 数据库中没有该 IP 的记录
+	ErrNotFound = errors.New("未找到IP地理位置")
+)
+
+// Location IP 地理位置
+type Location struct {
+	CountryCode string  // 国家代码（ISO 3166-1，如 CN、US）
+	Country     string  // 国家名称
+	Province    string  // 省份/州
+	City        string  // 城市
+	Latitude    float64 // 纬度
+	Longitude   float64 // 经度
+}
+
+// HasCoordinates 是否包含经纬度
+func (l *Location) HasCoordinates() bool {
+	return l != nil && (l.Latitude != 0 || l.Longitude != 0)
+}
+
+// String 地理位置描述，如 "中国 广东省 深圳市"，相邻重复的名称只保留一个
+func (l *Location) String() string {
+	if l == nil {
+		return ""
+	}
+	parts := make([]string, 0, 3)
+	for _, name := range []string{l.Country, l.Province, l.City} {
+		if name == "" || (len(parts) > 0 && parts[len(parts)-1] == name) {
+			continue
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Resolver IP 地理位置解析器
+type Resolver interface {
+	// Lookup 查询 IP 的地理位置
+	Lookup(ip string) (*Location, error)
+	// Close 释放数据库文件
+	Close() error
+}
+
+// Distance 计算两个经纬度之间的球面距离（公里，Haversine 公式）
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// Open 按格式打开数据库文件，format 为空时根据文件扩展名识别
+func Open(path, format string) (Resolver, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	switch format {
+	case FormatMaxMind:
+		return NewMaxMindResolver(path)
+	case FormatIp2Region:
+		return NewIp2RegionResolver(path)
+	default:
+		return nil, fmt.Errorf("unsupported geoip database format: %s", format)
+	}
+}
+
+// Describe 返回 IP 的地理位置描述
+// 内网 IP 标记为 InternalLocation；解析器为空或查询失败时返回空字符串
+func Describe(r Resolver, ip string) string {
+	if ip == "" {
+		return ""
+	}
+	if useragent.IsInternalIP(ip) {
+		return InternalLocation
+	}
+	if r == nil {
+		return ""
+	}
+	loc, err := r.Lookup(ip)
+	if err != nil {
+		return ""
+	}
+	return loc.String()
+}
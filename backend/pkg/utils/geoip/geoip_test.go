@@ -0,0 +1,46 @@
+package geoip
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want                   float64
+	}{
+		{"same point", 39.9042, 116.4074, 39.9042, 116.4074, 0},
+		{"beijing to shanghai", 39.9042, 116.4074, 31.2304, 121.4737, 1067},
+		{"london to new york", 51.5074, -0.1278, 40.7128, -74.0060, 5570},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Distance(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if math.Abs(got-tt.want) > tt.want*0.01+1 {
+				t.Errorf("Distance() = %.1f km, want about %.1f km", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocationHasCoordinates(t *testing.T) {
+	var nilLoc *Location
+	if nilLoc.HasCoordinates() {
+		t.Error("nil location should not have coordinates")
+	}
+	if (&Location{CountryCode: "CN"}).HasCoordinates() {
+		t.Error("location without lat/lon should not have coordinates")
+	}
+	if !(&Location{Latitude: 31.2, Longitude: 121.4}).HasCoordinates() {
+		t.Error("location with lat/lon should have coordinates")
+	}
+}
+
+func TestNewMaxMindResolverMissingFile(t *testing.T) {
+	if _, err := NewMaxMindResolver("testdata/not-exist.mmdb"); err == nil {
+		t.Fatal("NewMaxMindResolver with missing file should fail")
+	}
+}
@@ -0,0 +1,118 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// ip2region xdb（v2）文件结构：
+// | header(256) | vector index(256*256*8) | region data | segment index(n*14) |
+// vector index 按 IP 前两个字节定位 segment index 区间，segment index 内二分查找
+const (
+	xdbHeaderSize        = 256
+	xdbVectorIndexCols   = 256
+	xdbVectorIndexSize   = 8
+	xdbSegmentIndexSize  = 14
+	xdbVectorIndexLength = xdbVectorIndexCols * xdbVectorIndexCols * xdbVectorIndexSize
+)
+
+// Ip2RegionResolver 基于 ip2region xdb 数据库的解析器（仅支持 IPv4）
+// 整个文件加载到内存中查询，xdb 不包含国家代码和经纬度
+type Ip2RegionResolver struct {
+	content []byte
+}
+
+// NewIp2RegionResolver 加载本地 xdb 数据库文件
+func NewIp2RegionResolver(path string) (*Ip2RegionResolver, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open xdb %s failed: %w", path, err)
+	}
+	if len(content) < xdbHeaderSize+xdbVectorIndexLength {
+		return nil, fmt.Errorf("invalid xdb %s: file too small", path)
+	}
+	return &Ip2RegionResolver{content: content}, nil
+}
+
+// Lookup 查询 IP 的地理位置
+func (r *Ip2RegionResolver) Lookup(ip string) (*Location, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, ErrInvalidIP
+	}
+	ip4 := parsed.To4()
+	if ip4 == nil {
+		// xdb 只收录 IPv4
+		return nil, ErrNotFound
+	}
+
+	region, err := r.search(binary.BigEndian.Uint32(ip4))
+	if err != nil {
+		return nil, err
+	}
+	return parseRegion(region)
+}
+
+// Close 释放内存中的数据库
+func (r *Ip2RegionResolver) Close() error {
+	r.content = nil
+	return nil
+}
+
+// search 查询 IP 对应的 region 字符串
+func (r *Ip2RegionResolver) search(ip uint32) (string, error) {
+	il0, il1 := int(ip>>24&0xFF), int(ip>>16&0xFF)
+	offset := xdbHeaderSize + il0*xdbVectorIndexCols*xdbVectorIndexSize + il1*xdbVectorIndexSize
+	sPtr := int(binary.LittleEndian.Uint32(r.content[offset:]))
+	ePtr := int(binary.LittleEndian.Uint32(r.content[offset+4:]))
+	if sPtr == 0 && ePtr == 0 {
+		return "", ErrNotFound
+	}
+
+	l, h := 0, (ePtr-sPtr)/xdbSegmentIndexSize
+	for l <= h {
+		m := (l + h) / 2
+		p := sPtr + m*xdbSegmentIndexSize
+		if p+xdbSegmentIndexSize > len(r.content) {
+			return "", fmt.Errorf("invalid xdb: segment index out of range")
+		}
+
+		if ip < binary.LittleEndian.Uint32(r.content[p:]) {
+			h = m - 1
+		} else if ip > binary.LittleEndian.Uint32(r.content[p+4:]) {
+			l = m + 1
+		} else {
+			dataLen := int(binary.LittleEndian.Uint16(r.content[p+8:]))
+			dataPtr := int(binary.LittleEndian.Uint32(r.content[p+10:]))
+			if dataPtr+dataLen > len(r.content) {
+				return "", fmt.Errorf("invalid xdb: region data out of range")
+			}
+			return string(r.content[dataPtr : dataPtr+dataLen]), nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// parseRegion 解析 region 字符串：国家|区域|省份|城市|ISP，缺失字段为 0
+func parseRegion(region string) (*Location, error) {
+	fields := strings.Split(region, "|")
+	field := func(i int) string {
+		if i >= len(fields) || fields[i] == "0" {
+			return ""
+		}
+		return fields[i]
+	}
+
+	loc := &Location{
+		Country:  field(0),
+		Province: field(2),
+		City:     field(3),
+	}
+	if loc.Country == "" && loc.Province == "" && loc.City == "" {
+		return nil, ErrNotFound
+	}
+	return loc, nil
+}
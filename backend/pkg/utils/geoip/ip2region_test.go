@@ -0,0 +1,180 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type xdbSegment struct {
+	start, end uint32
+	region     string
+}
+
+// buildXdb 构造测试用 xdb 文件内容，每个区间须落在同一个 /16 网段内且按顺序排列
+func buildXdb(segments []xdbSegment) []byte {
+	buf := make([]byte, xdbHeaderSize+xdbVectorIndexLength)
+
+	dataPtrs := make([]int, len(segments))
+	for i, seg := range segments {
+		dataPtrs[i] = len(buf)
+		buf = append(buf, seg.region...)
+	}
+
+	for i, seg := range segments {
+		ptr := len(buf)
+		entry := make([]byte, xdbSegmentIndexSize)
+		binary.LittleEndian.PutUint32(entry[0:], seg.start)
+		binary.LittleEndian.PutUint32(entry[4:], seg.end)
+		binary.LittleEndian.PutUint16(entry[8:], uint16(len(seg.region)))
+		binary.LittleEndian.PutUint32(entry[10:], uint32(dataPtrs[i]))
+		buf = append(buf, entry...)
+
+		offset := xdbHeaderSize + int(seg.start>>24&0xFF)*xdbVectorIndexCols*xdbVectorIndexSize + int(seg.start>>16&0xFF)*xdbVectorIndexSize
+		if binary.LittleEndian.Uint32(buf[offset:]) == 0 {
+			binary.LittleEndian.PutUint32(buf[offset:], uint32(ptr))
+		}
+		binary.LittleEndian.PutUint32(buf[offset+4:], uint32(ptr))
+	}
+	return buf
+}
+
+func ipv4(a, b, c, d byte) uint32 {
+	return binary.BigEndian.Uint32([]byte{a, b, c, d})
+}
+
+func writeXdb(t *testing.T, path string, segments []xdbSegment) {
+	t.Helper()
+	if err := os.WriteFile(path, buildXdb(segments), 0o644); err != nil {
+		t.Fatalf("write xdb failed: %v", err)
+	}
+}
+
+func TestIp2RegionResolverLookup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip2region.xdb")
+	writeXdb(t, path, []xdbSegment{
+		{ipv4(1, 2, 0, 0), ipv4(1, 2, 127, 255), "中国|0|广东省|深圳市|电信"},
+		{ipv4(1, 2, 128, 0), ipv4(1, 2, 255, 255), "中国|0|北京|北京市|联通"},
+		{ipv4(8, 8, 8, 0), ipv4(8, 8, 8, 255), "美国|0|0|0|Google"},
+	})
+
+	r, err := NewIp2RegionResolver(path)
+	if err != nil {
+		t.Fatalf("NewIp2RegionResolver() error = %v", err)
+	}
+	defer r.Close()
+
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"1.2.3.4", "中国 广东省 深圳市"},
+		{"1.2.200.1", "中国 北京 北京市"},
+		{"8.8.8.8", "美国"},
+	}
+	for _, tt := range tests {
+		loc, err := r.Lookup(tt.ip)
+		if err != nil {
+			t.Fatalf("Lookup(%s) error = %v", tt.ip, err)
+		}
+		if got := loc.String(); got != tt.want {
+			t.Errorf("Lookup(%s) = %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+
+	if _, err := r.Lookup("9.9.9.9"); err != ErrNotFound {
+		t.Errorf("Lookup(9.9.9.9) error = %v, want ErrNotFound", err)
+	}
+	if _, err := r.Lookup("::1"); err != ErrNotFound {
+		t.Errorf("Lookup(::1) error = %v, want ErrNotFound", err)
+	}
+	if _, err := r.Lookup("bad-ip"); err != ErrInvalidIP {
+		t.Errorf("Lookup(bad-ip) error = %v, want ErrInvalidIP", err)
+	}
+}
+
+func TestOpenFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip2region.xdb")
+	writeXdb(t, path, []xdbSegment{{ipv4(1, 2, 0, 0), ipv4(1, 2, 255, 255), "中国|0|上海|上海市|电信"}})
+
+	r, err := Open(path, "")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer r.Close()
+	if _, ok := r.(*Ip2RegionResolver); !ok {
+		t.Errorf("Open() = %T, want *Ip2RegionResolver", r)
+	}
+
+	if _, err := Open(path, "csv"); err == nil {
+		t.Error("Open() with unsupported format should fail")
+	}
+}
+
+func TestReloadingResolver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip2region.xdb")
+	writeXdb(t, path, []xdbSegment{{ipv4(1, 2, 0, 0), ipv4(1, 2, 255, 255), "中国|0|广东省|深圳市|电信"}})
+
+	r, err := NewReloadingResolver(path, func(p string) (Resolver, error) { return NewIp2RegionResolver(p) }, 0)
+	if err != nil {
+		t.Fatalf("NewReloadingResolver() error = %v", err)
+	}
+	defer r.Close()
+
+	if got := Describe(r, "1.2.3.4"); got != "中国 广东省 深圳市" {
+		t.Fatalf("Describe() = %q before reload", got)
+	}
+
+	if reloaded, err := r.Reload(); err != nil || reloaded {
+		t.Fatalf("Reload() on unchanged file = %v, %v", reloaded, err)
+	}
+
+	writeXdb(t, path, []xdbSegment{{ipv4(1, 2, 0, 0), ipv4(1, 2, 255, 255), "中国|0|浙江省|杭州市|电信"}})
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("chtimes failed: %v", err)
+	}
+
+	if reloaded, err := r.Reload(); err != nil || !reloaded {
+		t.Fatalf("Reload() on updated file = %v, %v", reloaded, err)
+	}
+	if got := Describe(r, "1.2.3.4"); got != "中国 浙江省 杭州市" {
+		t.Errorf("Describe() = %q after reload", got)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"", ""},
+		{"127.0.0.1", InternalLocation},
+		{"192.168.1.10", InternalLocation},
+		{"10.0.0.1", InternalLocation},
+		{"8.8.8.8", ""},
+	}
+	for _, tt := range tests {
+		if got := Describe(nil, tt.ip); got != tt.want {
+			t.Errorf("Describe(nil, %q) = %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestLocationString(t *testing.T) {
+	tests := []struct {
+		loc  *Location
+		want string
+	}{
+		{nil, ""},
+		{&Location{Country: "中国", Province: "北京", City: "北京"}, "中国 北京"},
+		{&Location{Country: "United States", City: "Mountain View"}, "United States Mountain View"},
+	}
+	for _, tt := range tests {
+		if got := tt.loc.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// MaxMindResolver 基于 MaxMind GeoLite2/GeoIP2 City 数据库（.mmdb）的解析器
+type MaxMindResolver struct {
+	reader *geoip2.Reader
+}
+
+// NewMaxMindResolver 打开本地 mmdb 数据库文件
+func NewMaxMindResolver(path string) (*MaxMindResolver, error) {
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open mmdb %s failed: %w", path, err)
+	}
+	return &MaxMindResolver{reader: reader}, nil
+}
+
+// Lookup 查询 IP 的地理位置
+func (r *MaxMindResolver) Lookup(ip string) (*Location, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, ErrInvalidIP
+	}
+
+	record, err := r.reader.City(parsed)
+	if err != nil {
+		return nil, err
+	}
+	if record.Country.IsoCode == "" && record.Location.Latitude == 0 && record.Location.Longitude == 0 {
+		return nil, ErrNotFound
+	}
+
+	loc := &Location{
+		CountryCode: record.Country.IsoCode,
+		Country:     localizedName(record.Country.Names),
+		City:        localizedName(record.City.Names),
+		Latitude:    record.Location.Latitude,
+		Longitude:   record.Location.Longitude,
+	}
+	if len(record.Subdivisions) > 0 {
+		loc.Province = localizedName(record.Subdivisions[0].Names)
+	}
+	return loc, nil
+}
+
+// Close 关闭数据库文件
+func (r *MaxMindResolver) Close() error {
+	return r.reader.Close()
+}
+
+// localizedName 优先取简体中文名称，缺失时回退英文
+func localizedName(names map[string]string) string {
+	if name := names["zh-CN"]; name != "" {
+		return name
+	}
+	return names["en"]
+}
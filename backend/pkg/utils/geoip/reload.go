@@ -0,0 +1,129 @@
+package geoip
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OpenFunc 打开数据库文件创建解析器
+type OpenFunc func(path string) (Resolver, error)
+
+// ReloadingResolver 支持热更新的解析器
+// 定期检查数据库文件修改时间，文件更新后重新加载并原子替换，加载失败时继续使用旧数据
+type ReloadingResolver struct {
+	path     string
+	open     OpenFunc
+	interval time.Duration
+
+	mu       sync.RWMutex
+	current  Resolver
+	modTime  time.Time
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewReloadingResolver 加载数据库并启动热更新检查
+// interval <= 0 时只加载一次，不做热更新
+func NewReloadingResolver(path string, open OpenFunc, interval time.Duration) (*ReloadingResolver, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	current, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ReloadingResolver{
+		path:     path,
+		open:     open,
+		interval: interval,
+		current:  current,
+		modTime:  stat.ModTime(),
+		stopCh:   make(chan struct{}),
+	}
+	if interval > 0 {
+		go r.watch()
+	}
+	return r, nil
+}
+
+// Lookup 查询 IP 的地理位置
+func (r *ReloadingResolver) Lookup(ip string) (*Location, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.current == nil {
+		return nil, ErrNotFound
+	}
+	return r.current.Lookup(ip)
+}
+
+// Reload 文件有更新时重新加载，返回是否发生了替换
+func (r *ReloadingResolver) Reload() (bool, error) {
+	stat, err := os.Stat(r.path)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := stat.ModTime().Equal(r.modTime)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	next, err := r.open(r.path)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	old := r.current
+	r.current = next
+	r.modTime = stat.ModTime()
+	r.mu.Unlock()
+
+	// 写锁保证旧解析器上的查询均已结束
+	if old != nil {
+		old.Close()
+	}
+	return true, nil
+}
+
+// Close 停止热更新并释放数据库
+func (r *ReloadingResolver) Close() error {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+func (r *ReloadingResolver) watch() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				log.Warn().Err(err).Str("path", r.path).Msg("IP 地理位置库热更新失败，继续使用旧数据")
+				continue
+			}
+			if reloaded {
+				log.Info().Str("path", r.path).Msg("IP 地理位置库已重新加载")
+			}
+		}
+	}
+}
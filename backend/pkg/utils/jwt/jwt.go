@@ -21,9 +21,17 @@ type Claims struct {
 	Roles      []string `json:"roles"`              // 角色编码列表
 	RoleIDs    []string `json:"role_ids"`           // 角色ID列表（用于 PermissionCache 查询）
 	TokenID    string   `json:"token_id,omitempty"` // refresh token的唯一标识
+	Act        *Actor   `json:"act,omitempty"`      // 实际操作者（模拟登录时为发起模拟的管理员，RFC 8693）
 	jwt.RegisteredClaims
 }
 
+// Actor 模拟登录的实际操作者
+type Actor struct {
+	TenantID string `json:"tenant_id"` // 操作者租户ID
+	UserID   string `json:"user_id"`   // 操作者用户ID
+	UserName string `json:"user_name"` // 操作者用户名
+}
+
 type TokenPair struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
@@ -43,6 +51,7 @@ type JWTConfig struct {
 var (
 	ErrTokenExpired      = jwt.ErrTokenExpired
 	ErrTokenBlacklisted  = errors.New("令牌已被加入黑名单")
+	ErrSessionIdle       = errors.New("会话空闲超时")
 	ErrInvalidToken      = errors.New("无效的令牌")
 	ErrMissingToken      = errors.New("缺少令牌")
 	ErrInvalidClaims     = errors.New("无效的声明")
@@ -77,6 +86,45 @@ func GenerateTokenPair(tenantID, tenantCode, userID, userName string, roles, rol
 	}, nil
 }
 
+// GenerateAccessToken 仅生成 access token（不签发 refresh token）
+// 用于 OAuth2 client_credentials 等机器授权场景，过期后由客户端重新换取
+func GenerateAccessToken(tenantID, tenantCode, userID, userName string, roles, roleIDs []string, config *JWTConfig) (*TokenPair, error) {
+	tokenID := uuid.New().String()
+
+	accessToken, err := generateToken(tenantID, tenantCode, userID, userName, roles, roleIDs, tokenID, config.AccessExpire, config.AccessSecret, config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken: accessToken,
+		TokenID:     tokenID,
+		ExpiresIn:   config.AccessExpire,
+	}, nil
+}
+
+// GenerateImpersonationToken 生成模拟登录 access token（不签发 refresh token）
+// 令牌身份为目标用户，act 声明记录实际操作者，有效期由调用方指定（秒）
+func GenerateImpersonationToken(tenantID, tenantCode, userID, userName string, roles, roleIDs []string, actor *Actor, expire int64, config *JWTConfig) (*TokenPair, error) {
+	if actor == nil {
+		return nil, ErrInvalidClaims
+	}
+	tokenID := uuid.New().String()
+
+	claims := newClaims(tenantID, tenantCode, userID, userName, roles, roleIDs, tokenID, expire, config.Issuer)
+	claims.Act = actor
+	accessToken, err := signToken(claims, config.AccessSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken: accessToken,
+		TokenID:     tokenID,
+		ExpiresIn:   expire,
+	}, nil
+}
+
 // VerifyToken 验证任意 token（去除 Bearer 前缀，校验签名与过期）
 func VerifyToken(tokenString string, secret []byte) (*Claims, error) {
 	return verifyToken(tokenString, secret)
@@ -84,8 +132,14 @@ func VerifyToken(tokenString string, secret []byte) (*Claims, error) {
 
 // generateToken 生成单个 token（带 Claims）
 func generateToken(tenantID, tenantCode, userID, userName string, roles, roleIDs []string, tokenID string, expire int64, secret []byte, issuer string) (string, error) {
+	claims := newClaims(tenantID, tenantCode, userID, userName, roles, roleIDs, tokenID, expire, issuer)
+	return signToken(claims, secret)
+}
+
+// newClaims 构造声明
+func newClaims(tenantID, tenantCode, userID, userName string, roles, roleIDs []string, tokenID string, expire int64, issuer string) *Claims {
 	now := time.Now()
-	claims := &Claims{
+	return &Claims{
 		TenantID:   tenantID,
 		TenantCode: tenantCode,
 		UserID:     userID,
@@ -100,7 +154,10 @@ func generateToken(tenantID, tenantCode, userID, userName string, roles, roleIDs
 			Issuer:    issuer,
 		},
 	}
+}
 
+// signToken 使用 HS256 签名声明
+func signToken(claims *Claims, secret []byte) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenStr, err := token.SignedString(secret)
 	if err != nil {
@@ -52,6 +52,63 @@ func TestGenerateTokenPairAndParse(t *testing.T) {
 	}
 }
 
+func TestGenerateAccessToken(t *testing.T) {
+	cfg := testConfig()
+
+	pair, err := GenerateAccessToken("tenant-1", "tenant-1", "sa-1", "ci-bot", []string{"role-1"}, []string{"role-id-1"}, cfg)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken returned error: %v", err)
+	}
+	if pair.AccessToken == "" || pair.TokenID == "" {
+		t.Fatalf("GenerateAccessToken returned empty token: %+v", pair)
+	}
+	if pair.RefreshToken != "" {
+		t.Fatalf("GenerateAccessToken should not issue refresh token")
+	}
+	if pair.ExpiresIn != cfg.AccessExpire {
+		t.Fatalf("expires_in = %d, want %d", pair.ExpiresIn, cfg.AccessExpire)
+	}
+
+	claims, err := VerifyToken(pair.AccessToken, []byte(cfg.AccessSecret))
+	if err != nil {
+		t.Fatalf("VerifyToken(access) returned error: %v", err)
+	}
+	if claims.UserID != "sa-1" || claims.TokenID != pair.TokenID {
+		t.Fatalf("unexpected access claims: %+v", claims)
+	}
+}
+
+func TestGenerateImpersonationToken(t *testing.T) {
+	cfg := testConfig()
+	actor := &Actor{TenantID: "tenant-0", UserID: "admin-1", UserName: "admin"}
+
+	pair, err := GenerateImpersonationToken("tenant-1", "tenant-1", "user-1", "alice", []string{"role-1"}, []string{"role-id-1"}, actor, 600, cfg)
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken returned error: %v", err)
+	}
+	if pair.RefreshToken != "" {
+		t.Fatalf("GenerateImpersonationToken should not issue refresh token")
+	}
+	if pair.ExpiresIn != 600 {
+		t.Fatalf("expires_in = %d, want 600", pair.ExpiresIn)
+	}
+
+	claims, err := VerifyToken(pair.AccessToken, []byte(cfg.AccessSecret))
+	if err != nil {
+		t.Fatalf("VerifyToken(access) returned error: %v", err)
+	}
+	if claims.UserID != "user-1" || claims.Act == nil || claims.Act.UserID != "admin-1" {
+		t.Fatalf("unexpected impersonation claims: %+v", claims)
+	}
+	if got := claims.ExpiresAt.Sub(claims.IssuedAt.Time); got != 600*time.Second {
+		t.Fatalf("token lifetime = %v, want 10m", got)
+	}
+
+	if _, err := GenerateImpersonationToken("tenant-1", "tenant-1", "user-1", "alice", nil, nil, nil, 600, cfg); err == nil {
+		t.Fatalf("GenerateImpersonationToken without actor should fail")
+	}
+}
+
 func TestVerifyTokenInvalidSignature(t *testing.T) {
 	cfg := testConfig()
 
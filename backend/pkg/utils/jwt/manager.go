@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type Manager struct {
@@ -53,10 +54,47 @@ func (m *Manager) GenerateTokenPair(ctx context.Context, tenantID, tenantCode, u
 	return tokenPair, nil
 }
 
+// GenerateAccessToken 仅生成 access token（client_credentials 授权）
+// 说明：
+// - 不签发 refresh token
+// - 仍维护用户会话索引，便于轮换密钥时通过 RevokeAllUserTokens 统一吊销
+func (m *Manager) GenerateAccessToken(ctx context.Context, tenantID, tenantCode, userID, userName string, roles, roleIDs []string) (*TokenPair, error) {
+	tokenPair, err := GenerateAccessToken(tenantID, tenantCode, userID, userName, roles, roleIDs, m.config)
+	if err != nil {
+		return nil, fmt.Errorf("generate access token failed: %w", err)
+	}
+
+	userKey := m.generateUserKey(tenantCode, userID)
+	if err := m.store.AddUserToken(ctx, userKey, tokenPair.TokenID, m.config.AccessExpire); err != nil {
+		return nil, fmt.Errorf("add user token index failed: %w", err)
+	}
+
+	return tokenPair, nil
+}
+
+// GenerateImpersonationToken 生成模拟登录 access token
+// 说明：
+// - 不签发 refresh token，到期即结束模拟
+// - 会话索引到目标用户集合，目标用户被强制下线时一并失效
+func (m *Manager) GenerateImpersonationToken(ctx context.Context, tenantID, tenantCode, userID, userName string, roles, roleIDs []string, actor *Actor, expire int64) (*TokenPair, error) {
+	tokenPair, err := GenerateImpersonationToken(tenantID, tenantCode, userID, userName, roles, roleIDs, actor, expire, m.config)
+	if err != nil {
+		return nil, fmt.Errorf("generate impersonation token failed: %w", err)
+	}
+
+	userKey := m.generateUserKey(tenantCode, userID)
+	if err := m.store.AddUserToken(ctx, userKey, tokenPair.TokenID, expire); err != nil {
+		return nil, fmt.Errorf("add user token index failed: %w", err)
+	}
+
+	return tokenPair, nil
+}
+
 // VerifyAccessToken 验证 access token（签名/过期/黑名单）
 // 说明：
 // - 先校验签名与过期；若过期将返回 ErrTokenExpired（来自第三方库）
 // - 再检查是否命中黑名单，命中则返回 ErrTokenBlacklisted
+// - 开启空闲检查的会话超时后返回 ErrSessionIdle，否则记录本次活动
 // 返回值：
 // - Claims: token 中的声明信息
 // - error: 验证失败的错误原因
@@ -75,6 +113,11 @@ func (m *Manager) VerifyAccessToken(ctx context.Context, tokenString string) (*C
 		return nil, ErrTokenBlacklisted
 	}
 
+	// 空闲超时校验：超时即撤销会话，未超时则记录本次活动
+	if _, err := m.checkSession(ctx, claims.TokenID, true); err != nil {
+		return nil, err
+	}
+
 	return claims, nil
 }
 
@@ -83,6 +126,7 @@ func (m *Manager) VerifyAccessToken(ctx context.Context, tokenString string) (*C
 // - 先校验签名与过期；若过期将返回 ErrTokenExpired（来自第三方库）
 // - 再检查是否命中黑名单，命中则返回 ErrTokenBlacklisted
 // - 检查 refresh token 是否匹配存储值
+// - 开启空闲检查的会话超时后返回 ErrSessionIdle，未超时则延续到新会话
 // 返回值：
 // - TokenPair: 刷新后的令牌对（access + refresh）
 // - error: 验证失败的错误原因
@@ -104,6 +148,12 @@ func (m *Manager) VerifyRefreshToken(ctx context.Context, refreshToken string) (
 		return nil, errors.New("refresh token not match")
 	}
 
+	// 空闲超时的会话不能刷新
+	session, err := m.checkSession(ctx, claims.TokenID, false)
+	if err != nil {
+		return nil, err
+	}
+
 	// 撤销旧会话：将旧 tokenID 置入黑名单，TTL 为 access token 生命周期
 	// 说明：刷新后旧 access token 需要立即失效，避免并发窗口
 	if err := m.store.BlacklistToken(ctx, claims.TokenID, m.config.AccessExpire); err != nil {
@@ -135,9 +185,63 @@ func (m *Manager) VerifyRefreshToken(ctx context.Context, refreshToken string) (
 		return nil, fmt.Errorf("add new user token index failed: %w", err)
 	}
 
+	// 空闲超时随会话延续到新 tokenID，刷新视为一次活动
+	if session != nil {
+		if err := m.StartSession(ctx, tokenPair.TokenID, session.IdleTimeout); err != nil {
+			return nil, err
+		}
+		if err := m.store.DeleteSession(ctx, claims.TokenID); err != nil {
+			return nil, fmt.Errorf("delete old session failed: %w", err)
+		}
+	}
+
 	return tokenPair, nil
 }
 
+// StartSession 为会话开启空闲超时检查
+// 说明：
+// - idleTimeout 为空闲超时（秒），<= 0 时不做空闲检查
+// - 超过 idleTimeout 没有使用 access token 或刷新时，会话被撤销，需要重新登录
+func (m *Manager) StartSession(ctx context.Context, tokenID string, idleTimeout int64) error {
+	if idleTimeout <= 0 {
+		return nil
+	}
+	session := Session{IdleTimeout: idleTimeout, ActiveAt: time.Now().UnixMilli()}
+	if err := m.store.SetSession(ctx, tokenID, session, m.config.RefreshExpire); err != nil {
+		return fmt.Errorf("start session failed: %w", err)
+	}
+	return nil
+}
+
+// checkSession 校验会话是否空闲超时，超时则撤销会话并返回 ErrSessionIdle
+// 未开启空闲检查的会话（服务账号、模拟登录等）返回 nil；touch 为 true 时记录本次活动
+func (m *Manager) checkSession(ctx context.Context, tokenID string, touch bool) (*Session, error) {
+	if tokenID == "" {
+		return nil, nil
+	}
+	session, ok, err := m.store.GetSession(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("get session failed: %w", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	now := time.Now().UnixMilli()
+	if now-session.ActiveAt > session.IdleTimeout*1000 {
+		if err := m.RevokeToken(ctx, tokenID); err != nil {
+			return nil, err
+		}
+		return nil, ErrSessionIdle
+	}
+	if touch {
+		if err := m.store.TouchSession(ctx, tokenID, now); err != nil {
+			return nil, fmt.Errorf("touch session failed: %w", err)
+		}
+	}
+	return &session, nil
+}
+
 // RevokeToken 撤销指定 tokenID 的会话
 // 说明：
 // - 将 tokenID 放入黑名单（TTL 为 access token 生命周期）
@@ -151,6 +255,10 @@ func (m *Manager) RevokeToken(ctx context.Context, tokenID string) error {
 	if err := m.store.Delete(ctx, tokenID); err != nil {
 		return fmt.Errorf("delete refresh token failed: %w", err)
 	}
+	// 删除会话空闲状态
+	if err := m.store.DeleteSession(ctx, tokenID); err != nil {
+		return fmt.Errorf("delete session failed: %w", err)
+	}
 	return nil
 }
 
@@ -172,6 +280,9 @@ func (m *Manager) RevokeAllUserTokens(ctx context.Context, tenantID, userID stri
 		if err := m.store.Delete(ctx, tid); err != nil {
 			return fmt.Errorf("delete user token failed: %w", err)
 		}
+		if err := m.store.DeleteSession(ctx, tid); err != nil {
+			return fmt.Errorf("delete user session failed: %w", err)
+		}
 		if err := m.store.RemoveUserToken(ctx, userKey, tid); err != nil {
 			return fmt.Errorf("remove user token index failed: %w", err)
 		}
@@ -0,0 +1,211 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// memoryStore 内存实现的 Store，仅用于测试
+type memoryStore struct {
+	refresh   map[string]string
+	users     map[string]map[string]bool
+	blacklist map[string]bool
+	sessions  map[string]Session
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		refresh:   make(map[string]string),
+		users:     make(map[string]map[string]bool),
+		blacklist: make(map[string]bool),
+		sessions:  make(map[string]Session),
+	}
+}
+
+func (s *memoryStore) Set(ctx context.Context, tokenID, refreshToken string, expiration int64) error {
+	s.refresh[tokenID] = refreshToken
+	return nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, tokenID string) (string, error) {
+	token, ok := s.refresh[tokenID]
+	if !ok {
+		return "", errors.New("refresh token not found")
+	}
+	return token, nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, tokenID string) error {
+	delete(s.refresh, tokenID)
+	return nil
+}
+
+func (s *memoryStore) AddUserToken(ctx context.Context, userID, tokenID string, expiration int64) error {
+	if s.users[userID] == nil {
+		s.users[userID] = make(map[string]bool)
+	}
+	s.users[userID][tokenID] = true
+	return nil
+}
+
+func (s *memoryStore) RemoveUserToken(ctx context.Context, userID, tokenID string) error {
+	delete(s.users[userID], tokenID)
+	return nil
+}
+
+func (s *memoryStore) GetUserTokens(ctx context.Context, userID string) ([]string, error) {
+	tokenIDs := make([]string, 0, len(s.users[userID]))
+	for tokenID := range s.users[userID] {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	return tokenIDs, nil
+}
+
+func (s *memoryStore) BlacklistToken(ctx context.Context, tokenID string, expiration int64) error {
+	s.blacklist[tokenID] = true
+	return nil
+}
+
+func (s *memoryStore) IsBlacklisted(ctx context.Context, tokenID string) (bool, error) {
+	return s.blacklist[tokenID], nil
+}
+
+func (s *memoryStore) SetSession(ctx context.Context, tokenID string, session Session, expiration int64) error {
+	s.sessions[tokenID] = session
+	return nil
+}
+
+func (s *memoryStore) GetSession(ctx context.Context, tokenID string) (Session, bool, error) {
+	session, ok := s.sessions[tokenID]
+	return session, ok, nil
+}
+
+func (s *memoryStore) TouchSession(ctx context.Context, tokenID string, activeAt int64) error {
+	if session, ok := s.sessions[tokenID]; ok {
+		session.ActiveAt = activeAt
+		s.sessions[tokenID] = session
+	}
+	return nil
+}
+
+func (s *memoryStore) DeleteSession(ctx context.Context, tokenID string) error {
+	delete(s.sessions, tokenID)
+	return nil
+}
+
+// idle 将会话的最近活动时间回拨 d
+func (s *memoryStore) idle(tokenID string, d time.Duration) {
+	session := s.sessions[tokenID]
+	session.ActiveAt -= d.Milliseconds()
+	s.sessions[tokenID] = session
+}
+
+func newTestSession(t *testing.T, idleTimeout int64) (*Manager, *memoryStore, *TokenPair) {
+	t.Helper()
+
+	store := newMemoryStore()
+	m := NewManager(testConfig(), store)
+	ctx := context.Background()
+	pair, err := m.GenerateTokenPair(ctx, "tenant-1", "tenant-1", "user-1", "user-1", []string{"role-1"}, []string{"role-id-1"})
+	if err != nil {
+		t.Fatalf("GenerateTokenPair returned error: %v", err)
+	}
+	if err := m.StartSession(ctx, pair.TokenID, idleTimeout); err != nil {
+		t.Fatalf("StartSession returned error: %v", err)
+	}
+	return m, store, pair
+}
+
+func TestVerifyAccessTokenIdleSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("active session is touched", func(t *testing.T) {
+		m, store, pair := newTestSession(t, 60)
+		store.idle(pair.TokenID, 30*time.Second)
+		before := store.sessions[pair.TokenID].ActiveAt
+
+		if _, err := m.VerifyAccessToken(ctx, pair.AccessToken); err != nil {
+			t.Fatalf("VerifyAccessToken returned error: %v", err)
+		}
+		if store.sessions[pair.TokenID].ActiveAt <= before {
+			t.Fatalf("VerifyAccessToken did not record activity")
+		}
+	})
+
+	t.Run("idle session is revoked", func(t *testing.T) {
+		m, store, pair := newTestSession(t, 60)
+		store.idle(pair.TokenID, 61*time.Second)
+
+		if _, err := m.VerifyAccessToken(ctx, pair.AccessToken); !errors.Is(err, ErrSessionIdle) {
+			t.Fatalf("VerifyAccessToken error = %v, want %v", err, ErrSessionIdle)
+		}
+		if !store.blacklist[pair.TokenID] {
+			t.Fatalf("idle session was not blacklisted")
+		}
+		if _, ok := store.refresh[pair.TokenID]; ok {
+			t.Fatalf("idle session refresh token was not deleted")
+		}
+		if _, err := m.VerifyAccessToken(ctx, pair.AccessToken); !errors.Is(err, ErrTokenBlacklisted) {
+			t.Fatalf("VerifyAccessToken after revoke error = %v, want %v", err, ErrTokenBlacklisted)
+		}
+	})
+
+	t.Run("untracked session is not checked", func(t *testing.T) {
+		m, store, pair := newTestSession(t, 0)
+		if _, ok := store.sessions[pair.TokenID]; ok {
+			t.Fatalf("StartSession with zero timeout should not track the session")
+		}
+		if _, err := m.VerifyAccessToken(ctx, pair.AccessToken); err != nil {
+			t.Fatalf("VerifyAccessToken returned error: %v", err)
+		}
+	})
+}
+
+func TestVerifyRefreshTokenIdleSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("session carries over to new token", func(t *testing.T) {
+		m, store, pair := newTestSession(t, 60)
+		store.idle(pair.TokenID, 30*time.Second)
+
+		next, err := m.VerifyRefreshToken(ctx, pair.RefreshToken)
+		if err != nil {
+			t.Fatalf("VerifyRefreshToken returned error: %v", err)
+		}
+		if _, ok := store.sessions[pair.TokenID]; ok {
+			t.Fatalf("old session was not deleted")
+		}
+		session, ok := store.sessions[next.TokenID]
+		if !ok || session.IdleTimeout != 60 {
+			t.Fatalf("new session = %+v (found %v), want idle timeout 60", session, ok)
+		}
+	})
+
+	t.Run("idle session cannot refresh", func(t *testing.T) {
+		m, store, pair := newTestSession(t, 60)
+		store.idle(pair.TokenID, 61*time.Second)
+
+		if _, err := m.VerifyRefreshToken(ctx, pair.RefreshToken); !errors.Is(err, ErrSessionIdle) {
+			t.Fatalf("VerifyRefreshToken error = %v, want %v", err, ErrSessionIdle)
+		}
+		if _, ok := store.refresh[pair.TokenID]; ok {
+			t.Fatalf("idle session refresh token was not deleted")
+		}
+	})
+}
+
+func TestParseSession(t *testing.T) {
+	want := Session{IdleTimeout: 7200, ActiveAt: 1700000000000}
+	got, err := parseSession(formatSession(want))
+	if err != nil {
+		t.Fatalf("parseSession returned error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("parseSession = %+v, want %+v", got, want)
+	}
+	if _, err := parseSession("7200"); err == nil {
+		t.Fatalf("parseSession should reject malformed value")
+	}
+}
@@ -9,6 +9,7 @@ import (
 // - 刷新令牌持久化：用 tokenID 作为键，值为 refreshToken，本质是维持会话可刷新能力
 // - 用户会话索引：用 userID 作为集合键（可传入组合键 tenantID:userID），集合成员为该用户的所有 tokenID
 // - 黑名单：对被撤销的 tokenID 建立短期标记（TTL 建议为 access token 剩余有效时间），用于即时失效
+// - 会话空闲：按 tokenID 记录空闲超时与最近活动时间，未记录的会话不做空闲检查
 type Store interface {
 	// 刷新令牌存储：tokenID -> refreshToken
 	Set(ctx context.Context, tokenID string, refreshToken string, expiration int64) error
@@ -23,4 +24,16 @@ type Store interface {
 	// 黑名单：撤销某个 tokenID（通常 TTL 设为 access token 剩余时间）
 	BlacklistToken(ctx context.Context, tokenID string, expiration int64) error
 	IsBlacklisted(ctx context.Context, tokenID string) (bool, error)
+
+	// 会话空闲：tokenID -> {空闲超时(秒), 最近活动时间(毫秒)}，会话不存在时 ok 为 false
+	SetSession(ctx context.Context, tokenID string, session Session, expiration int64) error
+	GetSession(ctx context.Context, tokenID string) (session Session, ok bool, err error)
+	TouchSession(ctx context.Context, tokenID string, activeAt int64) error
+	DeleteSession(ctx context.Context, tokenID string) error
+}
+
+// Session 会话空闲状态
+type Session struct {
+	IdleTimeout int64 // 空闲超时（秒）
+	ActiveAt    int64 // 最近活动时间（毫秒）
 }
@@ -2,6 +2,9 @@ package jwt
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -16,6 +19,8 @@ const (
 	UserTokensKeyPrefix = "user_tokens:"
 	// 黑名单键前缀：blacklist:{tokenID}
 	BlacklistKeyPrefix = "blacklist:"
+	// 会话空闲键前缀：session_idle:{tokenID}
+	SessionIdleKeyPrefix = "session_idle:"
 )
 
 // 使用redis 存储 refresh token
@@ -120,3 +125,69 @@ func (s *redisStore) IsBlacklisted(ctx context.Context, tokenID string) (bool, e
 	}
 	return exists > 0, nil
 }
+
+func (s *redisStore) SetSession(ctx context.Context, tokenID string, session Session, expiration int64) error {
+	// 写入 session_idle:{tokenID} = "{空闲超时}:{最近活动时间}"，TTL 与 refresh token 一致，过期即不再跟踪
+	key := SessionIdleKeyPrefix + tokenID
+	var ttl time.Duration
+	if expiration > 0 {
+		ttl = time.Duration(expiration) * time.Second
+	}
+	return s.client.Set(ctx, key, formatSession(session), ttl).Err()
+}
+
+func (s *redisStore) GetSession(ctx context.Context, tokenID string) (Session, bool, error) {
+	key := SessionIdleKeyPrefix + tokenID
+	value, err := s.client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return Session{}, false, nil
+		}
+		return Session{}, false, err
+	}
+	session, err := parseSession(value)
+	if err != nil {
+		return Session{}, false, err
+	}
+	return session, true, nil
+}
+
+func (s *redisStore) TouchSession(ctx context.Context, tokenID string, activeAt int64) error {
+	// 只更新已存在的会话并保留 TTL，避免为并发中已删除的会话重新建键
+	key := SessionIdleKeyPrefix + tokenID
+	session, ok, err := s.GetSession(ctx, tokenID)
+	if err != nil || !ok {
+		return err
+	}
+	session.ActiveAt = activeAt
+	err = s.client.SetArgs(ctx, key, formatSession(session), redis.SetArgs{Mode: "XX", KeepTTL: true}).Err()
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
+func (s *redisStore) DeleteSession(ctx context.Context, tokenID string) error {
+	key := SessionIdleKeyPrefix + tokenID
+	return s.client.Del(ctx, key).Err()
+}
+
+func formatSession(session Session) string {
+	return strconv.FormatInt(session.IdleTimeout, 10) + ":" + strconv.FormatInt(session.ActiveAt, 10)
+}
+
+func parseSession(value string) (Session, error) {
+	idle, active, found := strings.Cut(value, ":")
+	if !found {
+		return Session{}, fmt.Errorf("invalid session value: %q", value)
+	}
+	idleTimeout, err := strconv.ParseInt(idle, 10, 64)
+	if err != nil {
+		return Session{}, err
+	}
+	activeAt, err := strconv.ParseInt(active, 10, 64)
+	if err != nil {
+		return Session{}, err
+	}
+	return Session{IdleTimeout: idleTimeout, ActiveAt: activeAt}, nil
+}
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// 通知渠道
+const (
+	ChannelEmail = "EMAIL" // 邮件
+	ChannelSMS   = "SMS"   // 短信
+)
+
+// Message 通知消息
+type Message struct {
+	Channel string // 通知渠道
+	To      string // 接收方（邮箱或手机号）
+	Subject string // 标题
+	Content string // 正文
+}
+
+// Sender 通知发送器
+// 邮件/短信网关按渠道实现该接口后在 App 初始化时替换默认实现
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// LogSender 仅输出日志的发送器
+// 未接入邮件/短信网关时使用，正文（含验证码）会写入日志，仅适用于开发环境
+type LogSender struct{}
+
+// NewLogSender 创建日志发送器
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send 将通知写入日志
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	log.Info().
+		Str("channel", msg.Channel).
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("content", msg.Content).
+		Msg("[通知] 未配置发送通道，通知内容仅写入日志")
+	return nil
+}
@@ -0,0 +1,44 @@
+package passwordgen
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"math/big"
+)
+
+// GenerateSecret 生成机器凭证使用的随机密钥（hex 编码，长度为 2*size）
+// 用于访问令牌、客户端密钥等高熵凭证
+func GenerateSecret(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HashSecret 计算随机密钥的 SHA256 哈希（hex 编码）
+// 随机密钥熵足够高，无需 Argon2 等慢哈希，避免每次请求的校验开销
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifySecret 常量时间校验随机密钥与哈希是否匹配
+func VerifySecret(secret, secretHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(secretHash)) == 1
+}
+
+// GenerateNumericCode 生成指定位数的数字验证码（如短信/邮件二次验证码）
+func GenerateNumericCode(digits int) (string, error) {
+	buf := make([]byte, digits)
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		buf[i] = byte('0' + n.Int64())
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,53 @@
+package passwordgen
+
+import "testing"
+
+func TestSecretFlow(t *testing.T) {
+	secret, err := GenerateSecret(32)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+	if len(secret) != 64 {
+		t.Fatalf("密钥长度错误: %d", len(secret))
+	}
+
+	other, err := GenerateSecret(32)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+	if secret == other {
+		t.Error("两次生成的密钥相同")
+	}
+
+	hashed := HashSecret(secret)
+
+	// 验证正确密钥
+	if !VerifySecret(secret, hashed) {
+		t.Error("正确密钥验证失败")
+	}
+
+	// 验证错误密钥
+	if VerifySecret(other, hashed) {
+		t.Error("错误密钥验证通过")
+	}
+
+	// 验证空哈希
+	if VerifySecret(secret, "") {
+		t.Error("空哈希验证通过")
+	}
+}
+
+func TestGenerateNumericCode(t *testing.T) {
+	code, err := GenerateNumericCode(6)
+	if err != nil {
+		t.Fatalf("GenerateNumericCode returned error: %v", err)
+	}
+	if len(code) != 6 {
+		t.Fatalf("code length = %d, want 6", len(code))
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			t.Fatalf("code %q contains non-digit", code)
+		}
+	}
+}
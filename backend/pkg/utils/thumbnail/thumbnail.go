@@ -0,0 +1,133 @@
+// Package thumbnail 生成正方形缩略图（如用户头像）
+// 按文件内容识别格式而不是扩展名，解码前检查像素尺寸，避免超大图片耗尽内存
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// MaxPixels 允许解码的最大像素数（宽 × 高）
+const MaxPixels = 40_000_000
+
+var (
+	// ErrUnsupportedFormat 不支持的图片格式
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	// ErrTooManyPixels 图片像素尺寸超出限制
+	ErrTooManyPixels = errors.New("image dimensions too large")
+)
+
+// 支持的图片格式
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+)
+
+// Result 缩略图
+type Result struct {
+	Data        []byte // 编码后的图片内容
+	ContentType string // image/jpeg 或 image/png
+	Ext         string // 文件扩展名，不含点
+}
+
+// DetectFormat 按文件头识别图片格式，不支持时返回 ErrUnsupportedFormat
+func DetectFormat(data []byte) (string, error) {
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		return FormatJPEG, nil
+	case "image/png":
+		return FormatPNG, nil
+	case "image/gif":
+		return FormatGIF, nil
+	case "image/webp":
+		return FormatWebP, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Square 居中裁剪为正方形并缩放到 size × size
+// JPEG 输出为 JPEG，其他格式输出为 PNG 以保留透明度；GIF 只取第一帧；小于 size 的图片不放大
+func Square(data []byte, size int) (*Result, error) {
+	format, err := DetectFormat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := decodeConfig(bytes.NewReader(data), format)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	src, err := decode(bytes.NewReader(data), format)
+	if err != nil {
+		return nil, err
+	}
+
+	// 居中裁剪
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2))
+
+	out := min(side, size)
+	dst := image.NewRGBA(image.Rect(0, 0, out, out))
+	if out == side {
+		draw.Draw(dst, dst.Bounds(), src, crop.Min, draw.Src)
+	} else {
+		xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, xdraw.Src, nil)
+	}
+
+	var buf bytes.Buffer
+	if format == FormatJPEG {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 90}); err != nil {
+			return nil, err
+		}
+		return &Result{Data: buf.Bytes(), ContentType: "image/jpeg", Ext: "jpg"}, nil
+	}
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return &Result{Data: buf.Bytes(), ContentType: "image/png", Ext: "png"}, nil
+}
+
+func decodeConfig(r io.Reader, format string) (image.Config, error) {
+	switch format {
+	case FormatJPEG:
+		return jpeg.DecodeConfig(r)
+	case FormatPNG:
+		return png.DecodeConfig(r)
+	case FormatGIF:
+		return gif.DecodeConfig(r)
+	case FormatWebP:
+		return webp.DecodeConfig(r)
+	}
+	return image.Config{}, ErrUnsupportedFormat
+}
+
+func decode(r io.Reader, format string) (image.Image, error) {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Decode(r)
+	case FormatPNG:
+		return png.Decode(r)
+	case FormatGIF:
+		return gif.Decode(r)
+	case FormatWebP:
+		return webp.Decode(r)
+	}
+	return nil, ErrUnsupportedFormat
+}
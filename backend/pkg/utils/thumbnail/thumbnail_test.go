@@ -0,0 +1,101 @@
+package thumbnail
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSquare(t *testing.T) {
+	tests := []struct {
+		name     string
+		w, h     int
+		size     int
+		wantSide int
+	}{
+		{"横图缩小", 400, 200, 128, 128},
+		{"竖图缩小", 150, 300, 64, 64},
+		{"小图不放大", 50, 80, 128, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Square(encodePNG(t, tt.w, tt.h), tt.size)
+			if err != nil {
+				t.Fatalf("Square() error = %v", err)
+			}
+			if res.ContentType != "image/png" || res.Ext != "png" {
+				t.Errorf("Square() type = %s/%s, want image/png/png", res.ContentType, res.Ext)
+			}
+			cfg, err := png.DecodeConfig(bytes.NewReader(res.Data))
+			if err != nil {
+				t.Fatalf("decode result error = %v", err)
+			}
+			if cfg.Width != tt.wantSide || cfg.Height != tt.wantSide {
+				t.Errorf("Square() = %dx%d, want %dx%d", cfg.Width, cfg.Height, tt.wantSide, tt.wantSide)
+			}
+		})
+	}
+}
+
+func TestSquareJPEG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 200)), nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	res, err := Square(buf.Bytes(), 100)
+	if err != nil {
+		t.Fatalf("Square() error = %v", err)
+	}
+	if res.ContentType != "image/jpeg" || res.Ext != "jpg" {
+		t.Errorf("Square() type = %s/%s, want image/jpeg/jpg", res.ContentType, res.Ext)
+	}
+}
+
+func TestSquareUnsupported(t *testing.T) {
+	inputs := map[string][]byte{
+		"文本":    []byte("hello, not an image"),
+		"SVG":   []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`),
+		"截断PNG": encodePNG(t, 10, 10)[:20],
+	}
+	for name, data := range inputs {
+		if _, err := Square(data, 64); err == nil {
+			t.Errorf("Square(%s) error = nil, want error", name)
+		}
+	}
+	if _, err := Square([]byte("plain text"), 64); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Square(text) error = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestSquareTooManyPixels(t *testing.T) {
+	// 仅构造 PNG 头部声明超大尺寸，DecodeConfig 即可拒绝，无需真正分配像素
+	data := encodePNG(t, 1, 1)
+	// IHDR 宽高位于偏移 16-23，修改后重新计算块校验和（覆盖块类型和数据，偏移 12-28）
+	binary.BigEndian.PutUint32(data[16:20], 65536)
+	binary.BigEndian.PutUint32(data[20:24], 65536)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	if _, err := Square(data, 64); !errors.Is(err, ErrTooManyPixels) {
+		t.Errorf("Square() error = %v, want ErrTooManyPixels", err)
+	}
+}
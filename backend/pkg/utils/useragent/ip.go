@@ -53,6 +53,11 @@ func GetClientIP(r *http.Request) string {
 	return "127.0.0.1"
 }
 
+// IsInternalIP 判断是否为内网IP（回环、私有网段、链路本地地址）
+func IsInternalIP(ipStr string) bool {
+	return isInternalIP(ipStr)
+}
+
 // isInternalIP 判断是否为内网IP
 func isInternalIP(ipStr string) bool {
 	ip := net.ParseIP(ipStr)
@@ -1,7 +1,10 @@
 package useragent
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
+	"strings"
 
 	"github.com/mssola/user_agent"
 )
@@ -27,8 +30,29 @@ func GetClientInfo(r *http.Request) *ClientInfo {
 	clientInfo.IP = GetClientIP(r)
 
 	// 获取 User-Agent 并解析
-	clientInfo.UserAgent = r.UserAgent()
-	ua := user_agent.New(clientInfo.UserAgent)
+	parseUserAgent(clientInfo, r.UserAgent())
+
+	// 判断是否使用代理
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		clientInfo.Proxy = xff
+	} else {
+		clientInfo.Proxy = ""
+	}
+
+	return clientInfo
+}
+
+// ParseUserAgent 解析 User-Agent 字符串（不含 IP、代理等请求信息）
+func ParseUserAgent(userAgent string) *ClientInfo {
+	clientInfo := &ClientInfo{}
+	parseUserAgent(clientInfo, userAgent)
+	return clientInfo
+}
+
+// parseUserAgent 解析 User-Agent 并填充浏览器、系统和设备信息
+func parseUserAgent(clientInfo *ClientInfo, userAgent string) {
+	clientInfo.UserAgent = userAgent
+	ua := user_agent.New(userAgent)
 	clientInfo.Browser, clientInfo.BrowserVer = ua.Browser()
 	clientInfo.OS = ua.OS()
 	if ua.Mobile() {
@@ -39,13 +63,37 @@ func GetClientInfo(r *http.Request) *ClientInfo {
 
 	clientInfo.Platform = ua.Platform()
 	clientInfo.Localization = ua.Localization()
+}
 
-	// 判断是否使用代理
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		clientInfo.Proxy = xff
-	} else {
-		clientInfo.Proxy = ""
+// Fingerprint 生成设备指纹（设备类型 + 平台 + 操作系统 + 浏览器）
+// 只取浏览器主版本号，避免浏览器自动升级后被识别为新设备
+func Fingerprint(clientInfo *ClientInfo) string {
+	if clientInfo == nil || clientInfo.UserAgent == "" {
+		return ""
 	}
 
-	return clientInfo
+	majorVer, _, _ := strings.Cut(clientInfo.BrowserVer, ".")
+	raw := strings.Join([]string{
+		clientInfo.Device,
+		clientInfo.Platform,
+		clientInfo.OS,
+		clientInfo.Browser,
+		majorVer,
+	}, "|")
+
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:8])
+}
+
+// DeviceName 生成便于阅读的设备描述，如 "Chrome 120 / Windows 10"
+func DeviceName(clientInfo *ClientInfo) string {
+	if clientInfo == nil {
+		return ""
+	}
+	majorVer, _, _ := strings.Cut(clientInfo.BrowserVer, ".")
+	browser := strings.TrimSpace(clientInfo.Browser + " " + majorVer)
+	if clientInfo.OS == "" {
+		return browser
+	}
+	return browser + " / " + clientInfo.OS
 }
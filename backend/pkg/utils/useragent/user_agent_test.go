@@ -39,3 +39,26 @@ func TestNew(t *testing.T) {
 	fmt.Printf("%v\n", name)    // => Googlebot
 	fmt.Printf("%v\n", version) // => 2.1
 }
+
+func TestFingerprint(t *testing.T) {
+	chrome120 := ParseUserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.109 Safari/537.36")
+	chrome120Patch := ParseUserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.216 Safari/537.36")
+	android := ParseUserAgent("Mozilla/5.0 (Linux; U; Android 2.3.7; en-us; Nexus One Build/FRF91) AppleWebKit/533.1 (KHTML, like Gecko) Version/4.0 Mobile Safari/533.1")
+
+	fp := Fingerprint(chrome120)
+	if len(fp) != 16 {
+		t.Fatalf("Fingerprint length = %d, want 16", len(fp))
+	}
+	if fp != Fingerprint(chrome120Patch) {
+		t.Errorf("patch upgrade should keep the same fingerprint")
+	}
+	if fp == Fingerprint(android) {
+		t.Errorf("different devices should have different fingerprints")
+	}
+	if Fingerprint(nil) != "" || Fingerprint(ParseUserAgent("")) != "" {
+		t.Errorf("empty user agent should have empty fingerprint")
+	}
+	if name := DeviceName(chrome120); name != "Chrome 120 / Windows 10" {
+		t.Errorf("DeviceName() = %q", name)
+	}
+}
@@ -0,0 +1,391 @@
+// Package xlsx 提供不依赖第三方库的 XLSX 读写，仅支持首个工作表的纯文本数据，
+// 用于批量导入模板和导入结果文件
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContentType XLSX 文件的 MIME 类型
+	ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	// maxRows Excel 单个工作表的最大行数
+	maxRows = 1048576
+	// maxPartSize 单个 XML 部件解压后的最大字节数，防止压缩炸弹
+	maxPartSize = 64 << 20
+)
+
+var (
+	// ErrInvalidFile 文件不是有效的 XLSX
+	ErrInvalidFile = errors.New("xlsx: invalid file")
+	// ErrTooLarge 文件内容超出限制
+	ErrTooLarge = errors.New("xlsx: file too large")
+)
+
+// ReadFirstSheet 读取首个工作表的所有行
+// 返回的第 i 行对应工作表第 i+1 行，中间的空行以空切片占位，单元格统一按文本返回
+func ReadFirstSheet(r io.ReaderAt, size int64) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := readSharedStrings(files)
+	if err != nil {
+		return nil, err
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, ErrInvalidFile
+	}
+	var ws worksheet
+	if err := decodePart(f, &ws); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for i, row := range ws.Rows {
+		index := i + 1
+		if row.R != "" {
+			n, err := strconv.Atoi(row.R)
+			if err != nil || n < 1 {
+				return nil, ErrInvalidFile
+			}
+			index = n
+		}
+		if index > maxRows {
+			return nil, ErrTooLarge
+		}
+		if index < len(rows)+1 {
+			return nil, ErrInvalidFile
+		}
+		for len(rows) < index-1 {
+			rows = append(rows, []string{})
+		}
+
+		var values []string
+		for _, c := range row.Cells {
+			// 未写引用的单元格紧跟前一个单元格
+			col := len(values)
+			if c.R != "" {
+				if col, err = columnIndex(c.R); err != nil {
+					return nil, err
+				}
+			}
+			if col < len(values) {
+				return nil, ErrInvalidFile
+			}
+			for len(values) < col {
+				values = append(values, "")
+			}
+			value, err := c.text(shared)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// Write 将表头和数据写为只含一个工作表的 XLSX 文件
+func Write(w io.Writer, sheetName string, headers []string, rows [][]string) error {
+	sw, err := NewStreamWriter(w, sheetName)
+	if err != nil {
+		return err
+	}
+	if err := sw.WriteRow(headers); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := sw.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+// StreamWriter 逐行写出只含一个工作表的 XLSX 文件，内存占用与行数无关
+// 单元格均使用内联字符串，避免数字文本（如手机号）被转为科学计数法
+type StreamWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+// NewStreamWriter 创建流式写出器，写完所有行后必须调用 Close
+func NewStreamWriter(w io.Writer, sheetName string) (*StreamWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escape(sheetName))},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	}
+	for _, part := range parts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(pw, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	// 工作表是压缩包中的最后一个部件，可以直接流式写入
+	pw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(pw)
+	if _, err := sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+	return &StreamWriter{zw: zw, sheet: sheet}, nil
+}
+
+// WriteRow 写出一行
+func (s *StreamWriter) WriteRow(values []string) error {
+	if s.rows >= maxRows {
+		return ErrTooLarge
+	}
+	s.rows++
+	fmt.Fprintf(s.sheet, `<row r="%d">`, s.rows)
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		fmt.Fprintf(s.sheet, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`,
+			columnName(i), s.rows, escape(value))
+	}
+	_, err := s.sheet.WriteString(`</row>`)
+	return err
+}
+
+// Close 结束工作表并写出压缩包目录
+func (s *StreamWriter) Close() error {
+	if _, err := s.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := s.sheet.Flush(); err != nil {
+		return err
+	}
+	return s.zw.Close()
+}
+
+// firstSheetPath 从工作簿关系中找到首个工作表的路径
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", ErrInvalidFile
+	}
+	var wb workbook
+	if err := decodePart(wbFile, &wb); err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", ErrInvalidFile
+	}
+
+	relFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return fallback, nil
+	}
+	var rels relationships
+	if err := decodePart(relFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Items {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// readSharedStrings 读取共享字符串表，文件不存在时返回空表
+func readSharedStrings(files map[string]*zip.File) ([]string, error) {
+	f, ok := files["xl/sharedStrings.xml"]
+	if !ok {
+		return nil, nil
+	}
+	var sst sharedStrings
+	if err := decodePart(f, &sst); err != nil {
+		return nil, err
+	}
+	list := make([]string, len(sst.Items))
+	for i, si := range sst.Items {
+		list[i] = si.String()
+	}
+	return list, nil
+}
+
+// decodePart 解析压缩包中的 XML 部件
+func decodePart(f *zip.File, v any) error {
+	if f.UncompressedSize64 > maxPartSize {
+		return ErrTooLarge
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return ErrInvalidFile
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, maxPartSize)).Decode(v); err != nil {
+		return ErrInvalidFile
+	}
+	return nil
+}
+
+// columnIndex 将单元格引用（如 AB12）转换为从 0 开始的列序号
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+		n++
+	}
+	if n == 0 || n > 3 {
+		return 0, ErrInvalidFile
+	}
+	return col - 1, nil
+}
+
+// columnName 将从 0 开始的列序号转换为列名（如 0 -> A，27 -> AB）
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// escape 转义 XML 文本
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+type workbook struct {
+	Sheets []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+// richText 共享字符串或内联字符串，富文本由多个片段拼接
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (r richText) String() string {
+	if len(r.Runs) == 0 {
+		return r.T
+	}
+	var b strings.Builder
+	b.WriteString(r.T)
+	for _, run := range r.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+type worksheet struct {
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []cell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type cell struct {
+	R  string   `xml:"r,attr"`
+	T  string   `xml:"t,attr"`
+	V  string   `xml:"v"`
+	Is richText `xml:"is"`
+}
+
+// text 返回单元格的文本值
+func (c cell) text(shared []string) (string, error) {
+	switch c.T {
+	case "s":
+		i, err := strconv.Atoi(strings.TrimSpace(c.V))
+		if err != nil || i < 0 || i >= len(shared) {
+			return "", ErrInvalidFile
+		}
+		return shared[i], nil
+	case "inlineStr":
+		return c.Is.String(), nil
+	case "b":
+		if c.V == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	default:
+		return c.V, nil
+	}
+}
+
+const contentTypesXML = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const rootRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookXML = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const workbookRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
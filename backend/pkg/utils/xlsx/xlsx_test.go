@@ -0,0 +1,117 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	headers := []string{"用户名", "邮箱", "手机号"}
+	rows := [][]string{
+		{"张三", "zhangsan@example.com", "13800138000"},
+		{"李四 & <王五>", "", "13900139000"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "用户", headers, rows); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := ReadFirstSheet(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("ReadFirstSheet() error = %v", err)
+	}
+
+	want := [][]string{
+		headers,
+		{"张三", "zhangsan@example.com", "13800138000"},
+		{"李四 & <王五>", "", "13900139000"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadFirstSheet() = %v, want %v", got, want)
+	}
+}
+
+func TestReadFirstSheet_SharedStrings(t *testing.T) {
+	data := buildFile(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId3" Target="worksheets/data.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>name</t></si><si><r><t>Ali</t></r><r><t>ce</t></r></si></sst>`,
+		"xl/worksheets/data.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="b"><v>1</v></c></row>` +
+			`<row r="3"><c r="B3" t="s"><v>1</v></c><c r="C3"><v>42</v></c></row>` +
+			`</sheetData></worksheet>`,
+	})
+
+	got, err := ReadFirstSheet(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadFirstSheet() error = %v", err)
+	}
+
+	want := [][]string{
+		{"name", "", "TRUE"},
+		{},
+		{"", "Alice", "42"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadFirstSheet() = %v, want %v", got, want)
+	}
+}
+
+func TestReadFirstSheet_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not zip", []byte("user_name,email\n")},
+		{"no workbook", buildFile(t, map[string]string{"xl/worksheets/sheet1.xml": `<worksheet/>`})},
+		{"bad shared string index", buildFile(t, map[string]string{
+			"xl/workbook.xml":          `<workbook><sheets><sheet name="Sheet1"/></sheets></workbook>`,
+			"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row><c t="s"><v>5</v></c></row></sheetData></worksheet>`,
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadFirstSheet(bytes.NewReader(tt.data), int64(len(tt.data))); err == nil {
+				t.Error("ReadFirstSheet() expected error")
+			}
+		})
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, want := range tests {
+		if got := columnName(index); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", index, got, want)
+		}
+		if got, err := columnIndex(want + "1"); err != nil || got != index {
+			t.Errorf("columnIndex(%s1) = %d, %v, want %d", want, got, err, index)
+		}
+	}
+}
+
+// buildFile 构造只包含指定部件的压缩包
+func buildFile(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,45 @@
+package xcontext
+
+import "context"
+
+const (
+	// 访问令牌相关（PAT / API Key）
+	AccessKeyIDKey contextKey = "access_key_id"
+	ScopesKey      contextKey = "scopes"
+)
+
+// SetAccessKeyID 设置访问令牌ID到context
+func SetAccessKeyID(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, AccessKeyIDKey, keyID)
+}
+
+// GetAccessKeyID 从context获取访问令牌ID，JWT 认证时返回空字符串
+func GetAccessKeyID(ctx context.Context) string {
+	value := ctx.Value(AccessKeyIDKey)
+	if value == nil {
+		return ""
+	}
+	keyID, ok := value.(string)
+	if !ok {
+		return ""
+	}
+	return keyID
+}
+
+// SetScopes 设置访问令牌授权范围到context（格式："METHOD /path"）
+func SetScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, ScopesKey, scopes)
+}
+
+// GetScopes 从context获取访问令牌授权范围
+func GetScopes(ctx context.Context) []string {
+	value := ctx.Value(ScopesKey)
+	if value == nil {
+		return nil
+	}
+	scopes, ok := value.([]string)
+	if !ok {
+		return nil
+	}
+	return scopes
+}
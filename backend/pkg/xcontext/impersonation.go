@@ -0,0 +1,46 @@
+package xcontext
+
+import "context"
+
+const (
+	// 模拟登录相关（act 声明中的实际操作者）
+	ImpersonatorIDKey   contextKey = "impersonator_id"
+	ImpersonatorNameKey contextKey = "impersonator_name"
+)
+
+// SetImpersonator 设置模拟登录的实际操作者到context
+func SetImpersonator(ctx context.Context, userID, userName string) context.Context {
+	ctx = context.WithValue(ctx, ImpersonatorIDKey, userID)
+	return context.WithValue(ctx, ImpersonatorNameKey, userName)
+}
+
+// GetImpersonatorID 从context获取实际操作者ID，非模拟登录时返回空字符串
+func GetImpersonatorID(ctx context.Context) string {
+	value := ctx.Value(ImpersonatorIDKey)
+	if value == nil {
+		return ""
+	}
+	userID, ok := value.(string)
+	if !ok {
+		return ""
+	}
+	return userID
+}
+
+// GetImpersonatorName 从context获取实际操作者用户名
+func GetImpersonatorName(ctx context.Context) string {
+	value := ctx.Value(ImpersonatorNameKey)
+	if value == nil {
+		return ""
+	}
+	userName, ok := value.(string)
+	if !ok {
+		return ""
+	}
+	return userName
+}
+
+// IsImpersonating 判断当前请求是否处于模拟登录状态
+func IsImpersonating(ctx context.Context) bool {
+	return GetImpersonatorID(ctx) != ""
+}
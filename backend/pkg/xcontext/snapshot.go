@@ -0,0 +1,78 @@
+package xcontext
+
+import "context"
+
+// Snapshot 可序列化的认证上下文快照
+// 用于持久化的后台任务：入队时保存发起人的租户、用户和角色信息，执行时在工作进程中重建上下文
+type Snapshot struct {
+	TenantID         string   `json:"tenant_id,omitempty"`
+	TenantCode       string   `json:"tenant_code,omitempty"`
+	UserID           string   `json:"user_id,omitempty"`
+	UserName         string   `json:"user_name,omitempty"`
+	TokenID          string   `json:"token_id,omitempty"`
+	Roles            []string `json:"roles,omitempty"`
+	RoleIDs          []string `json:"role_ids,omitempty"`
+	AccessKeyID      string   `json:"access_key_id,omitempty"`
+	Scopes           []string `json:"scopes,omitempty"`
+	ImpersonatorID   string   `json:"impersonator_id,omitempty"`
+	ImpersonatorName string   `json:"impersonator_name,omitempty"`
+	PlatformScope    bool     `json:"platform_scope,omitempty"`
+}
+
+// TakeSnapshot 从context中提取认证上下文快照
+func TakeSnapshot(ctx context.Context) *Snapshot {
+	return &Snapshot{
+		TenantID:         GetTenantID(ctx),
+		TenantCode:       GetTenantCode(ctx),
+		UserID:           GetUserID(ctx),
+		UserName:         GetUserName(ctx),
+		TokenID:          GetTokenID(ctx),
+		Roles:            GetRoles(ctx),
+		RoleIDs:          GetRoleIDs(ctx),
+		AccessKeyID:      GetAccessKeyID(ctx),
+		Scopes:           GetScopes(ctx),
+		ImpersonatorID:   GetImpersonatorID(ctx),
+		ImpersonatorName: GetImpersonatorName(ctx),
+		PlatformScope:    IsPlatformScope(ctx),
+	}
+}
+
+// Restore 将快照中的认证信息写入ctx，未设置的字段不写入
+//
+//tenantscope:allow 只恢复快照时已标记的平台级操作
+func (s *Snapshot) Restore(ctx context.Context) context.Context {
+	if s.TenantID != "" {
+		ctx = SetTenantID(ctx, s.TenantID)
+	}
+	if s.TenantCode != "" {
+		ctx = SetTenantCode(ctx, s.TenantCode)
+	}
+	if s.UserID != "" {
+		ctx = SetUserID(ctx, s.UserID)
+	}
+	if s.UserName != "" {
+		ctx = SetUserName(ctx, s.UserName)
+	}
+	if s.TokenID != "" {
+		ctx = SetTokenID(ctx, s.TokenID)
+	}
+	if s.Roles != nil {
+		ctx = SetRoles(ctx, s.Roles)
+	}
+	if len(s.RoleIDs) > 0 {
+		ctx = SetRoleIDs(ctx, s.RoleIDs)
+	}
+	if s.AccessKeyID != "" {
+		ctx = SetAccessKeyID(ctx, s.AccessKeyID)
+	}
+	if len(s.Scopes) > 0 {
+		ctx = SetScopes(ctx, s.Scopes)
+	}
+	if s.ImpersonatorID != "" {
+		ctx = SetImpersonator(ctx, s.ImpersonatorID, s.ImpersonatorName)
+	}
+	if s.PlatformScope {
+		ctx = SetPlatformScope(ctx)
+	}
+	return ctx
+}
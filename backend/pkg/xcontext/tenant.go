@@ -9,6 +9,13 @@ const (
 	// 租户相关
 	TenantIDKey   contextKey = "tenant_id"
 	TenantCodeKey contextKey = "tenant_code"
+
+	// 登录前由域名或请求头识别出的租户
+	ResolvedTenantIDKey   contextKey = "resolved_tenant_id"
+	ResolvedTenantCodeKey contextKey = "resolved_tenant_code"
+
+	// 平台级操作标记，数据库行级安全策略据此放行跨租户访问
+	PlatformScopeKey contextKey = "platform_scope"
 )
 
 // TenantContext 租户上下文信息
@@ -53,30 +60,39 @@ func GetTenantCode(ctx context.Context) string {
 	return tenantCode
 }
 
+// SetResolvedTenant 设置由域名或请求头识别出的租户
+// 与认证后的租户分开保存，登录等未认证接口据此限定查询范围
+func SetResolvedTenant(ctx context.Context, tenantID, tenantCode string) context.Context {
+	ctx = context.WithValue(ctx, ResolvedTenantIDKey, tenantID)
+	return context.WithValue(ctx, ResolvedTenantCodeKey, tenantCode)
+}
+
+// GetResolvedTenantID 获取识别出的租户ID，未识别时返回空字符串
+func GetResolvedTenantID(ctx context.Context) string {
+	tenantID, _ := ctx.Value(ResolvedTenantIDKey).(string)
+	return tenantID
+}
+
+// GetResolvedTenantCode 获取识别出的租户编码，未识别时返回空字符串
+func GetResolvedTenantCode(ctx context.Context) string {
+	tenantCode, _ := ctx.Value(ResolvedTenantCodeKey).(string)
+	return tenantCode
+}
+
+// SetPlatformScope 标记为平台级操作（如定时任务中的跨租户处理），数据库层不按租户隔离
+// 调用处所在函数需添加 //tenantscope:allow 注解说明原因，由 tenantscope 检查器校验
+func SetPlatformScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, PlatformScopeKey, true)
+}
+
+// IsPlatformScope 判断是否为平台级操作
+func IsPlatformScope(ctx context.Context) bool {
+	platform, _ := ctx.Value(PlatformScopeKey).(bool)
+	return platform
+}
+
 // CopyContext 将认证相关上下文信息拷贝到 background context
 // 用于异步场景：避免请求取消影响后台任务，同时保留租户、用户和角色信息
 func CopyContext(ctx context.Context) context.Context {
-	bg := context.Background()
-	if tenantID := GetTenantID(ctx); tenantID != "" {
-		bg = SetTenantID(bg, tenantID)
-	}
-	if tenantCode := GetTenantCode(ctx); tenantCode != "" {
-		bg = SetTenantCode(bg, tenantCode)
-	}
-	if userID := GetUserID(ctx); userID != "" {
-		bg = SetUserID(bg, userID)
-	}
-	if userName := GetUserName(ctx); userName != "" {
-		bg = SetUserName(bg, userName)
-	}
-	if tokenID := GetTokenID(ctx); tokenID != "" {
-		bg = SetTokenID(bg, tokenID)
-	}
-	if roles := GetRoles(ctx); roles != nil {
-		bg = SetRoles(bg, roles)
-	}
-	if roleIDs := GetRoleIDs(ctx); len(roleIDs) > 0 {
-		bg = SetRoleIDs(bg, roleIDs)
-	}
-	return bg
+	return TakeSnapshot(ctx).Restore(context.Background())
 }
@@ -36,6 +36,26 @@ var (
 	ErrUserNoTenants          = New(2109, "用户未关联任何租户")
 	ErrUserTenantAccessDenied = New(2110, "用户无该租户访问权限")
 	ErrUserNoRoles            = New(2111, "用户在租户中无任何角色")
+	ErrAccessTokenNotFound    = New(2112, "访问令牌不存在")
+	ErrAccessTokenScope       = New(2113, "访问令牌权限超出所有者权限范围")
+	ErrServiceAccountLogin    = New(2114, "服务账号不支持交互式登录")
+	ErrServiceAccountPassword = New(2115, "服务账号不支持密码操作")
+	ErrInvalidClient          = New(2116, "客户端ID或密钥错误")
+	ErrUnsupportedGrantType   = New(2117, "不支持的授权类型")
+	ErrImpersonationForbidden = New(2118, "模拟登录状态下不允许此操作")
+	ErrImpersonationDenied    = New(2119, "无权模拟该用户")
+	ErrLoginBlocked           = New(2120, "登录存在安全风险，已被拒绝")
+	ErrMFAChallengeInvalid    = New(2121, "二次验证已失效，请重新登录")
+	ErrMFACodeInvalid         = New(2122, "验证码错误")
+	ErrUserImportInvalid      = New(2123, "导入文件格式无效")
+	ErrUserImportTooLarge     = New(2124, "导入文件超出行数限制")
+	ErrAvatarInvalid          = New(2125, "头像仅支持 JPEG、PNG、GIF、WebP 格式的图片")
+	ErrAvatarTooLarge         = New(2126, "头像文件过大")
+	ErrContactUnchanged       = New(2127, "新的邮箱或手机号与当前相同")
+	ErrVerifyCodeTooFrequent  = New(2128, "验证码发送过于频繁，请稍后再试")
+	ErrContactCodeInvalid     = New(2129, "验证码错误或已失效")
+	ErrSessionIdleTimeout     = New(2130, "长时间未操作，会话已超时，请重新登录")
+	ErrLoginMethodDisabled    = New(2131, "该租户未启用此登录方式")
 
 	// 租户错误 2200-2299
 	ErrTenantCodeRequired = New(2200, "租户编码不能为空")
@@ -43,6 +63,33 @@ var (
 	ErrTenantExists       = New(2202, "租户已存在")
 	ErrTenantCodeExists   = New(2203, "租户编码已存在")
 	ErrTenantDisabled     = New(2204, "租户已禁用")
+	ErrTenantProvisioned  = New(2205, "租户已完成初始化")
+	ErrTenantProvisioning = New(2206, "租户初始化未完成，请重试初始化")
+	ErrTenantExpired      = New(2207, "租户订阅已过期")
+	ErrTenantArchived     = New(2208, "租户已归档")
+	ErrTenantReadOnly     = New(2209, "租户订阅已到期，宽限期内仅允许只读访问")
+
+	ErrPlanNotFound        = New(2210, "套餐不存在")
+	ErrPlanCodeExists      = New(2211, "套餐编码已存在")
+	ErrPlanDisabled        = New(2212, "套餐已禁用")
+	ErrPlanInUse           = New(2213, "套餐正在被租户使用")
+	ErrPermissionOutOfPlan = New(2214, "权限超出租户套餐范围")
+	ErrQuotaExceeded       = New(2215, "租户资源配额已用尽")
+
+	ErrTenantDomainExists     = New(2216, "域名已被绑定")
+	ErrTenantDomainNotFound   = New(2217, "域名不存在")
+	ErrTenantDomainUnverified = New(2218, "域名验证失败，未找到匹配的 TXT 记录")
+	ErrTenantArchiveInvalid   = New(2219, "租户数据包格式无效")
+	ErrTenantImportConflict   = New(2220, "租户数据导入存在冲突")
+	ErrTenantNotDeleted       = New(2221, "租户未被删除")
+	ErrTenantPurgeStarted     = New(2222, "租户数据已开始清除，无法恢复")
+	ErrSettingNotFound        = New(2223, "设置项不存在")
+	ErrSettingConflict        = New(2224, "设置已被其他人修改，请刷新后重试")
+	ErrInvitationNotFound     = New(2225, "邀请不存在")
+	ErrInvitationInvalid      = New(2226, "邀请链接无效或已被使用")
+	ErrInvitationExpired      = New(2227, "邀请链接已过期")
+	ErrAlreadyTenantMember    = New(2228, "该邮箱已是租户成员")
+	ErrTenantMemberNotFound   = New(2229, "租户成员不存在")
 
 	// 角色错误 2300-2399
 	ErrRoleNotFound   = New(2300, "角色不存在")
@@ -50,6 +97,10 @@ var (
 	ErrRoleCodeExists = New(2302, "角色编码已存在")
 	ErrRoleInUse      = New(2303, "角色正在使用中")
 
+	ErrRoleTemplateNotFound   = New(2310, "角色模板不存在")
+	ErrRoleTemplateCodeExists = New(2311, "角色模板编码已存在")
+	ErrRoleTemplateDisabled   = New(2312, "角色模板已禁用")
+
 	// 菜单错误 2400-2499
 	ErrMenuNotFound         = New(2400, "菜单不存在")
 	ErrMenuExists           = New(2401, "菜单已存在")
@@ -72,4 +123,15 @@ var (
 	ErrPositionExists     = New(2601, "岗位已存在")
 	ErrPositionCodeExists = New(2602, "岗位编码已存在")
 	ErrPositionInUse      = New(2603, "岗位正在使用中")
+
+	// 导出错误 2700-2799
+	ErrExportNotFound = New(2700, "导出任务不存在")
+	ErrExportNotReady = New(2701, "导出文件尚未生成")
+	ErrExportExpired  = New(2702, "导出文件已过期")
+
+	// 后台任务错误 2800-2899
+	ErrTaskNotFound      = New(2800, "任务不存在")
+	ErrTaskNotCancelable = New(2801, "任务已结束，无法取消")
+	ErrTaskNotReady      = New(2802, "任务结果文件尚未生成")
+	ErrTaskFileExpired   = New(2803, "任务结果文件已过期")
 )
@@ -78,8 +78,8 @@ func SeedAllData(db *gorm.DB) (*SeedResult, error) {
 	}
 
 	// 生成所需的ID
-	// 6个基础ID (租户、用户、4个角色) + 29个菜单ID + 19个部门ID + 37个岗位ID + 52个字典ID (13个类型+39个项) = 143个ID
-	ids, err := idgen.GenerateUUIDs(143)
+	// 6个基础ID (租户、用户、4个角色) + 3个角色模板ID + 29个菜单ID + 19个部门ID + 37个岗位ID + 52个字典ID (13个类型+39个项) = 146个ID
+	ids, err := idgen.GenerateUUIDs(146)
 	if err != nil {
 		return nil, fmt.Errorf("生成ID失败: %w", err)
 	}
@@ -110,6 +110,13 @@ func SeedAllData(db *gorm.DB) (*SeedResult, error) {
 	result.Roles = roles
 	idIndex += 4
 
+	// 3.5 初始化全局角色模板
+	templateDefs := seeds.DefaultRoleTemplateDefinitions(ids[idIndex : idIndex+3])
+	if err := seeds.SeedRoleTemplates(db, templateDefs); err != nil {
+		return nil, fmt.Errorf("初始化角色模板失败: %w", err)
+	}
+	idIndex += 3
+
 	// 7. 初始化系统菜单
 	menuDefs := seeds.DefaultMenuDefinitions(ids[idIndex : idIndex+29])
 	if err := seeds.SeedSystemMenus(db, menuDefs); err != nil {
@@ -50,3 +50,45 @@ func DefaultRoleDefinitions(roleIDs []string) []RoleDefinition {
 		{roleIDs[3], "user", "普通用户"},
 	}
 }
+
+// RoleTemplateDefinition 角色模板定义
+type RoleTemplateDefinition struct {
+	TemplateID   string
+	TemplateCode string
+	Name         string
+	Description  string
+}
+
+// SeedRoleTemplates 初始化全局角色模板
+// 模板权限由平台在后台配置，新租户初始化时优先使用 admin 模板创建租户管理员角色
+func SeedRoleTemplates(db *gorm.DB, defs []RoleTemplateDefinition) error {
+	for _, def := range defs {
+		var template model.RoleTemplate
+		if err := db.Where("template_code = ? AND deleted_at = 0", def.TemplateCode).First(&template).Error; err == nil {
+			fmt.Printf("ℹ️  角色模板已存在 template_id=%s template_code=%s name=%s\n", template.TemplateID, template.TemplateCode, template.Name)
+			continue
+		}
+
+		template = model.RoleTemplate{
+			TemplateID:   def.TemplateID,
+			TemplateCode: def.TemplateCode,
+			Name:         def.Name,
+			Description:  def.Description,
+			Status:       1,
+		}
+		if err := db.Create(&template).Error; err != nil {
+			return fmt.Errorf("创建角色模板 %s 失败: %w", def.Name, err)
+		}
+		fmt.Printf("✅ 角色模板创建成功 template_id=%s template_code=%s name=%s\n", template.TemplateID, template.TemplateCode, template.Name)
+	}
+	return nil
+}
+
+// DefaultRoleTemplateDefinitions 返回默认角色模板定义
+func DefaultRoleTemplateDefinitions(templateIDs []string) []RoleTemplateDefinition {
+	return []RoleTemplateDefinition{
+		{templateIDs[0], "admin", "租户管理员", "租户初始化时使用的管理员角色模板"},
+		{templateIDs[1], "auditor", "监管员", "只读查看日志与用户"},
+		{templateIDs[2], "user", "普通用户", "租户普通用户"},
+	}
+}